@@ -2,4 +2,9 @@ module github.com/sendly-live/sendly-go
 
 go 1.21
 
-require golang.org/x/time v0.5.0
+require (
+	golang.org/x/oauth2 v0.21.0
+	golang.org/x/time v0.5.0
+)
+
+require github.com/google/go-cmp v0.6.0 // indirect
@@ -1,8 +1,13 @@
 package sendly
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 )
 
 // AccountService provides methods for accessing account information.
@@ -12,10 +17,12 @@ type AccountService struct {
 
 // accountAPIResponse is the API response with snake_case fields.
 type accountAPIResponse struct {
-	ID        string  `json:"id"`
-	Email     string  `json:"email"`
-	Name      *string `json:"name,omitempty"`
-	CreatedAt string  `json:"created_at"`
+	ID                string  `json:"id"`
+	Email             string  `json:"email"`
+	Name              *string `json:"name,omitempty"`
+	NotificationEmail *string `json:"notification_email,omitempty"`
+	Timezone          string  `json:"timezone,omitempty"`
+	CreatedAt         string  `json:"created_at"`
 }
 
 // creditsAPIResponse is the API response with snake_case fields.
@@ -50,6 +57,23 @@ type apiKeyAPIResponse struct {
 	IsRevoked   bool     `json:"is_revoked"`
 }
 
+// verificationAPIResponse is the API response with snake_case fields.
+type verificationAPIResponse struct {
+	ID         string  `json:"id"`
+	Channel    string  `json:"channel"`
+	Target     string  `json:"target"`
+	Status     string  `json:"status"`
+	ExpiresAt  string  `json:"expires_at"`
+	VerifiedAt *string `json:"verified_at,omitempty"`
+}
+
+// autoTopUpAPIResponse is the API response with snake_case fields.
+type autoTopUpAPIResponse struct {
+	Enabled   bool `json:"enabled"`
+	Threshold int  `json:"threshold"`
+	Amount    int  `json:"amount"`
+}
+
 // Get retrieves account information.
 func (s *AccountService) Get(ctx context.Context) (*Account, error) {
 	var apiResp accountAPIResponse
@@ -57,12 +81,50 @@ func (s *AccountService) Get(ctx context.Context) (*Account, error) {
 		return nil, err
 	}
 
+	return transformAccount(apiResp), nil
+}
+
+// Update changes the account profile and returns the updated account.
+func (s *AccountService) Update(ctx context.Context, req UpdateAccountRequest) (*Account, error) {
+	if err := validateAccountUpdate(req); err != nil {
+		return nil, err
+	}
+
+	var apiResp accountAPIResponse
+	if err := s.client.request(ctx, "PATCH", "/account", req, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return transformAccount(apiResp), nil
+}
+
+// validateAccountUpdate checks an account update before it is sent.
+func validateAccountUpdate(req UpdateAccountRequest) error {
+	if req.Name == nil && req.NotificationEmail == nil && req.Timezone == nil {
+		return &ValidationError{APIError: APIError{Message: "at least one field to update is required"}}
+	}
+	if req.Name != nil && *req.Name == "" {
+		return &ValidationError{APIError: APIError{Message: "account name cannot be empty"}}
+	}
+	if req.NotificationEmail != nil && !strings.Contains(*req.NotificationEmail, "@") {
+		return &ValidationError{APIError: APIError{Message: "notification email must be an email address"}}
+	}
+	if req.Timezone != nil && *req.Timezone == "" {
+		return &ValidationError{APIError: APIError{Message: "timezone cannot be empty"}}
+	}
+	return nil
+}
+
+// transformAccount converts API response to SDK type.
+func transformAccount(api accountAPIResponse) *Account {
 	return &Account{
-		ID:        apiResp.ID,
-		Email:     apiResp.Email,
-		Name:      apiResp.Name,
-		CreatedAt: apiResp.CreatedAt,
-	}, nil
+		ID:                api.ID,
+		Email:             api.Email,
+		Name:              api.Name,
+		NotificationEmail: api.NotificationEmail,
+		Timezone:          api.Timezone,
+		CreatedAt:         api.CreatedAt,
+	}
 }
 
 // GetCredits retrieves credit balance information.
@@ -72,6 +134,10 @@ func (s *AccountService) GetCredits(ctx context.Context) (*Credits, error) {
 		return nil, err
 	}
 
+	if s.client.forecaster != nil {
+		s.client.forecaster.SetBalance(apiResp.AvailableBalance)
+	}
+
 	return &Credits{
 		Balance:          apiResp.Balance,
 		ReservedBalance:  apiResp.ReservedBalance,
@@ -81,32 +147,95 @@ func (s *AccountService) GetCredits(ctx context.Context) (*Credits, error) {
 
 // ListCreditTransactionsOptions are options for listing credit transactions.
 type ListCreditTransactionsOptions struct {
-	Limit  int
+	// Limit is the maximum number of transactions to return.
+	Limit int
+	// Offset is the number of transactions to skip.
 	Offset int
+	// Type filters by transaction type.
+	Type TransactionType
+	// CreatedAfter only includes transactions created at or after this time (ISO 8601).
+	CreatedAfter string
+	// CreatedBefore only includes transactions created before this time (ISO 8601).
+	CreatedBefore string
+	// MessageID only includes transactions for this message.
+	MessageID string
 }
 
-// GetCreditTransactions retrieves credit transaction history.
+// ListCreditTransactionsResponse is the response from listing credit transactions.
+// Transactions are listed newest first.
+type ListCreditTransactionsResponse = Page[CreditTransaction]
+
+// transactionListAPIResponse is the API response with snake_case fields.
+type transactionListAPIResponse struct {
+	Data    []transactionAPIResponse `json:"data"`
+	Count   int                      `json:"count"`
+	HasMore *bool                    `json:"has_more,omitempty"`
+}
+
+// GetCreditTransactions retrieves credit transaction history. Use
+// ListCreditTransactions for the total count and whether more pages follow.
 func (s *AccountService) GetCreditTransactions(ctx context.Context, opts *ListCreditTransactionsOptions) ([]CreditTransaction, error) {
-	path := "/credits/transactions"
-	if opts != nil {
-		params := make(map[string]string)
-		if opts.Limit > 0 {
-			params["limit"] = strconv.Itoa(opts.Limit)
-		}
-		if opts.Offset > 0 {
-			params["offset"] = strconv.Itoa(opts.Offset)
-		}
-		path += buildQueryString(params)
+	resp, err := s.ListCreditTransactions(ctx, opts)
+	if err != nil {
+		return nil, err
 	}
+	return resp.Data, nil
+}
 
-	var apiResp []transactionAPIResponse
-	if err := s.client.request(ctx, "GET", path, nil, &apiResp); err != nil {
+// ListCreditTransactions retrieves a page of credit transaction history
+// matching opts, along with the total count.
+func (s *AccountService) ListCreditTransactions(ctx context.Context, opts *ListCreditTransactionsOptions) (*ListCreditTransactionsResponse, error) {
+	if opts == nil {
+		opts = &ListCreditTransactionsOptions{}
+	}
+
+	params := make(map[string]string)
+	if opts.Limit > 0 {
+		params["limit"] = strconv.Itoa(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		params["offset"] = strconv.Itoa(opts.Offset)
+	}
+	if opts.Type != "" {
+		params["type"] = string(opts.Type)
+	}
+	if opts.CreatedAfter != "" {
+		params["createdAfter"] = opts.CreatedAfter
+	}
+	if opts.CreatedBefore != "" {
+		params["createdBefore"] = opts.CreatedBefore
+	}
+	if opts.MessageID != "" {
+		params["messageId"] = opts.MessageID
+	}
+	path := "/credits/transactions" + buildQueryString(params)
+
+	var raw json.RawMessage
+	if err := s.client.request(ctx, "GET", path, nil, &raw); err != nil {
 		return nil, err
 	}
 
-	transactions := make([]CreditTransaction, len(apiResp))
-	for i, api := range apiResp {
-		transactions[i] = CreditTransaction{
+	// Older API versions return a bare array without a count.
+	var apiResp transactionListAPIResponse
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &apiResp.Data); err != nil {
+			return nil, &NetworkError{Message: "failed to unmarshal response", Err: err}
+		}
+		apiResp.Count = opts.Offset + len(apiResp.Data)
+		hasMore := opts.Limit > 0 && len(apiResp.Data) == opts.Limit
+		apiResp.HasMore = &hasMore
+	} else if len(trimmed) > 0 {
+		if err := json.Unmarshal(trimmed, &apiResp); err != nil {
+			return nil, &NetworkError{Message: "failed to unmarshal response", Err: err}
+		}
+	}
+
+	resp := &ListCreditTransactionsResponse{
+		Data:  make([]CreditTransaction, len(apiResp.Data)),
+		Count: apiResp.Count,
+	}
+	for i, api := range apiResp.Data {
+		resp.Data[i] = CreditTransaction{
 			ID:           api.ID,
 			Type:         TransactionType(api.Type),
 			Amount:       api.Amount,
@@ -116,7 +245,58 @@ func (s *AccountService) GetCreditTransactions(ctx context.Context, opts *ListCr
 			CreatedAt:    api.CreatedAt,
 		}
 	}
-	return transactions, nil
+	if apiResp.HasMore != nil {
+		resp.HasMore = *apiResp.HasMore
+	}
+	resp.fill(opts.Offset)
+	return resp, nil
+}
+
+// GetAutoTopUp retrieves the automatic top-up configuration.
+func (s *AccountService) GetAutoTopUp(ctx context.Context) (*AutoTopUpConfig, error) {
+	var apiResp autoTopUpAPIResponse
+	if err := s.client.request(ctx, "GET", "/credits/auto-top-up", nil, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &AutoTopUpConfig{
+		Enabled:   apiResp.Enabled,
+		Threshold: apiResp.Threshold,
+		Amount:    apiResp.Amount,
+	}, nil
+}
+
+// SetAutoTopUp replaces the automatic top-up configuration and returns the
+// configuration now in effect.
+func (s *AccountService) SetAutoTopUp(ctx context.Context, config AutoTopUpConfig) (*AutoTopUpConfig, error) {
+	if err := validateAutoTopUp(config); err != nil {
+		return nil, err
+	}
+
+	var apiResp autoTopUpAPIResponse
+	if err := s.client.request(ctx, "PUT", "/credits/auto-top-up", config, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &AutoTopUpConfig{
+		Enabled:   apiResp.Enabled,
+		Threshold: apiResp.Threshold,
+		Amount:    apiResp.Amount,
+	}, nil
+}
+
+// validateAutoTopUp checks an auto top-up configuration before it is sent.
+func validateAutoTopUp(config AutoTopUpConfig) error {
+	if config.Threshold < 0 {
+		return &ValidationError{APIError: APIError{Message: "auto top-up threshold cannot be negative"}}
+	}
+	if config.Amount < 0 {
+		return &ValidationError{APIError: APIError{Message: "auto top-up amount cannot be negative"}}
+	}
+	if config.Enabled && config.Amount == 0 {
+		return &ValidationError{APIError: APIError{Message: "auto top-up amount is required when enabled"}}
+	}
+	return nil
 }
 
 // ListAPIKeys retrieves all API keys for the account.
@@ -165,6 +345,27 @@ func (s *AccountService) GetAPIKey(ctx context.Context, keyID string) (*APIKey,
 	}, nil
 }
 
+// GetCurrentAPIKey retrieves the API key the client authenticates with.
+func (s *AccountService) GetCurrentAPIKey(ctx context.Context) (*APIKey, error) {
+	var apiResp apiKeyAPIResponse
+	if err := s.client.request(ctx, "GET", "/keys/current", nil, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &APIKey{
+		ID:          apiResp.ID,
+		Name:        apiResp.Name,
+		Type:        apiResp.Type,
+		Prefix:      apiResp.Prefix,
+		LastFour:    apiResp.LastFour,
+		Permissions: apiResp.Permissions,
+		CreatedAt:   apiResp.CreatedAt,
+		LastUsedAt:  apiResp.LastUsedAt,
+		ExpiresAt:   apiResp.ExpiresAt,
+		IsRevoked:   apiResp.IsRevoked,
+	}, nil
+}
+
 // APIKeyUsage contains usage statistics for an API key.
 type APIKeyUsage struct {
 	KeyID             string `json:"keyId"`
@@ -185,10 +386,49 @@ func (s *AccountService) GetAPIKeyUsage(ctx context.Context, keyID string) (*API
 	return &usage, nil
 }
 
+// APIKeyType is the environment an API key operates in.
+type APIKeyType string
+
+const (
+	// APIKeyTypeTest keys send to the sandbox and are never billed.
+	APIKeyTypeTest APIKeyType = "test"
+	// APIKeyTypeLive keys send real messages.
+	APIKeyTypeLive APIKeyType = "live"
+)
+
+// Permissions that can be granted to an API key. They are untyped so they
+// can be used directly in CreateAPIKeyRequest.Permissions and
+// UpdateAPIKeyRequest.Permissions.
+const (
+	// PermissionSMSSend allows sending messages and batches.
+	PermissionSMSSend = "sms:send"
+	// PermissionSMSRead allows reading messages, batches, and scheduled messages.
+	PermissionSMSRead = "sms:read"
+	// PermissionWebhooksRead allows listing webhooks and their deliveries.
+	PermissionWebhooksRead = "webhooks:read"
+	// PermissionWebhooksWrite allows creating, updating, and deleting webhooks.
+	PermissionWebhooksWrite = "webhooks:write"
+	// PermissionAccountRead allows reading account details, credits, and keys.
+	PermissionAccountRead = "account:read"
+	// PermissionAccountWrite allows managing API keys and account settings.
+	PermissionAccountWrite = "account:write"
+)
+
+// ReadOnlyPermissions grants read access without the ability to send or
+// change anything.
+var ReadOnlyPermissions = []string{PermissionSMSRead, PermissionWebhooksRead, PermissionAccountRead}
+
+// SendOnlyPermissions grants only what is needed to send messages.
+var SendOnlyPermissions = []string{PermissionSMSSend}
+
 // CreateAPIKeyRequest is the request to create a new API key.
 type CreateAPIKeyRequest struct {
-	Name      string  `json:"name"`
-	ExpiresAt *string `json:"expiresAt,omitempty"`
+	Name string `json:"name"`
+	// Type is the key environment (default: the type of the key making the request).
+	Type APIKeyType `json:"type,omitempty"`
+	// Permissions limits what the key can do. Empty grants full access.
+	Permissions []string `json:"permissions,omitempty"`
+	ExpiresAt   *string  `json:"expiresAt,omitempty"`
 }
 
 // CreateAPIKeyResponse is the response from creating an API key.
@@ -207,6 +447,9 @@ func (s *AccountService) CreateAPIKeyWithOptions(ctx context.Context, req Create
 	if req.Name == "" {
 		return nil, &ValidationError{APIError: APIError{Message: "API key name is required"}}
 	}
+	if req.Type != "" && req.Type != APIKeyTypeTest && req.Type != APIKeyTypeLive {
+		return nil, &ValidationError{APIError: APIError{Message: fmt.Sprintf("invalid API key type %q", req.Type)}}
+	}
 
 	var resp CreateAPIKeyResponse
 	if err := s.client.request(ctx, "POST", "/account/keys", req, &resp); err != nil {
@@ -215,6 +458,30 @@ func (s *AccountService) CreateAPIKeyWithOptions(ctx context.Context, req Create
 	return &resp, nil
 }
 
+// UpdateAPIKeyRequest is the request to update an API key. Only non-nil
+// fields are changed.
+type UpdateAPIKeyRequest struct {
+	Name        *string   `json:"name,omitempty"`
+	Permissions *[]string `json:"permissions,omitempty"`
+	ExpiresAt   *string   `json:"expiresAt,omitempty"`
+}
+
+// UpdateAPIKey renames an API key or changes its permissions or expiry.
+func (s *AccountService) UpdateAPIKey(ctx context.Context, keyID string, req UpdateAPIKeyRequest) (*APIKey, error) {
+	if keyID == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "API key ID is required"}}
+	}
+	if req.Name != nil && *req.Name == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "API key name cannot be empty"}}
+	}
+
+	var key APIKey
+	if err := s.client.request(ctx, "PATCH", "/account/keys/"+url.PathEscape(keyID), req, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
 // RevokeAPIKey revokes an API key.
 func (s *AccountService) RevokeAPIKey(ctx context.Context, keyID string) error {
 	if keyID == "" {
@@ -223,3 +490,72 @@ func (s *AccountService) RevokeAPIKey(ctx context.Context, keyID string) error {
 
 	return s.client.request(ctx, "DELETE", "/account/keys/"+keyID, nil, nil)
 }
+
+// startVerificationRequest is the request to send a verification code.
+type startVerificationRequest struct {
+	Channel VerificationChannel `json:"channel"`
+	Target  string              `json:"target"`
+}
+
+// confirmVerificationRequest is the request to confirm a verification code.
+type confirmVerificationRequest struct {
+	Code string `json:"code"`
+}
+
+// StartEmailVerification sends a verification code to email. Once confirmed
+// with ConfirmVerification, it becomes the account's notification email.
+func (s *AccountService) StartEmailVerification(ctx context.Context, email string) (*ContactVerification, error) {
+	if !strings.Contains(email, "@") {
+		return nil, &ValidationError{APIError: APIError{Message: "a valid email address is required"}}
+	}
+	return s.startVerification(ctx, VerificationChannelEmail, email)
+}
+
+// StartTestNumberVerification texts a verification code to phone. Once
+// confirmed with ConfirmVerification, the number is added to the account's
+// verified personal test numbers.
+func (s *AccountService) StartTestNumberVerification(ctx context.Context, phone string) (*ContactVerification, error) {
+	if !strings.HasPrefix(phone, "+") {
+		return nil, &ValidationError{APIError: APIError{Message: "phone number must be in E.164 format"}}
+	}
+	return s.startVerification(ctx, VerificationChannelSMS, phone)
+}
+
+func (s *AccountService) startVerification(ctx context.Context, channel VerificationChannel, target string) (*ContactVerification, error) {
+	req := startVerificationRequest{Channel: channel, Target: target}
+
+	var apiResp verificationAPIResponse
+	if err := s.client.request(ctx, "POST", "/account/verifications", req, &apiResp); err != nil {
+		return nil, err
+	}
+	return transformVerification(apiResp), nil
+}
+
+// ConfirmVerification completes a verification with the code that was sent
+// to the contact.
+func (s *AccountService) ConfirmVerification(ctx context.Context, verificationID, code string) (*ContactVerification, error) {
+	if verificationID == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "verification ID is required"}}
+	}
+	if code == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "verification code is required"}}
+	}
+
+	path := "/account/verifications/" + url.PathEscape(verificationID) + "/confirm"
+	var apiResp verificationAPIResponse
+	if err := s.client.request(ctx, "POST", path, confirmVerificationRequest{Code: code}, &apiResp); err != nil {
+		return nil, err
+	}
+	return transformVerification(apiResp), nil
+}
+
+func transformVerification(api verificationAPIResponse) *ContactVerification {
+	return &ContactVerification{
+		ID:         api.ID,
+		Channel:    VerificationChannel(api.Channel),
+		Target:     api.Target,
+		Status:     VerificationStatus(api.Status),
+		ExpiresAt:  api.ExpiresAt,
+		VerifiedAt: api.VerifiedAt,
+	}
+}
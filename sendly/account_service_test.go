@@ -0,0 +1,559 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccountGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/account" {
+			t.Errorf("expected path '/account', got '%s'", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-api-key" {
+			t.Errorf("expected Authorization header to be 'Bearer test-api-key', got '%s'", r.Header.Get("Authorization"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"user_123","email":"ops@example.com","name":"Ops","created_at":"2024-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	account, err := client.Account.Get(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if account.ID != "user_123" {
+		t.Errorf("expected ID to be 'user_123', got '%s'", account.ID)
+	}
+	if account.Email != "ops@example.com" {
+		t.Errorf("expected Email to be 'ops@example.com', got '%s'", account.Email)
+	}
+	if account.Name == nil || *account.Name != "Ops" {
+		t.Errorf("expected Name to be 'Ops', got %v", account.Name)
+	}
+	if account.CreatedAt != "2024-01-01T00:00:00Z" {
+		t.Errorf("expected CreatedAt to be '2024-01-01T00:00:00Z', got '%s'", account.CreatedAt)
+	}
+}
+
+func TestAccountGet_AuthenticationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(APIError{
+			Code:    "UNAUTHORIZED",
+			Message: "Invalid API key",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("invalid-key", WithBaseURL(server.URL))
+	_, err := client.Account.Get(context.Background())
+
+	if !IsAuthenticationError(err) {
+		t.Errorf("expected AuthenticationError, got %T", err)
+	}
+}
+
+func TestAccountUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		if r.URL.Path != "/account" {
+			t.Errorf("expected path '/account', got '%s'", r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["timezone"] != "Europe/Berlin" || body["notificationEmail"] != "alerts@example.com" {
+			t.Errorf("unexpected request body %v", body)
+		}
+		if _, ok := body["name"]; ok {
+			t.Error("expected unset name to be omitted")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"user_123","email":"ops@example.com","notification_email":"alerts@example.com","timezone":"Europe/Berlin"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	email := "alerts@example.com"
+	timezone := "Europe/Berlin"
+	account, err := client.Account.Update(context.Background(), UpdateAccountRequest{
+		NotificationEmail: &email,
+		Timezone:          &timezone,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if account.Timezone != "Europe/Berlin" {
+		t.Errorf("expected Timezone to be 'Europe/Berlin', got '%s'", account.Timezone)
+	}
+	if account.NotificationEmail == nil || *account.NotificationEmail != email {
+		t.Errorf("expected NotificationEmail to be '%s', got %v", email, account.NotificationEmail)
+	}
+}
+
+func TestAccountUpdate_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+	empty := ""
+	notEmail := "alerts"
+	requests := []UpdateAccountRequest{
+		{},
+		{Name: &empty},
+		{NotificationEmail: &notEmail},
+		{Timezone: &empty},
+	}
+
+	for _, req := range requests {
+		if _, err := client.Account.Update(context.Background(), req); !IsValidationError(err) {
+			t.Errorf("expected ValidationError for %+v, got %v", req, err)
+		}
+	}
+}
+
+func TestAccountGetCredits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/credits" {
+			t.Errorf("expected path '/credits', got '%s'", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"balance":150,"reserved_balance":50,"available_balance":100}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	credits, err := client.Account.GetCredits(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if credits.Balance != 150 {
+		t.Errorf("expected Balance to be 150, got %d", credits.Balance)
+	}
+	if credits.ReservedBalance != 50 {
+		t.Errorf("expected ReservedBalance to be 50, got %d", credits.ReservedBalance)
+	}
+	if credits.AvailableBalance != 100 {
+		t.Errorf("expected AvailableBalance to be 100, got %d", credits.AvailableBalance)
+	}
+}
+
+func TestAccountGetAutoTopUp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/credits/auto-top-up" {
+			t.Errorf("expected path '/credits/auto-top-up', got '%s'", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"enabled":true,"threshold":500,"amount":2000}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	config, err := client.Account.GetAutoTopUp(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !config.Enabled || config.Threshold != 500 || config.Amount != 2000 {
+		t.Errorf("expected enabled config with threshold 500 and amount 2000, got %+v", config)
+	}
+}
+
+func TestAccountSetAutoTopUp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+
+		var req AutoTopUpConfig
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.Enabled || req.Threshold != 100 || req.Amount != 1000 {
+			t.Errorf("unexpected request body %+v", req)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"enabled":true,"threshold":100,"amount":1000}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	config, err := client.Account.SetAutoTopUp(context.Background(), AutoTopUpConfig{
+		Enabled:   true,
+		Threshold: 100,
+		Amount:    1000,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Amount != 1000 {
+		t.Errorf("expected Amount to be 1000, got %d", config.Amount)
+	}
+}
+
+func TestAccountSetAutoTopUp_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+	configs := []AutoTopUpConfig{
+		{Enabled: true, Threshold: 100},
+		{Threshold: -1, Amount: 100},
+		{Enabled: true, Threshold: 100, Amount: -5},
+	}
+
+	for _, config := range configs {
+		if _, err := client.Account.SetAutoTopUp(context.Background(), config); !IsValidationError(err) {
+			t.Errorf("expected ValidationError for %+v, got %v", config, err)
+		}
+	}
+}
+
+func TestAccountGetCreditTransactions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/credits/transactions" {
+			t.Errorf("expected path '/credits/transactions', got '%s'", r.URL.Path)
+		}
+		if r.URL.Query().Get("limit") != "10" {
+			t.Errorf("expected limit to be '10', got '%s'", r.URL.Query().Get("limit"))
+		}
+		if r.URL.Query().Get("offset") != "20" {
+			t.Errorf("expected offset to be '20', got '%s'", r.URL.Query().Get("offset"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id":"txn_1","type":"usage","amount":-2,"balance_after":98,"description":"SMS","message_id":"msg_1","created_at":"2024-01-01T00:00:00Z"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	txns, err := client.Account.GetCreditTransactions(context.Background(), &ListCreditTransactionsOptions{Limit: 10, Offset: 20})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(txns) != 1 {
+		t.Fatalf("expected 1 transaction, got %d", len(txns))
+	}
+	if txns[0].Type != TransactionTypeUsage {
+		t.Errorf("expected Type to be 'usage', got '%s'", txns[0].Type)
+	}
+	if txns[0].Amount != -2 {
+		t.Errorf("expected Amount to be -2, got %d", txns[0].Amount)
+	}
+	if txns[0].BalanceAfter != 98 {
+		t.Errorf("expected BalanceAfter to be 98, got %d", txns[0].BalanceAfter)
+	}
+	if txns[0].MessageID == nil || *txns[0].MessageID != "msg_1" {
+		t.Errorf("expected MessageID to be 'msg_1', got %v", txns[0].MessageID)
+	}
+}
+
+func TestAccountListAPIKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/keys" {
+			t.Errorf("expected path '/keys', got '%s'", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id":"key_1","name":"Production","type":"live","prefix":"sk_live_v1_","last_four":"abcd","permissions":["sms:send"],"created_at":"2024-01-01T00:00:00Z","is_revoked":false}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	keys, err := client.Account.ListAPIKeys(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(keys) != 1 {
+		t.Fatalf("expected 1 key, got %d", len(keys))
+	}
+	if keys[0].Name != "Production" {
+		t.Errorf("expected Name to be 'Production', got '%s'", keys[0].Name)
+	}
+	if keys[0].LastFour != "abcd" {
+		t.Errorf("expected LastFour to be 'abcd', got '%s'", keys[0].LastFour)
+	}
+	if len(keys[0].Permissions) != 1 || keys[0].Permissions[0] != "sms:send" {
+		t.Errorf("expected Permissions to be [sms:send], got %v", keys[0].Permissions)
+	}
+}
+
+func TestAccountGetAPIKey_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/keys/key_missing" {
+			t.Errorf("expected path '/keys/key_missing', got '%s'", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(APIError{Code: "NOT_FOUND", Message: "API key not found"})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	_, err := client.Account.GetAPIKey(context.Background(), "key_missing")
+
+	if !IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %T", err)
+	}
+}
+
+func TestAccountCreateAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/account/keys" {
+			t.Errorf("expected path '/account/keys', got '%s'", r.URL.Path)
+		}
+
+		var req CreateAPIKeyRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Name != "CI" {
+			t.Errorf("expected Name to be 'CI', got '%s'", req.Name)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"apiKey":{"id":"key_2","name":"CI","type":"test"},"key":"sk_test_v1_secret"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.Account.CreateAPIKey(context.Background(), "CI")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.APIKey.ID != "key_2" {
+		t.Errorf("expected APIKey.ID to be 'key_2', got '%s'", resp.APIKey.ID)
+	}
+	if resp.Key != "sk_test_v1_secret" {
+		t.Errorf("expected Key to be 'sk_test_v1_secret', got '%s'", resp.Key)
+	}
+}
+
+func TestAccountCreateAPIKey_MissingName(t *testing.T) {
+	client := NewClient("test-api-key")
+	_, err := client.Account.CreateAPIKey(context.Background(), "")
+
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
+
+func TestAccountCreateAPIKeyWithOptions_Scoped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CreateAPIKeyRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Type != APIKeyTypeLive {
+			t.Errorf("expected Type to be 'live', got '%s'", req.Type)
+		}
+		if len(req.Permissions) != 1 || req.Permissions[0] != PermissionSMSSend {
+			t.Errorf("expected Permissions to be [sms:send], got %v", req.Permissions)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"apiKey":{"id":"key_3","name":"Sender","type":"live","permissions":["sms:send"]},"key":"sk_live_v1_secret"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.Account.CreateAPIKeyWithOptions(context.Background(), CreateAPIKeyRequest{
+		Name:        "Sender",
+		Type:        APIKeyTypeLive,
+		Permissions: SendOnlyPermissions,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.APIKey.Type != "live" {
+		t.Errorf("expected APIKey.Type to be 'live', got '%s'", resp.APIKey.Type)
+	}
+}
+
+func TestAccountCreateAPIKeyWithOptions_InvalidType(t *testing.T) {
+	client := NewClient("test-api-key")
+	_, err := client.Account.CreateAPIKeyWithOptions(context.Background(), CreateAPIKeyRequest{
+		Name: "CI",
+		Type: "staging",
+	})
+
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
+
+func TestAccountUpdateAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		if r.URL.Path != "/account/keys/key_1" {
+			t.Errorf("expected path '/account/keys/key_1', got '%s'", r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "Renamed" {
+			t.Errorf("expected name to be 'Renamed', got '%v'", body["name"])
+		}
+		if _, ok := body["expiresAt"]; ok {
+			t.Error("expected expiresAt to be omitted")
+		}
+		if perms, ok := body["permissions"].([]interface{}); !ok || len(perms) != 0 {
+			t.Errorf("expected permissions to be an empty list, got %v", body["permissions"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"key_1","name":"Renamed","type":"live","permissions":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	name := "Renamed"
+	permissions := []string{}
+	key, err := client.Account.UpdateAPIKey(context.Background(), "key_1", UpdateAPIKeyRequest{
+		Name:        &name,
+		Permissions: &permissions,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if key.Name != "Renamed" {
+		t.Errorf("expected Name to be 'Renamed', got '%s'", key.Name)
+	}
+}
+
+func TestAccountUpdateAPIKey_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+	empty := ""
+
+	if _, err := client.Account.UpdateAPIKey(context.Background(), "", UpdateAPIKeyRequest{}); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for missing ID, got %T", err)
+	}
+	if _, err := client.Account.UpdateAPIKey(context.Background(), "key_1", UpdateAPIKeyRequest{Name: &empty}); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for empty name, got %T", err)
+	}
+}
+
+func TestAccountRevokeAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/account/keys/key_1" {
+			t.Errorf("expected path '/account/keys/key_1', got '%s'", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	if err := client.Account.RevokeAPIKey(context.Background(), "key_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAccountStartEmailVerification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/account/verifications" {
+			t.Errorf("expected path '/account/verifications', got '%s'", r.URL.Path)
+		}
+
+		var req startVerificationRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Channel != VerificationChannelEmail {
+			t.Errorf("expected channel to be 'email', got '%s'", req.Channel)
+		}
+		if req.Target != "ops@example.com" {
+			t.Errorf("expected target to be 'ops@example.com', got '%s'", req.Target)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"ver_123","channel":"email","target":"ops@example.com","status":"pending","expires_at":"2024-01-01T00:10:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	v, err := client.Account.StartEmailVerification(context.Background(), "ops@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v.ID != "ver_123" {
+		t.Errorf("expected ID to be 'ver_123', got '%s'", v.ID)
+	}
+	if v.Status != VerificationStatusPending {
+		t.Errorf("expected Status to be 'pending', got '%s'", v.Status)
+	}
+	if v.ExpiresAt != "2024-01-01T00:10:00Z" {
+		t.Errorf("expected ExpiresAt to be '2024-01-01T00:10:00Z', got '%s'", v.ExpiresAt)
+	}
+}
+
+func TestAccountStartTestNumberVerification_InvalidPhone(t *testing.T) {
+	client := NewClient("test-api-key")
+	_, err := client.Account.StartTestNumberVerification(context.Background(), "5551234567")
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
+
+func TestAccountConfirmVerification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/account/verifications/ver_123/confirm" {
+			t.Errorf("expected path '/account/verifications/ver_123/confirm', got '%s'", r.URL.Path)
+		}
+
+		var req confirmVerificationRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Code != "123456" {
+			t.Errorf("expected code to be '123456', got '%s'", req.Code)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"ver_123","channel":"sms","target":"+15551234567","status":"verified","expires_at":"2024-01-01T00:10:00Z","verified_at":"2024-01-01T00:01:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	v, err := client.Account.ConfirmVerification(context.Background(), "ver_123", "123456")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v.Channel != VerificationChannelSMS {
+		t.Errorf("expected Channel to be 'sms', got '%s'", v.Channel)
+	}
+	if v.Status != VerificationStatusVerified {
+		t.Errorf("expected Status to be 'verified', got '%s'", v.Status)
+	}
+	if v.VerifiedAt == nil || *v.VerifiedAt != "2024-01-01T00:01:00Z" {
+		t.Errorf("expected VerifiedAt to be set, got %v", v.VerifiedAt)
+	}
+}
+
+func TestAccountConfirmVerification_MissingCode(t *testing.T) {
+	client := NewClient("test-api-key")
+	_, err := client.Account.ConfirmVerification(context.Background(), "ver_123", "")
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
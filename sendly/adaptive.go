@@ -0,0 +1,82 @@
+package sendly
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// minAdaptiveRate is the slowest the adaptive limiter will go, in requests per second.
+const minAdaptiveRate = 0.1
+
+// adaptiveRecoverySteps is how many successful responses it takes to climb
+// from a standstill back to the ceiling after a 429.
+const adaptiveRecoverySteps = 10
+
+// WithAdaptiveRateLimit makes the client-side limiter follow server feedback:
+// it slows to the pace allowed by X-RateLimit-Remaining and X-RateLimit-Reset,
+// halves its rate after each 429, and recovers gradually on success. The rate
+// never exceeds the configured limit (see WithRateLimit).
+func WithAdaptiveRateLimit() ClientOption {
+	return func(c *Client) {
+		c.adaptive = &adaptiveLimiter{}
+	}
+}
+
+// adaptiveLimiter adjusts a rate.Limiter using additive increase and
+// multiplicative decrease.
+type adaptiveLimiter struct {
+	mu      sync.Mutex
+	limiter *rate.Limiter
+	base    rate.Limit
+}
+
+// attach binds the adaptive limiter to the client's limiter, creating a
+// default limiter if rate limiting was disabled.
+func (a *adaptiveLimiter) attach(c *Client) {
+	if c.rateLimiter == nil {
+		c.rateLimiter = rate.NewLimiter(DefaultRateLimit, DefaultRateBurst)
+	}
+	a.limiter = c.rateLimiter
+	a.base = c.rateLimiter.Limit()
+}
+
+// observe updates the rate from one response.
+func (a *adaptiveLimiter) observe(statusCode int, state *RateLimitState, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	current := a.limiter.Limit()
+
+	if statusCode == http.StatusTooManyRequests {
+		a.limiter.SetLimitAt(now, clampRate(current/2, a.base))
+		return
+	}
+
+	ceiling := a.base
+	if state != nil && state.Limit > 0 {
+		if until := state.Reset.Sub(now); until > 0 {
+			if allowed := rate.Limit(float64(state.Remaining) / until.Seconds()); allowed < ceiling {
+				ceiling = allowed
+			}
+		}
+	}
+
+	next := current + a.base/adaptiveRecoverySteps
+	if next > ceiling {
+		next = ceiling
+	}
+	a.limiter.SetLimitAt(now, clampRate(next, a.base))
+}
+
+func clampRate(r, max rate.Limit) rate.Limit {
+	if r < minAdaptiveRate {
+		return minAdaptiveRate
+	}
+	if r > max {
+		return max
+	}
+	return r
+}
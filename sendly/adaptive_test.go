@@ -0,0 +1,76 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestAdaptiveRateLimit_BackoffAndRecovery(t *testing.T) {
+	client := NewClient("test-api-key", WithRateLimit(20, 20), WithAdaptiveRateLimit())
+	a := client.adaptive
+	now := time.Now()
+
+	a.observe(http.StatusTooManyRequests, nil, now)
+	if got := client.rateLimiter.Limit(); got != 10 {
+		t.Errorf("expected rate to halve to 10 after a 429, got %v", got)
+	}
+	a.observe(http.StatusTooManyRequests, nil, now)
+	if got := client.rateLimiter.Limit(); got != 5 {
+		t.Errorf("expected rate to halve to 5 after a second 429, got %v", got)
+	}
+
+	a.observe(http.StatusOK, nil, now)
+	if got := client.rateLimiter.Limit(); got != 7 {
+		t.Errorf("expected rate to recover by 2 to 7, got %v", got)
+	}
+
+	for i := 0; i < adaptiveRecoverySteps; i++ {
+		a.observe(http.StatusOK, nil, now)
+	}
+	if got := client.rateLimiter.Limit(); got != 20 {
+		t.Errorf("expected rate to recover to the configured 20, got %v", got)
+	}
+}
+
+func TestAdaptiveRateLimit_FollowsHeaders(t *testing.T) {
+	client := NewClient("test-api-key", WithRateLimit(20, 20), WithAdaptiveRateLimit())
+	now := time.Now()
+
+	client.adaptive.observe(http.StatusOK, &RateLimitState{
+		Limit:     600,
+		Remaining: 50,
+		Reset:     now.Add(10 * time.Second),
+	}, now)
+
+	if got := client.rateLimiter.Limit(); got != 5 {
+		t.Errorf("expected rate to slow to 5 (50 remaining over 10s), got %v", got)
+	}
+}
+
+func TestAdaptiveRateLimit_WithoutRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "1")
+		w.Header().Set("X-RateLimit-Reset", "10")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithoutRateLimit(), WithAdaptiveRateLimit())
+	if client.rateLimiter == nil {
+		t.Fatal("expected adaptive mode to install a limiter")
+	}
+
+	if _, err := client.Messages.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := client.rateLimiter.Limit(); got > rate.Limit(0.2) {
+		t.Errorf("expected rate to slow to about 0.1, got %v", got)
+	}
+}
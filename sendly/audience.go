@@ -0,0 +1,192 @@
+package sendly
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// OptInStatus is a contact's marketing consent.
+type OptInStatus string
+
+const (
+	// OptInStatusOptedIn means the contact agreed to receive marketing messages.
+	OptInStatusOptedIn OptInStatus = "opted_in"
+	// OptInStatusOptedOut means the contact replied STOP or otherwise withdrew consent.
+	OptInStatusOptedOut OptInStatus = "opted_out"
+	// OptInStatusPending means the contact has not confirmed consent yet.
+	OptInStatusPending OptInStatus = "pending"
+)
+
+// SegmentFilter selects the contacts in an audience segment. A contact must
+// match every filter that is set. Segments are evaluated when messages are
+// sent, so new contacts that match are included automatically.
+type SegmentFilter struct {
+	// Tags matches contacts with any of these tags.
+	Tags []string `json:"tags,omitempty"`
+	// Countries matches contacts in any of these countries, as ISO 3166-1
+	// alpha-2 codes such as "US".
+	Countries []string `json:"countries,omitempty"`
+	// OptInStatus matches contacts with this consent status.
+	OptInStatus OptInStatus `json:"optInStatus,omitempty"`
+}
+
+// AudienceSegment is a saved, server-side group of contacts to send
+// marketing messages to.
+type AudienceSegment struct {
+	// ID is the segment ID (seg_xxx).
+	ID string `json:"id"`
+	// Name is the segment name.
+	Name string `json:"name"`
+	// Filter selects the segment's contacts.
+	Filter SegmentFilter `json:"filter"`
+	// ContactCount is the number of contacts currently in the segment.
+	ContactCount int `json:"contactCount"`
+	// CreatedAt is when the segment was created.
+	CreatedAt string `json:"createdAt"`
+	// UpdatedAt is when the segment was last changed.
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// CreateSegmentRequest is the request to create an audience segment.
+type CreateSegmentRequest struct {
+	// Name is the segment name (required).
+	Name string `json:"name"`
+	// Filter selects the segment's contacts.
+	Filter SegmentFilter `json:"filter"`
+}
+
+// UpdateSegmentRequest is the request to update an audience segment. Nil
+// fields are left unchanged.
+type UpdateSegmentRequest struct {
+	// Name is the new segment name.
+	Name *string `json:"name,omitempty"`
+	// Filter replaces the segment's filter.
+	Filter *SegmentFilter `json:"filter,omitempty"`
+}
+
+// ListSegmentsRequest is the request to list audience segments.
+type ListSegmentsRequest struct {
+	// Limit is the maximum number of segments to return (default: 20, max: 100).
+	Limit int
+	// Offset is the number of segments to skip.
+	Offset int
+}
+
+// ListSegmentsResponse is the response from listing audience segments.
+type ListSegmentsResponse = Page[AudienceSegment]
+
+// sendToSegmentRequest is the body of a segment send.
+type sendToSegmentRequest struct {
+	Text string `json:"text"`
+}
+
+// SegmentsService manages audience segments. Send to a segment with
+// Messages.SendToSegment.
+type SegmentsService struct {
+	client *Client
+}
+
+// Create creates an audience segment.
+func (s *SegmentsService) Create(ctx context.Context, req CreateSegmentRequest) (*AudienceSegment, error) {
+	if req.Name == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "segment name is required"}}
+	}
+
+	var resp AudienceSegment
+	if err := s.client.request(ctx, "POST", "/segments", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List retrieves audience segments.
+func (s *SegmentsService) List(ctx context.Context, req *ListSegmentsRequest) (*ListSegmentsResponse, error) {
+	params := make(map[string]string)
+	offset := 0
+	if req != nil {
+		if req.Limit > 0 {
+			params["limit"] = strconv.Itoa(req.Limit)
+		}
+		offset = req.Offset
+		if req.Offset > 0 {
+			params["offset"] = strconv.Itoa(req.Offset)
+		}
+	}
+
+	var resp ListSegmentsResponse
+	if err := s.client.request(ctx, "GET", "/segments"+buildQueryString(params), nil, &resp); err != nil {
+		return nil, err
+	}
+	resp.fill(offset)
+	return &resp, nil
+}
+
+// Get retrieves an audience segment by ID.
+func (s *SegmentsService) Get(ctx context.Context, id string) (*AudienceSegment, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "segment ID is required"}}
+	}
+
+	var resp AudienceSegment
+	if err := s.client.request(ctx, "GET", "/segments/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Update changes an audience segment's name or filter.
+func (s *SegmentsService) Update(ctx context.Context, id string, req UpdateSegmentRequest) (*AudienceSegment, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "segment ID is required"}}
+	}
+	if req.Name != nil && *req.Name == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "segment name cannot be empty"}}
+	}
+
+	var resp AudienceSegment
+	if err := s.client.request(ctx, "PATCH", "/segments/"+url.PathEscape(id), req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Delete deletes an audience segment. Its contacts are not affected.
+func (s *SegmentsService) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &ValidationError{APIError: APIError{Message: "segment ID is required"}}
+	}
+	return s.client.request(ctx, "DELETE", "/segments/"+url.PathEscape(id), nil, nil)
+}
+
+// SendToSegment sends text to every contact in an audience segment as a
+// single server-side batch, without downloading the contact list. Contacts
+// that have opted out are always skipped. Track progress with GetBatch. It
+// fails on a WithDryRun client, as a segment cannot be priced without sending.
+func (s *MessagesService) SendToSegment(ctx context.Context, segmentID, text string) (*BatchMessageResponse, error) {
+	if segmentID == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "segment ID is required"}}
+	}
+	if text == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "text is required"}}
+	}
+	if s.client.dryRun {
+		return nil, errDryRunUnsupported("SendToSegment")
+	}
+	if err := s.checkSendWindow(MessageTypeMarketing); err != nil {
+		return nil, err
+	}
+	if err := s.client.budget.check(); err != nil {
+		return nil, err
+	}
+
+	var resp BatchMessageResponse
+	path := "/segments/" + url.PathEscape(segmentID) + "/messages"
+	if err := s.client.request(ctx, "POST", path, &sendToSegmentRequest{Text: text}, &resp); err != nil {
+		return nil, err
+	}
+
+	s.rememberBatch(&resp)
+
+	return &resp, nil
+}
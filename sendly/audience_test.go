@@ -0,0 +1,154 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSegmentsCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/segments" {
+			t.Errorf("expected path '/segments', got '%s'", r.URL.Path)
+		}
+
+		var req CreateSegmentRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Name != "VIPs" || len(req.Filter.Tags) != 1 || req.Filter.OptInStatus != OptInStatusOptedIn {
+			t.Errorf("unexpected request body %+v", req)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"seg_1","name":"VIPs","filter":{"tags":["vip"],"countries":["US"],"optInStatus":"opted_in"},"contactCount":42}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	seg, err := client.Segments.Create(context.Background(), CreateSegmentRequest{
+		Name:   "VIPs",
+		Filter: SegmentFilter{Tags: []string{"vip"}, Countries: []string{"US"}, OptInStatus: OptInStatusOptedIn},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if seg.ID != "seg_1" || seg.ContactCount != 42 || seg.Filter.Countries[0] != "US" {
+		t.Errorf("unexpected segment %+v", seg)
+	}
+}
+
+func TestSegmentsList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("offset"); got != "20" {
+			t.Errorf("expected offset to be '20', got '%s'", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"seg_21","name":"Lapsed"}],"count":21}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.Segments.List(context.Background(), &ListSegmentsRequest{Offset: 20})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 1 || resp.Data[0].ID != "seg_21" || resp.HasMore {
+		t.Errorf("unexpected page %+v", resp)
+	}
+}
+
+func TestSegmentsUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		if r.URL.Path != "/segments/seg_1" {
+			t.Errorf("expected path '/segments/seg_1', got '%s'", r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["name"]; ok {
+			t.Error("expected name to be omitted")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"seg_1","name":"VIPs","filter":{"countries":["CA"]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	seg, err := client.Segments.Update(context.Background(), "seg_1", UpdateSegmentRequest{
+		Filter: &SegmentFilter{Countries: []string{"CA"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if seg.Filter.Countries[0] != "CA" {
+		t.Errorf("unexpected segment %+v", seg)
+	}
+}
+
+func TestSegments_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+	ctx := context.Background()
+	empty := ""
+
+	if _, err := client.Segments.Create(ctx, CreateSegmentRequest{}); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for missing name, got %T", err)
+	}
+	if _, err := client.Segments.Update(ctx, "seg_1", UpdateSegmentRequest{Name: &empty}); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for empty name, got %T", err)
+	}
+	if err := client.Segments.Delete(ctx, ""); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for missing ID, got %T", err)
+	}
+	if _, err := client.Messages.SendToSegment(ctx, "", "Hello"); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for missing segment ID, got %T", err)
+	}
+	if _, err := client.Messages.SendToSegment(ctx, "seg_1", ""); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for missing text, got %T", err)
+	}
+}
+
+func TestMessagesSendToSegment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/segments/seg_1/messages" {
+			t.Errorf("expected path '/segments/seg_1/messages', got '%s'", r.URL.Path)
+		}
+
+		var req sendToSegmentRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Text != "Spring sale!" {
+			t.Errorf("expected text to be 'Spring sale!', got '%s'", req.Text)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"batchId":"batch_1","status":"processing","total":42,"queued":42,"creditsUsed":42}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCreditBudget(100))
+	batch, err := client.Messages.SendToSegment(context.Background(), "seg_1", "Spring sale!")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if batch.BatchID != "batch_1" || batch.Total != 42 {
+		t.Errorf("unexpected batch %+v", batch)
+	}
+	if got := client.CreditsSpent(); got != 42 {
+		t.Errorf("expected 42 credits spent, got %d", got)
+	}
+}
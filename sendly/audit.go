@@ -0,0 +1,214 @@
+package sendly
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// AuditRecord describes one call that changed state, such as a send, for a
+// compliance trail of who texted whom.
+type AuditRecord struct {
+	// Timestamp is when the call completed.
+	Timestamp time.Time `json:"timestamp"`
+	// Method is the HTTP method.
+	Method string `json:"method"`
+	// Path is the request path, with any phone number in it hashed like
+	// Recipients.
+	Path string `json:"path"`
+	// SubAccount is the sub-account the call acted for, if any.
+	SubAccount string `json:"subAccount,omitempty"`
+	// Recipients are the phone numbers the call addressed, hashed unless
+	// recorded in plain text with WithAuditPlainRecipients.
+	Recipients []string `json:"recipients,omitempty"`
+	// Success reports whether the API accepted the call.
+	Success bool `json:"success"`
+	// StatusCode is the HTTP status of the final attempt, or 0 if no
+	// response was received.
+	StatusCode int `json:"statusCode,omitempty"`
+	// Error is the error message if the call failed.
+	Error string `json:"error,omitempty"`
+	// RequestID is the API's correlation ID for a failed call, if known.
+	RequestID string `json:"requestId,omitempty"`
+	// CreditsUsed is the number of credits a send reported consuming.
+	CreditsUsed int `json:"creditsUsed,omitempty"`
+}
+
+// AuditSink receives audit records. Audit is called on the goroutine that
+// made the call, after it completes, and must be safe for concurrent use.
+// Sinks handle their own write failures; they cannot fail the call.
+type AuditSink interface {
+	Audit(ctx context.Context, record AuditRecord)
+}
+
+// AuditSinkFunc adapts a function to an AuditSink.
+type AuditSinkFunc func(ctx context.Context, record AuditRecord)
+
+// Audit calls f(ctx, record).
+func (f AuditSinkFunc) Audit(ctx context.Context, record AuditRecord) {
+	f(ctx, record)
+}
+
+// JSONAuditSink writes each record as a line of JSON to an io.Writer, such
+// as an append-only file.
+type JSONAuditSink struct {
+	mu  sync.Mutex
+	w   io.Writer
+	err error
+}
+
+// NewJSONAuditSink creates a sink that writes JSON lines to w.
+func NewJSONAuditSink(w io.Writer) *JSONAuditSink {
+	return &JSONAuditSink{w: w}
+}
+
+// Audit writes record as one line of JSON.
+func (s *JSONAuditSink) Audit(ctx context.Context, record AuditRecord) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(line); err != nil && s.err == nil {
+		s.err = err
+	}
+}
+
+// Err returns the first error writing to the underlying writer, if any.
+func (s *JSONAuditSink) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// AuditOption configures how audit records are built.
+type AuditOption func(*auditor)
+
+// WithAuditHashKey hashes recipients with HMAC-SHA256 under key instead of
+// plain SHA-256. Phone numbers are few enough that plain hashes can be
+// reversed by brute force, so a secret key is recommended.
+func WithAuditHashKey(key []byte) AuditOption {
+	return func(a *auditor) {
+		a.hashKey = append([]byte(nil), key...)
+	}
+}
+
+// WithAuditPlainRecipients records recipients as plain phone numbers, for
+// trails kept where storing them is permitted.
+func WithAuditPlainRecipients() AuditOption {
+	return func(a *auditor) {
+		a.plain = true
+	}
+}
+
+// WithAuditSink writes an audit record to sink for every call that changes
+// state: sends, schedules, cancellations, and account, key, webhook, and
+// sub-account changes. Reads are not audited. Recipients are hashed with
+// SHA-256 by default; see WithAuditHashKey and WithAuditPlainRecipients.
+func WithAuditSink(sink AuditSink, opts ...AuditOption) ClientOption {
+	return func(c *Client) {
+		a := &auditor{sink: sink}
+		for _, opt := range opts {
+			opt(a)
+		}
+		c.auditor = a
+	}
+}
+
+// auditor builds audit records and hands them to a sink.
+type auditor struct {
+	sink    AuditSink
+	hashKey []byte
+	plain   bool
+}
+
+// recipienter is implemented by request bodies that address recipients.
+type recipienter interface {
+	recipients() []string
+}
+
+func (r *SendMessageRequest) recipients() []string { return []string{r.To} }
+
+func (r *ScheduleMessageRequest) recipients() []string { return []string{r.To} }
+
+func (r *SendBatchRequest) recipients() []string { return batchRecipients(r.Messages) }
+
+func (r *CreateBatchDraftRequest) recipients() []string { return batchRecipients(r.Messages) }
+
+func (r *addDraftItemsRequest) recipients() []string { return batchRecipients(r.Messages) }
+
+func (r *sendTransactionRequest) recipients() []string {
+	to := make([]string, len(r.Messages))
+	for i, m := range r.Messages {
+		to[i] = m.To
+	}
+	return to
+}
+
+func batchRecipients(items []BatchMessageItem) []string {
+	to := make([]string, len(items))
+	for i, item := range items {
+		to[i] = item.To
+	}
+	return to
+}
+
+// audit records a completed call if it changed state.
+func (c *Client) audit(ctx context.Context, method, path string, body, result interface{}, status int, err error) {
+	if c.auditor == nil || method == http.MethodGet {
+		return
+	}
+	a := c.auditor
+
+	record := AuditRecord{
+		Timestamp:  time.Now().UTC(),
+		Method:     method,
+		Path:       phonePattern.ReplaceAllStringFunc(path, a.recipient),
+		SubAccount: c.subAccount,
+		Success:    err == nil,
+		StatusCode: status,
+	}
+	if sub := callOptionsFrom(ctx).subAccount; sub != "" {
+		record.SubAccount = sub
+	}
+	if r, ok := body.(recipienter); ok {
+		for _, to := range r.recipients() {
+			record.Recipients = append(record.Recipients, a.recipient(to))
+		}
+	}
+	if err != nil {
+		record.Error = err.Error()
+		var apiErr interface{ apiError() *APIError }
+		if errors.As(err, &apiErr) {
+			record.RequestID = apiErr.apiError().RequestID
+		}
+	} else if r, ok := result.(creditsReporter); ok {
+		record.CreditsUsed = r.creditsConsumed()
+	}
+
+	a.sink.Audit(ctx, record)
+}
+
+// recipient returns to as it should appear in a record.
+func (a *auditor) recipient(to string) string {
+	if a.plain {
+		return to
+	}
+	if a.hashKey != nil {
+		mac := hmac.New(sha256.New, a.hashKey)
+		mac.Write([]byte(to))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	sum := sha256.Sum256([]byte(to))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,205 @@
+package sendly
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// auditServer accepts sends and batches and rejects everything else.
+func auditServer(t *testing.T) *httptest.Server {
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/messages":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id":"msg_1","status":"queued","creditsUsed":1}`))
+		case "/messages/batch":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"batchId":"batch_1","status":"processing","total":2,"creditsUsed":2}`))
+		default:
+			w.Header().Set(requestIDHeader, "req_123")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not_found","message":"Webhook not found"}`))
+		}
+	})
+	return server
+}
+
+// recordingSink collects audit records.
+type recordingSink struct {
+	mu      sync.Mutex
+	records []AuditRecord
+}
+
+func (s *recordingSink) Audit(ctx context.Context, record AuditRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestWithAuditSink_Send(t *testing.T) {
+	sink := &recordingSink{}
+	client := NewClient("test-api-key", WithBaseURL(auditServer(t).URL), WithAuditSink(sink))
+	ctx := WithCallOptions(context.Background(), WithCallSubAccount("sub_1"))
+
+	req := &SendMessageRequest{To: "+15551234567", Text: "Hello"}
+	if _, err := client.Messages.Send(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sink.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(sink.records))
+	}
+	record := sink.records[0]
+	if record.Method != "POST" || record.Path != "/messages" {
+		t.Errorf("expected POST /messages, got %s %s", record.Method, record.Path)
+	}
+	if !record.Success || record.StatusCode != http.StatusOK {
+		t.Errorf("expected a successful record with status 200, got %+v", record)
+	}
+	if record.SubAccount != "sub_1" {
+		t.Errorf("expected SubAccount to be 'sub_1', got '%s'", record.SubAccount)
+	}
+	if len(record.Recipients) != 1 || record.Recipients[0] != sha256Hex("+15551234567") {
+		t.Errorf("expected the SHA-256 of the recipient, got %v", record.Recipients)
+	}
+	if record.CreditsUsed != 1 {
+		t.Errorf("expected 1 credit used, got %d", record.CreditsUsed)
+	}
+	if record.Timestamp.IsZero() {
+		t.Error("expected a timestamp")
+	}
+}
+
+func TestWithAuditSink_BatchAndFailure(t *testing.T) {
+	sink := &recordingSink{}
+	client := NewClient("test-api-key", WithBaseURL(auditServer(t).URL), WithAuditSink(sink, WithAuditPlainRecipients()))
+	ctx := context.Background()
+
+	batch := &SendBatchRequest{Messages: []BatchMessageItem{
+		{To: "+15551234567", Text: "Hello"},
+		{To: "+15557654321", Text: "Hello"},
+	}}
+	if _, err := client.Messages.SendBatch(ctx, batch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.WebhooksService.Delete(ctx, "whk_missing"); !IsNotFoundError(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	if len(sink.records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(sink.records))
+	}
+	if got := strings.Join(sink.records[0].Recipients, ","); got != "+15551234567,+15557654321" {
+		t.Errorf("expected plain recipients, got '%s'", got)
+	}
+	failed := sink.records[1]
+	if failed.Success || failed.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a failed record with status 404, got %+v", failed)
+	}
+	if failed.RequestID != "req_123" {
+		t.Errorf("expected RequestID to be 'req_123', got '%s'", failed.RequestID)
+	}
+	if failed.Error == "" {
+		t.Error("expected the error message to be recorded")
+	}
+}
+
+func TestWithAuditSink_HashKey(t *testing.T) {
+	sink := &recordingSink{}
+	key := []byte("audit-secret")
+	client := NewClient("test-api-key", WithBaseURL(auditServer(t).URL), WithAuditSink(sink, WithAuditHashKey(key)))
+
+	req := &SendMessageRequest{To: "+15551234567", Text: "Hello"}
+	if _, err := client.Messages.Send(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("+15551234567"))
+	want := hex.EncodeToString(mac.Sum(nil))
+	if got := sink.records[0].Recipients[0]; got != want {
+		t.Errorf("expected HMAC '%s', got '%s'", want, got)
+	}
+}
+
+func TestWithAuditSink_SkipsReads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	sink := &recordingSink{}
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithAuditSink(sink))
+
+	if _, err := client.Messages.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sink.records) != 0 {
+		t.Errorf("expected no records for reads, got %d", len(sink.records))
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestJSONAuditSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONAuditSink(&buf)
+	client := NewClient("test-api-key", WithBaseURL(auditServer(t).URL), WithAuditSink(sink))
+
+	req := &SendMessageRequest{To: "+15551234567", Text: "Hello"}
+	client.Messages.Send(context.Background(), req)
+	client.Messages.Send(context.Background(), req)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	var record AuditRecord
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("expected a JSON record: %v", err)
+	}
+	if record.Path != "/messages" || !record.Success {
+		t.Errorf("expected a successful /messages record, got %+v", record)
+	}
+	if strings.Contains(buf.String(), "+15551234567") {
+		t.Error("expected the recipient not to appear in plain text")
+	}
+
+	failing := NewJSONAuditSink(failingWriter{})
+	failing.Audit(context.Background(), AuditRecord{Method: "POST"})
+	if failing.Err() == nil {
+		t.Error("expected the write error to be kept")
+	}
+}
+
+func TestWithAuditSink_HashesPhoneInPath(t *testing.T) {
+	sink := &recordingSink{}
+	client := NewClient("test-api-key", WithBaseURL(auditServer(t).URL), WithAuditSink(sink))
+
+	client.OptOuts.Remove(context.Background(), "+15551234567")
+
+	if len(sink.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(sink.records))
+	}
+	if got, want := sink.records[0].Path, "/opt-outs/"+sha256Hex("+15551234567"); got != want {
+		t.Errorf("expected Path '%s', got '%s'", want, got)
+	}
+}
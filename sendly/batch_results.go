@@ -0,0 +1,107 @@
+package sendly
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// DefaultBatchResultPageSize is the page size BatchResultIterator requests.
+const DefaultBatchResultPageSize = 100
+
+// ListBatchResultsRequest is the request to list one page of a batch's
+// per-message results.
+type ListBatchResultsRequest struct {
+	// Limit is the maximum number of results to return (default: 20, max: 100).
+	Limit int
+	// Offset is the number of results to skip.
+	Offset int
+}
+
+// ListBatchResultsResponse is one page of a batch's per-message results.
+type ListBatchResultsResponse = Page[BatchMessageResult]
+
+// ListBatchResults retrieves one page of a batch's per-message results,
+// without the rest of the batch. Use StreamBatchResults to walk them all.
+func (s *MessagesService) ListBatchResults(ctx context.Context, batchID string, req *ListBatchResultsRequest) (*ListBatchResultsResponse, error) {
+	if batchID == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "batch ID is required"}}
+	}
+
+	params := make(map[string]string)
+	offset := 0
+	if req != nil {
+		if req.Limit > 0 {
+			params["limit"] = strconv.Itoa(req.Limit)
+		}
+		offset = req.Offset
+		if req.Offset > 0 {
+			params["offset"] = strconv.Itoa(req.Offset)
+		}
+	}
+
+	path := "/messages/batch/" + url.PathEscape(batchID) + "/results" + buildQueryString(params)
+
+	var resp ListBatchResultsResponse
+	if err := s.client.request(ctx, "GET", path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	resp.fill(offset)
+	return &resp, nil
+}
+
+// BatchResultIterator walks every per-message result of a batch, fetching
+// pages as needed, so results of large batches are never held in memory at
+// once. Use it like bufio.Scanner:
+//
+//	it, err := client.Messages.StreamBatchResults(ctx, batchID)
+//	if err != nil {
+//		// handle error
+//	}
+//	for it.Next(ctx) {
+//		record(it.Result())
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type BatchResultIterator struct {
+	*PageIterator[BatchMessageResult]
+}
+
+// StreamBatchResults returns an iterator over a batch's per-message results.
+// The first page is fetched before returning, so an unknown batch is
+// reported here; later pages are fetched as the iterator reaches them.
+func (s *MessagesService) StreamBatchResults(ctx context.Context, batchID string) (*BatchResultIterator, error) {
+	return NewBatchResultIterator(ctx, s, batchID)
+}
+
+// NewBatchResultIterator implements MessagesAPI.StreamBatchResults on top of
+// messages.ListBatchResults, so alternative MessagesAPI implementations can
+// share it.
+func NewBatchResultIterator(ctx context.Context, messages MessagesAPI, batchID string) (*BatchResultIterator, error) {
+	fetch := func(ctx context.Context, offset int) (*Page[BatchMessageResult], error) {
+		return messages.ListBatchResults(ctx, batchID, &ListBatchResultsRequest{
+			Limit:  DefaultBatchResultPageSize,
+			Offset: offset,
+		})
+	}
+
+	first, err := fetch(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchResultIterator{NewPageIterator(0, func(ctx context.Context, offset int) (*Page[BatchMessageResult], error) {
+		if first != nil && offset == 0 {
+			page := first
+			first = nil
+			return page, nil
+		}
+		return fetch(ctx, offset)
+	})}, nil
+}
+
+// Result returns the result Next advanced to.
+func (it *BatchResultIterator) Result() BatchMessageResult {
+	return it.Item()
+}
@@ -0,0 +1,93 @@
+package sendly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMessagesStreamBatchResults(t *testing.T) {
+	const total = 250
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/messages/batch/batch_1/results" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if limit != DefaultBatchResultPageSize {
+			t.Errorf("expected limit %d, got %d", DefaultBatchResultPageSize, limit)
+		}
+
+		end := offset + limit
+		if end > total {
+			end = total
+		}
+		body := `{"data":[`
+		for i := offset; i < end; i++ {
+			if i > offset {
+				body += ","
+			}
+			body += fmt.Sprintf(`{"to":"+1555000%04d","messageId":"msg_%d","status":"delivered"}`, i, i)
+		}
+		body += fmt.Sprintf(`],"count":%d}`, total)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	ctx := context.Background()
+	it, err := client.Messages.StreamBatchResults(ctx, "batch_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected only the first page to be fetched up front, got %d requests", n)
+	}
+
+	count := 0
+	for it.Next(ctx) {
+		if want := fmt.Sprintf("msg_%d", count); *it.Result().MessageID != want {
+			t.Fatalf("expected %s at position %d, got %s", want, count, *it.Result().MessageID)
+		}
+		count++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != total {
+		t.Errorf("expected %d results, got %d", total, count)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+}
+
+func TestMessagesStreamBatchResults_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"not_found","message":"Batch not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	_, err := client.Messages.StreamBatchResults(context.Background(), "batch_missing")
+	if !IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestMessagesListBatchResults_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+	_, err := client.Messages.ListBatchResults(context.Background(), "", nil)
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// CircuitBreakerConfig configures WithCircuitBreaker.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive server or network failures
+	// that opens the breaker (default: 5).
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before letting a trial
+	// request through (default: 30 seconds).
+	OpenTimeout time.Duration
+	// OnStateChange is called whenever the breaker changes state.
+	OnStateChange func(from, to CircuitState)
+}
+
+// WithCircuitBreaker makes the client fail fast with a *CircuitOpenError
+// after sustained 5xx or network errors, instead of spending retries and
+// timeouts on every call during an outage. After OpenTimeout one trial request
+// is allowed; if it succeeds the breaker closes again.
+func WithCircuitBreaker(config CircuitBreakerConfig) ClientOption {
+	return func(c *Client) {
+		if config.FailureThreshold <= 0 {
+			config.FailureThreshold = 5
+		}
+		if config.OpenTimeout <= 0 {
+			config.OpenTimeout = 30 * time.Second
+		}
+		c.breaker = &circuitBreaker{config: config, state: CircuitStateClosed, now: time.Now}
+	}
+}
+
+// CircuitState returns the client's circuit breaker state, or
+// CircuitStateClosed if no breaker is configured.
+func (c *Client) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitStateClosed
+	}
+	c.breaker.mu.Lock()
+	defer c.breaker.mu.Unlock()
+	return c.breaker.state
+}
+
+// circuitBreaker tracks consecutive failures across requests.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	config   CircuitBreakerConfig
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	trial    bool
+	now      func() time.Time
+}
+
+// allow reports whether a request may proceed, moving an expired open
+// breaker to half-open and admitting a single trial request.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	var change func()
+	defer func() {
+		b.mu.Unlock()
+		if change != nil {
+			change()
+		}
+	}()
+
+	switch b.state {
+	case CircuitStateOpen:
+		retryAt := b.openedAt.Add(b.config.OpenTimeout)
+		if b.now().Before(retryAt) {
+			return &CircuitOpenError{RetryAt: retryAt}
+		}
+		change = b.setState(CircuitStateHalfOpen)
+		b.trial = true
+		return nil
+	case CircuitStateHalfOpen:
+		if b.trial {
+			return &CircuitOpenError{RetryAt: b.now()}
+		}
+		b.trial = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record updates the breaker with the outcome of one attempt.
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	var change func()
+	defer func() {
+		b.mu.Unlock()
+		if change != nil {
+			change()
+		}
+	}()
+
+	b.trial = false
+	// The caller giving up says nothing either way, so a cancelled trial
+	// only frees the slot for the next request.
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if !isServerFailure(err) {
+		b.failures = 0
+		if b.state != CircuitStateClosed {
+			change = b.setState(CircuitStateClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitStateHalfOpen || b.failures >= b.config.FailureThreshold {
+		b.openedAt = b.now()
+		if b.state != CircuitStateOpen {
+			change = b.setState(CircuitStateOpen)
+		}
+	}
+}
+
+// setState changes the state and returns the callback to run after unlocking.
+// Callers must hold b.mu.
+func (b *circuitBreaker) setState(to CircuitState) func() {
+	from := b.state
+	b.state = to
+	if b.config.OnStateChange == nil {
+		return nil
+	}
+	return func() { b.config.OnStateChange(from, to) }
+}
+
+// isServerFailure reports whether err counts against the breaker: network
+// errors and 5xx responses. Client errors mean the server is healthy.
+func isServerFailure(err error) bool {
+	if IsNetworkError(err) {
+		// The caller giving up says nothing about the server.
+		return !errors.Is(err, context.Canceled)
+	}
+	var apiErr *SendlyError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
+}
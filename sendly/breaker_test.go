@@ -0,0 +1,120 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	var healthy atomic.Bool
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":"unavailable","message":"down for maintenance"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	var changes []CircuitState
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithMaxRetries(0),
+		WithCircuitBreaker(CircuitBreakerConfig{
+			FailureThreshold: 3,
+			OpenTimeout:      time.Minute,
+			OnStateChange:    func(from, to CircuitState) { changes = append(changes, to) },
+		}),
+	)
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Messages.List(ctx, nil); IsCircuitOpenError(err) {
+			t.Fatalf("expected server error on call %d, got %v", i, err)
+		}
+	}
+	if client.CircuitState() != CircuitStateOpen {
+		t.Fatalf("expected breaker to be open, got '%s'", client.CircuitState())
+	}
+
+	_, err := client.Messages.List(ctx, nil)
+	if !IsCircuitOpenError(err) {
+		t.Fatalf("expected CircuitOpenError, got %T", err)
+	}
+	if hits != 3 {
+		t.Errorf("expected no request while open, got %d hits", hits)
+	}
+
+	healthy.Store(true)
+	now = now.Add(time.Minute)
+	if _, err := client.Messages.List(ctx, nil); err != nil {
+		t.Fatalf("expected trial request to succeed, got %v", err)
+	}
+	if client.CircuitState() != CircuitStateClosed {
+		t.Errorf("expected breaker to close, got '%s'", client.CircuitState())
+	}
+
+	want := []CircuitState{CircuitStateOpen, CircuitStateHalfOpen, CircuitStateClosed}
+	if len(changes) != len(want) {
+		t.Fatalf("expected state changes %v, got %v", want, changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("expected state change %d to be '%s', got '%s'", i, want[i], changes[i])
+		}
+	}
+}
+
+func TestCircuitBreaker_IgnoresClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"not_found","message":"Message not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1}),
+	)
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Messages.Get(context.Background(), "msg_missing"); !IsNotFoundError(err) {
+			t.Fatalf("expected NotFoundError, got %T", err)
+		}
+	}
+	if client.CircuitState() != CircuitStateClosed {
+		t.Errorf("expected breaker to stay closed, got '%s'", client.CircuitState())
+	}
+}
+
+func TestCircuitBreaker_CancelledTrialStaysHalfOpen(t *testing.T) {
+	now := time.Now()
+	b := &circuitBreaker{
+		config: CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute},
+		state:  CircuitStateOpen,
+		now:    func() time.Time { return now },
+	}
+	b.openedAt = now
+	now = now.Add(time.Minute)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected a trial request, got %v", err)
+	}
+	b.record(&NetworkError{Err: context.Canceled})
+	if b.state != CircuitStateHalfOpen {
+		t.Errorf("expected a cancelled trial to leave the breaker half-open, got '%s'", b.state)
+	}
+	if err := b.allow(); err != nil {
+		t.Errorf("expected the next request to get the trial, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package sendly
+
+import "sync"
+
+// WithCreditBudget caps the credits the client may spend. Credits reported
+// by Send, SendTransaction, SendBatch, LaunchDraft and SendToSegment
+// responses, and credits reserved by Schedule and by sends a SendWindow
+// defers, are tallied in-process, and once maxCredits have been spent
+// those calls fail with a *BudgetExceededError without contacting the API.
+// It is a safety net for runaway jobs, not an exact limit: the call that
+// crosses the budget still completes.
+func WithCreditBudget(maxCredits int) ClientOption {
+	b := &creditBudget{max: maxCredits}
+	return func(c *Client) {
+		c.budget = b
+	}
+}
+
+// CreditsSpent returns the credits spent by this client and its clones, as
+// tallied for WithCreditBudget. It is zero if no budget is set.
+func (c *Client) CreditsSpent() int {
+	if c.budget == nil {
+		return 0
+	}
+	c.budget.mu.Lock()
+	defer c.budget.mu.Unlock()
+	return c.budget.spent
+}
+
+// creditBudget tallies credits spent against a maximum.
+type creditBudget struct {
+	mu    sync.Mutex
+	max   int
+	spent int
+}
+
+// check returns a *BudgetExceededError if the budget is spent.
+func (b *creditBudget) check() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.spent >= b.max {
+		return &BudgetExceededError{Budget: b.max, Spent: b.spent}
+	}
+	return nil
+}
+
+// record adds credits to the tally.
+func (b *creditBudget) record(credits int) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spent += credits
+}
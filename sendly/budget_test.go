@@ -0,0 +1,116 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithCreditBudget(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Path == "/messages/batch" {
+			w.Write([]byte(`{"batchId":"batch_1","status":"processing","total":2,"creditsUsed":4}`))
+			return
+		}
+		w.Write([]byte(`{"id":"msg_1","status":"queued","creditsUsed":2}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCreditBudget(5))
+	ctx := context.Background()
+	req := &SendMessageRequest{To: "+15551234567", Text: "Hello"}
+
+	if _, err := client.Messages.Send(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	batch := &SendBatchRequest{Messages: []BatchMessageItem{
+		{To: "+15551234567", Text: "Hello"},
+		{To: "+15559876543", Text: "Hello"},
+	}}
+	if _, err := client.Messages.SendBatch(ctx, batch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := client.CreditsSpent(); got != 6 {
+		t.Errorf("expected 6 credits spent, got %d", got)
+	}
+
+	_, err := client.Messages.Send(ctx, req)
+	var exceeded *BudgetExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected BudgetExceededError, got %v", err)
+	}
+	if exceeded.Budget != 5 || exceeded.Spent != 6 {
+		t.Errorf("unexpected error %+v", exceeded)
+	}
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Error("expected error to match ErrBudgetExceeded")
+	}
+	if _, err := client.Messages.SendBatch(ctx, batch); !IsBudgetExceededError(err) {
+		t.Errorf("expected BudgetExceededError for the batch, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestWithCreditBudget_SchedulesAndDrafts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/messages/schedule":
+			w.Write([]byte(`{"id":"sched_1","status":"scheduled","creditsReserved":2}`))
+		default:
+			w.Write([]byte(`{"batchId":"batch_1","status":"processing","total":3,"creditsUsed":3}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCreditBudget(4))
+	ctx := context.Background()
+	schedule := &ScheduleMessageRequest{To: "+15551234567", Text: "Hello", SendAt: time.Now().Add(time.Hour)}
+
+	if _, err := client.Messages.Schedule(ctx, schedule); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Messages.LaunchDraft(ctx, "draft_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := client.CreditsSpent(); got != 5 {
+		t.Errorf("expected 5 credits spent, got %d", got)
+	}
+	if _, err := client.Messages.Schedule(ctx, schedule); !IsBudgetExceededError(err) {
+		t.Errorf("expected BudgetExceededError for the schedule, got %v", err)
+	}
+}
+
+func TestWithCreditBudget_DryRunsAllowed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"canSend":true,"totalMessages":1,"willSend":1,"creditsNeeded":1,"currentBalance":10,"hasEnoughCredits":true,"messages":[{"to":"+15551234567","canSend":true,"segments":1,"credits":1}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCreditBudget(0))
+
+	msg, err := client.Messages.Send(context.Background(), &SendMessageRequest{To: "+15551234567", Text: "Hello", DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !msg.DryRun {
+		t.Error("expected a dry run message")
+	}
+}
+
+func TestCreditsSpent_NoBudget(t *testing.T) {
+	client := NewClient("test-api-key")
+	if got := client.CreditsSpent(); got != 0 {
+		t.Errorf("expected 0 credits spent, got %d", got)
+	}
+}
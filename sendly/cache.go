@@ -0,0 +1,108 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheablePaths are GET endpoints whose responses change rarely enough to
+// cache: the account, its balance and top-up settings, API keys, and the
+// webhook event catalogue.
+var cacheablePaths = []string{
+	"/account",
+	"/credits",
+	"/credits/auto-top-up",
+	"/keys",
+	"/webhooks/event-types",
+}
+
+// WithCache caches responses from stable GET endpoints, such as the account,
+// credit balance, and API keys, for ttl. It is meant for dashboards that
+// refresh every second. Any successful call that changes state, such as a
+// send, clears the cache, as does InvalidateCache.
+func WithCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.cache = &responseCache{ttl: ttl}
+	}
+}
+
+// InvalidateCache discards all responses cached by WithCache, so the next
+// call fetches fresh data. It does nothing if caching is not enabled.
+func (c *Client) InvalidateCache() {
+	if c.cache != nil {
+		c.cache.clear()
+	}
+}
+
+// responseCache holds encoded responses by sub-account and path.
+type responseCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// isCacheable reports whether a GET for path may be served from the cache.
+func isCacheable(path string) bool {
+	for _, p := range cacheablePaths {
+		if path == p {
+			return true
+		}
+	}
+	return strings.HasPrefix(path, "/keys/") && !strings.HasSuffix(path, "/usage")
+}
+
+// cacheKey returns the cache key for a request, or false if the response
+// must not be cached.
+func (c *Client) cacheKey(ctx context.Context, method, path string, result interface{}) (string, bool) {
+	if c.cache == nil || method != http.MethodGet || result == nil || !isCacheable(path) {
+		return "", false
+	}
+	subAccount := c.subAccount
+	if call := callOptionsFrom(ctx); call.subAccount != "" {
+		subAccount = call.subAccount
+	}
+	return subAccount + " " + path, true
+}
+
+// get decodes a fresh cached response for key into result, reporting whether
+// there was one. Responses are stored encoded, so callers never share values.
+func (rc *responseCache) get(key string, result interface{}) bool {
+	rc.mu.Lock()
+	entry, ok := rc.entries[key]
+	rc.mu.Unlock()
+
+	if !ok || time.Now().After(entry.expires) {
+		return false
+	}
+	return json.Unmarshal(entry.body, result) == nil
+}
+
+// put stores result under key.
+func (rc *responseCache) put(key string, result interface{}) {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.entries == nil {
+		rc.entries = make(map[string]cacheEntry)
+	}
+	rc.entries[key] = cacheEntry{body: body, expires: time.Now().Add(rc.ttl)}
+}
+
+func (rc *responseCache) clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = nil
+}
@@ -0,0 +1,137 @@
+package sendly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// creditsServer reports a balance that grows by one on every GET /credits
+// and accepts sends, counting GET /credits requests.
+func creditsServer(t *testing.T) (*httptest.Server, *int32) {
+	var hits int32
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/messages" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+			return
+		}
+		n := atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"balance":%d,"reserved_balance":0,"available_balance":%d}`, n, n)
+	})
+	return server, &hits
+}
+
+func TestWithCache(t *testing.T) {
+	server, hits := creditsServer(t)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCache(time.Minute))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		credits, err := client.Account.GetCredits(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if credits.Balance != 1 {
+			t.Errorf("expected cached balance 1, got %d", credits.Balance)
+		}
+	}
+	if n := atomic.LoadInt32(hits); n != 1 {
+		t.Errorf("expected 1 upstream request, got %d", n)
+	}
+}
+
+func TestWithCache_Expires(t *testing.T) {
+	server, hits := creditsServer(t)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCache(20*time.Millisecond))
+	ctx := context.Background()
+
+	client.Account.GetCredits(ctx)
+	time.Sleep(40 * time.Millisecond)
+	credits, err := client.Account.GetCredits(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if credits.Balance != 2 {
+		t.Errorf("expected fresh balance 2, got %d", credits.Balance)
+	}
+	if n := atomic.LoadInt32(hits); n != 2 {
+		t.Errorf("expected 2 upstream requests, got %d", n)
+	}
+}
+
+func TestClient_InvalidateCache(t *testing.T) {
+	server, hits := creditsServer(t)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCache(time.Minute))
+	ctx := context.Background()
+
+	client.Account.GetCredits(ctx)
+	client.InvalidateCache()
+	client.Account.GetCredits(ctx)
+
+	if n := atomic.LoadInt32(hits); n != 2 {
+		t.Errorf("expected 2 upstream requests, got %d", n)
+	}
+}
+
+func TestWithCache_ClearedByWrites(t *testing.T) {
+	server, hits := creditsServer(t)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCache(time.Minute))
+	ctx := context.Background()
+
+	client.Account.GetCredits(ctx)
+	req := &SendMessageRequest{To: "+15551234567", Text: "Hello"}
+	if _, err := client.Messages.Send(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	credits, err := client.Account.GetCredits(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if credits.Balance != 2 {
+		t.Errorf("expected fresh balance 2 after a send, got %d", credits.Balance)
+	}
+	if n := atomic.LoadInt32(hits); n != 2 {
+		t.Errorf("expected 2 upstream requests, got %d", n)
+	}
+}
+
+func TestIsCacheable(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/account", true},
+		{"/credits", true},
+		{"/keys", true},
+		{"/keys/key_123", true},
+		{"/keys/key_123/usage", false},
+		{"/messages", false},
+		{"/messages/msg_1", false},
+		{"/credits/transactions?limit=10", false},
+	}
+
+	for _, tt := range tests {
+		if got := isCacheable(tt.path); got != tt.want {
+			t.Errorf("isCacheable(%q): expected %v, got %v", tt.path, tt.want, got)
+		}
+	}
+}
+
+func TestClient_NoCacheByDefault(t *testing.T) {
+	server, hits := creditsServer(t)
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+
+	client.Account.GetCredits(context.Background())
+	client.Account.GetCredits(context.Background())
+	client.InvalidateCache()
+
+	if n := atomic.LoadInt32(hits); n != 2 {
+		t.Errorf("expected 2 upstream requests, got %d", n)
+	}
+}
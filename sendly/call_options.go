@@ -0,0 +1,63 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// CallOption overrides client settings for a single call. Attach call options
+// to a context with WithCallOptions and pass that context to any method.
+type CallOption func(*callOptions)
+
+// callOptions holds the per-call overrides.
+type callOptions struct {
+	maxRetries     *int
+	bypassLimiter  bool
+	subAccount     string
+	noDegrade      bool
+	idempotencyKey string
+	allowDuplicate bool
+	requestID      string
+	tags           url.Values
+	header         http.Header
+	onResponse     func(*http.Response)
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a context carrying opts. Options already on ctx are
+// kept unless overridden.
+//
+// Example:
+//
+//	// Interactive OTP: fail fast instead of waiting behind background jobs.
+//	ctx = sendly.WithCallOptions(ctx, sendly.WithCallMaxRetries(0), sendly.WithLimiterBypass())
+//	msg, err := client.Messages.Send(ctx, req)
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	o := callOptionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, o)
+}
+
+// WithCallMaxRetries overrides the client's MaxRetries for the call.
+func WithCallMaxRetries(n int) CallOption {
+	return func(o *callOptions) {
+		o.maxRetries = &n
+	}
+}
+
+// WithLimiterBypass skips the client-side rate limiter for the call. Server
+// rate limits still apply.
+func WithLimiterBypass() CallOption {
+	return func(o *callOptions) {
+		o.bypassLimiter = true
+	}
+}
+
+func callOptionsFrom(ctx context.Context) callOptions {
+	o, _ := ctx.Value(callOptionsKey{}).(callOptions)
+	return o
+}
@@ -0,0 +1,72 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithCallMaxRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"internal_error","message":"boom"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(3))
+	ctx := WithCallOptions(context.Background(), WithCallMaxRetries(0))
+
+	if _, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+15551234567", Text: "123456"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+	if client.MaxRetries != 3 {
+		t.Errorf("expected client MaxRetries to stay 3, got %d", client.MaxRetries)
+	}
+}
+
+func TestWithLimiterBypass(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	// One request per minute: a second limited call would block.
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithRateLimit(1.0/60, 1))
+	req := &SendMessageRequest{To: "+15551234567", Text: "123456"}
+
+	if _, err := client.Messages.Send(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := client.Messages.Send(WithCallOptions(ctx, WithLimiterBypass()), req); err != nil {
+		t.Fatalf("expected bypassed call to succeed, got %v", err)
+	}
+	if _, err := client.Messages.Send(ctx, req); err == nil {
+		t.Error("expected limited call to fail waiting for the limiter")
+	}
+}
+
+func TestWithCallOptions_Merges(t *testing.T) {
+	ctx := WithCallOptions(context.Background(), WithLimiterBypass())
+	ctx = WithCallOptions(ctx, WithCallMaxRetries(1))
+
+	o := callOptionsFrom(ctx)
+	if !o.bypassLimiter {
+		t.Error("expected limiter bypass to be kept")
+	}
+	if o.maxRetries == nil || *o.maxRetries != 1 {
+		t.Errorf("expected max retries to be 1, got %v", o.maxRetries)
+	}
+}
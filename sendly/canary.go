@@ -0,0 +1,173 @@
+package sendly
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// CanaryOptions configures SendBatchWithCanary.
+type CanaryOptions struct {
+	// Percent is the share of recipients sent to first, from 0 to 100
+	// (default: 1). At least one recipient is always sampled.
+	Percent float64
+	// MinDeliveryRate is the share of the sample, from 0 to 1, that must be
+	// delivered before the remainder is released (default: 0.95).
+	MinDeliveryRate float64
+	// Timeout is how long to wait for the sample to reach MinDeliveryRate
+	// (default: 10 minutes).
+	Timeout time.Duration
+	// PollInterval is how often the sample's status is checked (default: 10 seconds).
+	PollInterval time.Duration
+	// Rand picks the sample. Defaults to a randomly seeded source.
+	Rand *rand.Rand
+}
+
+// CanaryResult is the outcome of a canary batch send.
+type CanaryResult struct {
+	// Sample is the batch sent to the canary sample, at its last known status.
+	Sample *BatchMessageResponse
+	// Remainder is the batch sent to everyone else, or nil if the canary aborted.
+	Remainder *BatchMessageResponse
+	// DeliveryRate is the share of the sample that was delivered.
+	DeliveryRate float64
+}
+
+// SendBatchWithCanary sends req to a random sample of its recipients first,
+// waits until enough of the sample is delivered, and only then sends the
+// rest. If the sample falls short of MinDeliveryRate before the timeout, the
+// remainder is not sent and a *CanaryAbortedError is returned along with the
+// result.
+func SendBatchWithCanary(ctx context.Context, messages MessagesAPI, req *SendBatchRequest, opts CanaryOptions) (*CanaryResult, error) {
+	if req == nil {
+		return nil, &ValidationError{APIError: APIError{Message: "request is required"}}
+	}
+	if len(req.Messages) == 0 {
+		return nil, &ValidationError{APIError: APIError{Message: "messages are required"}}
+	}
+	if err := validateBatchItems(req.Messages, req.TemplateID); err != nil {
+		return nil, err
+	}
+	opts = opts.withDefaults()
+
+	sample, remainder := splitCanarySample(req.Messages, opts)
+
+	sampleReq := *req
+	sampleReq.Messages = sample
+	sent, err := messages.SendBatch(ctx, &sampleReq)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CanaryResult{Sample: sent}
+	if err := waitForCanary(ctx, messages, result, len(sample), opts); err != nil {
+		return result, err
+	}
+
+	if len(remainder) == 0 {
+		return result, nil
+	}
+	remainderReq := *req
+	remainderReq.Messages = remainder
+	result.Remainder, err = messages.SendBatch(ctx, &remainderReq)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (o CanaryOptions) withDefaults() CanaryOptions {
+	if o.Percent <= 0 {
+		o.Percent = 1
+	}
+	if o.MinDeliveryRate <= 0 {
+		o.MinDeliveryRate = 0.95
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 10 * time.Minute
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = 10 * time.Second
+	}
+	if o.Rand == nil {
+		o.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return o
+}
+
+// splitCanarySample picks a random sample of items and returns it along with
+// the remaining items in their original order.
+func splitCanarySample(items []BatchMessageItem, opts CanaryOptions) ([]BatchMessageItem, []BatchMessageItem) {
+	n := int(math.Ceil(float64(len(items)) * math.Min(opts.Percent, 100) / 100))
+	if n < 1 {
+		n = 1
+	}
+
+	picked := make(map[int]bool, n)
+	for _, i := range opts.Rand.Perm(len(items))[:n] {
+		picked[i] = true
+	}
+
+	sample := make([]BatchMessageItem, 0, n)
+	remainder := make([]BatchMessageItem, 0, len(items)-n)
+	for i, item := range items {
+		if picked[i] {
+			sample = append(sample, item)
+		} else {
+			remainder = append(remainder, item)
+		}
+	}
+	return sample, remainder
+}
+
+// waitForCanary polls the sample batch until its delivery rate reaches the
+// threshold, can no longer reach it, or the timeout passes.
+func waitForCanary(ctx context.Context, messages MessagesAPI, result *CanaryResult, size int, opts CanaryOptions) error {
+	deadline := time.Now().Add(opts.Timeout)
+	batch := result.Sample
+
+	for {
+		delivered, failed := countCanaryOutcomes(batch)
+		result.Sample = batch
+		result.DeliveryRate = float64(delivered) / float64(size)
+
+		if result.DeliveryRate >= opts.MinDeliveryRate {
+			return nil
+		}
+		best := float64(size-failed) / float64(size)
+		if best < opts.MinDeliveryRate || !time.Now().Before(deadline) {
+			return &CanaryAbortedError{
+				BatchID:         batch.BatchID,
+				DeliveryRate:    result.DeliveryRate,
+				MinDeliveryRate: opts.MinDeliveryRate,
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(opts.PollInterval):
+		}
+
+		next, err := messages.GetBatch(ctx, batch.BatchID)
+		if err != nil {
+			return err
+		}
+		batch = next
+	}
+}
+
+// countCanaryOutcomes counts delivered and failed messages in a batch.
+// Messages rejected when the batch was sent count as failed.
+func countCanaryOutcomes(batch *BatchMessageResponse) (delivered, failed int) {
+	for _, m := range batch.Messages {
+		switch {
+		case m.Error != nil || MessageStatus(m.Status) == MessageStatusFailed:
+			failed++
+		case MessageStatus(m.Status) == MessageStatusDelivered:
+			delivered++
+		}
+	}
+	return delivered, failed
+}
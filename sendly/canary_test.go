@@ -0,0 +1,147 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// canaryStub sends batches and reports each recipient's status from outcomes.
+type canaryStub struct {
+	MessagesAPI
+	outcomes map[string]MessageStatus
+	batches  [][]BatchMessageItem
+	polls    int
+}
+
+func (s *canaryStub) SendBatch(ctx context.Context, req *SendBatchRequest) (*BatchMessageResponse, error) {
+	s.batches = append(s.batches, req.Messages)
+	return s.batch(len(s.batches)-1, MessageStatusQueued), nil
+}
+
+func (s *canaryStub) GetBatch(ctx context.Context, batchID string) (*BatchMessageResponse, error) {
+	s.polls++
+	i, _ := strconv.Atoi(batchID)
+	return s.batch(i, ""), nil
+}
+
+func (s *canaryStub) batch(i int, status MessageStatus) *BatchMessageResponse {
+	resp := &BatchMessageResponse{BatchID: strconv.Itoa(i), Total: len(s.batches[i])}
+	for _, item := range s.batches[i] {
+		st := status
+		if st == "" {
+			st = s.outcomes[item.To]
+		}
+		resp.Messages = append(resp.Messages, BatchMessageResult{To: item.To, Status: string(st)})
+	}
+	return resp
+}
+
+func canaryRecipients(n int) []BatchMessageItem {
+	items := make([]BatchMessageItem, n)
+	for i := range items {
+		items[i] = BatchMessageItem{To: "+1555000" + strconv.Itoa(1000+i), Text: "Sale starts now"}
+	}
+	return items
+}
+
+func TestSendBatchWithCanary_ReleasesRemainder(t *testing.T) {
+	stub := &canaryStub{outcomes: map[string]MessageStatus{}}
+	items := canaryRecipients(200)
+	for _, item := range items {
+		stub.outcomes[item.To] = MessageStatusDelivered
+	}
+
+	result, err := SendBatchWithCanary(context.Background(), stub, &SendBatchRequest{Messages: items}, CanaryOptions{
+		Percent:      5,
+		PollInterval: time.Millisecond,
+		Rand:         rand.New(rand.NewSource(1)),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(stub.batches) != 2 {
+		t.Fatalf("expected 2 batches, got %d", len(stub.batches))
+	}
+	if len(stub.batches[0]) != 10 {
+		t.Errorf("expected sample of 10, got %d", len(stub.batches[0]))
+	}
+	if len(stub.batches[1]) != 190 {
+		t.Errorf("expected remainder of 190, got %d", len(stub.batches[1]))
+	}
+	if result.DeliveryRate != 1 {
+		t.Errorf("expected DeliveryRate to be 1, got %v", result.DeliveryRate)
+	}
+	if result.Remainder == nil {
+		t.Error("expected Remainder to be set")
+	}
+
+	seen := map[string]bool{}
+	for _, batch := range stub.batches {
+		for _, item := range batch {
+			if seen[item.To] {
+				t.Errorf("recipient %s sent twice", item.To)
+			}
+			seen[item.To] = true
+		}
+	}
+}
+
+func TestSendBatchWithCanary_AbortsOnFailures(t *testing.T) {
+	stub := &canaryStub{outcomes: map[string]MessageStatus{}}
+	items := canaryRecipients(10)
+	for _, item := range items {
+		stub.outcomes[item.To] = MessageStatusFailed
+	}
+
+	result, err := SendBatchWithCanary(context.Background(), stub, &SendBatchRequest{Messages: items}, CanaryOptions{
+		Percent:      20,
+		PollInterval: time.Millisecond,
+	})
+
+	if !IsCanaryAbortedError(err) || !errors.Is(err, ErrCanaryAborted) {
+		t.Fatalf("expected CanaryAbortedError, got %v", err)
+	}
+	if len(stub.batches) != 1 {
+		t.Errorf("expected remainder not to be sent, got %d batches", len(stub.batches))
+	}
+	if result == nil || result.Remainder != nil || result.DeliveryRate != 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if stub.polls != 1 {
+		t.Errorf("expected abort as soon as the threshold is out of reach, got %d polls", stub.polls)
+	}
+}
+
+func TestSendBatchWithCanary_Timeout(t *testing.T) {
+	stub := &canaryStub{outcomes: map[string]MessageStatus{}}
+	items := canaryRecipients(4)
+	for _, item := range items {
+		stub.outcomes[item.To] = MessageStatusSent
+	}
+
+	_, err := SendBatchWithCanary(context.Background(), stub, &SendBatchRequest{Messages: items}, CanaryOptions{
+		Percent:      50,
+		Timeout:      20 * time.Millisecond,
+		PollInterval: 5 * time.Millisecond,
+	})
+
+	var aborted *CanaryAbortedError
+	if !errors.As(err, &aborted) {
+		t.Fatalf("expected CanaryAbortedError, got %v", err)
+	}
+	if aborted.MinDeliveryRate != 0.95 {
+		t.Errorf("expected MinDeliveryRate to be 0.95, got %v", aborted.MinDeliveryRate)
+	}
+}
+
+func TestSendBatchWithCanary_Validation(t *testing.T) {
+	_, err := SendBatchWithCanary(context.Background(), &canaryStub{}, &SendBatchRequest{}, CanaryOptions{})
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
@@ -4,11 +4,14 @@ package sendly
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -21,31 +24,116 @@ const (
 	DefaultTimeout = 30 * time.Second
 	// Version is the SDK version.
 	Version = "3.8.1"
+	// DefaultRateLimit is the default client-side limit in requests per second.
+	DefaultRateLimit = 10
+	// DefaultRateBurst is the default number of requests allowed in a burst.
+	DefaultRateBurst = 10
 )
 
-// Client is the Sendly API client.
+// Client is the Sendly API client. It is safe for concurrent use. Its
+// settings are fixed when it is created; use Clone to derive a client with
+// different settings.
 type Client struct {
 	// BaseURL is the API base URL.
+	//
+	// Deprecated: Use Config().BaseURL. Changes after NewClient have no effect.
 	BaseURL string
 	// APIKey is the authentication key.
+	//
+	// Deprecated: Use Config().APIKey. Changes after NewClient have no effect.
 	APIKey string
 	// HTTPClient is the underlying HTTP client.
+	//
+	// Deprecated: Use Config().HTTPClient. Changes after NewClient have no effect.
 	HTTPClient *http.Client
 	// MaxRetries is the maximum number of retry attempts.
+	//
+	// Deprecated: Use Config().MaxRetries. Changes after NewClient have no effect.
 	MaxRetries int
 	// Timeout is the request timeout.
+	//
+	// Deprecated: Use Config().Timeout. Changes after NewClient have no effect.
 	Timeout time.Duration
 	// Debug enables debug logging.
+	//
+	// Deprecated: Use Config().Debug. Changes after NewClient have no effect.
 	Debug bool
 
 	// Messages provides access to message operations.
-	Messages *MessagesService
+	Messages MessagesAPI
 	// WebhooksService provides access to webhook management operations.
-	WebhooksService *WebhooksService
+	WebhooksService WebhooksAPI
 	// Account provides access to account operations.
-	Account *AccountService
-
-	rateLimiter *rate.Limiter
+	Account AccountAPI
+	// SubAccounts provides access to sub-account management.
+	SubAccounts SubAccountsAPI
+	// Compliance provides access to data protection requests.
+	Compliance ComplianceAPI
+	// Segments provides access to audience segment management.
+	Segments SegmentsAPI
+	// Links provides access to short links and their clicks.
+	Links LinksAPI
+	// OptOuts provides access to the opt-out list.
+	OptOuts OptOutsAPI
+	// Numbers provides access to dedicated number provisioning.
+	Numbers NumbersAPI
+	// Registrations provides access to 10DLC and toll-free registration.
+	Registrations RegistrationsAPI
+	// Email provides access to transactional email.
+	Email EmailAPI
+
+	rateLimiter          *rate.Limiter
+	readYourWritesWindow time.Duration
+	userAgent            string
+	appInfo              string
+	onVersionWarning     func(VersionWarning)
+	versionMu            sync.Mutex
+	warnedMinSDK         string
+	recorder             *recorder
+	sandbox              bool
+	dryRun               bool
+	forecaster           *CreditForecaster
+	disableCompression   bool
+	compressRequests     bool
+	rateStateMu          sync.Mutex
+	rateState            *RateLimitState
+	adaptive             *adaptiveLimiter
+	diagnostics          *connDiagnostics
+	breaker              *circuitBreaker
+	metadataLimits       MetadataLimits
+	sendWindow           *SendWindow
+	destinationPolicy    *DestinationPolicy
+	budget               *creditBudget
+	dedupe               *dedupeGuard
+	tokens               *tokenCache
+	signingSecret        string
+	region               Region
+	failover             *regionFailover
+	lifecycleMu          sync.Mutex
+	closed               bool
+	closers              []func(context.Context) error
+	inFlight             sync.WaitGroup
+	proxyURL             string
+	noProxy              []string
+	transportOpts        *TransportOptions
+	maxResponseBytes     int64
+	tlsConfig            *tls.Config
+	retryBudget          time.Duration
+	unsafeRetries        bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	flights              *flightGroup
+	cache                *responseCache
+	etags                *etagStore
+	observers            []func(RequestEvent)
+	auditor              *auditor
+	redaction            RedactionPolicy
+	subAccount           string
+	degradedMode         bool
+	degradedOpts         []QueueOption
+	degraded             *Queue
+	cfg                  Config
+	options              []ClientOption
 }
 
 // ClientOption is a function that configures the client.
@@ -61,8 +149,12 @@ func WithBaseURL(baseURL string) ClientOption {
 // WithTimeout sets the request timeout.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
+		// Copy the client so one passed to WithHTTPClient, and any clone
+		// sharing it, keeps its own timeout.
+		hc := *c.HTTPClient
+		hc.Timeout = timeout
 		c.Timeout = timeout
-		c.HTTPClient.Timeout = timeout
+		c.HTTPClient = &hc
 	}
 }
 
@@ -80,13 +172,31 @@ func WithMaxRetries(maxRetries int) ClientOption {
 	}
 }
 
-// WithDebug enables debug mode.
+// WithDebug enables debug mode, which logs each request attempt with the
+// standard logger. Phone numbers and message text are masked according to
+// the client's RedactionPolicy.
 func WithDebug(debug bool) ClientOption {
 	return func(c *Client) {
 		c.Debug = debug
 	}
 }
 
+// WithRateLimit sets the client-side rate limit to rps requests per second,
+// allowing bursts of up to burst requests.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = rate.NewLimiter(rate.Limit(rps), burst)
+	}
+}
+
+// WithoutRateLimit disables client-side rate limiting. Server-side limits
+// still apply and are retried using Retry-After.
+func WithoutRateLimit() ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = nil
+	}
+}
+
 // NewClient creates a new Sendly API client.
 func NewClient(apiKey string, opts ...ClientOption) *Client {
 	c := &Client{
@@ -97,122 +207,377 @@ func NewClient(apiKey string, opts ...ClientOption) *Client {
 		},
 		MaxRetries:  3,
 		Timeout:     DefaultTimeout,
-		rateLimiter: rate.NewLimiter(rate.Every(time.Second), 10), // 10 requests per second
+		rateLimiter: rate.NewLimiter(DefaultRateLimit, DefaultRateBurst),
+		userAgent:   "sendly-go/" + Version,
+
+		metadataLimits: DefaultMetadataLimits,
 	}
 
+	c.options = append([]ClientOption(nil), opts...)
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	if c.appInfo != "" {
+		c.userAgent += " " + c.appInfo
+	}
+	if c.adaptive != nil {
+		c.adaptive.attach(c)
+	}
+	if c.failover != nil {
+		c.failover.attach(c)
+	}
+	c.applyTransportOptions()
+	c.applyTLSConfig()
+	c.applyProxy()
+	if c.recorder != nil {
+		c.HTTPClient = c.recorder.wrap(c.HTTPClient, c.APIKey)
+	}
+	c.snapshotConfig()
+
 	c.Messages = &MessagesService{client: c}
 	c.WebhooksService = &WebhooksService{client: c}
 	c.Account = &AccountService{client: c}
+	c.SubAccounts = &SubAccountsService{client: c}
+	c.Compliance = &ComplianceService{client: c}
+	c.Segments = &SegmentsService{client: c}
+	c.Links = &LinksService{client: c}
+	c.OptOuts = &OptOutsService{client: c}
+	c.Numbers = &NumbersService{client: c}
+	c.Registrations = &RegistrationsService{client: c}
+	c.Email = &EmailService{client: c}
+	if c.degradedMode {
+		c.degraded = NewQueue(c.Messages, c.degradedOpts...)
+	}
 
 	return c
 }
 
-// request performs an HTTP request with retries and rate limiting.
+// request performs an HTTP request with retries and rate limiting. If
+// enabled, stable responses are served from the cache and identical requests
+// in flight are coalesced.
 func (c *Client) request(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	if err := c.checkDestinations(body); err != nil {
+		return err
+	}
+
+	cacheKey, cacheable := c.cacheKey(ctx, method, path, result)
+	if cacheable && c.cache.get(cacheKey, result) {
+		return nil
+	}
+
+	var err error
+	if key, ok := c.coalesceKey(ctx, method, path, result); ok {
+		err = c.flights.do(ctx, key, result, func(ctx context.Context, into interface{}) error {
+			_, err := c.requestWithRetries(ctx, method, path, body, into)
+			return err
+		})
+	} else {
+		var status int
+		status, err = c.requestWithRetries(ctx, method, path, body, result)
+		c.audit(ctx, method, path, body, result, status, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cacheable:
+		c.cache.put(cacheKey, result)
+	case c.cache != nil && method != http.MethodGet:
+		c.cache.clear()
+	}
+	return nil
+}
+
+// requestWithRetries performs an HTTP request with retries and rate limiting.
+// It returns the status of the last response, or 0 if none was received.
+func (c *Client) requestWithRetries(ctx context.Context, method, path string, body interface{}, result interface{}) (int, error) {
+	if err := c.beginRequest(); err != nil {
+		return 0, err
+	}
+	defer c.inFlight.Done()
+
+	call := callOptionsFrom(ctx)
+	maxRetries := c.cfg.maxRetries
+	if call.maxRetries != nil {
+		maxRetries = *call.maxRetries
+	}
+
 	// Wait for rate limiter
-	if err := c.rateLimiter.Wait(ctx); err != nil {
-		return &NetworkError{Message: "rate limiter error", Err: err}
+	if c.rateLimiter != nil && !call.bypassLimiter {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return 0, &NetworkError{Message: "rate limiter error", Err: err}
+		}
 	}
 
 	var lastErr error
-	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+	var status int
+	var refreshed bool
+	waits := retryWaits{budget: c.retryBudget}
+	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
 			// Exponential backoff
 			backoff := time.Duration(1<<uint(attempt-1)) * time.Second
+			if !waits.allow(backoff) {
+				return status, lastErr
+			}
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return status, ctx.Err()
 			case <-time.After(backoff):
 			}
 		}
 
-		err := c.doRequest(ctx, method, path, body, result)
-		if err == nil {
-			return nil
+		if c.breaker != nil {
+			if err := c.breaker.allow(); err != nil {
+				if lastErr != nil {
+					return status, lastErr
+				}
+				return status, err
+			}
 		}
 
-		// Don't retry on certain errors
-		if _, ok := err.(*AuthenticationError); ok {
-			return err
+		start := time.Now()
+		var err error
+		status, err = c.attempt(ctx, method, path, body, result)
+		c.observe(method, path, attempt+1, status, start, result, err)
+		c.debugLog(method, path, body, attempt+1, status, start, err)
+		if c.tokens != nil && !refreshed && IsAuthenticationError(err) {
+			// The token may have been revoked before it expired. Try once
+			// more with a new one, without spending a retry, unless the
+			// source would only hand back the token it has cached.
+			refreshed = true
+			c.tokens.invalidate(err)
+			if !c.tokens.reusesTokens() {
+				start = time.Now()
+				status, err = c.attempt(ctx, method, path, body, result)
+				c.observe(method, path, attempt+1, status, start, result, err)
+				c.debugLog(method, path, body, attempt+1, status, start, err)
+			}
 		}
-		if _, ok := err.(*ValidationError); ok {
-			return err
+		if c.breaker != nil {
+			c.breaker.record(err)
 		}
-		if _, ok := err.(*NotFoundError); ok {
-			return err
+		if err == nil {
+			return status, nil
 		}
-		if _, ok := err.(*InsufficientCreditsError); ok {
-			return err
+
+		// Don't retry on certain errors
+		if IsAuthenticationError(err) || IsTokenError(err) || IsValidationError(err) ||
+			IsNotFoundError(err) || IsInsufficientCreditsError(err) ||
+			errors.Is(err, ErrResponseTooLarge) || !c.canRetry(method, call, err) {
+			return status, err
 		}
 
 		lastErr = err
 
 		// Check for rate limit error with Retry-After
-		if rateLimitErr, ok := err.(*RateLimitError); ok {
+		var rateLimitErr *RateLimitError
+		if errors.As(err, &rateLimitErr) {
 			if rateLimitErr.RetryAfter > 0 {
+				retryAfter := time.Duration(rateLimitErr.RetryAfter) * time.Second
+				if attempt == maxRetries || !waits.allow(retryAfter) {
+					return status, err
+				}
 				select {
 				case <-ctx.Done():
-					return ctx.Err()
-				case <-time.After(time.Duration(rateLimitErr.RetryAfter) * time.Second):
+					return status, ctx.Err()
+				case <-time.After(retryAfter):
 				}
 			}
 		}
 	}
 
-	return lastErr
+	return status, lastErr
 }
 
 // doRequest performs a single HTTP request.
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
-	fullURL := c.BaseURL + path
+// It returns the response status, or 0 if no response was received.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) (int, error) {
+	baseURL, onSecondary := c.requestBaseURL()
+	fullURL := baseURL + path
 
 	var bodyReader io.Reader
+	var jsonBody []byte
+	var contentEncoding string
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
-			return &ValidationError{APIError: APIError{Message: "failed to marshal request body"}, Err: err}
+			return 0, &ValidationError{APIError: APIError{Message: "failed to marshal request body"}, Err: err}
+		}
+		if c.compressRequests {
+			jsonBody, contentEncoding, err = encodeRequestBody(jsonBody)
+			if err != nil {
+				return 0, &ValidationError{APIError: APIError{Message: "failed to compress request body"}, Err: err}
+			}
 		}
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 	if err != nil {
-		return &NetworkError{Message: "failed to create request", Err: err}
+		return 0, &NetworkError{Message: "failed to create request", Err: err}
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	call := callOptionsFrom(ctx)
+	for key, values := range call.header {
+		req.Header[key] = values
+	}
+
+	bearer := c.cfg.apiKey
+	if c.tokens != nil {
+		if bearer, err = c.tokens.get(ctx); err != nil {
+			return 0, err
+		}
+	}
+	req.Header.Set("Authorization", "Bearer "+bearer)
 	req.Header.Set("Content-Type", "application/json")
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "sendly-go/"+Version)
+	req.Header.Set("User-Agent", c.userAgent)
+	if !c.disableCompression {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	req.Header.Set(sdkVersionHeader, "go/"+Version)
+	if c.appInfo != "" {
+		req.Header.Set(appInfoHeader, c.appInfo)
+	}
+	if c.sandbox {
+		req.Header.Set(sandboxHeader, "true")
+	}
+	if call.idempotencyKey != "" {
+		req.Header.Set(idempotencyKeyHeader, call.idempotencyKey)
+	}
+	if call.requestID != "" {
+		req.Header.Set(requestIDHeader, call.requestID)
+	}
+	if len(call.tags) > 0 {
+		req.Header.Set(tagsHeader, call.tags.Encode())
+	}
+	if subAccount := call.subAccount; subAccount != "" {
+		req.Header.Set(subAccountHeader, subAccount)
+	} else if c.subAccount != "" {
+		req.Header.Set(subAccountHeader, c.subAccount)
+	}
+	if c.signingSecret != "" {
+		if err := c.signRequest(req, jsonBody); err != nil {
+			return 0, &NetworkError{Message: "failed to sign request", Err: err}
+		}
+	}
+
+	etagKey, conditional := c.etagKey(ctx, method, path)
+	var cached etagEntry
+	var haveCached bool
+	if conditional {
+		if cached, haveCached = c.etags.get(etagKey); haveCached {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+	}
+
+	var recordDiagnostics func(*http.Response)
+	if c.diagnostics != nil {
+		req, recordDiagnostics = c.diagnostics.trace(req, method, path)
+	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.cfg.httpClient.Do(req)
+	if c.failover != nil && !onSecondary && ctx.Err() == nil {
+		c.failover.record(err)
+	}
 	if err != nil {
-		return &NetworkError{Message: "request failed", Err: err}
+		return 0, &NetworkError{Message: "request failed", Err: err}
 	}
 	defer resp.Body.Close()
+	if call.onResponse != nil {
+		call.onResponse(resp)
+	}
 
-	respBody, err := io.ReadAll(resp.Body)
+	if recordDiagnostics != nil {
+		recordDiagnostics(resp)
+	}
+
+	c.checkMinSDK(resp.Header)
+	rateState := c.observeRateLimit(resp.Header)
+	if c.adaptive != nil {
+		c.adaptive.observe(resp.StatusCode, rateState, time.Now())
+	}
+
+	respBody, err := c.responseBody(resp)
 	if err != nil {
-		return &NetworkError{Message: "failed to read response body", Err: err}
+		return resp.StatusCode, &NetworkError{Message: "failed to read response body", Err: err}
 	}
+	defer respBody.Close()
 
 	if resp.StatusCode >= 400 {
-		return c.handleErrorResponse(resp, respBody)
+		raw, err := io.ReadAll(respBody)
+		if err != nil {
+			return resp.StatusCode, &NetworkError{Message: "failed to read response body", Err: err}
+		}
+		err = c.handleErrorResponse(resp, raw)
+		if rateLimitErr, ok := err.(*RateLimitError); ok {
+			rateLimitErr.State = rateState
+		}
+		return resp.StatusCode, err
 	}
 
-	if result != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return &NetworkError{Message: "failed to unmarshal response", Err: err}
+	if resp.StatusCode == http.StatusNotModified && haveCached {
+		if result == nil {
+			return resp.StatusCode, nil
+		}
+		if err := json.Unmarshal(cached.body, result); err != nil {
+			return resp.StatusCode, &NetworkError{Message: "failed to decode cached response", Err: err}
 		}
+		c.markSandbox(resp.Header, result)
+		return resp.StatusCode, nil
 	}
 
-	return nil
+	if result == nil {
+		return resp.StatusCode, nil
+	}
+
+	// Keep a copy of the entity if the response can be revalidated later.
+	var source io.Reader = respBody
+	var entity *bytes.Buffer
+	etag := resp.Header.Get("ETag")
+	if conditional && etag != "" {
+		entity = &bytes.Buffer{}
+		source = io.TeeReader(respBody, entity)
+	}
+
+	// Decode straight off the wire so large list responses are not held in
+	// memory twice, then drain the rest so the connection can be reused.
+	var snippet snippetWriter
+	err = json.NewDecoder(io.TeeReader(source, &snippet)).Decode(result)
+	if err == io.EOF {
+		return resp.StatusCode, nil
+	}
+	if err != nil && !isDecodeFailure(err) {
+		return resp.StatusCode, &NetworkError{Message: "failed to read response body", Err: err}
+	}
+	if err != nil {
+		snippet.fill(respBody)
+		return resp.StatusCode, &DecodeError{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Snippet:     c.redaction.Redact(snippet.String()),
+			Err:         err,
+		}
+	}
+	io.Copy(io.Discard, source)
+	if entity != nil {
+		c.etags.put(etagKey, etag, entity.Bytes())
+	}
+	c.markSandbox(resp.Header, result)
+
+	return resp.StatusCode, nil
 }
 
+// requestIDHeader carries the API's correlation ID for a request.
+const requestIDHeader = "X-Request-Id"
+
 // handleErrorResponse converts HTTP error responses to typed errors.
 func (c *Client) handleErrorResponse(resp *http.Response, body []byte) error {
 	var apiErr APIError
@@ -222,6 +587,13 @@ func (c *Client) handleErrorResponse(resp *http.Response, body []byte) error {
 			Message: string(body),
 		}
 	}
+	if id := resp.Header.Get(requestIDHeader); id != "" {
+		apiErr.RequestID = id
+	}
+	apiErr.StatusCode = resp.StatusCode
+	apiErr.RawBody = body
+	c.redaction.redactAPIError(&apiErr)
+	apiErr.response = resp
 
 	switch resp.StatusCode {
 	case http.StatusUnauthorized:
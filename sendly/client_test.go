@@ -36,6 +36,9 @@ func TestNewClient(t *testing.T) {
 				if c.Messages == nil {
 					t.Error("expected Messages service to be initialized")
 				}
+				if c.rateLimiter.Limit() != DefaultRateLimit {
+					t.Errorf("expected rate limit to be %d, got %v", DefaultRateLimit, c.rateLimiter.Limit())
+				}
 			},
 		},
 		{
@@ -93,6 +96,29 @@ func TestNewClient(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "with custom rate limit",
+			apiKey: "test-api-key",
+			opts:   []ClientOption{WithRateLimit(50, 25)},
+			validate: func(t *testing.T, c *Client) {
+				if c.rateLimiter.Limit() != 50 {
+					t.Errorf("expected rate limit to be 50, got %v", c.rateLimiter.Limit())
+				}
+				if c.rateLimiter.Burst() != 25 {
+					t.Errorf("expected burst to be 25, got %d", c.rateLimiter.Burst())
+				}
+			},
+		},
+		{
+			name:   "without rate limit",
+			apiKey: "test-api-key",
+			opts:   []ClientOption{WithoutRateLimit()},
+			validate: func(t *testing.T, c *Client) {
+				if c.rateLimiter != nil {
+					t.Error("expected rate limiter to be disabled")
+				}
+			},
+		},
 		{
 			name:   "with multiple options",
 			apiKey: "test-api-key",
@@ -353,3 +379,23 @@ func TestBuildQueryString(t *testing.T) {
 		})
 	}
 }
+
+func TestClientRequest_WithoutRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithoutRateLimit())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	for i := 0; i < 50; i++ {
+		var result map[string]interface{}
+		if err := client.request(ctx, "GET", "/test", nil, &result); err != nil {
+			t.Fatalf("unexpected error on request %d: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package sendly
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// NewClientID returns a random (version 4) UUID for use as
+// SendMessageRequest.ClientID. Generating the ID before sending lets it be
+// logged at enqueue time and later matched to the Message and its webhook
+// events.
+func NewClientID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("sendly: cannot read random bytes: " + err.Error())
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,68 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestNewClientID(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		id := NewClientID()
+		if !pattern.MatchString(id) {
+			t.Fatalf("expected a version 4 UUID, got '%s'", id)
+		}
+		if seen[id] {
+			t.Fatalf("expected unique IDs, got '%s' twice", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestMessagesSend_ClientID(t *testing.T) {
+	clientID := NewClientID()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SendMessageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.ClientID != clientID {
+			t.Errorf("expected ClientID to be '%s', got '%s'", clientID, req.ClientID)
+		}
+
+		// The API response does not echo the client ID.
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_123","to":"+15551234567","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	msg, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+		To:       "+15551234567",
+		Text:     "Hello",
+		ClientID: clientID,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.ClientID != clientID {
+		t.Errorf("expected message ClientID to be '%s', got '%s'", clientID, msg.ClientID)
+	}
+}
+
+func TestParseEvent_ClientID(t *testing.T) {
+	payload := `{"id":"evt_1","type":"message.delivered","created_at":"2024-06-01T00:00:00Z","api_version":"` + CurrentWebhookAPIVersion + `",` +
+		`"data":{"message_id":"msg_1","client_id":"c0ffee","status":"delivered","to":"+15551234567"}}`
+
+	event, err := Webhooks{}.ParseEvent(payload, Webhooks{}.GenerateSignature(payload, "whsec_test"), "whsec_test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Data.ClientID != "c0ffee" {
+		t.Errorf("expected ClientID to be 'c0ffee', got '%s'", event.Data.ClientID)
+	}
+}
@@ -0,0 +1,90 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// WithRequestCoalescing makes concurrent identical GET requests share a
+// single upstream call, so many goroutines polling the same message or batch
+// cost one request and one rate limiter token. Requests are identical when
+// they have the same path and act for the same sub-account.
+//
+// Coalesced callers receive copies of the same response, which share any
+// slices and maps it contains; treat them as read-only.
+func WithRequestCoalescing() ClientOption {
+	return func(c *Client) {
+		c.flights = &flightGroup{}
+	}
+}
+
+// flightGroup tracks GET requests currently in flight, by key.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is one upstream call shared by every caller with the same key.
+type flight struct {
+	done  chan struct{}
+	value reflect.Value
+	err   error
+}
+
+// coalesceKey identifies requests that can share a call, or returns false if
+// the request must be sent on its own.
+func (c *Client) coalesceKey(ctx context.Context, method, path string, result interface{}) (string, bool) {
+	if c.flights == nil || method != http.MethodGet || result == nil {
+		return "", false
+	}
+	subAccount := c.subAccount
+	if call := callOptionsFrom(ctx); call.subAccount != "" {
+		subAccount = call.subAccount
+	}
+	return subAccount + " " + path, true
+}
+
+// do joins the flight for key, starting it with fn if there is none, and
+// copies the shared response into result. The shared call is not cancelled
+// when ctx is, since other callers may still be waiting for it; ctx only
+// bounds how long this caller waits.
+func (g *flightGroup) do(ctx context.Context, key string, result interface{}, fn func(ctx context.Context, into interface{}) error) error {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	f, ok := g.flights[key]
+	if !ok {
+		f = &flight{done: make(chan struct{}), value: reflect.New(reflect.TypeOf(result).Elem())}
+		g.flights[key] = f
+
+		go func() {
+			f.err = fn(context.WithoutCancel(ctx), f.value.Interface())
+
+			g.mu.Lock()
+			delete(g.flights, key)
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-f.done:
+	}
+	if f.err != nil {
+		return f.err
+	}
+
+	target := reflect.ValueOf(result).Elem()
+	if !f.value.Elem().Type().AssignableTo(target.Type()) {
+		// Same path decoded into a different type: fall back to our own call.
+		return fn(ctx, result)
+	}
+	target.Set(f.value.Elem())
+	return nil
+}
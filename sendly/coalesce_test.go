@@ -0,0 +1,116 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// slowMessageServer answers every request after delay.
+func slowMessageServer(t *testing.T, delay time.Duration) (*httptest.Server, func() []*http.Request) {
+	return newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","to":"+15551234567","status":"delivered"}`))
+	})
+}
+
+func TestWithRequestCoalescing(t *testing.T) {
+	server, reqs := slowMessageServer(t, 100*time.Millisecond)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithRequestCoalescing(), WithoutRateLimit())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			msg, err := client.Messages.Get(context.Background(), "msg_1")
+			if err == nil && msg.Status != MessageStatusDelivered {
+				t.Errorf("expected status 'delivered', got '%s'", msg.Status)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := len(reqs()); n != 1 {
+		t.Errorf("expected 1 upstream request, got %d", n)
+	}
+}
+
+func TestWithRequestCoalescing_SeparatesSubAccounts(t *testing.T) {
+	server, reqs := slowMessageServer(t, 100*time.Millisecond)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithRequestCoalescing(), WithoutRateLimit())
+
+	var wg sync.WaitGroup
+	for _, sub := range []string{"sub_a", "sub_b", "sub_a", "sub_b"} {
+		wg.Add(1)
+		go func(sub string) {
+			defer wg.Done()
+			ctx := WithCallOptions(context.Background(), WithCallSubAccount(sub))
+			if _, err := client.Messages.Get(ctx, "msg_1"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(sub)
+	}
+	wg.Wait()
+
+	if n := len(reqs()); n != 2 {
+		t.Errorf("expected 2 upstream requests, got %d", n)
+	}
+}
+
+func TestWithRequestCoalescing_CancelledCallerDoesNotFailOthers(t *testing.T) {
+	server, _ := slowMessageServer(t, 200*time.Millisecond)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithRequestCoalescing(), WithoutRateLimit())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.Messages.Get(ctx, "msg_1")
+		leaderErr <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	msg, err := client.Messages.Get(context.Background(), "msg_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.ID != "msg_1" {
+		t.Errorf("expected ID to be 'msg_1', got '%s'", msg.ID)
+	}
+	if err := <-leaderErr; err != context.DeadlineExceeded {
+		t.Errorf("expected the cancelled caller to get its own deadline error, got %v", err)
+	}
+}
+
+func TestClient_NoCoalescingByDefault(t *testing.T) {
+	server, reqs := slowMessageServer(t, 50*time.Millisecond)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithoutRateLimit())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Messages.Get(context.Background(), "msg_1")
+		}()
+	}
+	wg.Wait()
+
+	if n := len(reqs()); n != 3 {
+		t.Errorf("expected 3 upstream requests, got %d", n)
+	}
+}
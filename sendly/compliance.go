@@ -0,0 +1,134 @@
+package sendly
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultDeletionPollInterval is how often WaitForDeletion checks a job when
+// no interval is given.
+const defaultDeletionPollInterval = 5 * time.Second
+
+// RecipientData is everything the message archive holds about a recipient,
+// returned for subject-access requests.
+type RecipientData struct {
+	// Phone is the recipient phone number.
+	Phone string `json:"phone"`
+	// Messages are the archived messages sent to the recipient, newest first.
+	Messages []Message `json:"messages"`
+	// ScheduledMessages are pending scheduled messages to the recipient.
+	ScheduledMessages []ScheduledMessage `json:"scheduledMessages,omitempty"`
+	// GeneratedAt is when the export was generated.
+	GeneratedAt string `json:"generatedAt"`
+}
+
+// DataDeletionStatus represents the status of a recipient data deletion job.
+type DataDeletionStatus string
+
+const (
+	// DataDeletionStatusPending means the job has not started yet.
+	DataDeletionStatusPending DataDeletionStatus = "pending"
+	// DataDeletionStatusProcessing means the archive is being erased.
+	DataDeletionStatusProcessing DataDeletionStatus = "processing"
+	// DataDeletionStatusCompleted means all of the recipient's data was erased.
+	DataDeletionStatusCompleted DataDeletionStatus = "completed"
+	// DataDeletionStatusFailed means the job stopped; see DataDeletionJob.Error.
+	DataDeletionStatusFailed DataDeletionStatus = "failed"
+)
+
+// DataDeletionJob tracks a right-to-erasure request. Deletion runs
+// asynchronously; poll it with GetDeletionJob or WaitForDeletion.
+type DataDeletionJob struct {
+	// ID is the deletion job ID.
+	ID string `json:"id"`
+	// Phone is the recipient whose data is being erased.
+	Phone string `json:"phone"`
+	// Status is the job status.
+	Status DataDeletionStatus `json:"status"`
+	// MessagesDeleted is the number of archived messages erased so far.
+	MessagesDeleted int `json:"messagesDeleted"`
+	// CreatedAt is when the deletion was requested.
+	CreatedAt string `json:"createdAt"`
+	// CompletedAt is when the job completed or failed.
+	CompletedAt *string `json:"completedAt,omitempty"`
+	// Error describes why the job failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Done reports whether the job has completed or failed.
+func (j *DataDeletionJob) Done() bool {
+	return j.Status == DataDeletionStatusCompleted || j.Status == DataDeletionStatusFailed
+}
+
+// ComplianceService handles data protection requests, such as GDPR
+// subject-access and right-to-erasure requests, against the message archive.
+type ComplianceService struct {
+	client *Client
+}
+
+// GetRecipientData exports everything the message archive holds about phone.
+func (s *ComplianceService) GetRecipientData(ctx context.Context, phone string) (*RecipientData, error) {
+	if !strings.HasPrefix(phone, "+") {
+		return nil, &ValidationError{APIError: APIError{Message: "phone number must be in E.164 format"}}
+	}
+
+	var resp RecipientData
+	if err := s.client.request(ctx, "GET", "/compliance/recipients/"+url.PathEscape(phone), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteRecipientData starts erasing all archived messages and pending
+// scheduled messages for phone. The returned job completes asynchronously.
+func (s *ComplianceService) DeleteRecipientData(ctx context.Context, phone string) (*DataDeletionJob, error) {
+	if !strings.HasPrefix(phone, "+") {
+		return nil, &ValidationError{APIError: APIError{Message: "phone number must be in E.164 format"}}
+	}
+
+	var resp DataDeletionJob
+	if err := s.client.request(ctx, "DELETE", "/compliance/recipients/"+url.PathEscape(phone), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDeletionJob retrieves a deletion job by ID.
+func (s *ComplianceService) GetDeletionJob(ctx context.Context, jobID string) (*DataDeletionJob, error) {
+	if jobID == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "deletion job ID is required"}}
+	}
+
+	var resp DataDeletionJob
+	if err := s.client.request(ctx, "GET", "/compliance/deletions/"+url.PathEscape(jobID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// WaitForDeletion polls a deletion job every pollInterval (default: 5
+// seconds) until it completes or fails, and returns the final job. A failed
+// job is returned without an error; check its Status.
+func (s *ComplianceService) WaitForDeletion(ctx context.Context, jobID string, pollInterval time.Duration) (*DataDeletionJob, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultDeletionPollInterval
+	}
+
+	for {
+		job, err := s.GetDeletionJob(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if job.Done() {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
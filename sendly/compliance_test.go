@@ -0,0 +1,121 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestComplianceGetRecipientData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/compliance/recipients/+15551234567" {
+			t.Errorf("expected path '/compliance/recipients/+15551234567', got '%s'", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"phone":"+15551234567","messages":[{"id":"msg_1","to":"+15551234567","text":"Hi"}],"generatedAt":"2025-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	data, err := client.Compliance.GetRecipientData(context.Background(), "+15551234567")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if data.Phone != "+15551234567" || len(data.Messages) != 1 || data.Messages[0].ID != "msg_1" {
+		t.Errorf("unexpected recipient data %+v", data)
+	}
+}
+
+func TestComplianceDeleteRecipientData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/compliance/recipients/+15551234567" {
+			t.Errorf("expected path '/compliance/recipients/+15551234567', got '%s'", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"id":"del_1","phone":"+15551234567","status":"pending","createdAt":"2025-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	job, err := client.Compliance.DeleteRecipientData(context.Background(), "+15551234567")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if job.ID != "del_1" || job.Status != DataDeletionStatusPending || job.Done() {
+		t.Errorf("unexpected deletion job %+v", job)
+	}
+}
+
+func TestCompliance_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+	ctx := context.Background()
+
+	if _, err := client.Compliance.GetRecipientData(ctx, "5551234567"); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for a non-E.164 number, got %T", err)
+	}
+	if _, err := client.Compliance.DeleteRecipientData(ctx, ""); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for a missing number, got %T", err)
+	}
+	if _, err := client.Compliance.GetDeletionJob(ctx, ""); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for a missing job ID, got %T", err)
+	}
+}
+
+func TestComplianceWaitForDeletion(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/compliance/deletions/del_1" {
+			t.Errorf("expected path '/compliance/deletions/del_1', got '%s'", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if atomic.AddInt32(&polls, 1) < 3 {
+			w.Write([]byte(`{"id":"del_1","status":"processing","messagesDeleted":10}`))
+			return
+		}
+		w.Write([]byte(`{"id":"del_1","status":"completed","messagesDeleted":42,"completedAt":"2025-01-01T00:05:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	job, err := client.Compliance.WaitForDeletion(context.Background(), "del_1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if job.Status != DataDeletionStatusCompleted || job.MessagesDeleted != 42 || job.CompletedAt == nil {
+		t.Errorf("unexpected deletion job %+v", job)
+	}
+	if got := atomic.LoadInt32(&polls); got != 3 {
+		t.Errorf("expected 3 polls, got %d", got)
+	}
+}
+
+func TestComplianceWaitForDeletion_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"del_1","status":"processing"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Compliance.WaitForDeletion(ctx, "del_1", 5*time.Millisecond); err == nil {
+		t.Error("expected an error when the context is cancelled")
+	}
+}
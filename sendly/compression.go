@@ -0,0 +1,104 @@
+package sendly
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is sent when compression is enabled. Setting it explicitly
+// turns off net/http's transparent gzip, so responses are decoded by readBody.
+const acceptEncoding = "gzip, deflate"
+
+// compressRequestThreshold is the request body size, in bytes, from which
+// bodies are gzipped when request compression is enabled. Smaller bodies gain
+// too little to be worth the CPU.
+const compressRequestThreshold = 8 << 10
+
+// WithCompression controls compression in both directions. Gzip or deflate
+// encoded responses are requested by default; false turns that off. True
+// also sends large request bodies, such as batches with thousands of
+// recipients, gzipped. That is opt-in because not every server or proxy in
+// front of the API accepts a Content-Encoding on requests.
+func WithCompression(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.disableCompression = !enabled
+		c.compressRequests = enabled
+	}
+}
+
+// encodeRequestBody gzips body when it is at least compressRequestThreshold
+// bytes, returning the body to send and its Content-Encoding, if any.
+func encodeRequestBody(body []byte) ([]byte, string, error) {
+	if len(body) < compressRequestThreshold {
+		return body, "", nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, "", err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "gzip", nil
+}
+
+// readBody reads the response body, decoding it according to Content-Encoding.
+func readBody(resp *http.Response) ([]byte, error) {
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBody(resp.Header.Get("Content-Encoding"), raw)
+}
+
+// decodeBody decompresses raw according to a Content-Encoding value.
+// Unknown or identity encodings are returned unchanged.
+func decodeBody(encoding string, raw []byte) ([]byte, error) {
+	if len(raw) == 0 {
+		return raw, nil
+	}
+
+	zr, err := newBodyReader(encoding, bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// newBodyReader wraps r to decompress it as it is read, according to a
+// Content-Encoding value. Unknown or identity encodings are read unchanged.
+func newBodyReader(encoding string, r io.Reader) (io.ReadCloser, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "gzip", "x-gzip":
+		return gzip.NewReader(r)
+	case "deflate":
+		// "deflate" should be zlib-wrapped, but some servers send raw DEFLATE.
+		br := bufio.NewReader(r)
+		if hasZlibHeader(br) {
+			return zlib.NewReader(br)
+		}
+		return flate.NewReader(br), nil
+	default:
+		return io.NopCloser(r), nil
+	}
+}
+
+// hasZlibHeader reports whether br starts with a valid zlib header, without
+// consuming it.
+func hasZlibHeader(br *bufio.Reader) bool {
+	header, err := br.Peek(2)
+	if err != nil {
+		return false
+	}
+	cmf, flg := header[0], header[1]
+	return cmf&0x0f == 8 && (uint16(cmf)<<8|uint16(flg))%31 == 0
+}
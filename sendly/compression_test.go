@@ -0,0 +1,206 @@
+package sendly
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClientRequest_DecompressesResponses(t *testing.T) {
+	const body = `{"data":[{"id":"msg_1","to":"+15551234567","status":"delivered"}],"count":1}`
+
+	tests := []struct {
+		name     string
+		encoding string
+		compress func(io.Writer) io.WriteCloser
+	}{
+		{"gzip", "gzip", func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) }},
+		{"zlib deflate", "deflate", func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) }},
+		{"raw deflate", "deflate", func(w io.Writer) io.WriteCloser {
+			fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+			return fw
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept-Encoding") != acceptEncoding {
+					t.Errorf("expected Accept-Encoding '%s', got '%s'", acceptEncoding, r.Header.Get("Accept-Encoding"))
+				}
+
+				var buf bytes.Buffer
+				zw := tt.compress(&buf)
+				zw.Write([]byte(body))
+				zw.Close()
+
+				w.Header().Set("Content-Encoding", tt.encoding)
+				w.WriteHeader(http.StatusOK)
+				w.Write(buf.Bytes())
+			}))
+			defer server.Close()
+
+			client := NewClient("test-api-key", WithBaseURL(server.URL))
+
+			resp, err := client.Messages.List(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(resp.Data) != 1 || resp.Data[0].ID != "msg_1" {
+				t.Errorf("expected one message 'msg_1', got %+v", resp.Data)
+			}
+		})
+	}
+}
+
+func TestWithCompression_Disabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") == acceptEncoding {
+			t.Error("expected Accept-Encoding not to be set by the client")
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCompression(false))
+
+	if _, err := client.Messages.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// largeBatch returns a batch whose JSON body exceeds compressRequestThreshold.
+func largeBatch() *SendBatchRequest {
+	req := &SendBatchRequest{}
+	for i := 0; i < 500; i++ {
+		req.Messages = append(req.Messages, BatchMessageItem{
+			To:   fmt.Sprintf("+1555%07d", i),
+			Text: "Your order has shipped and will arrive tomorrow.",
+		})
+	}
+	return req
+}
+
+func TestClientRequest_CompressesLargeBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("expected Content-Encoding 'gzip', got '%s'", r.Header.Get("Content-Encoding"))
+		}
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Fatalf("expected a gzipped body: %v", err)
+		}
+		var req SendBatchRequest
+		if err := json.NewDecoder(zr).Decode(&req); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if len(req.Messages) != 500 {
+			t.Errorf("expected 500 messages, got %d", len(req.Messages))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"batchId":"batch_1","status":"processing","total":500}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCompression(true))
+
+	if _, err := client.Messages.SendBatch(context.Background(), largeBatch()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientRequest_SmallBodiesUncompressed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "" {
+			t.Errorf("expected no Content-Encoding, got '%s'", r.Header.Get("Content-Encoding"))
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+
+	req := &SendMessageRequest{To: "+15551234567", Text: "Hello"}
+	if _, err := client.Messages.Send(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWithCompression_DisabledSendsPlainBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "" {
+			t.Errorf("expected no Content-Encoding, got '%s'", r.Header.Get("Content-Encoding"))
+		}
+		var req SendBatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("expected a plain JSON body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"batchId":"batch_1","status":"processing","total":500}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCompression(false))
+
+	if _, err := client.Messages.SendBatch(context.Background(), largeBatch()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientRequest_PlainBodiesByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "" {
+			t.Errorf("expected no Content-Encoding, got '%s'", r.Header.Get("Content-Encoding"))
+		}
+		var req SendBatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("expected a plain JSON body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"batchId":"batch_1","status":"processing","total":500}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+
+	if _, err := client.Messages.SendBatch(context.Background(), largeBatch()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRecorder_StoresDecompressedRequestBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"batchId":"batch_1","status":"processing","total":500}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "batch.json")
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithRecorder(path, RecordModeRecord), WithCompression(true))
+
+	if _, err := client.Messages.SendBatch(context.Background(), largeBatch()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected cassette to be written: %v", err)
+	}
+	if !strings.Contains(string(data), "+15550000499") {
+		t.Error("expected the cassette to contain the uncompressed request body")
+	}
+}
@@ -0,0 +1,81 @@
+package sendly
+
+import (
+	"net/http"
+	"time"
+)
+
+// Config is a read-only snapshot of a client's settings, taken when the
+// client is created. Unlike the deprecated exported Client fields, it is
+// safe to read from any goroutine.
+type Config struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	maxRetries int
+	timeout    time.Duration
+	debug      bool
+}
+
+// BaseURL returns the API base URL.
+func (c Config) BaseURL() string { return c.baseURL }
+
+// APIKey returns the authentication key.
+func (c Config) APIKey() string { return c.apiKey }
+
+// HTTPClient returns the underlying HTTP client.
+func (c Config) HTTPClient() *http.Client { return c.httpClient }
+
+// MaxRetries returns the maximum number of retry attempts.
+func (c Config) MaxRetries() int { return c.maxRetries }
+
+// Timeout returns the request timeout.
+func (c Config) Timeout() time.Duration { return c.timeout }
+
+// Debug reports whether debug mode is enabled.
+func (c Config) Debug() bool { return c.debug }
+
+// WithAPIKey sets the authentication key, replacing the one passed to
+// NewClient. It is mainly useful with Clone.
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *Client) {
+		c.APIKey = apiKey
+	}
+}
+
+// Config returns the client's settings.
+func (c *Client) Config() Config {
+	return c.cfg
+}
+
+// snapshotConfig freezes the exported settings once every option has been
+// applied.
+func (c *Client) snapshotConfig() {
+	c.cfg = Config{
+		baseURL:    c.BaseURL,
+		apiKey:     c.APIKey,
+		httpClient: c.HTTPClient,
+		maxRetries: c.MaxRetries,
+		timeout:    c.Timeout,
+		debug:      c.Debug,
+	}
+}
+
+// Clone returns a new client built with the options c was created with,
+// followed by opts, so variants can be derived without mutating a client
+// that other goroutines are using:
+//
+//	slow := client.Clone(sendly.WithTimeout(2 * time.Minute))
+//	tenant := client.Clone(sendly.WithSubAccount("sub_123"))
+//
+// The clone shares whatever the options share, such as an HTTP client passed
+// to WithHTTPClient, the cassette from WithRecorder, the tally behind
+// WithCreditBudget and the sends remembered by WithDedupeWindow, but has its
+// own rate limiter, circuit breaker and lifecycle: closing one client does
+// not close the other.
+func (c *Client) Clone(opts ...ClientOption) *Client {
+	all := make([]ClientOption, 0, len(c.options)+len(opts))
+	all = append(all, c.options...)
+	all = append(all, opts...)
+	return NewClient(c.cfg.apiKey, all...)
+}
@@ -0,0 +1,153 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestClientConfig(t *testing.T) {
+	client := NewClient("test-api-key",
+		WithBaseURL("https://custom.example.com"),
+		WithMaxRetries(5),
+		WithTimeout(time.Minute),
+	)
+
+	cfg := client.Config()
+	if cfg.APIKey() != "test-api-key" {
+		t.Errorf("expected APIKey to be 'test-api-key', got '%s'", cfg.APIKey())
+	}
+	if cfg.BaseURL() != "https://custom.example.com" {
+		t.Errorf("expected BaseURL to be 'https://custom.example.com', got '%s'", cfg.BaseURL())
+	}
+	if cfg.MaxRetries() != 5 {
+		t.Errorf("expected MaxRetries to be 5, got %d", cfg.MaxRetries())
+	}
+	if cfg.Timeout() != time.Minute || cfg.HTTPClient().Timeout != time.Minute {
+		t.Errorf("expected Timeout to be 1m, got %v", cfg.Timeout())
+	}
+}
+
+func TestClientConfig_FieldChangesIgnored(t *testing.T) {
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	client.APIKey = "changed"
+	client.BaseURL = "http://127.0.0.1:1"
+
+	if _, err := client.Messages.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if auth != "Bearer test-api-key" {
+		t.Errorf("expected the original key to be used, got '%s'", auth)
+	}
+}
+
+func TestClientClone(t *testing.T) {
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(1))
+	clone := client.Clone(WithAPIKey("other-key"), WithSubAccount("sub_1"))
+
+	if clone.Config().BaseURL() != server.URL || clone.Config().MaxRetries() != 1 {
+		t.Errorf("expected clone to keep the original options, got %+v", clone.Config())
+	}
+	if client.Config().APIKey() != "test-api-key" {
+		t.Errorf("expected original key to be unchanged, got '%s'", client.Config().APIKey())
+	}
+
+	ctx := context.Background()
+	client.Messages.List(ctx, nil)
+	clone.Messages.List(ctx, nil)
+
+	if len(headers) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(headers))
+	}
+	if got := headers[0].Get("Authorization"); got != "Bearer test-api-key" {
+		t.Errorf("expected original client to use 'test-api-key', got '%s'", got)
+	}
+	if headers[0].Get(subAccountHeader) != "" {
+		t.Errorf("expected no sub-account header on the original client")
+	}
+	if got := headers[1].Get("Authorization"); got != "Bearer other-key" {
+		t.Errorf("expected clone to use 'other-key', got '%s'", got)
+	}
+	if got := headers[1].Get(subAccountHeader); got != "sub_1" {
+		t.Errorf("expected clone sub-account header to be 'sub_1', got '%s'", got)
+	}
+
+	// Closing the clone leaves the original usable.
+	clone.Close(ctx)
+	if _, err := client.Messages.List(ctx, nil); err != nil {
+		t.Errorf("expected original client to stay open, got %v", err)
+	}
+}
+
+func TestClientClone_SharedState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","status":"queued","creditsUsed":2}`))
+	}))
+	defer server.Close()
+
+	hc := &http.Client{Timeout: 5 * time.Second}
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithHTTPClient(hc),
+		WithCreditBudget(3),
+		WithDedupeWindow(time.Minute),
+		WithRecorder(path, RecordModeRecord),
+	)
+	clone := client.Clone(WithTimeout(time.Minute))
+
+	if hc.Timeout != 5*time.Second {
+		t.Errorf("expected the shared HTTP client to keep its timeout, got %v", hc.Timeout)
+	}
+
+	ctx := context.Background()
+	req := &SendMessageRequest{To: "+15551234567", Text: "Hi"}
+	if _, err := client.Messages.Send(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var dupErr *DuplicateMessageError
+	if _, err := clone.Messages.Send(ctx, req); !errors.As(err, &dupErr) {
+		t.Errorf("expected the clone to see the original's send, got %v", err)
+	}
+	if _, err := clone.Messages.Send(ctx, &SendMessageRequest{To: "+15557654321", Text: "Hi"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := client.CreditsSpent(); got != 4 {
+		t.Errorf("expected the budget to count both clients' credits, got %d", got)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var recorded cassette
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recorded.Interactions) != 2 {
+		t.Errorf("expected both clients' interactions in the cassette, got %d", len(recorded.Interactions))
+	}
+}
@@ -0,0 +1,105 @@
+package sendly
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// readYourWritesInitialBackoff is the first wait before re-fetching a fresh ID.
+	readYourWritesInitialBackoff = 50 * time.Millisecond
+	// readYourWritesMaxBackoff caps the wait between re-fetches of a fresh ID.
+	readYourWritesMaxBackoff = 500 * time.Millisecond
+)
+
+// WithReadYourWrites makes Messages.Get retry a 404 for IDs returned by Send or
+// SendBatch within the last window, smoothing over replication lag when a
+// message is looked up immediately after sending it. A zero window disables it.
+func WithReadYourWrites(window time.Duration) ClientOption {
+	return func(c *Client) {
+		c.readYourWritesWindow = window
+	}
+}
+
+// freshIDs tracks recently created message IDs for read-your-writes retries.
+type freshIDs struct {
+	mu        sync.Mutex
+	ids       map[string]time.Time
+	lastSweep time.Time
+}
+
+// add records id as created at now. Entries older than window are pruned
+// at most once per window, so recording a large batch stays linear.
+func (f *freshIDs) add(id string, now time.Time, window time.Duration) {
+	if id == "" {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.ids == nil {
+		f.ids = make(map[string]time.Time)
+	}
+	if now.Sub(f.lastSweep) >= window {
+		for k, created := range f.ids {
+			if now.Sub(created) > window {
+				delete(f.ids, k)
+			}
+		}
+		f.lastSweep = now
+	}
+	f.ids[id] = now
+}
+
+// deadline returns when id stops being considered fresh, if it is tracked.
+func (f *freshIDs) deadline(id string, window time.Duration) (time.Time, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created, ok := f.ids[id]
+	if !ok {
+		return time.Time{}, false
+	}
+	return created.Add(window), true
+}
+
+// retryFresh calls fn until it stops returning a NotFoundError or id is no
+// longer fresh, backing off between attempts.
+func (s *MessagesService) retryFresh(ctx context.Context, id string, fn func() error) error {
+	err := fn()
+
+	window := s.client.readYourWritesWindow
+	if window <= 0 {
+		return err
+	}
+	deadline, ok := s.fresh.deadline(id, window)
+	if !ok {
+		return err
+	}
+
+	backoff := readYourWritesInitialBackoff
+	for IsNotFoundError(err) {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return err
+		}
+		if backoff > remaining {
+			backoff = remaining
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		err = fn()
+		backoff *= 2
+		if backoff > readYourWritesMaxBackoff {
+			backoff = readYourWritesMaxBackoff
+		}
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// laggingServer accepts sends and returns 404 for the first lag Gets of any message.
+func laggingServer(t *testing.T, lag int32) (*httptest.Server, *int32) {
+	var gets int32
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(Message{ID: "msg_fresh", Status: MessageStatusQueued})
+		case "GET":
+			if atomic.AddInt32(&gets, 1) <= lag {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(APIError{Code: "NOT_FOUND", Message: "Message not found"})
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(Message{ID: "msg_fresh", Status: MessageStatusSent})
+		}
+	})
+	return server, &gets
+}
+
+func TestReadYourWrites_RetriesFreshID(t *testing.T) {
+	server, gets := laggingServer(t, 2)
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithReadYourWrites(2*time.Second))
+	ctx := context.Background()
+
+	sent, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+15551234567", Text: "Hi"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg, err := client.Messages.Get(ctx, sent.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Status != MessageStatusSent {
+		t.Errorf("expected Status to be 'sent', got '%s'", msg.Status)
+	}
+	if atomic.LoadInt32(gets) != 3 {
+		t.Errorf("expected 3 GET attempts, got %d", atomic.LoadInt32(gets))
+	}
+}
+
+func TestReadYourWrites_UnknownIDNotRetried(t *testing.T) {
+	server, gets := laggingServer(t, 2)
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithReadYourWrites(2*time.Second))
+
+	_, err := client.Messages.Get(context.Background(), "msg_other")
+	if !IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %T", err)
+	}
+	if atomic.LoadInt32(gets) != 1 {
+		t.Errorf("expected 1 GET attempt, got %d", atomic.LoadInt32(gets))
+	}
+}
+
+func TestReadYourWrites_DisabledByDefault(t *testing.T) {
+	server, gets := laggingServer(t, 2)
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	sent, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+15551234567", Text: "Hi"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.Messages.Get(ctx, sent.ID)
+	if !IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %T", err)
+	}
+	if atomic.LoadInt32(gets) != 1 {
+		t.Errorf("expected 1 GET attempt, got %d", atomic.LoadInt32(gets))
+	}
+}
+
+func TestReadYourWrites_GivesUpAfterWindow(t *testing.T) {
+	server, _ := laggingServer(t, 1000)
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithReadYourWrites(200*time.Millisecond))
+	ctx := context.Background()
+
+	sent, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+15551234567", Text: "Hi"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	start := time.Now()
+	_, err = client.Messages.Get(ctx, sent.ID)
+	if !IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %T", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected to give up within the window, took %v", elapsed)
+	}
+}
+
+func TestFreshIDs_SweepsOncePerWindow(t *testing.T) {
+	var f freshIDs
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	f.add("msg_1", now, time.Minute)
+	f.add("msg_2", now.Add(5*time.Second), time.Minute)
+	f.add("msg_3", now.Add(61*time.Second), time.Minute)
+	if _, ok := f.ids["msg_1"]; ok {
+		t.Error("expected msg_1 to be pruned by the sweep a window after the first")
+	}
+
+	// msg_2 is now stale, but the last sweep was less than a window ago.
+	f.add("msg_4", now.Add(70*time.Second), time.Minute)
+	if _, ok := f.ids["msg_2"]; !ok {
+		t.Error("expected no sweep within a window of the last one")
+	}
+
+	f.add("msg_5", now.Add(125*time.Second), time.Minute)
+	if len(f.ids) != 2 {
+		t.Errorf("expected msg_4 and msg_5 to remain, got %v", f.ids)
+	}
+}
@@ -0,0 +1,31 @@
+package sendly
+
+import "testing"
+
+func TestCountryForNumber(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  string
+	}{
+		{"+15551234567", "US"},
+		{"+14165551234", "CA"},
+		{"+18765551234", "JM"},
+		{"+447911123456", "GB"},
+		{"+33612345678", "FR"},
+		{"+353871234567", "IE"},
+		{"+2348012345678", "NG"},
+		{"+79161234567", "RU"},
+		{"+8613812345678", "CN"},
+		{"+8521234567", "HK"},
+		{"15551234567", ""},
+		{"+15", ""},
+		{"+44 7911 123456", ""},
+		{"+8001234567", ""},
+	}
+
+	for _, tt := range tests {
+		if got := CountryForNumber(tt.phone); got != tt.want {
+			t.Errorf("CountryForNumber(%q): expected '%s', got '%s'", tt.phone, tt.want, got)
+		}
+	}
+}
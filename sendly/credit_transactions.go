@@ -0,0 +1,52 @@
+package sendly
+
+import "context"
+
+// DefaultTransactionPageSize is the page size CreditTransactionIterator
+// requests when ListCreditTransactionsOptions.Limit is zero.
+const DefaultTransactionPageSize = 100
+
+// CreditTransactionIterator walks every credit transaction matching a set of
+// filters, fetching pages as needed. Use it like bufio.Scanner:
+//
+//	it := client.Account.IterateCreditTransactions(nil)
+//	for it.Next(ctx) {
+//		export(it.Transaction())
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type CreditTransactionIterator struct {
+	*PageIterator[CreditTransaction]
+}
+
+// IterateCreditTransactions returns an iterator over all credit transactions
+// matching opts. opts.Limit sets the page size (default:
+// DefaultTransactionPageSize) and opts.Offset where to start.
+func (s *AccountService) IterateCreditTransactions(opts *ListCreditTransactionsOptions) *CreditTransactionIterator {
+	return NewCreditTransactionIterator(s, opts)
+}
+
+// NewCreditTransactionIterator implements
+// AccountAPI.IterateCreditTransactions on top of
+// account.ListCreditTransactions, so alternative AccountAPI implementations
+// can share it.
+func NewCreditTransactionIterator(account AccountAPI, opts *ListCreditTransactionsOptions) *CreditTransactionIterator {
+	var page ListCreditTransactionsOptions
+	if opts != nil {
+		page = *opts
+	}
+	if page.Limit <= 0 {
+		page.Limit = DefaultTransactionPageSize
+	}
+
+	return &CreditTransactionIterator{NewPageIterator(page.Offset, func(ctx context.Context, offset int) (*Page[CreditTransaction], error) {
+		page.Offset = offset
+		return account.ListCreditTransactions(ctx, &page)
+	})}
+}
+
+// Transaction returns the transaction Next advanced to.
+func (it *CreditTransactionIterator) Transaction() CreditTransaction {
+	return it.Item()
+}
@@ -0,0 +1,132 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestAccountListCreditTransactions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		expected := map[string]string{
+			"limit":         "2",
+			"type":          "usage",
+			"createdAfter":  "2024-01-01T00:00:00Z",
+			"createdBefore": "2024-02-01T00:00:00Z",
+			"messageId":     "msg_1",
+		}
+		for key, want := range expected {
+			if got := query.Get(key); got != want {
+				t.Errorf("expected %s to be '%s', got '%s'", key, want, got)
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"txn_1","type":"usage","amount":-1,"balance_after":99,"message_id":"msg_1"}],"count":7,"has_more":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.Account.ListCreditTransactions(context.Background(), &ListCreditTransactionsOptions{
+		Limit:         2,
+		Type:          TransactionTypeUsage,
+		CreatedAfter:  "2024-01-01T00:00:00Z",
+		CreatedBefore: "2024-02-01T00:00:00Z",
+		MessageID:     "msg_1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Count != 7 || !resp.HasMore {
+		t.Errorf("expected Count 7 and HasMore, got %d and %v", resp.Count, resp.HasMore)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].BalanceAfter != 99 {
+		t.Errorf("expected one transaction with BalanceAfter 99, got %+v", resp.Data)
+	}
+}
+
+func TestAccountListCreditTransactions_BareArray(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id":"txn_1","type":"usage","amount":-1},{"id":"txn_2","type":"purchase","amount":100}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.Account.ListCreditTransactions(context.Background(), &ListCreditTransactionsOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 2 || resp.Count != 2 {
+		t.Errorf("expected 2 transactions and Count 2, got %d and %d", len(resp.Data), resp.Count)
+	}
+	if !resp.HasMore {
+		t.Error("expected HasMore when a bare array fills the page")
+	}
+}
+
+func TestCreditTransactionIterator(t *testing.T) {
+	const total = 5
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		resp := transactionListAPIResponse{Count: total}
+		for i := offset; i < total && i < offset+limit; i++ {
+			resp.Data = append(resp.Data, transactionAPIResponse{ID: "txn_" + strconv.Itoa(i)})
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	ctx := context.Background()
+	it := client.Account.IterateCreditTransactions(&ListCreditTransactionsOptions{Limit: 2})
+
+	var ids []string
+	for it.Next(ctx) {
+		ids = append(ids, it.Transaction().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(ids) != total {
+		t.Fatalf("expected %d transactions, got %v", total, ids)
+	}
+	for i, id := range ids {
+		if id != "txn_"+strconv.Itoa(i) {
+			t.Errorf("expected transaction %d to be 'txn_%d', got '%s'", i, i, id)
+		}
+	}
+	if pages != 3 {
+		t.Errorf("expected 3 pages, got %d", pages)
+	}
+}
+
+func TestCreditTransactionIterator_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"unauthorized","message":"Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	it := client.Account.IterateCreditTransactions(nil)
+
+	if it.Next(context.Background()) {
+		t.Error("expected Next to return false")
+	}
+	if !IsAuthenticationError(it.Err()) {
+		t.Errorf("expected AuthenticationError, got %T", it.Err())
+	}
+}
@@ -0,0 +1,102 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DefaultWatchInterval is how often WatchCredits polls when
+// WatchOptions.Interval is zero.
+const DefaultWatchInterval = time.Minute
+
+// WatchOptions configures WatchCredits.
+type WatchOptions struct {
+	// Interval is the time between polls (default: DefaultWatchInterval).
+	Interval time.Duration
+	// Threshold is the available balance below which OnLow is called.
+	// Zero disables the check.
+	Threshold int
+	// OnLow is called when the available balance drops below Threshold. It is
+	// called once per drop: the balance must recover to Threshold or above
+	// before it fires again.
+	OnLow func(Credits)
+	// OnError, if set, is called when a poll fails. Failed polls are
+	// otherwise skipped and retried at the next interval.
+	OnError func(error)
+}
+
+// WatchCredits polls the credit balance every opts.Interval and sends it on
+// the returned channel whenever it changes, starting with the current
+// balance. The channel is closed when ctx is done, or at the next poll after
+// the client is closed. The first poll happens before WatchCredits returns,
+// and its error, if any, is returned.
+func (s *AccountService) WatchCredits(ctx context.Context, opts WatchOptions) (<-chan Credits, error) {
+	return WatchCredits(ctx, s, opts)
+}
+
+// WatchCredits implements AccountAPI.WatchCredits on top of
+// account.GetCredits, so alternative AccountAPI implementations can share it.
+func WatchCredits(ctx context.Context, account AccountAPI, opts WatchOptions) (<-chan Credits, error) {
+	if opts.Interval < 0 {
+		return nil, &ValidationError{APIError: APIError{Message: "watch interval cannot be negative"}}
+	}
+	if opts.Interval == 0 {
+		opts.Interval = DefaultWatchInterval
+	}
+
+	first, err := account.GetCredits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan Credits, 1)
+	go func() {
+		defer close(updates)
+
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+
+		var last Credits
+		emitted := false
+		low := false
+		current := first
+		for {
+			if current != nil {
+				if opts.Threshold > 0 {
+					below := current.AvailableBalance < opts.Threshold
+					if below && !low && opts.OnLow != nil {
+						opts.OnLow(*current)
+					}
+					low = below
+				}
+
+				if !emitted || *current != last {
+					select {
+					case updates <- *current:
+					case <-ctx.Done():
+						return
+					}
+					last, emitted = *current, true
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current, err = account.GetCredits(ctx)
+			if err != nil {
+				if ctx.Err() != nil || errors.Is(err, ErrClientClosed) {
+					return
+				}
+				if opts.OnError != nil {
+					opts.OnError(err)
+				}
+			}
+		}
+	}()
+	return updates, nil
+}
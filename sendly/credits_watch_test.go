@@ -0,0 +1,151 @@
+package sendly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchCredits(t *testing.T) {
+	balances := []int{100, 100, 40, 30, 120, 20}
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(atomic.AddInt32(&polls, 1)) - 1
+		if i >= len(balances) {
+			i = len(balances) - 1
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"balance":%d,"reserved_balance":0,"available_balance":%d}`, balances[i], balances[i])
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithoutRateLimit())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var lows []int
+	updates, err := client.Account.WatchCredits(ctx, WatchOptions{
+		Interval:  time.Millisecond,
+		Threshold: 50,
+		OnLow: func(c Credits) {
+			mu.Lock()
+			lows = append(lows, c.AvailableBalance)
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []int
+	for c := range updates {
+		got = append(got, c.AvailableBalance)
+		if len(got) == 5 {
+			cancel()
+		}
+	}
+
+	want := []int{100, 40, 30, 120, 20}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected updates %v, got %v", want, got)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if fmt.Sprint(lows) != "[40 20]" {
+		t.Errorf("expected OnLow for 40 and 20, got %v", lows)
+	}
+}
+
+func TestWatchCredits_FirstPollError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"unauthorized","message":"Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	_, err := client.Account.WatchCredits(context.Background(), WatchOptions{})
+
+	if !IsAuthenticationError(err) {
+		t.Errorf("expected AuthenticationError, got %T", err)
+	}
+}
+
+func TestWatchCredits_ReportsPollErrors(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&polls, 1) == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"bad_request","message":"boom"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"balance":10,"reserved_balance":0,"available_balance":10}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithoutRateLimit())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 10)
+	updates, err := client.Account.WatchCredits(ctx, WatchOptions{
+		Interval: time.Millisecond,
+		OnError:  func(err error) { errs <- err },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	<-updates
+	select {
+	case err := <-errs:
+		if !IsValidationError(err) {
+			t.Errorf("expected ValidationError, got %T", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected OnError to be called")
+	}
+	cancel()
+	for range updates {
+	}
+}
+
+func TestWatchCredits_StopsWhenClientClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"balance":10,"reserved_balance":0,"available_balance":10}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithoutRateLimit())
+	var errCount int32
+	updates, err := client.Account.WatchCredits(context.Background(), WatchOptions{
+		Interval: time.Millisecond,
+		OnError:  func(err error) { atomic.AddInt32(&errCount, 1) },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-updates
+
+	if err := client.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deadline := time.After(time.Second)
+	for open := true; open; {
+		select {
+		case _, open = <-updates:
+		case <-deadline:
+			t.Fatal("expected the channel to close after the client closed")
+		}
+	}
+	if n := atomic.LoadInt32(&errCount); n != 0 {
+		t.Errorf("expected OnError not to be called for ErrClientClosed, got %d calls", n)
+	}
+}
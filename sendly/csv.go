@@ -0,0 +1,227 @@
+package sendly
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxBatchSize is the largest number of messages accepted in one batch.
+const MaxBatchSize = 1000
+
+// CSVOptions configures SendBatchFromCSV and ParseRecipientsCSV.
+type CSVOptions struct {
+	// Template is the message text. Placeholders such as {{name}} are replaced
+	// with the value of the matching column in each row. If empty, the "text"
+	// column is sent as-is.
+	Template string
+	// PhoneColumn is the header of the recipient column (default: "phone").
+	PhoneColumn string
+	// From is the sender ID for every message.
+	From string
+	// MessageType is the message type for compliance: "marketing" (default) or "transactional".
+	MessageType MessageType
+	// BatchSize is the number of rows sent per batch (default and max: MaxBatchSize).
+	BatchSize int
+}
+
+// CSVRecipient is a parsed row ready to be sent.
+type CSVRecipient struct {
+	// Line is the 1-based line number of the row in the input.
+	Line int
+	// Item is the message to send.
+	Item BatchMessageItem
+}
+
+// CSVRowError reports a row that could not be parsed or sent.
+type CSVRowError struct {
+	// Line is the 1-based line number of the row in the input.
+	Line int
+	// To is the recipient, if the row had one.
+	To string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *CSVRowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *CSVRowError) Unwrap() error {
+	return e.Err
+}
+
+// CSVSendResult is the outcome of SendBatchFromCSV.
+type CSVSendResult struct {
+	// Rows is the number of data rows read.
+	Rows int
+	// Queued is the number of messages accepted for delivery.
+	Queued int
+	// CreditsUsed is the total credits used across all batches.
+	CreditsUsed int
+	// Batches contains the response for each batch sent.
+	Batches []*BatchMessageResponse
+	// Errors contains one entry per row that was skipped or rejected.
+	Errors []*CSVRowError
+}
+
+var csvPlaceholder = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// ParseRecipientsCSV reads a recipients file with a header row and renders
+// opts.Template for each row. Rows that cannot be rendered are returned as
+// row errors; an error is returned only if the file itself is unreadable.
+func ParseRecipientsCSV(r io.Reader, opts CSVOptions) ([]CSVRecipient, []*CSVRowError, error) {
+	phoneColumn := strings.ToLower(opts.PhoneColumn)
+	if phoneColumn == "" {
+		phoneColumn = "phone"
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, &ValidationError{APIError: APIError{Message: "CSV is empty"}}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns[phoneColumn]; !ok {
+		return nil, nil, &ValidationError{APIError: APIError{Message: "CSV has no '" + phoneColumn + "' column"}}
+	}
+	if _, ok := columns["text"]; opts.Template == "" && !ok {
+		return nil, nil, &ValidationError{APIError: APIError{Message: "template is required when the CSV has no 'text' column"}}
+	}
+
+	var recipients []CSVRecipient
+	var rowErrors []*CSVRowError
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rowErrors = append(rowErrors, &CSVRowError{Line: parseErr.Line, Err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		value := func(column string) (string, bool) {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return "", false
+			}
+			return strings.TrimSpace(record[i]), true
+		}
+
+		to, _ := value(phoneColumn)
+		if to == "" {
+			rowErrors = append(rowErrors, &CSVRowError{Line: line, Err: errors.New("phone number is missing")})
+			continue
+		}
+
+		var text string
+		if opts.Template == "" {
+			text, _ = value("text")
+		} else {
+			var missing string
+			text = csvPlaceholder.ReplaceAllStringFunc(opts.Template, func(match string) string {
+				name := strings.ToLower(csvPlaceholder.FindStringSubmatch(match)[1])
+				v, ok := value(name)
+				if !ok && missing == "" {
+					missing = name
+				}
+				return v
+			})
+			if missing != "" {
+				rowErrors = append(rowErrors, &CSVRowError{Line: line, To: to, Err: fmt.Errorf("no value for template variable %q", missing)})
+				continue
+			}
+		}
+		if text == "" {
+			rowErrors = append(rowErrors, &CSVRowError{Line: line, To: to, Err: errors.New("message text is empty")})
+			continue
+		}
+
+		recipients = append(recipients, CSVRecipient{Line: line, Item: BatchMessageItem{To: to, Text: text}})
+	}
+
+	return recipients, rowErrors, nil
+}
+
+// SendBatchFromCSV parses a recipients file, renders opts.Template for each
+// row, and sends the rows in batches of opts.BatchSize. Rows that fail to
+// parse or are rejected by the API are reported in the result's Errors with
+// their line numbers. If a batch request fails, the result so far is returned
+// along with the error; earlier batches have already been sent.
+func (s *MessagesService) SendBatchFromCSV(ctx context.Context, r io.Reader, opts CSVOptions) (*CSVSendResult, error) {
+	return sendCSV(ctx, s.SendBatch, r, opts)
+}
+
+// sendCSV implements SendBatchFromCSV on top of a SendBatch function.
+func sendCSV(ctx context.Context, sendBatch func(context.Context, *SendBatchRequest) (*BatchMessageResponse, error), r io.Reader, opts CSVOptions) (*CSVSendResult, error) {
+	recipients, rowErrors, err := ParseRecipientsCSV(r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CSVSendResult{Rows: len(recipients) + len(rowErrors), Errors: rowErrors}
+
+	size := opts.BatchSize
+	if size <= 0 || size > MaxBatchSize {
+		size = MaxBatchSize
+	}
+
+	for start := 0; start < len(recipients); start += size {
+		end := start + size
+		if end > len(recipients) {
+			end = len(recipients)
+		}
+		chunk := recipients[start:end]
+
+		req := &SendBatchRequest{From: opts.From, MessageType: opts.MessageType}
+		for _, recipient := range chunk {
+			req.Messages = append(req.Messages, recipient.Item)
+		}
+
+		resp, err := sendBatch(ctx, req)
+		if err != nil {
+			return result, err
+		}
+
+		result.Batches = append(result.Batches, resp)
+		result.Queued += resp.Queued
+		result.CreditsUsed += resp.CreditsUsed
+		for i, msg := range resp.Messages {
+			if i >= len(chunk) || msg.Error == nil {
+				continue
+			}
+			result.Errors = append(result.Errors, &CSVRowError{
+				Line: chunk[i].Line,
+				To:   msg.To,
+				Err:  errors.New(*msg.Error),
+			})
+		}
+	}
+
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Line < result.Errors[j].Line
+	})
+
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseRecipientsCSV(t *testing.T) {
+	input := "phone,name\n" +
+		"+15551234567,Alice\n" +
+		",Bob\n" +
+		"+15551234568\n" +
+		"+15551234569,Carol\n"
+
+	recipients, rowErrors, err := ParseRecipientsCSV(strings.NewReader(input), CSVOptions{Template: "Hi {{ name }}!"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(recipients) != 2 {
+		t.Fatalf("expected 2 recipients, got %d", len(recipients))
+	}
+	if recipients[0].Item.Text != "Hi Alice!" {
+		t.Errorf("expected Text to be 'Hi Alice!', got '%s'", recipients[0].Item.Text)
+	}
+	if recipients[1].Line != 5 {
+		t.Errorf("expected Line to be 5, got %d", recipients[1].Line)
+	}
+
+	if len(rowErrors) != 2 {
+		t.Fatalf("expected 2 row errors, got %d", len(rowErrors))
+	}
+	if rowErrors[0].Line != 3 || rowErrors[1].Line != 4 {
+		t.Errorf("expected row errors on lines 3 and 4, got %d and %d", rowErrors[0].Line, rowErrors[1].Line)
+	}
+}
+
+func TestParseRecipientsCSV_MissingColumns(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  CSVOptions
+	}{
+		{"no phone column", "number,name\n+15551234567,Alice\n", CSVOptions{Template: "Hi"}},
+		{"no template or text column", "phone,name\n+15551234567,Alice\n", CSVOptions{}},
+		{"empty", "", CSVOptions{Template: "Hi"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseRecipientsCSV(strings.NewReader(tt.input), tt.opts)
+			if !IsValidationError(err) {
+				t.Errorf("expected ValidationError, got %T", err)
+			}
+		})
+	}
+}
+
+func TestMessagesSendBatchFromCSV(t *testing.T) {
+	var batches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages/batch" {
+			t.Errorf("expected path '/messages/batch', got '%s'", r.URL.Path)
+		}
+		batches++
+
+		var req SendBatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		resp := BatchMessageResponse{BatchID: "batch_" + req.Messages[0].To, Status: BatchStatusProcessing}
+		for _, item := range req.Messages {
+			result := BatchMessageResult{To: item.To, Status: "queued"}
+			if item.To == "+15550000000" {
+				reason := "invalid_number"
+				result.Status = "failed"
+				result.Error = &reason
+				resp.Failed++
+			} else {
+				resp.Queued++
+			}
+			resp.Messages = append(resp.Messages, result)
+		}
+		resp.Total = len(req.Messages)
+		resp.CreditsUsed = resp.Queued
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+
+	input := "phone,text\n" +
+		"+15551234567,One\n" +
+		"+15550000000,Two\n" +
+		"+15551234569,Three\n"
+
+	result, err := client.Messages.SendBatchFromCSV(context.Background(), strings.NewReader(input), CSVOptions{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if batches != 2 {
+		t.Errorf("expected 2 batch requests, got %d", batches)
+	}
+	if result.Rows != 3 {
+		t.Errorf("expected Rows to be 3, got %d", result.Rows)
+	}
+	if result.Queued != 2 {
+		t.Errorf("expected Queued to be 2, got %d", result.Queued)
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected 1 row error, got %d", len(result.Errors))
+	}
+	if result.Errors[0].Line != 3 {
+		t.Errorf("expected error on line 3, got %d", result.Errors[0].Line)
+	}
+	if result.Errors[0].Error() != "line 3: invalid_number" {
+		t.Errorf("expected error 'line 3: invalid_number', got '%s'", result.Errors[0].Error())
+	}
+}
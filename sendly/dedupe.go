@@ -0,0 +1,168 @@
+package sendly
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// WithDedupeWindow refuses a Send, or a SendTransaction message, with the same recipient and text as one
+// made by this client or its clones within window, returning a *DuplicateMessageError
+// without contacting the API. It catches retry storms and double submits in
+// application code. Sends acting for different sub-accounts are tracked
+// separately, and a failed send does not count. Use WithAllowDuplicate to
+// resend on purpose.
+func WithDedupeWindow(window time.Duration) ClientOption {
+	g := &dedupeGuard{window: window, sent: make(map[string]dedupeEntry)}
+	return func(c *Client) {
+		c.dedupe = g
+	}
+}
+
+// WithAllowDuplicate skips the WithDedupeWindow check for the call.
+func WithAllowDuplicate() CallOption {
+	return func(o *callOptions) {
+		o.allowDuplicate = true
+	}
+}
+
+// sendOnce sends req unless an identical message was sent within the
+// client's dedupe window.
+func (s *MessagesService) sendOnce(ctx context.Context, req *SendMessageRequest) (*Message, error) {
+	key := s.client.dedupeKey(ctx, req)
+	if err := s.client.dedupe.reserve(key, req.To, time.Now()); err != nil {
+		return nil, err
+	}
+
+	msg, err := s.send(ctx, req)
+	var id string
+	if msg != nil {
+		id = msg.ID
+	}
+	s.client.dedupe.complete(key, id, err)
+	return msg, err
+}
+
+// sendTransactionOnce is SendTransaction guarded against duplicates. Every
+// message is reserved before the transaction is sent, so one duplicate
+// fails the whole transaction.
+func (s *MessagesService) sendTransactionOnce(ctx context.Context, reqs []SendMessageRequest) (*TransactionResponse, error) {
+	now := time.Now()
+	keys := make([]string, len(reqs))
+	for i := range reqs {
+		keys[i] = s.client.dedupeKey(ctx, &reqs[i])
+		if err := s.client.dedupe.reserve(keys[i], reqs[i].To, now); err != nil {
+			for _, key := range keys[:i] {
+				s.client.dedupe.complete(key, "", err)
+			}
+			return nil, err
+		}
+	}
+
+	resp, err := s.sendTransaction(ctx, reqs)
+	var partial *PartialAcceptanceError
+	switch {
+	case errors.As(err, &partial):
+		s.completeTransaction(keys, partial.Response)
+	case err != nil:
+		for _, key := range keys {
+			s.client.dedupe.complete(key, "", err)
+		}
+	default:
+		s.completeTransaction(keys, resp)
+	}
+	return resp, err
+}
+
+// completeTransaction records the message IDs of a transaction's accepted
+// messages and releases the reservations of rejected ones.
+func (s *MessagesService) completeTransaction(keys []string, resp *TransactionResponse) {
+	rejected := make(map[int]bool, len(resp.Rejected))
+	for _, r := range resp.Rejected {
+		rejected[r.Index] = true
+	}
+
+	accepted := 0
+	for i, key := range keys {
+		if rejected[i] {
+			s.client.dedupe.complete(key, "", errTransactionRejected)
+			continue
+		}
+		var id string
+		if accepted < len(resp.Messages) {
+			id = resp.Messages[accepted].ID
+		}
+		accepted++
+		s.client.dedupe.complete(key, id, nil)
+	}
+}
+
+// errTransactionRejected releases the reservation of a message the API
+// rejected from a transaction.
+var errTransactionRejected = errors.New("message rejected from transaction")
+
+// dedupeGuard remembers recent sends by a hash of their recipient and text.
+type dedupeGuard struct {
+	window time.Duration
+
+	mu        sync.Mutex
+	sent      map[string]dedupeEntry
+	lastSweep time.Time
+}
+
+// dedupeEntry is a recent send. The message ID is empty while it is in flight.
+type dedupeEntry struct {
+	at        time.Time
+	messageID string
+}
+
+// dedupeKey hashes a send so message text isn't kept in memory.
+func (c *Client) dedupeKey(ctx context.Context, req *SendMessageRequest) string {
+	subAccount := callOptionsFrom(ctx).subAccount
+	if subAccount == "" {
+		subAccount = c.subAccount
+	}
+	sum := sha256.Sum256([]byte(subAccount + "\x00" + req.To + "\x00" + req.Text))
+	return hex.EncodeToString(sum[:])
+}
+
+// reserve claims key for a send, or returns a *DuplicateMessageError if a
+// send with the same key was made within the window.
+func (g *dedupeGuard) reserve(key, to string, now time.Time) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if now.Sub(g.lastSweep) >= g.window {
+		for k, e := range g.sent {
+			if now.Sub(e.at) >= g.window {
+				delete(g.sent, k)
+			}
+		}
+		g.lastSweep = now
+	}
+
+	if e, ok := g.sent[key]; ok && now.Sub(e.at) < g.window {
+		return &DuplicateMessageError{To: to, OriginalID: e.messageID, SentAt: e.at}
+	}
+	g.sent[key] = dedupeEntry{at: now}
+	return nil
+}
+
+// complete records the message ID of a reserved send, or releases the
+// reservation if the send failed.
+func (g *dedupeGuard) complete(key string, messageID string, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err != nil {
+		delete(g.sent, key)
+		return
+	}
+	if e, ok := g.sent[key]; ok {
+		e.messageID = messageID
+		g.sent[key] = e
+	}
+}
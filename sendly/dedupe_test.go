@@ -0,0 +1,102 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithDedupeWindow(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithDedupeWindow(time.Minute))
+	ctx := context.Background()
+	req := &SendMessageRequest{To: "+15551234567", Text: "Your order shipped"}
+
+	if _, err := client.Messages.Send(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := client.Messages.Send(ctx, req)
+	var dup *DuplicateMessageError
+	if !errors.As(err, &dup) {
+		t.Fatalf("expected DuplicateMessageError, got %v", err)
+	}
+	if dup.OriginalID != "msg_1" || dup.To != "+15551234567" || dup.SentAt.IsZero() {
+		t.Errorf("unexpected error %+v", dup)
+	}
+	if !errors.Is(err, ErrDuplicateMessage) {
+		t.Error("expected error to match ErrDuplicateMessage")
+	}
+
+	if _, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+15551234567", Text: "Your order arrived"}); err != nil {
+		t.Errorf("expected different text to be sent, got %v", err)
+	}
+	if _, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+15559876543", Text: "Your order shipped"}); err != nil {
+		t.Errorf("expected a different recipient to be sent, got %v", err)
+	}
+	if _, err := client.Messages.Send(WithCallOptions(ctx, WithAllowDuplicate()), req); err != nil {
+		t.Errorf("expected WithAllowDuplicate to send, got %v", err)
+	}
+	if _, err := client.Messages.Send(WithCallOptions(ctx, WithCallSubAccount("sub_1")), req); err != nil {
+		t.Errorf("expected another sub-account to be sent, got %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 5 {
+		t.Errorf("expected 5 requests, got %d", got)
+	}
+}
+
+func TestWithDedupeWindow_FailedSendReleases(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_request","message":"bad"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_2","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithDedupeWindow(time.Minute))
+	req := &SendMessageRequest{To: "+15551234567", Text: "Hello"}
+
+	if _, err := client.Messages.Send(context.Background(), req); err == nil {
+		t.Fatal("expected the first send to fail")
+	}
+	if _, err := client.Messages.Send(context.Background(), req); err != nil {
+		t.Errorf("expected the retry to be sent, got %v", err)
+	}
+}
+
+func TestDedupeGuard_Expires(t *testing.T) {
+	g := &dedupeGuard{window: time.Minute, sent: make(map[string]dedupeEntry)}
+	start := time.Now()
+
+	if err := g.reserve("key", "+15551234567", start); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	g.complete("key", "msg_1", nil)
+
+	if err := g.reserve("key", "+15551234567", start.Add(30*time.Second)); !IsDuplicateMessageError(err) {
+		t.Errorf("expected DuplicateMessageError within the window, got %v", err)
+	}
+	if err := g.reserve("key", "+15551234567", start.Add(2*time.Minute)); err != nil {
+		t.Errorf("expected no error after the window, got %v", err)
+	}
+	if len(g.sent) != 1 {
+		t.Errorf("expected expired entries to be swept, got %d entries", len(g.sent))
+	}
+}
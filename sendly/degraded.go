@@ -0,0 +1,66 @@
+package sendly
+
+import "context"
+
+// WithDegradedMode makes Messages.Send accept messages locally while the
+// circuit breaker is open, instead of failing with a *CircuitOpenError.
+// The message is added to a Queue built with opts. Send then returns a
+// synthetic Message with status MessageStatusAcceptedLocally whose ID is the
+// queue ID. Use it as the tracking handle. Once the API is reachable again,
+// the queue sends the message, and the WithQueueOnSent callback receives the
+// queue entry and the real Message so the two can be reconciled. A ClientID is
+// generated for messages that have none, so webhook events can be matched
+// too.
+//
+// It requires WithCircuitBreaker: only sends the breaker rejected are
+// accepted locally, because they are known not to have reached the API. Run
+// the queue with DegradedQueue. Pass WithQueueStore so accepted messages
+// survive restarts.
+func WithDegradedMode(opts ...QueueOption) ClientOption {
+	return func(c *Client) {
+		c.degradedMode = true
+		c.degradedOpts = opts
+	}
+}
+
+// DegradedQueue returns the queue that holds messages accepted locally by
+// WithDegradedMode, or nil if the mode is off. Start it with Run:
+//
+//	go client.DegradedQueue().Run(ctx)
+func (c *Client) DegradedQueue() *Queue {
+	return c.degraded
+}
+
+// withoutDegradedMode makes Send fail with the *CircuitOpenError instead of
+// accepting the message locally. The degraded queue uses it so flushing
+// during an outage does not requeue messages.
+func withoutDegradedMode() CallOption {
+	return func(o *callOptions) {
+		o.noDegrade = true
+	}
+}
+
+// acceptLocally queues req and returns a synthetic message for it.
+func (s *MessagesService) acceptLocally(ctx context.Context, req *SendMessageRequest) (*Message, error) {
+	queued := *req
+	if queued.ClientID == "" {
+		queued.ClientID = NewClientID()
+	}
+
+	id, err := s.client.degraded.Enqueue(ctx, &queued)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		ID:        id,
+		ClientID:  queued.ClientID,
+		ThreadKey: queued.ThreadKey,
+		Metadata:  queued.Metadata,
+		To:        queued.To,
+		Text:      queued.Text,
+		Status:    MessageStatusAcceptedLocally,
+		Direction: "outbound",
+		Segments:  CountSegments(queued.Text),
+	}, nil
+}
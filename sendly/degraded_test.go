@@ -0,0 +1,149 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDegradedMode_AcceptsLocallyAndReconciles(t *testing.T) {
+	var healthy atomic.Bool
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":"unavailable","message":"down for maintenance"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_real","to":"+15551234567","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	var reconciled map[string]string
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithMaxRetries(0),
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute}),
+		WithDegradedMode(WithQueueOnSent(func(q QueuedMessage, msg *Message) {
+			reconciled = map[string]string{q.ID: msg.ID, "clientId": q.Request.ClientID}
+		})),
+	)
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+	ctx := context.Background()
+	req := &SendMessageRequest{To: "+15551234567", Text: "Your code is 123456"}
+
+	// The first failure reaches the API, so it is not accepted locally.
+	if _, err := client.Messages.Send(ctx, req); IsCircuitOpenError(err) || err == nil {
+		t.Fatalf("expected server error, got %v", err)
+	}
+
+	msg, err := client.Messages.Send(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Status != MessageStatusAcceptedLocally {
+		t.Errorf("expected status to be '%s', got '%s'", MessageStatusAcceptedLocally, msg.Status)
+	}
+	if msg.ClientID == "" {
+		t.Error("expected a generated ClientID")
+	}
+	if hits != 1 {
+		t.Errorf("expected no request while the breaker is open, got %d hits", hits)
+	}
+	if n, _ := client.DegradedQueue().Len(ctx); n != 1 {
+		t.Fatalf("expected 1 queued message, got %d", n)
+	}
+
+	// Flushing while the breaker is still open leaves the message queued.
+	if err := client.DegradedQueue().Flush(ctx); !IsCircuitOpenError(err) {
+		t.Fatalf("expected CircuitOpenError, got %v", err)
+	}
+	if n, _ := client.DegradedQueue().Len(ctx); n != 1 {
+		t.Fatalf("expected message to stay queued, got %d", n)
+	}
+
+	healthy.Store(true)
+	now = now.Add(time.Minute)
+	if err := client.DegradedQueue().Flush(ctx); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+	if reconciled[msg.ID] != "msg_real" {
+		t.Errorf("expected handle %s to reconcile to 'msg_real', got %v", msg.ID, reconciled)
+	}
+	if reconciled["clientId"] != msg.ClientID {
+		t.Errorf("expected queued ClientID to be '%s', got '%s'", msg.ClientID, reconciled["clientId"])
+	}
+}
+
+func TestDegradedMode_OffByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":"unavailable","message":"down"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithMaxRetries(0),
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute}),
+	)
+	if client.DegradedQueue() != nil {
+		t.Fatal("expected no degraded queue")
+	}
+
+	ctx := context.Background()
+	req := &SendMessageRequest{To: "+15551234567", Text: "Hello"}
+	client.Messages.Send(ctx, req)
+	if _, err := client.Messages.Send(ctx, req); !IsCircuitOpenError(err) {
+		t.Errorf("expected CircuitOpenError, got %T", err)
+	}
+}
+
+func TestDegradedMode_FlushWithDedupeWindow(t *testing.T) {
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":"unavailable","message":"down for maintenance"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_real","to":"+15551234567","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	var failed error
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithMaxRetries(0),
+		WithDedupeWindow(time.Hour),
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute}),
+		WithDegradedMode(WithQueueOnFailed(func(q QueuedMessage, err error) { failed = err })),
+	)
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	client.Messages.Send(ctx, &SendMessageRequest{To: "+15551234567", Text: "Opening the breaker"})
+	if _, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+15551234567", Text: "Your code is 123456"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	healthy.Store(true)
+	now = now.Add(time.Minute)
+	if err := client.DegradedQueue().Flush(ctx); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+	if failed != nil {
+		t.Errorf("expected the queued message to be sent, got %v", failed)
+	}
+	if n, _ := client.DegradedQueue().Len(ctx); n != 0 {
+		t.Errorf("expected an empty queue, got %d", n)
+	}
+}
@@ -0,0 +1,88 @@
+package sendly
+
+import (
+	"context"
+	"net/url"
+)
+
+// DeliveryStage identifies a hop in a message's delivery path.
+type DeliveryStage string
+
+const (
+	// DeliveryStageAccepted means Sendly accepted the message.
+	DeliveryStageAccepted DeliveryStage = "accepted"
+	// DeliveryStageSubmitted means the message was submitted to the carrier.
+	DeliveryStageSubmitted DeliveryStage = "submitted"
+	// DeliveryStageCarrierAccepted means the carrier acknowledged the message.
+	DeliveryStageCarrierAccepted DeliveryStage = "carrier_accepted"
+	// DeliveryStageDelivered means the handset confirmed delivery.
+	DeliveryStageDelivered DeliveryStage = "delivered"
+	// DeliveryStageFailed means the carrier reported a permanent failure.
+	DeliveryStageFailed DeliveryStage = "failed"
+	// DeliveryStageExpired means the validity period lapsed before delivery.
+	DeliveryStageExpired DeliveryStage = "expired"
+)
+
+// DeliveryHop is one step in a message's delivery, as reported by Sendly or
+// the carrier.
+type DeliveryHop struct {
+	// Stage is the delivery step.
+	Stage DeliveryStage `json:"stage"`
+	// At is when the step happened (ISO 8601).
+	At string `json:"at"`
+	// StatusCode is the raw status code reported for the step, if any.
+	StatusCode string `json:"statusCode,omitempty"`
+	// Detail is a human-readable description of the step, if any.
+	Detail string `json:"detail,omitempty"`
+}
+
+// DeliveryNetwork identifies the mobile network that handled a message.
+type DeliveryNetwork struct {
+	// Name is the network operator name.
+	Name string `json:"name"`
+	// MCC is the mobile country code.
+	MCC string `json:"mcc,omitempty"`
+	// MNC is the mobile network code.
+	MNC string `json:"mnc,omitempty"`
+	// Country is the ISO 3166-1 alpha-2 country code.
+	Country string `json:"country,omitempty"`
+}
+
+// DeliveryReport is the carrier-level delivery report (DLR) for a message.
+// It explains a Status in more detail, for example why a message failed.
+type DeliveryReport struct {
+	// MessageID is the message the report is for.
+	MessageID string `json:"messageId"`
+	// Status is the message's current status.
+	Status MessageStatus `json:"status"`
+	// CarrierStatusCode is the final status code reported by the carrier,
+	// such as "DELIVRD" or a numeric error code.
+	CarrierStatusCode string `json:"carrierStatusCode,omitempty"`
+	// CarrierStatus describes CarrierStatusCode.
+	CarrierStatus string `json:"carrierStatus,omitempty"`
+	// ErrorCode is Sendly's error code for failed messages.
+	ErrorCode string `json:"errorCode,omitempty"`
+	// Network is the network that handled the message, if known.
+	Network *DeliveryNetwork `json:"network,omitempty"`
+	// Hops lists the delivery steps so far, oldest first.
+	Hops []DeliveryHop `json:"hops"`
+}
+
+// GetDeliveryReport retrieves the carrier-level delivery report for a message.
+func (s *MessagesService) GetDeliveryReport(ctx context.Context, messageID string) (*DeliveryReport, error) {
+	if messageID == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "message ID is required"}}
+	}
+
+	path := "/messages/" + url.PathEscape(messageID) + "/delivery-report"
+
+	var resp DeliveryReport
+	err := s.retryFresh(ctx, messageID, func() error {
+		return s.client.request(ctx, "GET", path, nil, &resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,61 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMessagesGetDeliveryReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/messages/msg_123/delivery-report" {
+			t.Errorf("expected path '/messages/msg_123/delivery-report', got '%s'", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"messageId": "msg_123",
+			"status": "failed",
+			"carrierStatusCode": "UNDELIV",
+			"carrierStatus": "Subscriber absent",
+			"errorCode": "subscriber_absent",
+			"network": {"name": "T-Mobile", "mcc": "310", "mnc": "260", "country": "US"},
+			"hops": [
+				{"stage": "accepted", "at": "2025-01-01T00:00:00Z"},
+				{"stage": "submitted", "at": "2025-01-01T00:00:01Z"},
+				{"stage": "failed", "at": "2025-01-01T00:00:09Z", "statusCode": "UNDELIV", "detail": "Subscriber absent"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	report, err := client.Messages.GetDeliveryReport(context.Background(), "msg_123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Status != MessageStatusFailed {
+		t.Errorf("expected status to be 'failed', got '%s'", report.Status)
+	}
+	if report.CarrierStatusCode != "UNDELIV" {
+		t.Errorf("expected carrier status code to be 'UNDELIV', got '%s'", report.CarrierStatusCode)
+	}
+	if report.Network == nil || report.Network.MNC != "260" {
+		t.Errorf("expected network MNC to be '260', got %+v", report.Network)
+	}
+	if len(report.Hops) != 3 || report.Hops[2].Stage != DeliveryStageFailed {
+		t.Errorf("expected 3 hops ending in 'failed', got %+v", report.Hops)
+	}
+}
+
+func TestMessagesGetDeliveryReport_RequiresID(t *testing.T) {
+	client := NewClient("test-api-key")
+	if _, err := client.Messages.GetDeliveryReport(context.Background(), ""); !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
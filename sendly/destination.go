@@ -0,0 +1,69 @@
+package sendly
+
+import "strings"
+
+// DestinationPolicy restricts which countries messages may be sent to,
+// guarding against accidental international sends. Countries are ISO 3166-1
+// alpha-2 codes, such as "US" or "GB", matched against CountryForNumber.
+type DestinationPolicy struct {
+	// Allow, if not empty, lists the only countries messages may be sent to.
+	// Numbers whose country is not recognised are blocked.
+	Allow []string
+	// Deny lists countries messages may never be sent to. It takes
+	// precedence over Allow.
+	Deny []string
+}
+
+// WithDestinationPolicy checks every recipient of Send, Schedule,
+// SendTransaction, SendBatch, batch previews, batch drafts and Client.Do
+// against the allow and deny lists before the request is made. A request with a blocked
+// recipient fails with a *DestinationBlockedError without reaching the API.
+func WithDestinationPolicy(allow, deny []string) ClientOption {
+	return func(c *Client) {
+		c.destinationPolicy = &DestinationPolicy{Allow: allow, Deny: deny}
+	}
+}
+
+// Check returns a *DestinationBlockedError if the policy blocks phone.
+func (p DestinationPolicy) Check(phone string) error {
+	country := CountryForNumber(phone)
+	blocked := len(p.Allow) > 0 && !containsCountry(p.Allow, country)
+	if country != "" && containsCountry(p.Deny, country) {
+		blocked = true
+	}
+	if blocked {
+		return &DestinationBlockedError{To: phone, Country: country}
+	}
+	return nil
+}
+
+// checkDestinations applies the client's destination policy to the
+// recipients of a request body.
+func (c *Client) checkDestinations(body interface{}) error {
+	if c.destinationPolicy == nil {
+		return nil
+	}
+	r, ok := body.(recipienter)
+	if !ok {
+		return nil
+	}
+	for _, to := range r.recipients() {
+		if err := c.destinationPolicy.Check(to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// containsCountry reports whether countries contains country, ignoring case.
+func containsCountry(countries []string, country string) bool {
+	if country == "" {
+		return false
+	}
+	for _, c := range countries {
+		if strings.EqualFold(c, country) {
+			return true
+		}
+	}
+	return false
+}
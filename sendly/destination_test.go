@@ -0,0 +1,80 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDestinationPolicyCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  DestinationPolicy
+		phone   string
+		blocked bool
+	}{
+		{"allowed", DestinationPolicy{Allow: []string{"US", "CA"}}, "+14165551234", false},
+		{"not allowed", DestinationPolicy{Allow: []string{"US", "CA"}}, "+447911123456", true},
+		{"case insensitive", DestinationPolicy{Allow: []string{"gb"}}, "+447911123456", false},
+		{"unknown country with allow list", DestinationPolicy{Allow: []string{"US"}}, "+8001234567", true},
+		{"denied", DestinationPolicy{Deny: []string{"NG"}}, "+2348012345678", true},
+		{"not denied", DestinationPolicy{Deny: []string{"NG"}}, "+15551234567", false},
+		{"unknown country with deny list", DestinationPolicy{Deny: []string{"NG"}}, "+8001234567", false},
+		{"deny wins", DestinationPolicy{Allow: []string{"US"}, Deny: []string{"US"}}, "+15551234567", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.phone)
+			if tt.blocked != IsDestinationBlockedError(err) {
+				t.Errorf("expected blocked to be %v, got error %v", tt.blocked, err)
+			}
+		})
+	}
+}
+
+func TestWithDestinationPolicy_BlocksBeforeSending(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithDestinationPolicy([]string{"US"}, nil))
+	ctx := context.Background()
+
+	_, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+447911123456", Text: "Hello"})
+	var blocked *DestinationBlockedError
+	if !errors.As(err, &blocked) {
+		t.Fatalf("expected DestinationBlockedError, got %v", err)
+	}
+	if blocked.To != "+447911123456" || blocked.Country != "GB" {
+		t.Errorf("unexpected error %+v", blocked)
+	}
+	if !errors.Is(err, ErrDestinationBlocked) {
+		t.Error("expected error to match ErrDestinationBlocked")
+	}
+
+	batch := &SendBatchRequest{Messages: []BatchMessageItem{
+		{To: "+15551234567", Text: "Hello"},
+		{To: "+33612345678", Text: "Bonjour"},
+	}}
+	if _, err := client.Messages.SendBatch(ctx, batch); !IsDestinationBlockedError(err) {
+		t.Errorf("expected DestinationBlockedError for the batch, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 0 {
+		t.Errorf("expected no requests, got %d", got)
+	}
+
+	if _, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+15551234567", Text: "Hello"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
@@ -0,0 +1,152 @@
+package sendly
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// EndpointDiagnostics summarizes connection handling for one endpoint.
+type EndpointDiagnostics struct {
+	// Endpoint is the method and path template, e.g. "GET /messages/:id".
+	Endpoint string
+	// Requests is the number of requests that obtained a connection.
+	Requests int
+	// ReusedConns is the number of requests served on a pooled connection.
+	ReusedConns int
+	// TLSHandshakes is the number of new TLS handshakes performed.
+	TLSHandshakes int
+	// TLSResumed is the number of TLS handshakes that resumed a previous session.
+	TLSResumed int
+	// Protocols counts responses by protocol, e.g. "HTTP/2.0" or "HTTP/1.1".
+	Protocols map[string]int
+}
+
+// ReuseRate returns the fraction of requests served on a reused connection.
+func (d EndpointDiagnostics) ReuseRate() float64 {
+	if d.Requests == 0 {
+		return 0
+	}
+	return float64(d.ReusedConns) / float64(d.Requests)
+}
+
+// WithConnectionDiagnostics records, per endpoint, the negotiated protocol,
+// connection reuse, and TLS session resumption of every request. Read the
+// results with ConnectionDiagnostics.
+func WithConnectionDiagnostics() ClientOption {
+	return func(c *Client) {
+		c.diagnostics = &connDiagnostics{endpoints: make(map[string]*EndpointDiagnostics)}
+	}
+}
+
+// ConnectionDiagnostics returns a snapshot of connection statistics keyed by
+// endpoint. It returns nil unless WithConnectionDiagnostics is set.
+func (c *Client) ConnectionDiagnostics() map[string]EndpointDiagnostics {
+	if c.diagnostics == nil {
+		return nil
+	}
+	return c.diagnostics.snapshot()
+}
+
+// connDiagnostics accumulates EndpointDiagnostics.
+type connDiagnostics struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointDiagnostics
+}
+
+// trace instruments req and returns it with a func that records the response.
+func (d *connDiagnostics) trace(req *http.Request, method, path string) (*http.Request, func(*http.Response)) {
+	endpoint := method + " " + endpointTemplate(path)
+
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			d.update(endpoint, func(e *EndpointDiagnostics) {
+				e.Requests++
+				if info.Reused {
+					e.ReusedConns++
+				}
+			})
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			if err != nil {
+				return
+			}
+			d.update(endpoint, func(e *EndpointDiagnostics) {
+				e.TLSHandshakes++
+				if state.DidResume {
+					e.TLSResumed++
+				}
+			})
+		},
+	}
+
+	done := func(resp *http.Response) {
+		d.update(endpoint, func(e *EndpointDiagnostics) {
+			e.Protocols[resp.Proto]++
+		})
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), done
+}
+
+func (d *connDiagnostics) update(endpoint string, fn func(*EndpointDiagnostics)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	e, ok := d.endpoints[endpoint]
+	if !ok {
+		e = &EndpointDiagnostics{Endpoint: endpoint, Protocols: make(map[string]int)}
+		d.endpoints[endpoint] = e
+	}
+	fn(e)
+}
+
+func (d *connDiagnostics) snapshot() map[string]EndpointDiagnostics {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	out := make(map[string]EndpointDiagnostics, len(d.endpoints))
+	for k, e := range d.endpoints {
+		copied := *e
+		copied.Protocols = make(map[string]int, len(e.Protocols))
+		for proto, n := range e.Protocols {
+			copied.Protocols[proto] = n
+		}
+		out[k] = copied
+	}
+	return out
+}
+
+// endpointTemplate strips the query string and replaces ID segments with
+// ":id" so that requests for different resources share an endpoint.
+func endpointTemplate(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if isIDSegment(seg) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isIDSegment reports whether a path segment looks like a resource ID, such
+// as "msg_abc123" or "42".
+func isIDSegment(seg string) bool {
+	if seg == "" {
+		return false
+	}
+	if strings.Contains(seg, "_") {
+		return true
+	}
+	for _, r := range seg {
+		if unicode.IsDigit(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConnectionDiagnostics(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithHTTPClient(server.Client()),
+		WithConnectionDiagnostics(),
+	)
+
+	for _, id := range []string{"msg_1", "msg_2", "msg_3"} {
+		if _, err := client.Messages.Get(context.Background(), id); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	stats, ok := client.ConnectionDiagnostics()["GET /messages/:id"]
+	if !ok {
+		t.Fatalf("expected stats for 'GET /messages/:id', got %v", client.ConnectionDiagnostics())
+	}
+	if stats.Requests != 3 {
+		t.Errorf("expected 3 requests, got %d", stats.Requests)
+	}
+	if stats.Protocols["HTTP/2.0"] != 3 {
+		t.Errorf("expected 3 HTTP/2.0 responses, got %v", stats.Protocols)
+	}
+	if stats.TLSHandshakes != 1 {
+		t.Errorf("expected 1 TLS handshake, got %d", stats.TLSHandshakes)
+	}
+	if rate := stats.ReuseRate(); rate < 0.6 {
+		t.Errorf("expected connections to be reused, got reuse rate %v", rate)
+	}
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/messages", "/messages"},
+		{"/messages?limit=10", "/messages"},
+		{"/messages/msg_abc", "/messages/:id"},
+		{"/messages/batch/preview", "/messages/batch/preview"},
+		{"/webhooks/whk_1/deliveries/del_2/retry", "/webhooks/:id/deliveries/:id/retry"},
+	}
+
+	for _, tt := range tests {
+		if got := endpointTemplate(tt.path); got != tt.want {
+			t.Errorf("endpointTemplate(%q): expected '%s', got '%s'", tt.path, tt.want, got)
+		}
+	}
+}
+
+func TestConnectionDiagnostics_Disabled(t *testing.T) {
+	client := NewClient("test-api-key")
+	if client.ConnectionDiagnostics() != nil {
+		t.Error("expected nil diagnostics when not enabled")
+	}
+}
@@ -0,0 +1,154 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// RequestOption configures a single Do call.
+type RequestOption func(*requestOptions)
+
+// requestOptions holds the settings for a Do call.
+type requestOptions struct {
+	header http.Header
+	query  url.Values
+}
+
+// WithRequestHeader sets a header on a Do call, such as one that opts in to
+// a beta endpoint. It cannot override the headers the client manages, such
+// as Authorization.
+func WithRequestHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// WithRequestQuery adds query parameters to a Do call.
+func WithRequestQuery(query url.Values) RequestOption {
+	return func(o *requestOptions) {
+		if o.query == nil {
+			o.query = make(url.Values)
+		}
+		for k, vs := range query {
+			for _, v := range vs {
+				o.query.Add(k, v)
+			}
+		}
+	}
+}
+
+// Do calls an API endpoint the SDK does not model yet, such as a new or beta
+// endpoint. path is relative to the base URL, for example "/messages/stats".
+// body is encoded as JSON if it is not nil, and a JSON response is decoded
+// into result if it is not nil.
+//
+// The call gets the same authentication, rate limiting, retries, typed
+// errors, destination policy and audit log as the modelled methods, and
+// honours call options on ctx. Bodies sent to /messages endpoints that are
+// not the SDK's request types are checked against WithDestinationPolicy by
+// their "to" and "messages[].to" fields. POST and PATCH requests are only
+// retried when it is safe, as described for WithIdempotencyKey.
+//
+// The returned response is the last one received, with its body already
+// consumed; it is nil if no response was received. Its headers and status
+// are available even when err is set.
+//
+// Example:
+//
+//	var stats struct {
+//		Sent int `json:"sent"`
+//	}
+//	_, err := client.Do(ctx, http.MethodGet, "/messages/stats", nil, &stats,
+//		sendly.WithRequestQuery(url.Values{"period": {"7d"}}))
+func (c *Client) Do(ctx context.Context, method, path string, body, result interface{}, opts ...RequestOption) (*http.Response, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, &ValidationError{APIError: APIError{Message: "path must start with /"}}
+	}
+	var o requestOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if len(o.query) > 0 {
+		sep := "?"
+		if strings.Contains(path, "?") {
+			sep = "&"
+		}
+		path += sep + o.query.Encode()
+	}
+
+	if _, ok := body.(recipienter); !ok && body != nil && strings.HasPrefix(path, "/messages") {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return nil, &ValidationError{APIError: APIError{Message: "failed to encode request body: " + err.Error()}}
+		}
+		body = newRawMessageBody(raw)
+	}
+	if err := c.checkDestinations(body); err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	var last *http.Response
+	ctx = WithCallOptions(ctx, func(call *callOptions) {
+		call.header = o.header
+		call.onResponse = func(resp *http.Response) {
+			copied := *resp
+			copied.Body = http.NoBody
+			mu.Lock()
+			last = &copied
+			mu.Unlock()
+		}
+	})
+
+	status, err := c.requestWithRetries(ctx, method, path, body, result)
+	c.audit(ctx, method, path, body, result, status, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	return last, err
+}
+
+// rawMessageBody is a Do body for a message endpoint. It is sent as given,
+// and exposes its recipients to the destination policy and audit log.
+type rawMessageBody struct {
+	raw json.RawMessage
+	to  []string
+}
+
+// newRawMessageBody reads the recipients of raw from its "to" and
+// "messages[].to" fields. Fields of other types are ignored.
+func newRawMessageBody(raw json.RawMessage) *rawMessageBody {
+	var fields struct {
+		To       json.RawMessage `json:"to"`
+		Messages []struct {
+			To json.RawMessage `json:"to"`
+		} `json:"messages"`
+	}
+	json.Unmarshal(raw, &fields)
+
+	b := &rawMessageBody{raw: raw}
+	b.addRecipient(fields.To)
+	for _, m := range fields.Messages {
+		b.addRecipient(m.To)
+	}
+	return b
+}
+
+func (b *rawMessageBody) addRecipient(field json.RawMessage) {
+	var to string
+	if json.Unmarshal(field, &to) == nil && to != "" {
+		b.to = append(b.to, to)
+	}
+}
+
+func (b *rawMessageBody) recipients() []string { return b.to }
+
+// MarshalJSON returns the body as given to Do.
+func (b *rawMessageBody) MarshalJSON() ([]byte, error) { return b.raw, nil }
@@ -0,0 +1,134 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClientDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages/stats" || r.URL.Query().Get("period") != "7d" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-api-key" {
+			t.Errorf("expected the API key, got %q", got)
+		}
+		if got := r.Header.Get("X-Sendly-Beta"); got != "stats" {
+			t.Errorf("expected the beta header, got %q", got)
+		}
+		w.Header().Set("X-Custom", "yes")
+		w.Write([]byte(`{"sent":42}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	var stats struct {
+		Sent int `json:"sent"`
+	}
+	resp, err := client.Do(context.Background(), http.MethodGet, "/messages/stats", nil, &stats,
+		WithRequestQuery(url.Values{"period": {"7d"}}),
+		WithRequestHeader("X-Sendly-Beta", "stats"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Sent != 42 {
+		t.Errorf("expected 42 sent, got %d", stats.Sent)
+	}
+	if resp == nil || resp.StatusCode != http.StatusOK || resp.Header.Get("X-Custom") != "yes" {
+		t.Errorf("expected the response headers and status, got %+v", resp)
+	}
+}
+
+func TestClientDo_RetriesAndTypedErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`{"error":"bad_gateway","message":"Upstream unavailable"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"not_found","message":"No such endpoint"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(1))
+	resp, err := client.Do(context.Background(), http.MethodGet, "/beta/widgets", nil, nil)
+	if !IsNotFoundError(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected the 502 to be retried, got %d calls", calls)
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected the 404 response, got %+v", resp)
+	}
+}
+
+func TestClientDo_ManagedHeadersWin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-api-key" {
+			t.Errorf("expected the client's Authorization header, got %q", got)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	if _, err := client.Do(context.Background(), http.MethodPost, "/beta/widgets", map[string]string{"name": "a"}, nil,
+		WithRequestHeader("Authorization", "Bearer other")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientDo_InvalidPath(t *testing.T) {
+	client := NewClient("test-api-key")
+	if _, err := client.Do(context.Background(), http.MethodGet, "messages", nil, nil); !IsValidationError(err) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
+
+func TestClientDo_DestinationPolicy(t *testing.T) {
+	var calls int32
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	sink := &recordingSink{}
+	client := NewClient("test-api-key", WithBaseURL(server.URL),
+		WithDestinationPolicy([]string{"US"}, nil), WithAuditSink(sink, WithAuditPlainRecipients()))
+	ctx := context.Background()
+
+	for _, body := range []interface{}{
+		&SendMessageRequest{To: "+447911123456", Text: "Hello"},
+		map[string]interface{}{"to": "+447911123456", "text": "Hello"},
+		map[string]interface{}{"messages": []map[string]string{{"to": "+15551234567"}, {"to": "+33612345678"}}},
+	} {
+		if _, err := client.Do(ctx, http.MethodPost, "/messages/beta", body, nil); !IsDestinationBlockedError(err) {
+			t.Errorf("expected DestinationBlockedError for %v, got %v", body, err)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 0 {
+		t.Fatalf("expected no requests, got %d", got)
+	}
+
+	if _, err := client.Do(ctx, http.MethodPost, "/messages/beta", map[string]interface{}{"to": "+15551234567", "text": "Hello"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["to"] != "+15551234567" || body["text"] != "Hello" {
+		t.Errorf("expected the body to be sent as given, got %v", body)
+	}
+	if len(sink.records) != 1 || len(sink.records[0].Recipients) != 1 || sink.records[0].Recipients[0] != "+15551234567" {
+		t.Errorf("expected an audit record with the recipient, got %+v", sink.records)
+	}
+}
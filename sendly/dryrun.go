@@ -0,0 +1,216 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// WithDryRun makes every Send, Schedule, SendTransaction and SendBatch call a
+// dry run: requests are fully validated and priced, but nothing is
+// dispatched. LaunchDraft and SendToSegment cannot be priced without sending,
+// so they fail with a *ValidationError instead.
+func WithDryRun() ClientOption {
+	return func(c *Client) {
+		c.dryRun = true
+	}
+}
+
+// errDryRunUnsupported returns the error for a send that cannot be dry run,
+// because its recipients are only known to the API.
+func errDryRunUnsupported(method string) error {
+	return &ValidationError{APIError: APIError{
+		Code:    "DRY_RUN_UNSUPPORTED",
+		Message: method + " cannot be dry run; nothing was sent",
+	}}
+}
+
+// dryRunSend validates and prices req through the batch preview endpoint and
+// returns a synthetic message without sending it.
+func (s *MessagesService) dryRunSend(ctx context.Context, req *SendMessageRequest) (*Message, error) {
+	preview, err := s.PreviewBatch(ctx, &SendBatchRequest{
+		Messages:    []BatchMessageItem{{To: req.To, Text: req.Text}},
+		MessageType: req.MessageType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var item *BatchPreviewItem
+	if len(preview.Messages) > 0 {
+		item = &preview.Messages[0]
+	} else {
+		item = &BatchPreviewItem{CanSend: true, Credits: preview.CreditsNeeded}
+	}
+	msg, err := dryRunMessage(req, item, preview.IsSandbox)
+	if err != nil {
+		return nil, err
+	}
+
+	if !preview.HasEnoughCredits {
+		return nil, dryRunInsufficientCredits(preview)
+	}
+
+	return msg, nil
+}
+
+// dryRunSchedule validates and prices a resolved schedule request through
+// the batch preview endpoint and returns a synthetic scheduled message
+// without scheduling it.
+func (s *MessagesService) dryRunSchedule(ctx context.Context, req *ScheduleMessageRequest) (*ScheduledMessage, error) {
+	msg, err := s.dryRunSend(ctx, &SendMessageRequest{To: req.To, Text: req.Text, MessageType: req.MessageType})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScheduledMessage{
+		To:                req.To,
+		From:              req.From,
+		Text:              req.Text,
+		ScheduledAt:       req.ScheduledAt,
+		Status:            ScheduledMessageStatusScheduled,
+		CreditsReserved:   msg.CreditsUsed,
+		IsSandbox:         msg.IsSandbox,
+		Metadata:          req.Metadata,
+		StatusCallbackURL: req.StatusCallbackURL,
+		DryRun:            true,
+	}, nil
+}
+
+// dryRunSendTransaction prices every message of a transaction through the
+// batch preview endpoint, one preview per message type, and returns a
+// synthetic response without sending anything. As with a real transaction,
+// one blocked message fails the whole transaction.
+func (s *MessagesService) dryRunSendTransaction(ctx context.Context, reqs []SendMessageRequest) (*TransactionResponse, error) {
+	var types []MessageType
+	byType := make(map[MessageType][]int)
+	for i, req := range reqs {
+		if _, ok := byType[req.MessageType]; !ok {
+			types = append(types, req.MessageType)
+		}
+		byType[req.MessageType] = append(byType[req.MessageType], i)
+	}
+
+	resp := &TransactionResponse{
+		Status:   TransactionStatusAccepted,
+		Messages: make([]Message, len(reqs)),
+		DryRun:   true,
+	}
+	balance := -1
+	for _, t := range types {
+		indexes := byType[t]
+		items := make([]BatchMessageItem, len(indexes))
+		for j, i := range indexes {
+			items[j] = BatchMessageItem{To: reqs[i].To, Text: reqs[i].Text}
+		}
+		preview, err := s.PreviewBatch(ctx, &SendBatchRequest{Messages: items, MessageType: t})
+		if err != nil {
+			return nil, err
+		}
+
+		for j, i := range indexes {
+			item := &BatchPreviewItem{CanSend: true}
+			if j < len(preview.Messages) {
+				item = &preview.Messages[j]
+			}
+			msg, err := dryRunMessage(&reqs[i], item, preview.IsSandbox)
+			if err != nil {
+				var verr *ValidationError
+				if errors.As(err, &verr) {
+					verr.Message += " for message at index " + strconv.Itoa(i)
+				}
+				return nil, err
+			}
+			resp.Messages[i] = *msg
+		}
+		resp.CreditsUsed += preview.CreditsNeeded
+		balance = preview.CurrentBalance
+	}
+
+	if resp.CreditsUsed > balance {
+		return nil, &InsufficientCreditsError{APIError: APIError{
+			Code:    "INSUFFICIENT_CREDITS",
+			Message: strconv.Itoa(resp.CreditsUsed) + " credits needed, " + strconv.Itoa(balance) + " available",
+		}}
+	}
+	return resp, nil
+}
+
+// dryRunMessage builds the synthetic message for req from its preview, or
+// returns the error a real send would if item cannot be sent.
+func dryRunMessage(req *SendMessageRequest, item *BatchPreviewItem, sandbox bool) (*Message, error) {
+	if !item.CanSend {
+		reason := "message would be blocked"
+		if item.BlockReason != nil {
+			reason += ": " + *item.BlockReason
+		}
+		return nil, &ValidationError{APIError: APIError{Code: "DRY_RUN_BLOCKED", Message: reason}}
+	}
+
+	msg := &Message{
+		ClientID:    req.ClientID,
+		ThreadKey:   req.ThreadKey,
+		Metadata:    req.Metadata,
+		To:          req.To,
+		Text:        req.Text,
+		Status:      MessageStatusQueued,
+		Direction:   "outbound",
+		Segments:    CountSegments(req.Text),
+		CreditsUsed: item.Credits,
+		IsSandbox:   sandbox,
+		DryRun:      true,
+	}
+	if item.Segments > 0 {
+		msg.Segments = item.Segments
+	}
+	return msg, nil
+}
+
+// dryRunSendBatch validates and prices req through the batch preview endpoint
+// and returns a synthetic batch without sending it.
+func (s *MessagesService) dryRunSendBatch(ctx context.Context, req *SendBatchRequest) (*BatchMessageResponse, error) {
+	preview, err := s.PreviewBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !preview.HasEnoughCredits {
+		return nil, dryRunInsufficientCredits(preview)
+	}
+
+	resp := &BatchMessageResponse{
+		Status:      BatchStatusProcessing,
+		Total:       preview.TotalMessages,
+		Queued:      preview.WillSend,
+		Failed:      preview.Blocked,
+		CreditsUsed: preview.CreditsNeeded,
+		IsSandbox:   preview.IsSandbox,
+		DryRun:      true,
+	}
+
+	for _, item := range preview.Messages {
+		result := BatchMessageResult{To: item.To, Status: string(MessageStatusQueued)}
+		if !item.CanSend {
+			result.Status = string(MessageStatusFailed)
+			result.Error = item.BlockReason
+		}
+		resp.Messages = append(resp.Messages, result)
+	}
+
+	if preview.Blocked == preview.TotalMessages && preview.TotalMessages > 0 {
+		resp.Status = BatchStatusFailed
+	} else if preview.Blocked > 0 {
+		resp.Status = BatchStatusPartialFailure
+	}
+
+	return resp, nil
+}
+
+// dryRunInsufficientCredits builds the error a real send would return.
+func dryRunInsufficientCredits(preview *BatchPreviewResponse) error {
+	return &InsufficientCreditsError{APIError: APIError{
+		Code: "INSUFFICIENT_CREDITS",
+		Message: strconv.Itoa(preview.CreditsNeeded) + " credits needed, " +
+			strconv.Itoa(preview.CurrentBalance) + " available",
+	}}
+}
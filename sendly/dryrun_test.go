@@ -0,0 +1,199 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// previewServer answers batch previews and fails the test on any real send.
+func previewServer(t *testing.T, preview BatchPreviewResponse) *httptest.Server {
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages/batch/preview" {
+			t.Errorf("expected only preview requests in dry run, got '%s'", r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(preview)
+	})
+	return server
+}
+
+func TestDryRun_Send(t *testing.T) {
+	server := previewServer(t, BatchPreviewResponse{
+		CanSend:          true,
+		TotalMessages:    1,
+		WillSend:         1,
+		CreditsNeeded:    2,
+		CurrentBalance:   100,
+		HasEnoughCredits: true,
+		Messages: []BatchPreviewItem{
+			{To: "+15551234567", Segments: 2, Credits: 2, CanSend: true},
+		},
+	})
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+
+	msg, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+		To:     "+15551234567",
+		Text:   "Hello",
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !msg.DryRun {
+		t.Error("expected DryRun to be true")
+	}
+	if msg.ID != "" {
+		t.Errorf("expected ID to be empty, got '%s'", msg.ID)
+	}
+	if msg.Segments != 2 {
+		t.Errorf("expected Segments to be 2, got %d", msg.Segments)
+	}
+	if msg.CreditsUsed != 2 {
+		t.Errorf("expected CreditsUsed to be 2, got %d", msg.CreditsUsed)
+	}
+}
+
+func TestDryRun_SendBlocked(t *testing.T) {
+	reason := "opted_out"
+	server := previewServer(t, BatchPreviewResponse{
+		TotalMessages:    1,
+		Blocked:          1,
+		HasEnoughCredits: true,
+		Messages: []BatchPreviewItem{
+			{To: "+15551234567", CanSend: false, BlockReason: &reason},
+		},
+	})
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithDryRun())
+
+	_, err := client.Messages.Send(context.Background(), &SendMessageRequest{To: "+15551234567", Text: "Hello"})
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
+
+func TestDryRun_SendInsufficientCredits(t *testing.T) {
+	server := previewServer(t, BatchPreviewResponse{
+		TotalMessages:    1,
+		WillSend:         1,
+		CreditsNeeded:    1,
+		CurrentBalance:   0,
+		HasEnoughCredits: false,
+		Messages: []BatchPreviewItem{
+			{To: "+15551234567", Segments: 1, Credits: 1, CanSend: true},
+		},
+	})
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithDryRun())
+
+	_, err := client.Messages.Send(context.Background(), &SendMessageRequest{To: "+15551234567", Text: "Hello"})
+	if !IsInsufficientCreditsError(err) {
+		t.Errorf("expected InsufficientCreditsError, got %T", err)
+	}
+}
+
+func TestDryRun_SendBatch(t *testing.T) {
+	reason := "invalid_number"
+	server := previewServer(t, BatchPreviewResponse{
+		TotalMessages:    2,
+		WillSend:         1,
+		Blocked:          1,
+		CreditsNeeded:    1,
+		CurrentBalance:   100,
+		HasEnoughCredits: true,
+		Messages: []BatchPreviewItem{
+			{To: "+15551234567", Segments: 1, Credits: 1, CanSend: true},
+			{To: "+1555", CanSend: false, BlockReason: &reason},
+		},
+	})
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithDryRun())
+
+	resp, err := client.Messages.SendBatch(context.Background(), &SendBatchRequest{
+		Messages: []BatchMessageItem{
+			{To: "+15551234567", Text: "One"},
+			{To: "+1555", Text: "Two"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.DryRun {
+		t.Error("expected DryRun to be true")
+	}
+	if resp.Status != BatchStatusPartialFailure {
+		t.Errorf("expected Status to be 'partial_failure', got '%s'", resp.Status)
+	}
+	if resp.Queued != 1 || resp.Failed != 1 {
+		t.Errorf("expected 1 queued and 1 failed, got %d and %d", resp.Queued, resp.Failed)
+	}
+	if len(resp.Messages) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(resp.Messages))
+	}
+	if resp.Messages[1].Error == nil || *resp.Messages[1].Error != "invalid_number" {
+		t.Errorf("expected second result error to be 'invalid_number', got %v", resp.Messages[1].Error)
+	}
+}
+
+func TestDryRun_LaunchDraftRefused(t *testing.T) {
+	server := previewServer(t, BatchPreviewResponse{})
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithDryRun())
+	_, err := client.Messages.LaunchDraft(context.Background(), "draft_1")
+	if !IsValidationError(err) || err.(*ValidationError).Code != "DRY_RUN_UNSUPPORTED" {
+		t.Errorf("expected a DRY_RUN_UNSUPPORTED error, got %v", err)
+	}
+}
+
+func TestDryRun_SendToSegmentRefused(t *testing.T) {
+	server := previewServer(t, BatchPreviewResponse{})
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithDryRun())
+	_, err := client.Messages.SendToSegment(context.Background(), "seg_1", "Sale today")
+	if !IsValidationError(err) || err.(*ValidationError).Code != "DRY_RUN_UNSUPPORTED" {
+		t.Errorf("expected a DRY_RUN_UNSUPPORTED error, got %v", err)
+	}
+}
+
+func TestDryRun_Schedule(t *testing.T) {
+	server := previewServer(t, BatchPreviewResponse{
+		CanSend:          true,
+		TotalMessages:    1,
+		WillSend:         1,
+		CreditsNeeded:    1,
+		CurrentBalance:   100,
+		HasEnoughCredits: true,
+		Messages:         []BatchPreviewItem{{To: "+15551234567", Segments: 1, Credits: 1, CanSend: true}},
+	})
+
+	at := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	for _, client := range []*Client{
+		NewClient("test-api-key", WithBaseURL(server.URL), WithDryRun()),
+		NewClient("test-api-key", WithBaseURL(server.URL)),
+	} {
+		scheduled, err := client.Messages.Schedule(context.Background(), &ScheduleMessageRequest{
+			To:     "+15551234567",
+			Text:   "Reminder",
+			SendAt: at,
+			DryRun: !client.dryRun,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !scheduled.DryRun || scheduled.ID != "" {
+			t.Errorf("expected a synthetic scheduled message, got %+v", scheduled)
+		}
+		if scheduled.CreditsReserved != 1 || scheduled.ScheduledAt != at.Format(time.RFC3339) {
+			t.Errorf("unexpected scheduled message %+v", scheduled)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package sendly
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// EmailStatus represents the status of a transactional email.
+type EmailStatus string
+
+const (
+	EmailStatusQueued    EmailStatus = "queued"
+	EmailStatusSent      EmailStatus = "sent"
+	EmailStatusDelivered EmailStatus = "delivered"
+	EmailStatusBounced   EmailStatus = "bounced"
+	EmailStatusFailed    EmailStatus = "failed"
+)
+
+// EmailRequest is the request to send a transactional email.
+type EmailRequest struct {
+	// To is the recipient email address (required).
+	To string `json:"to"`
+	// Subject is the subject line (required).
+	Subject string `json:"subject"`
+	// Text is the plain-text body. At least one of Text and HTML is required.
+	Text string `json:"text,omitempty"`
+	// HTML is the HTML body.
+	HTML string `json:"html,omitempty"`
+	// ReplyTo is the address replies go to (default: the account's
+	// notification email).
+	ReplyTo string `json:"replyTo,omitempty"`
+	// Metadata is custom metadata, checked against the client's
+	// MetadataLimits before sending.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Email is a transactional email.
+type Email struct {
+	// ID is the email ID (eml_xxx).
+	ID string `json:"id"`
+	// To is the recipient email address.
+	To string `json:"to"`
+	// Subject is the subject line.
+	Subject string `json:"subject"`
+	// Status is the delivery status.
+	Status EmailStatus `json:"status"`
+	// Error describes why the email bounced or failed.
+	Error string `json:"error,omitempty"`
+	// Metadata is the custom metadata from EmailRequest.Metadata.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// CreatedAt is when the email was accepted.
+	CreatedAt string `json:"createdAt"`
+}
+
+// EmailService sends transactional email, such as a one-time code fallback
+// for recipients who cannot receive SMS. Errors are the same types the
+// other services return.
+type EmailService struct {
+	client *Client
+}
+
+// Send sends a transactional email.
+func (s *EmailService) Send(ctx context.Context, req EmailRequest) (*Email, error) {
+	if !strings.Contains(req.To, "@") {
+		return nil, &ValidationError{APIError: APIError{Message: "a valid email address is required"}}
+	}
+	if req.Subject == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "subject is required"}}
+	}
+	if req.Text == "" && req.HTML == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "text or html body is required"}}
+	}
+	if req.ReplyTo != "" && !strings.Contains(req.ReplyTo, "@") {
+		return nil, &ValidationError{APIError: APIError{Message: "replyTo must be a valid email address"}}
+	}
+	if err := ValidateMetadata(req.Metadata, s.client.metadataLimits); err != nil {
+		return nil, err
+	}
+
+	var resp Email
+	if err := s.client.request(ctx, "POST", "/emails", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Get retrieves a transactional email by ID.
+func (s *EmailService) Get(ctx context.Context, id string) (*Email, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "email ID is required"}}
+	}
+
+	var resp Email
+	if err := s.client.request(ctx, "GET", "/emails/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,93 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEmailSend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/emails" {
+			t.Errorf("expected POST /emails, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var req EmailRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.To != "user@example.com" || req.Subject != "Your code" || req.Text != "Your code is 123456" {
+			t.Errorf("unexpected request %+v", req)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"eml_1","to":"user@example.com","subject":"Your code","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	email, err := client.Email.Send(context.Background(), EmailRequest{
+		To:      "user@example.com",
+		Subject: "Your code",
+		Text:    "Your code is 123456",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if email.ID != "eml_1" || email.Status != EmailStatusQueued {
+		t.Errorf("unexpected email %+v", email)
+	}
+}
+
+func TestEmailSend_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+
+	tests := []EmailRequest{
+		{To: "user", Subject: "Hi", Text: "Hello"},
+		{To: "user@example.com", Text: "Hello"},
+		{To: "user@example.com", Subject: "Hi"},
+		{To: "user@example.com", Subject: "Hi", Text: "Hello", ReplyTo: "support"},
+	}
+	for _, req := range tests {
+		if _, err := client.Email.Send(context.Background(), req); !IsValidationError(err) {
+			t.Errorf("expected ValidationError for %+v, got %T", req, err)
+		}
+	}
+}
+
+func TestEmailSend_SharesErrorTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		w.Write([]byte(`{"error":"insufficient_credits","message":"Not enough credits"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	_, err := client.Email.Send(context.Background(), EmailRequest{To: "user@example.com", Subject: "Hi", Text: "Hello"})
+	if !IsInsufficientCreditsError(err) {
+		t.Errorf("expected InsufficientCreditsError, got %T: %v", err, err)
+	}
+}
+
+func TestEmailGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/emails/eml_1" {
+			t.Errorf("expected path '/emails/eml_1', got '%s'", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"eml_1","status":"bounced","error":"mailbox does not exist"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	email, err := client.Email.Get(context.Background(), "eml_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if email.Status != EmailStatusBounced || email.Error == "" {
+		t.Errorf("unexpected email %+v", email)
+	}
+}
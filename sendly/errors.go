@@ -1,6 +1,32 @@
 package sendly
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Sentinel errors for use with errors.Is. Each typed error matches its
+// sentinel, so callers can test the kind of failure without a type assertion,
+// even after the error has been wrapped with fmt.Errorf("%w", err).
+var (
+	ErrUnauthorized        = errors.New("sendly: unauthorized")
+	ErrRateLimited         = errors.New("sendly: rate limited")
+	ErrInsufficientCredits = errors.New("sendly: insufficient credits")
+	ErrValidation          = errors.New("sendly: validation failed")
+	ErrNotFound            = errors.New("sendly: not found")
+	ErrCircuitOpen         = errors.New("sendly: circuit breaker open")
+	ErrPartialAcceptance   = errors.New("sendly: transaction partially accepted")
+	ErrNetwork             = errors.New("sendly: network error")
+	ErrCanaryAborted       = errors.New("sendly: canary aborted")
+	ErrResponseTooLarge    = errors.New("sendly: response body too large")
+	ErrDecode              = errors.New("sendly: malformed response")
+	ErrOutsideSendWindow   = errors.New("sendly: outside send window")
+	ErrDestinationBlocked  = errors.New("sendly: destination blocked")
+	ErrBudgetExceeded      = errors.New("sendly: credit budget exceeded")
+	ErrDuplicateMessage    = errors.New("sendly: duplicate message")
+	ErrInvalidScheduleTime = errors.New("sendly: invalid schedule time")
+)
 
 // SendlyError is the base error type for Sendly API errors.
 type SendlyError struct {
@@ -21,11 +47,18 @@ func (e *AuthenticationError) Error() string {
 	return fmt.Sprintf("sendly: authentication failed: %s", e.Message)
 }
 
+// Is reports whether target is ErrUnauthorized.
+func (e *AuthenticationError) Is(target error) bool {
+	return target == ErrUnauthorized
+}
+
 // RateLimitError indicates the rate limit has been exceeded.
 type RateLimitError struct {
 	APIError
 	// RetryAfter is the number of seconds to wait before retrying.
 	RetryAfter int
+	// State is the rate limit reported with the error, if any.
+	State *RateLimitState
 }
 
 func (e *RateLimitError) Error() string {
@@ -35,6 +68,11 @@ func (e *RateLimitError) Error() string {
 	return fmt.Sprintf("sendly: rate limit exceeded: %s", e.Message)
 }
 
+// Is reports whether target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
 // InsufficientCreditsError indicates the account has insufficient credits.
 type InsufficientCreditsError struct {
 	APIError
@@ -44,6 +82,11 @@ func (e *InsufficientCreditsError) Error() string {
 	return fmt.Sprintf("sendly: insufficient credits: %s", e.Message)
 }
 
+// Is reports whether target is ErrInsufficientCredits.
+func (e *InsufficientCreditsError) Is(target error) bool {
+	return target == ErrInsufficientCredits
+}
+
 // ValidationError indicates invalid request parameters.
 type ValidationError struct {
 	APIError
@@ -57,6 +100,11 @@ func (e *ValidationError) Error() string {
 	return fmt.Sprintf("sendly: validation error: %s", e.Message)
 }
 
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
 func (e *ValidationError) Unwrap() error {
 	return e.Err
 }
@@ -70,6 +118,188 @@ func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("sendly: not found: %s", e.Message)
 }
 
+// Is reports whether target is ErrNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// CircuitOpenError is returned without contacting the API while the client's
+// circuit breaker is open.
+type CircuitOpenError struct {
+	// RetryAt is when the breaker will next allow a trial request.
+	RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("sendly: circuit breaker open, retry after %s", e.RetryAt.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// PartialAcceptanceError indicates that a transactional send could not be
+// applied atomically and only some of its messages were accepted.
+type PartialAcceptanceError struct {
+	APIError
+	// Response is the transaction response, listing accepted and rejected messages.
+	Response *TransactionResponse
+}
+
+func (e *PartialAcceptanceError) Error() string {
+	return fmt.Sprintf("sendly: transaction partially accepted: %d accepted, %d rejected",
+		len(e.Response.Messages), len(e.Response.Rejected))
+}
+
+// Is reports whether target is ErrPartialAcceptance.
+func (e *PartialAcceptanceError) Is(target error) bool {
+	return target == ErrPartialAcceptance
+}
+
+// CanaryAbortedError is returned by SendBatchWithCanary when the canary sample
+// did not reach the required delivery rate and the remainder was not sent.
+type CanaryAbortedError struct {
+	// BatchID is the ID of the sample batch.
+	BatchID string
+	// DeliveryRate is the share of the sample that was delivered.
+	DeliveryRate float64
+	// MinDeliveryRate is the share that was required.
+	MinDeliveryRate float64
+}
+
+func (e *CanaryAbortedError) Error() string {
+	return fmt.Sprintf("sendly: canary aborted: %.1f%% of sample delivered, %.1f%% required",
+		e.DeliveryRate*100, e.MinDeliveryRate*100)
+}
+
+// Is reports whether target is ErrCanaryAborted.
+func (e *CanaryAbortedError) Is(target error) bool {
+	return target == ErrCanaryAborted
+}
+
+// OutsideSendWindowError is returned without contacting the API when a send
+// falls outside a SendWindow set to Reject.
+type OutsideSendWindowError struct {
+	// NextAllowed is when the window next opens.
+	NextAllowed time.Time
+}
+
+func (e *OutsideSendWindowError) Error() string {
+	return fmt.Sprintf("sendly: outside send window, next allowed at %s", e.NextAllowed.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrOutsideSendWindow.
+func (e *OutsideSendWindowError) Is(target error) bool {
+	return target == ErrOutsideSendWindow
+}
+
+// DestinationBlockedError is returned without contacting the API when a
+// recipient is blocked by the client's DestinationPolicy.
+type DestinationBlockedError struct {
+	// To is the blocked recipient.
+	To string
+	// Country is the recipient's country code, or empty if it was not
+	// recognised.
+	Country string
+}
+
+func (e *DestinationBlockedError) Error() string {
+	if e.Country == "" {
+		return fmt.Sprintf("sendly: destination blocked: %s is in an unrecognised country", e.To)
+	}
+	return fmt.Sprintf("sendly: destination blocked: %s is in %s", e.To, e.Country)
+}
+
+// Is reports whether target is ErrDestinationBlocked.
+func (e *DestinationBlockedError) Is(target error) bool {
+	return target == ErrDestinationBlocked
+}
+
+// BudgetExceededError is returned without contacting the API once the
+// client's WithCreditBudget has been spent.
+type BudgetExceededError struct {
+	// Budget is the maximum number of credits the client may spend.
+	Budget int
+	// Spent is the number of credits spent so far.
+	Spent int
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("sendly: credit budget exceeded: %d of %d credits spent", e.Spent, e.Budget)
+}
+
+// Is reports whether target is ErrBudgetExceeded.
+func (e *BudgetExceededError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+// DuplicateMessageError is returned without contacting the API when a send
+// repeats one made within the client's WithDedupeWindow.
+type DuplicateMessageError struct {
+	// To is the recipient.
+	To string
+	// OriginalID is the ID of the earlier message, or empty if it is still
+	// being sent.
+	OriginalID string
+	// SentAt is when the earlier message was sent.
+	SentAt time.Time
+}
+
+func (e *DuplicateMessageError) Error() string {
+	if e.OriginalID == "" {
+		return fmt.Sprintf("sendly: duplicate message to %s, identical send in progress", e.To)
+	}
+	return fmt.Sprintf("sendly: duplicate message to %s, already sent as %s at %s",
+		e.To, e.OriginalID, e.SentAt.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrDuplicateMessage.
+func (e *DuplicateMessageError) Is(target error) bool {
+	return target == ErrDuplicateMessage
+}
+
+// ScheduleTimeError is returned without contacting the API when a message is
+// scheduled for a time in the past or further ahead than MaxScheduleHorizon.
+type ScheduleTimeError struct {
+	// ScheduledAt is the requested send time.
+	ScheduledAt time.Time
+	// InPast is true if ScheduledAt had already passed, and false if it was
+	// beyond MaxScheduleHorizon.
+	InPast bool
+}
+
+func (e *ScheduleTimeError) Error() string {
+	if e.InPast {
+		return fmt.Sprintf("sendly: scheduled time %s is in the past", e.ScheduledAt.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("sendly: scheduled time %s is more than %s ahead", e.ScheduledAt.Format(time.RFC3339), MaxScheduleHorizon)
+}
+
+// Is reports whether target is ErrInvalidScheduleTime.
+func (e *ScheduleTimeError) Is(target error) bool {
+	return target == ErrInvalidScheduleTime
+}
+
+// TokenError is returned when a client configured with WithTokenSource
+// cannot get an access token. It matches ErrUnauthorized.
+type TokenError struct {
+	Err error
+}
+
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("sendly: failed to get access token: %v", e.Err)
+}
+
+// Is reports whether target is ErrUnauthorized.
+func (e *TokenError) Is(target error) bool {
+	return target == ErrUnauthorized
+}
+
+func (e *TokenError) Unwrap() error {
+	return e.Err
+}
+
 // NetworkError indicates a network-level error.
 type NetworkError struct {
 	Message string
@@ -83,42 +313,144 @@ func (e *NetworkError) Error() string {
 	return fmt.Sprintf("sendly: network error: %s", e.Message)
 }
 
+// Is reports whether target is ErrNetwork.
+func (e *NetworkError) Is(target error) bool {
+	return target == ErrNetwork
+}
+
 func (e *NetworkError) Unwrap() error {
 	return e.Err
 }
 
-// IsAuthenticationError checks if the error is an authentication error.
+// DecodeError indicates a successful response whose body could not be
+// decoded, typically because a proxy or load balancer answered with an HTML
+// or plain-text page instead of JSON.
+type DecodeError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// ContentType is the response's Content-Type header.
+	ContentType string
+	// Snippet is the start of the response body, truncated to a few hundred bytes.
+	Snippet string
+	// Err is the underlying decoding error.
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	contentType := e.ContentType
+	if contentType == "" {
+		contentType = "none"
+	}
+	return fmt.Sprintf("sendly: failed to decode response (status: %d, content type: %s): %v: %q",
+		e.StatusCode, contentType, e.Err, e.Snippet)
+}
+
+// Is reports whether target is ErrDecode.
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// IsAuthenticationError checks if the error is, or wraps, an authentication error.
 func IsAuthenticationError(err error) bool {
-	_, ok := err.(*AuthenticationError)
-	return ok
+	var target *AuthenticationError
+	return errors.As(err, &target)
 }
 
-// IsRateLimitError checks if the error is a rate limit error.
+// IsRateLimitError checks if the error is, or wraps, a rate limit error.
 func IsRateLimitError(err error) bool {
-	_, ok := err.(*RateLimitError)
-	return ok
+	var target *RateLimitError
+	return errors.As(err, &target)
 }
 
-// IsInsufficientCreditsError checks if the error is an insufficient credits error.
+// IsInsufficientCreditsError checks if the error is, or wraps, an insufficient credits error.
 func IsInsufficientCreditsError(err error) bool {
-	_, ok := err.(*InsufficientCreditsError)
-	return ok
+	var target *InsufficientCreditsError
+	return errors.As(err, &target)
 }
 
-// IsValidationError checks if the error is a validation error.
+// IsValidationError checks if the error is, or wraps, a validation error.
 func IsValidationError(err error) bool {
-	_, ok := err.(*ValidationError)
-	return ok
+	var target *ValidationError
+	return errors.As(err, &target)
 }
 
-// IsNotFoundError checks if the error is a not found error.
+// IsNotFoundError checks if the error is, or wraps, a not found error.
 func IsNotFoundError(err error) bool {
-	_, ok := err.(*NotFoundError)
-	return ok
+	var target *NotFoundError
+	return errors.As(err, &target)
 }
 
-// IsNetworkError checks if the error is a network error.
+// IsPartialAcceptanceError checks if the error is, or wraps, a partial acceptance error.
+func IsPartialAcceptanceError(err error) bool {
+	var target *PartialAcceptanceError
+	return errors.As(err, &target)
+}
+
+// IsNetworkError checks if the error is, or wraps, a network error.
 func IsNetworkError(err error) bool {
-	_, ok := err.(*NetworkError)
-	return ok
+	var target *NetworkError
+	return errors.As(err, &target)
+}
+
+// IsCircuitOpenError checks if the error is, or wraps, a circuit open error.
+func IsCircuitOpenError(err error) bool {
+	var target *CircuitOpenError
+	return errors.As(err, &target)
+}
+
+// IsCanaryAbortedError checks if the error is, or wraps, a canary aborted error.
+func IsCanaryAbortedError(err error) bool {
+	var target *CanaryAbortedError
+	return errors.As(err, &target)
+}
+
+// IsOutsideSendWindowError checks if the error is, or wraps, an outside send
+// window error.
+func IsOutsideSendWindowError(err error) bool {
+	var target *OutsideSendWindowError
+	return errors.As(err, &target)
+}
+
+// IsDestinationBlockedError checks if the error is, or wraps, a destination
+// blocked error.
+func IsDestinationBlockedError(err error) bool {
+	var target *DestinationBlockedError
+	return errors.As(err, &target)
+}
+
+// IsBudgetExceededError checks if the error is, or wraps, a budget exceeded
+// error.
+func IsBudgetExceededError(err error) bool {
+	var target *BudgetExceededError
+	return errors.As(err, &target)
+}
+
+// IsDuplicateMessageError checks if the error is, or wraps, a duplicate
+// message error.
+func IsDuplicateMessageError(err error) bool {
+	var target *DuplicateMessageError
+	return errors.As(err, &target)
+}
+
+// IsScheduleTimeError checks if the error is, or wraps, a schedule time
+// error.
+func IsScheduleTimeError(err error) bool {
+	var target *ScheduleTimeError
+	return errors.As(err, &target)
+}
+
+// IsTokenError checks if the error is, or wraps, a token source failure.
+func IsTokenError(err error) bool {
+	var target *TokenError
+	return errors.As(err, &target)
+}
+
+// IsDecodeError checks if the error is, or wraps, a decode error.
+func IsDecodeError(err error) bool {
+	var target *DecodeError
+	return errors.As(err, &target)
 }
@@ -1,7 +1,12 @@
 package sendly
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -521,3 +526,128 @@ func TestErrorTypesWithDetails(t *testing.T) {
 		t.Errorf("expected reason to be 'invalid format', got '%v'", err.Details["reason"])
 	}
 }
+
+func TestErrorHelpers_WrappedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error
+		is       func(error) bool
+	}{
+		{"authentication", &AuthenticationError{}, ErrUnauthorized, IsAuthenticationError},
+		{"rate limit", &RateLimitError{RetryAfter: 5}, ErrRateLimited, IsRateLimitError},
+		{"insufficient credits", &InsufficientCreditsError{}, ErrInsufficientCredits, IsInsufficientCreditsError},
+		{"validation", &ValidationError{}, ErrValidation, IsValidationError},
+		{"not found", &NotFoundError{}, ErrNotFound, IsNotFoundError},
+		{"partial acceptance", &PartialAcceptanceError{Response: &TransactionResponse{}}, ErrPartialAcceptance, IsPartialAcceptanceError},
+		{"network", &NetworkError{Message: "timeout"}, ErrNetwork, IsNetworkError},
+		{"circuit open", &CircuitOpenError{}, ErrCircuitOpen, IsCircuitOpenError},
+		{"decode", &DecodeError{Err: errors.New("unexpected EOF")}, ErrDecode, IsDecodeError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("sending reminder: %w", tt.err)
+
+			if !tt.is(wrapped) {
+				t.Error("expected helper to match wrapped error")
+			}
+			if !errors.Is(wrapped, tt.sentinel) {
+				t.Errorf("expected errors.Is to match %v", tt.sentinel)
+			}
+			if errors.Is(wrapped, ErrUnauthorized) != (tt.sentinel == ErrUnauthorized) {
+				t.Error("expected error to match only its own sentinel")
+			}
+		})
+	}
+}
+
+func TestErrorsAs_WrappedRateLimitError(t *testing.T) {
+	err := fmt.Errorf("outer: %w", &RateLimitError{RetryAfter: 30})
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatal("expected errors.As to find RateLimitError")
+	}
+	if rateLimitErr.RetryAfter != 30 {
+		t.Errorf("expected RetryAfter to be 30, got %d", rateLimitErr.RetryAfter)
+	}
+}
+
+func TestNetworkError_IsCause(t *testing.T) {
+	err := fmt.Errorf("outer: %w", &NetworkError{Message: "request failed", Err: context.DeadlineExceeded})
+
+	if !errors.Is(err, ErrNetwork) {
+		t.Error("expected error to match ErrNetwork")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expected error to still match its cause")
+	}
+}
+
+func TestErrorResponseDetails(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        string
+		body          string
+		wantRequestID string
+	}{
+		{"header", "req_header", `{"code":"NOT_FOUND","message":"message not found"}`, "req_header"},
+		{"body fallback", "", `{"code":"NOT_FOUND","message":"message not found","requestId":"req_body"}`, "req_body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("X-Request-Id", tt.header)
+				}
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient("test-api-key", WithBaseURL(server.URL))
+			_, err := client.Messages.Get(context.Background(), "msg_missing")
+
+			var notFound *NotFoundError
+			if !errors.As(err, &notFound) {
+				t.Fatalf("expected NotFoundError, got %T", err)
+			}
+			if notFound.RequestID != tt.wantRequestID {
+				t.Errorf("expected RequestID to be '%s', got '%s'", tt.wantRequestID, notFound.RequestID)
+			}
+			if notFound.StatusCode != http.StatusNotFound {
+				t.Errorf("expected StatusCode to be 404, got %d", notFound.StatusCode)
+			}
+			if string(notFound.RawBody) != tt.body {
+				t.Errorf("expected RawBody to be '%s', got '%s'", tt.body, notFound.RawBody)
+			}
+
+			resp := notFound.Response()
+			if resp == nil {
+				t.Fatal("expected Response to be set")
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tt.body {
+				t.Errorf("expected response body to be '%s', got '%s'", tt.body, body)
+			}
+		})
+	}
+}
+
+func TestErrorResponse_ClientSideError(t *testing.T) {
+	client := NewClient("test-api-key")
+	_, err := client.Messages.Send(context.Background(), &SendMessageRequest{})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+	if validationErr.Response() != nil {
+		t.Error("expected no response for a client-side error")
+	}
+	if validationErr.StatusCode != 0 {
+		t.Errorf("expected StatusCode to be 0, got %d", validationErr.StatusCode)
+	}
+}
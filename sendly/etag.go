@@ -0,0 +1,81 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// maxETagEntries bounds how many responses conditional requests keep. When
+// it is reached, the least recently stored entry is dropped.
+const maxETagEntries = 256
+
+// WithConditionalRequests keeps the body and ETag of GET responses that carry
+// one, and sends If-None-Match when the same resource is requested again. If
+// the API answers 304 Not Modified, the kept body is decoded instead, saving
+// the transfer of large, mostly unchanged list pages when polling.
+func WithConditionalRequests() ClientOption {
+	return func(c *Client) {
+		c.etags = &etagStore{}
+	}
+}
+
+// etagStore holds the latest entity for each GET request that returned an
+// ETag, keyed by sub-account and path.
+type etagStore struct {
+	mu      sync.Mutex
+	seq     uint64
+	entries map[string]etagEntry
+}
+
+type etagEntry struct {
+	etag string
+	body []byte
+	seq  uint64
+}
+
+// etagKey returns the store key for a request, or false if conditional
+// requests do not apply to it.
+func (c *Client) etagKey(ctx context.Context, method, path string) (string, bool) {
+	if c.etags == nil || method != http.MethodGet {
+		return "", false
+	}
+	subAccount := c.subAccount
+	if call := callOptionsFrom(ctx); call.subAccount != "" {
+		subAccount = call.subAccount
+	}
+	return subAccount + " " + path, true
+}
+
+func (s *etagStore) get(key string) (etagEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	return entry, ok
+}
+
+func (s *etagStore) put(key, etag string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.entries == nil {
+		s.entries = make(map[string]etagEntry)
+	}
+	if _, ok := s.entries[key]; !ok && len(s.entries) >= maxETagEntries {
+		s.evictOldest()
+	}
+	s.seq++
+	s.entries[key] = etagEntry{etag: etag, body: body, seq: s.seq}
+}
+
+// evictOldest drops the least recently stored entry. Callers must hold s.mu.
+func (s *etagStore) evictOldest() {
+	var oldestKey string
+	var oldestSeq uint64
+	for key, entry := range s.entries {
+		if oldestKey == "" || entry.seq < oldestSeq {
+			oldestKey, oldestSeq = key, entry.seq
+		}
+	}
+	delete(s.entries, oldestKey)
+}
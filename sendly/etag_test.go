@@ -0,0 +1,97 @@
+package sendly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// etagServer serves a message list with a fixed ETag, answering 304 when the
+// request carries it.
+func etagServer(t *testing.T) (*httptest.Server, func() []*http.Request) {
+	return newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"msg_1","to":"+15551234567","status":"delivered"}],"count":1}`))
+	})
+}
+
+func TestWithConditionalRequests(t *testing.T) {
+	server, reqs := etagServer(t)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithConditionalRequests())
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Messages.List(ctx, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Data) != 1 || resp.Data[0].ID != "msg_1" {
+			t.Errorf("request %d: expected message 'msg_1', got %+v", i+1, resp.Data)
+		}
+	}
+
+	got := reqs()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(got))
+	}
+	if condition := got[0].Header.Get("If-None-Match"); condition != "" {
+		t.Errorf("expected no If-None-Match on the first request, got '%s'", condition)
+	}
+	if condition := got[1].Header.Get("If-None-Match"); condition != `"v1"` {
+		t.Errorf("expected If-None-Match '\"v1\"' on the second request, got '%s'", condition)
+	}
+}
+
+func TestWithConditionalRequests_SeparatesPaths(t *testing.T) {
+	server, reqs := etagServer(t)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithConditionalRequests())
+	ctx := context.Background()
+
+	client.Messages.List(ctx, &ListMessagesRequest{Limit: 10})
+	client.Messages.List(ctx, &ListMessagesRequest{Limit: 20})
+
+	for i, r := range reqs() {
+		if condition := r.Header.Get("If-None-Match"); condition != "" {
+			t.Errorf("expected no If-None-Match on request %d, got '%s'", i+1, condition)
+		}
+	}
+}
+
+func TestClient_NoConditionalRequestsByDefault(t *testing.T) {
+	server, reqs := etagServer(t)
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	client.Messages.List(ctx, nil)
+	client.Messages.List(ctx, nil)
+
+	for i, r := range reqs() {
+		if condition := r.Header.Get("If-None-Match"); condition != "" {
+			t.Errorf("expected no If-None-Match on request %d, got '%s'", i+1, condition)
+		}
+	}
+}
+
+func TestETagStore_EvictsOldest(t *testing.T) {
+	store := &etagStore{}
+	for i := 0; i < maxETagEntries+1; i++ {
+		store.put(fmt.Sprintf("/messages?offset=%d", i), "etag", nil)
+	}
+
+	if len(store.entries) != maxETagEntries {
+		t.Errorf("expected %d entries, got %d", maxETagEntries, len(store.entries))
+	}
+	if _, ok := store.get("/messages?offset=0"); ok {
+		t.Error("expected the oldest entry to be evicted")
+	}
+	if _, ok := store.get(fmt.Sprintf("/messages?offset=%d", maxETagEntries)); !ok {
+		t.Error("expected the newest entry to be kept")
+	}
+}
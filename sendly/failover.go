@@ -0,0 +1,204 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultFailoverTimeout is how long an attempt has to be delivered when
+	// FailoverPolicy.Timeout is zero.
+	defaultFailoverTimeout = time.Minute
+	// defaultFailoverPollInterval is how often an attempt's status is checked
+	// when FailoverPolicy.PollInterval is zero.
+	defaultFailoverPollInterval = 5 * time.Second
+)
+
+// FailoverPolicy configures SendWithFailover.
+type FailoverPolicy struct {
+	// Senders are the sender IDs or numbers to try, in order (required). An
+	// empty string uses the account's default sender.
+	Senders []string
+	// RetryOn are the statuses that move on to the next sender (default:
+	// MessageStatusFailed and MessageStatusExpired).
+	RetryOn []MessageStatus
+	// Timeout is how long each attempt has to reach MessageStatusDelivered
+	// before the next sender is tried (default: 1 minute). The slow attempt
+	// is not cancelled, so the recipient may receive both.
+	Timeout time.Duration
+	// PollInterval is how often an attempt's status is checked (default: 5
+	// seconds).
+	PollInterval time.Duration
+}
+
+// FailoverAttempt is one send in a failover chain.
+type FailoverAttempt struct {
+	// Sender is the sender the attempt was made from.
+	Sender string
+	// Message is the last observed state of the attempt's message, or nil if
+	// the send failed.
+	Message *Message
+	// Err is why the send failed, if it did.
+	Err error
+	// TimedOut is true if the message was not delivered within the timeout.
+	TimedOut bool
+}
+
+// FailoverResult is the outcome of SendWithFailover.
+type FailoverResult struct {
+	// Attempts are the sends made, in order.
+	Attempts []FailoverAttempt
+	// Delivered reports whether the last attempt was delivered.
+	Delivered bool
+}
+
+// Message returns the last attempt's message, or nil if it was not sent.
+func (r *FailoverResult) Message() *Message {
+	if len(r.Attempts) == 0 {
+		return nil
+	}
+	return r.Attempts[len(r.Attempts)-1].Message
+}
+
+// SendWithFailover sends req from each of policy.Senders in turn until one is
+// delivered. An attempt moves on to the next sender if the send fails with a
+// network, rate limit or server error, if the message reaches one of
+// policy.RetryOn, or if it is not delivered within policy.Timeout.
+//
+// The result records every attempt. Delivered is false if every sender was
+// tried without success; the error is only set if the chain stopped early,
+// because ctx is done or the request itself was refused, such as with a
+// *ValidationError or *InsufficientCreditsError.
+//
+// Messages a SendWindow defers, that are accepted locally in degraded mode,
+// or that are dry runs cannot be tracked and are returned after the first
+// attempt, with Delivered false.
+func SendWithFailover(ctx context.Context, messages MessagesAPI, req *SendMessageRequest, policy FailoverPolicy) (*FailoverResult, error) {
+	if req == nil {
+		return nil, &ValidationError{APIError: APIError{Message: "request is required"}}
+	}
+	if len(policy.Senders) == 0 {
+		return nil, &ValidationError{APIError: APIError{Message: "at least one failover sender is required"}}
+	}
+	policy = policy.withDefaults()
+
+	result := &FailoverResult{}
+	key := callOptionsFrom(ctx).idempotencyKey
+	for i, sender := range policy.Senders {
+		attemptCtx := ctx
+		if i > 0 {
+			// A resend has the same recipient and text, so it must get past
+			// WithDedupeWindow and must not reuse the first idempotency key.
+			opts := []CallOption{WithAllowDuplicate()}
+			if key != "" {
+				opts = append(opts, WithIdempotencyKey(key+"-failover-"+strconv.Itoa(i)))
+			}
+			attemptCtx = WithCallOptions(ctx, opts...)
+		}
+
+		attemptReq := *req
+		attemptReq.From = sender
+		attempt := FailoverAttempt{Sender: sender}
+		attempt.Message, attempt.Err = messages.Send(attemptCtx, &attemptReq)
+		if attempt.Err != nil {
+			result.Attempts = append(result.Attempts, attempt)
+			if ctx.Err() != nil || !failoverOnSendError(attempt.Err) {
+				return result, attempt.Err
+			}
+			continue
+		}
+
+		if attempt.Message.DryRun {
+			result.Attempts = append(result.Attempts, attempt)
+			return result, nil
+		}
+		switch attempt.Message.Status {
+		case MessageStatusScheduled, MessageStatusAcceptedLocally:
+			result.Attempts = append(result.Attempts, attempt)
+			return result, nil
+		}
+
+		err := awaitFailoverAttempt(ctx, messages, &attempt, policy)
+		result.Attempts = append(result.Attempts, attempt)
+		if err != nil {
+			return result, err
+		}
+		if attempt.Message.Status == MessageStatusDelivered {
+			result.Delivered = true
+			return result, nil
+		}
+	}
+	return result, nil
+}
+
+// withDefaults fills in zero fields with their defaults.
+func (p FailoverPolicy) withDefaults() FailoverPolicy {
+	if p.Timeout <= 0 {
+		p.Timeout = defaultFailoverTimeout
+	}
+	if p.PollInterval <= 0 {
+		p.PollInterval = defaultFailoverPollInterval
+	}
+	if len(p.RetryOn) == 0 {
+		p.RetryOn = []MessageStatus{MessageStatusFailed, MessageStatusExpired}
+	}
+	return p
+}
+
+// awaitFailoverAttempt polls attempt's message until it is delivered,
+// reaches one of policy.RetryOn, or policy.Timeout passes. Lookup errors are
+// retried until the timeout; only ctx ending is returned.
+func awaitFailoverAttempt(ctx context.Context, messages MessagesAPI, attempt *FailoverAttempt, policy FailoverPolicy) error {
+	deadline := time.Now().Add(policy.Timeout)
+	for {
+		if attempt.Message.Status == MessageStatusDelivered || containsStatus(policy.RetryOn, attempt.Message.Status) {
+			return nil
+		}
+
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			attempt.TimedOut = true
+			return nil
+		}
+		if wait > policy.PollInterval {
+			wait = policy.PollInterval
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		if msg, err := messages.Get(ctx, attempt.Message.ID); err == nil {
+			attempt.Message = msg
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// failoverOnSendError reports whether a failed send should be retried from
+// the next sender. Errors caused by the request or the account would recur.
+func failoverOnSendError(err error) bool {
+	for _, target := range []error{
+		ErrValidation, ErrUnauthorized, ErrInsufficientCredits, ErrBudgetExceeded,
+		ErrDestinationBlocked, ErrOutsideSendWindow, ErrDuplicateMessage,
+	} {
+		if errors.Is(err, target) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsStatus reports whether statuses includes status.
+func containsStatus(statuses []MessageStatus, status MessageStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
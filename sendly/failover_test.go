@@ -0,0 +1,201 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// failoverStub sends messages and reports each sender's final status from
+// outcomes, or leaves it queued if the sender is not listed.
+type failoverStub struct {
+	MessagesAPI
+	outcomes map[string]MessageStatus
+	sendErrs map[string]error
+	sent     []SendMessageRequest
+	ctxs     []context.Context
+}
+
+func (s *failoverStub) Send(ctx context.Context, req *SendMessageRequest) (*Message, error) {
+	s.sent = append(s.sent, *req)
+	s.ctxs = append(s.ctxs, ctx)
+	if err := s.sendErrs[req.From]; err != nil {
+		return nil, err
+	}
+	return &Message{ID: strconv.Itoa(len(s.sent) - 1), To: req.To, From: req.From, Status: MessageStatusQueued}, nil
+}
+
+func (s *failoverStub) Get(ctx context.Context, id string) (*Message, error) {
+	i, _ := strconv.Atoi(id)
+	req := s.sent[i]
+	status, ok := s.outcomes[req.From]
+	if !ok {
+		status = MessageStatusQueued
+	}
+	return &Message{ID: id, To: req.To, From: req.From, Status: status}, nil
+}
+
+func TestSendWithFailover_FirstSenderDelivers(t *testing.T) {
+	stub := &failoverStub{outcomes: map[string]MessageStatus{"ACME": MessageStatusDelivered}}
+
+	result, err := SendWithFailover(context.Background(), stub, &SendMessageRequest{To: "+15551234567", Text: "Code 123456"}, FailoverPolicy{
+		Senders:      []string{"ACME", "+18005550100"},
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Delivered || len(result.Attempts) != 1 {
+		t.Errorf("expected delivery on the first attempt, got %+v", result)
+	}
+	if result.Message().From != "ACME" {
+		t.Errorf("expected the message from 'ACME', got '%s'", result.Message().From)
+	}
+}
+
+func TestSendWithFailover_FailsOverOnFailedStatus(t *testing.T) {
+	stub := &failoverStub{outcomes: map[string]MessageStatus{
+		"ACME":         MessageStatusFailed,
+		"+18005550100": MessageStatusDelivered,
+	}}
+
+	result, err := SendWithFailover(context.Background(), stub, &SendMessageRequest{To: "+15551234567", Text: "Code 123456"}, FailoverPolicy{
+		Senders:      []string{"ACME", "+18005550100"},
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Delivered || len(result.Attempts) != 2 {
+		t.Fatalf("expected delivery on the second attempt, got %+v", result)
+	}
+	if result.Attempts[0].Message.Status != MessageStatusFailed {
+		t.Errorf("expected the first attempt to have failed, got %+v", result.Attempts[0])
+	}
+	if !callOptionsFrom(stub.ctxs[1]).allowDuplicate {
+		t.Error("expected the resend to bypass the dedupe window")
+	}
+}
+
+func TestSendWithFailover_FailsOverOnTimeout(t *testing.T) {
+	stub := &failoverStub{outcomes: map[string]MessageStatus{"+18005550100": MessageStatusDelivered}}
+
+	result, err := SendWithFailover(context.Background(), stub, &SendMessageRequest{To: "+15551234567", Text: "Code 123456"}, FailoverPolicy{
+		Senders:      []string{"ACME", "+18005550100"},
+		Timeout:      10 * time.Millisecond,
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Delivered || len(result.Attempts) != 2 || !result.Attempts[0].TimedOut {
+		t.Errorf("expected the first attempt to time out, got %+v", result)
+	}
+}
+
+func TestSendWithFailover_FailsOverOnNetworkError(t *testing.T) {
+	stub := &failoverStub{
+		outcomes: map[string]MessageStatus{"+18005550100": MessageStatusDelivered},
+		sendErrs: map[string]error{"ACME": &NetworkError{Err: errors.New("connection reset")}},
+	}
+
+	result, err := SendWithFailover(context.Background(), stub, &SendMessageRequest{To: "+15551234567", Text: "Code 123456"}, FailoverPolicy{
+		Senders:      []string{"ACME", "+18005550100"},
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Delivered || result.Attempts[0].Err == nil {
+		t.Errorf("expected the network error to be recorded and failed over, got %+v", result)
+	}
+}
+
+func TestSendWithFailover_StopsOnRequestError(t *testing.T) {
+	stub := &failoverStub{sendErrs: map[string]error{"ACME": &InsufficientCreditsError{}}}
+
+	result, err := SendWithFailover(context.Background(), stub, &SendMessageRequest{To: "+15551234567", Text: "Code 123456"}, FailoverPolicy{
+		Senders: []string{"ACME", "+18005550100"},
+	})
+	if !IsInsufficientCreditsError(err) {
+		t.Fatalf("expected InsufficientCreditsError, got %v", err)
+	}
+	if len(result.Attempts) != 1 || len(stub.sent) != 1 {
+		t.Errorf("expected no second attempt, got %+v", result)
+	}
+}
+
+func TestSendWithFailover_Exhausted(t *testing.T) {
+	stub := &failoverStub{outcomes: map[string]MessageStatus{
+		"ACME":         MessageStatusFailed,
+		"+18005550100": MessageStatusExpired,
+	}}
+
+	result, err := SendWithFailover(context.Background(), stub, &SendMessageRequest{To: "+15551234567", Text: "Code 123456"}, FailoverPolicy{
+		Senders:      []string{"ACME", "+18005550100"},
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Delivered || len(result.Attempts) != 2 {
+		t.Errorf("expected both attempts to fail, got %+v", result)
+	}
+}
+
+func TestSendWithFailover_DerivesIdempotencyKeys(t *testing.T) {
+	stub := &failoverStub{outcomes: map[string]MessageStatus{
+		"ACME":         MessageStatusFailed,
+		"+18005550100": MessageStatusDelivered,
+	}}
+	ctx := WithCallOptions(context.Background(), WithIdempotencyKey("otp-42"))
+
+	if _, err := SendWithFailover(ctx, stub, &SendMessageRequest{To: "+15551234567", Text: "Code 123456"}, FailoverPolicy{
+		Senders:      []string{"ACME", "+18005550100"},
+		PollInterval: time.Millisecond,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := callOptionsFrom(stub.ctxs[0]).idempotencyKey; got != "otp-42" {
+		t.Errorf("expected the first attempt to use 'otp-42', got '%s'", got)
+	}
+	if got := callOptionsFrom(stub.ctxs[1]).idempotencyKey; got != "otp-42-failover-1" {
+		t.Errorf("expected the resend to use 'otp-42-failover-1', got '%s'", got)
+	}
+}
+
+func TestSendWithFailover_RequiresSenders(t *testing.T) {
+	if _, err := SendWithFailover(context.Background(), &failoverStub{}, &SendMessageRequest{To: "+15551234567", Text: "Hi"}, FailoverPolicy{}); !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
+
+func TestSendWithFailover_DryRunNotDelivered(t *testing.T) {
+	server := previewServer(t, BatchPreviewResponse{
+		CanSend:          true,
+		TotalMessages:    1,
+		CreditsNeeded:    1,
+		CurrentBalance:   100,
+		HasEnoughCredits: true,
+		Messages:         []BatchPreviewItem{{To: "+15551234567", Credits: 1, CanSend: true}},
+	})
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithDryRun())
+	result, err := SendWithFailover(context.Background(), client.Messages, &SendMessageRequest{To: "+15551234567", Text: "Code 123456"}, FailoverPolicy{
+		Senders: []string{"ACME", "+18005550100"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Delivered || len(result.Attempts) != 1 || !result.Message().DryRun {
+		t.Errorf("expected one undelivered dry-run attempt, got %+v", result)
+	}
+}
@@ -0,0 +1,303 @@
+package sendly
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultForecastWindow is how much recent usage a CreditForecaster averages over.
+const DefaultForecastWindow = 24 * time.Hour
+
+// minForecastSpan is how much usage history is needed before a burn rate is
+// reported, so a burst of sends right after startup is not extrapolated.
+const minForecastSpan = 10 * time.Minute
+
+// DepletionForecast describes when credits are projected to run out.
+type DepletionForecast struct {
+	// Balance is the estimated current credit balance.
+	Balance int
+	// BurnRate is the average credits used per hour over the forecast window.
+	BurnRate float64
+	// DepletionAt is when the balance is projected to reach zero.
+	DepletionAt time.Time
+}
+
+// CreditForecaster tracks credit usage over time and projects when the
+// balance will run out. Attach it to a client with WithCreditForecaster to
+// record usage from send responses and balances from GetCredits automatically.
+//
+// Usage comes either from sends or from transaction history, never both,
+// since the same credits appear in each. Once ObserveTransactions is called,
+// transaction history is the only source: usage recorded before is dropped,
+// and sends on attached clients are no longer recorded.
+//
+// A CreditForecaster is safe for concurrent use.
+type CreditForecaster struct {
+	mu           sync.Mutex
+	window       time.Duration
+	horizon      time.Duration
+	onDepletion  func(DepletionForecast)
+	alerted      bool
+	samples      []usageSample
+	balance      int
+	balanceKnown bool
+	balanceAt    time.Time
+	seen         map[string]time.Time
+	observing    bool
+	now          func() time.Time
+}
+
+// usageSample is credits used at a point in time.
+type usageSample struct {
+	at      time.Time
+	credits int
+}
+
+// ForecastOption configures a CreditForecaster.
+type ForecastOption func(*CreditForecaster)
+
+// WithForecastWindow sets how much recent usage is averaged to compute the burn rate.
+func WithForecastWindow(window time.Duration) ForecastOption {
+	return func(f *CreditForecaster) {
+		f.window = window
+	}
+}
+
+// WithDepletionAlert calls fn when projected depletion falls within horizon.
+// It fires once each time the projection enters the horizon, not on every update.
+func WithDepletionAlert(horizon time.Duration, fn func(DepletionForecast)) ForecastOption {
+	return func(f *CreditForecaster) {
+		f.horizon = horizon
+		f.onDepletion = fn
+	}
+}
+
+// NewCreditForecaster creates a forecaster. The balance is unknown until
+// SetBalance, ObserveTransactions, or a GetCredits call on an attached client.
+func NewCreditForecaster(opts ...ForecastOption) *CreditForecaster {
+	f := &CreditForecaster{
+		window: DefaultForecastWindow,
+		seen:   make(map[string]time.Time),
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// WithCreditForecaster records every send's credits and every GetCredits
+// balance into f.
+func WithCreditForecaster(f *CreditForecaster) ClientOption {
+	return func(c *Client) {
+		c.forecaster = f
+	}
+}
+
+// RecordUsage records credits spent now and deducts them from the known
+// balance. It is for usage the forecaster would not otherwise see; don't
+// combine it with ObserveTransactions for the same sends.
+func (f *CreditForecaster) RecordUsage(credits int) {
+	f.record(credits, false)
+}
+
+// recordSend records credits used by a send on an attached client, unless
+// transaction history has become the usage source.
+func (f *CreditForecaster) recordSend(credits int) {
+	f.record(credits, true)
+}
+
+// record adds credits spent now and deducts them from the known balance.
+func (f *CreditForecaster) record(credits int, fromSend bool) {
+	if credits <= 0 {
+		return
+	}
+	f.mu.Lock()
+	if fromSend && f.observing {
+		f.mu.Unlock()
+		return
+	}
+	f.addUsage(f.now(), credits)
+	if f.balanceKnown {
+		f.balance -= credits
+	}
+	alert := f.checkAlert()
+	f.mu.Unlock()
+
+	f.fire(alert)
+}
+
+// SetBalance sets the current available balance.
+func (f *CreditForecaster) SetBalance(balance int) {
+	f.mu.Lock()
+	f.balance = balance
+	f.balanceKnown = true
+	f.balanceAt = f.now()
+	alert := f.checkAlert()
+	f.mu.Unlock()
+
+	f.fire(alert)
+}
+
+// ObserveTransactions records usage from credit transaction history, as
+// returned by GetCreditTransactions, and takes the balance from the newest
+// transaction seen so far. Pages can be observed in any order: an older page
+// does not overwrite a newer balance. Transactions already observed are
+// ignored, and those older than the forecast window are not tracked. The
+// first call replaces any usage recorded from sends; see CreditForecaster.
+func (f *CreditForecaster) ObserveTransactions(txns []CreditTransaction) {
+	f.mu.Lock()
+	if !f.observing {
+		f.observing = true
+		f.samples = nil
+	}
+	cutoff := f.now().Add(-f.window)
+	for id, at := range f.seen {
+		if at.Before(cutoff) {
+			delete(f.seen, id)
+		}
+	}
+
+	for _, txn := range txns {
+		at, err := time.Parse(time.RFC3339, txn.CreatedAt)
+		if err != nil {
+			continue
+		}
+		if at.After(f.balanceAt) {
+			f.balance = txn.BalanceAfter
+			f.balanceKnown = true
+			f.balanceAt = at
+		}
+
+		if at.Before(cutoff) {
+			continue
+		}
+		if _, ok := f.seen[txn.ID]; ok {
+			continue
+		}
+		f.seen[txn.ID] = at
+		if txn.Type == TransactionTypeUsage && txn.Amount < 0 {
+			f.addUsage(at, -txn.Amount)
+		}
+	}
+	alert := f.checkAlert()
+	f.mu.Unlock()
+
+	f.fire(alert)
+}
+
+// BurnRate returns the average credits used per hour over the forecast window,
+// or 0 until at least ten minutes of usage has been recorded.
+func (f *CreditForecaster) BurnRate() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.burnRate(f.now())
+}
+
+// EstimatedDepletionAt returns when the balance is projected to reach zero.
+// It returns false if the balance is unknown or there is too little usage history.
+func (f *CreditForecaster) EstimatedDepletionAt() (time.Time, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	forecast, ok := f.forecast()
+	return forecast.DepletionAt, ok
+}
+
+// Forecast returns the current projection. It returns false if the balance
+// is unknown or there is too little usage history; Balance is still set.
+func (f *CreditForecaster) Forecast() (DepletionForecast, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.forecast()
+}
+
+// addUsage appends a sample, keeping samples sorted by time. Callers must hold f.mu.
+func (f *CreditForecaster) addUsage(at time.Time, credits int) {
+	i := len(f.samples)
+	for i > 0 && f.samples[i-1].at.After(at) {
+		i--
+	}
+	f.samples = append(f.samples, usageSample{})
+	copy(f.samples[i+1:], f.samples[i:])
+	f.samples[i] = usageSample{at: at, credits: credits}
+}
+
+// burnRate drops samples older than the window and averages the rest over
+// the time they cover. Callers must hold f.mu.
+func (f *CreditForecaster) burnRate(now time.Time) float64 {
+	cutoff := now.Add(-f.window)
+	drop := 0
+	for drop < len(f.samples) && f.samples[drop].at.Before(cutoff) {
+		drop++
+	}
+	f.samples = f.samples[drop:]
+	if len(f.samples) == 0 {
+		return 0
+	}
+
+	total := 0
+	for _, s := range f.samples {
+		total += s.credits
+	}
+
+	span := now.Sub(f.samples[0].at)
+	if span < minForecastSpan {
+		return 0
+	}
+	return float64(total) / span.Hours()
+}
+
+// forecast computes the projection. Callers must hold f.mu.
+func (f *CreditForecaster) forecast() (DepletionForecast, bool) {
+	now := f.now()
+	rate := f.burnRate(now)
+	if !f.balanceKnown || rate == 0 {
+		return DepletionForecast{Balance: f.balance, BurnRate: rate}, false
+	}
+
+	remaining := time.Duration(float64(f.balance) / rate * float64(time.Hour))
+	if remaining < 0 {
+		remaining = 0
+	}
+	return DepletionForecast{
+		Balance:     f.balance,
+		BurnRate:    rate,
+		DepletionAt: now.Add(remaining),
+	}, true
+}
+
+// checkAlert returns the forecast to report if depletion has just entered the
+// alert horizon. Callers must hold f.mu.
+func (f *CreditForecaster) checkAlert() *DepletionForecast {
+	if f.onDepletion == nil {
+		return nil
+	}
+	forecast, ok := f.forecast()
+	within := ok && forecast.DepletionAt.Sub(f.now()) <= f.horizon
+	if !within {
+		f.alerted = false
+		return nil
+	}
+	if f.alerted {
+		return nil
+	}
+	f.alerted = true
+	return &forecast
+}
+
+// fire invokes the depletion callback outside the lock.
+func (f *CreditForecaster) fire(forecast *DepletionForecast) {
+	if forecast != nil {
+		f.onDepletion(*forecast)
+	}
+}
+
+// recordCredits feeds credits used by a send into the credit budget and the
+// attached forecaster.
+func (c *Client) recordCredits(credits int) {
+	c.budget.record(credits)
+	if c.forecaster != nil {
+		c.forecaster.recordSend(credits)
+	}
+}
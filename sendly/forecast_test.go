@@ -0,0 +1,206 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreditForecaster_EstimatedDepletionAt(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+
+	f := NewCreditForecaster()
+	f.now = func() time.Time { return now }
+
+	if _, ok := f.EstimatedDepletionAt(); ok {
+		t.Error("expected no estimate before any data")
+	}
+
+	f.SetBalance(1000)
+	for i := 0; i < 10; i++ {
+		f.RecordUsage(10)
+		now = now.Add(6 * time.Minute)
+	}
+
+	// 100 credits over one hour leaves 900 credits, or nine more hours.
+	if rate := f.BurnRate(); rate != 100 {
+		t.Errorf("expected BurnRate to be 100, got %v", rate)
+	}
+	at, ok := f.EstimatedDepletionAt()
+	if !ok {
+		t.Fatal("expected an estimate")
+	}
+	if want := now.Add(9 * time.Hour); !at.Equal(want) {
+		t.Errorf("expected depletion at %v, got %v", want, at)
+	}
+}
+
+func TestCreditForecaster_DepletionAlert(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var alerts []DepletionForecast
+
+	f := NewCreditForecaster(WithDepletionAlert(2*time.Hour, func(forecast DepletionForecast) {
+		alerts = append(alerts, forecast)
+	}))
+	f.now = func() time.Time { return now }
+
+	f.SetBalance(1000)
+	for i := 0; i < 10; i++ {
+		f.RecordUsage(10)
+		now = now.Add(6 * time.Minute)
+	}
+	if len(alerts) != 0 {
+		t.Fatalf("expected no alerts with nine hours left, got %d", len(alerts))
+	}
+
+	f.SetBalance(150) // 100/h with 150 left: 1.5h to go
+	f.RecordUsage(1)
+
+	if len(alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(alerts))
+	}
+	if alerts[0].Balance != 150 {
+		t.Errorf("expected Balance to be 150, got %d", alerts[0].Balance)
+	}
+
+	f.SetBalance(10000)
+	f.SetBalance(100)
+	if len(alerts) != 2 {
+		t.Errorf("expected alert to re-arm after leaving the horizon, got %d alerts", len(alerts))
+	}
+}
+
+func TestCreditForecaster_ObserveTransactions(t *testing.T) {
+	now := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	f := NewCreditForecaster()
+	f.now = func() time.Time { return now }
+
+	txns := []CreditTransaction{
+		{ID: "txn_2", Type: TransactionTypeUsage, Amount: -50, BalanceAfter: 850, CreatedAt: "2024-01-01T01:00:00Z"},
+		{ID: "txn_1", Type: TransactionTypeUsage, Amount: -50, BalanceAfter: 900, CreatedAt: "2024-01-01T00:00:00Z"},
+	}
+	f.ObserveTransactions(txns)
+	f.ObserveTransactions(txns)
+
+	forecast, ok := f.Forecast()
+	if !ok {
+		t.Fatal("expected a forecast")
+	}
+	if forecast.Balance != 850 {
+		t.Errorf("expected Balance to be 850, got %d", forecast.Balance)
+	}
+	if forecast.BurnRate != 50 {
+		t.Errorf("expected BurnRate to be 50, got %v", forecast.BurnRate)
+	}
+}
+
+func TestCreditForecaster_ObserveTransactions_OlderPage(t *testing.T) {
+	now := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	f := NewCreditForecaster()
+	f.now = func() time.Time { return now }
+
+	f.ObserveTransactions([]CreditTransaction{
+		{ID: "txn_2", Type: TransactionTypeUsage, Amount: -50, BalanceAfter: 850, CreatedAt: "2024-01-01T01:00:00Z"},
+	})
+	f.ObserveTransactions([]CreditTransaction{
+		{ID: "txn_1", Type: TransactionTypeUsage, Amount: -50, BalanceAfter: 900, CreatedAt: "2024-01-01T00:00:00Z"},
+	})
+
+	if forecast, _ := f.Forecast(); forecast.Balance != 850 {
+		t.Errorf("expected an older page to keep Balance at 850, got %d", forecast.Balance)
+	}
+}
+
+func TestCreditForecaster_ObserveTransactions_PrunesSeen(t *testing.T) {
+	now := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	f := NewCreditForecaster()
+	f.now = func() time.Time { return now }
+
+	f.ObserveTransactions([]CreditTransaction{
+		{ID: "txn_1", Type: TransactionTypeUsage, Amount: -50, BalanceAfter: 900, CreatedAt: "2024-01-01T01:00:00Z"},
+	})
+	now = now.Add(f.window)
+	f.ObserveTransactions([]CreditTransaction{
+		{ID: "txn_2", Type: TransactionTypeUsage, Amount: -50, BalanceAfter: 850, CreatedAt: now.Add(-time.Minute).Format(time.RFC3339)},
+	})
+
+	if _, ok := f.seen["txn_1"]; ok {
+		t.Error("expected txn_1 to be pruned once outside the window")
+	}
+	if len(f.seen) != 1 {
+		t.Errorf("expected 1 tracked transaction, got %d", len(f.seen))
+	}
+}
+
+func TestWithCreditForecaster_RecordsSends(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/credits":
+			w.Write([]byte(`{"balance":100,"reserved_balance":0,"available_balance":100}`))
+		default:
+			json.NewEncoder(w).Encode(Message{ID: "msg_1", Status: MessageStatusQueued, CreditsUsed: 3})
+		}
+	}))
+	defer server.Close()
+
+	f := NewCreditForecaster()
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCreditForecaster(f))
+	ctx := context.Background()
+
+	if _, err := client.Account.GetCredits(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+15551234567", Text: "Hi"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Too little history for a projection, but the balance is tracked.
+	forecast, _ := f.Forecast()
+	if forecast.Balance != 97 {
+		t.Errorf("expected Balance to be 97, got %d", forecast.Balance)
+	}
+}
+
+func TestWithCreditForecaster_TransactionsReplaceSends(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(Message{ID: "msg_1", Status: MessageStatusQueued, CreditsUsed: 50})
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	f := NewCreditForecaster()
+	f.now = func() time.Time { return now }
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCreditForecaster(f))
+	ctx := context.Background()
+	req := &SendMessageRequest{To: "+15551234567", Text: "Hi"}
+
+	if _, err := client.Messages.Send(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The history includes the send above, and later sends will show up in
+	// the next page, so neither may be counted a second time.
+	f.ObserveTransactions([]CreditTransaction{
+		{ID: "txn_2", Type: TransactionTypeUsage, Amount: -50, BalanceAfter: 850, CreatedAt: "2024-01-01T01:50:00Z"},
+		{ID: "txn_1", Type: TransactionTypeUsage, Amount: -50, BalanceAfter: 900, CreatedAt: "2024-01-01T00:00:00Z"},
+	})
+	if _, err := client.Messages.Send(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	forecast, ok := f.Forecast()
+	if !ok {
+		t.Fatal("expected a forecast")
+	}
+	if forecast.Balance != 850 {
+		t.Errorf("expected Balance to be 850, got %d", forecast.Balance)
+	}
+	if forecast.BurnRate != 50 {
+		t.Errorf("expected BurnRate to be 50, got %v", forecast.BurnRate)
+	}
+}
@@ -0,0 +1,87 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// WithHedging sends a duplicate of a GET request that has not responded
+// within delay, up to maxHedges extra copies spaced delay apart, and uses the
+// first successful response. The slower copies are cancelled. This cuts tail
+// latency for status polling at the cost of extra requests; calls that change
+// state are never hedged.
+func WithHedging(delay time.Duration, maxHedges int) ClientOption {
+	return func(c *Client) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// hedgeOutcome is the result of one copy of a hedged request.
+type hedgeOutcome struct {
+	result reflect.Value
+	status int
+	err    error
+}
+
+// attempt performs one attempt of a request, hedging it if it is eligible.
+// It returns the response status, or 0 if no response was received.
+func (c *Client) attempt(ctx context.Context, method, path string, body interface{}, result interface{}) (int, error) {
+	if c.maxHedges <= 0 || method != http.MethodGet || result == nil {
+		return c.doRequest(ctx, method, path, body, result)
+	}
+	return c.doHedged(ctx, method, path, result)
+}
+
+// doHedged races copies of a GET request. Each copy decodes into its own
+// value, and the first to succeed is copied into result. If every copy fails,
+// the first error is returned.
+func (c *Client) doHedged(ctx context.Context, method, path string, result interface{}) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outcomes := make(chan hedgeOutcome, c.maxHedges+1)
+	launch := func() {
+		value := reflect.New(reflect.TypeOf(result).Elem())
+		// The caller's request holds inFlight, so Close also waits for copies
+		// still finishing after it has returned.
+		c.inFlight.Add(1)
+		go func() {
+			defer c.inFlight.Done()
+			status, err := c.doRequest(ctx, method, path, nil, value.Interface())
+			outcomes <- hedgeOutcome{result: value, status: status, err: err}
+		}()
+	}
+
+	launch()
+	launched, pending := 1, 1
+	timer := time.NewTimer(c.hedgeDelay)
+	defer timer.Stop()
+
+	var first *hedgeOutcome
+	for {
+		select {
+		case out := <-outcomes:
+			pending--
+			if out.err == nil {
+				reflect.ValueOf(result).Elem().Set(out.result.Elem())
+				return out.status, nil
+			}
+			if first == nil {
+				first = &out
+			}
+			if pending == 0 {
+				return first.status, first.err
+			}
+		case <-timer.C:
+			if launched <= c.maxHedges {
+				launch()
+				launched++
+				pending++
+				timer.Reset(c.hedgeDelay)
+			}
+		}
+	}
+}
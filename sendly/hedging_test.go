@@ -0,0 +1,101 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithHedging_UsesFastestResponse(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(2 * time.Second):
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","to":"+15551234567","status":"delivered"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithHedging(50*time.Millisecond, 1))
+
+	start := time.Now()
+	msg, err := client.Messages.Get(context.Background(), "msg_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.ID != "msg_1" || msg.Status != MessageStatusDelivered {
+		t.Errorf("expected delivered message 'msg_1', got %+v", msg)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the hedge to answer quickly, took %v", elapsed)
+	}
+	if atomic.LoadInt32(&hits) != 2 {
+		t.Errorf("expected 2 requests, got %d", hits)
+	}
+}
+
+func TestWithHedging_LimitsHedges(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		time.Sleep(300 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithHedging(20*time.Millisecond, 2))
+
+	if _, err := client.Messages.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if atomic.LoadInt32(&hits) != 3 {
+		t.Errorf("expected 3 requests, got %d", hits)
+	}
+}
+
+func TestWithHedging_ReturnsErrorWhenAllFail(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"not_found","message":"Message not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithHedging(10*time.Millisecond, 1))
+
+	if _, err := client.Messages.Get(context.Background(), "msg_missing"); !IsNotFoundError(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestWithHedging_DoesNotHedgePosts(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithHedging(10*time.Millisecond, 3))
+
+	req := &SendMessageRequest{To: "+15551234567", Text: "Hello"}
+	if _, err := client.Messages.Send(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if atomic.LoadInt32(&hits) != 1 {
+		t.Errorf("expected 1 request, got %d", hits)
+	}
+}
@@ -0,0 +1,171 @@
+package sendly
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// MessagesAPI is the set of message operations exposed by the client.
+// It is implemented by *MessagesService and can be mocked in tests.
+type MessagesAPI interface {
+	Send(ctx context.Context, req *SendMessageRequest) (*Message, error)
+	SendTransaction(ctx context.Context, reqs []SendMessageRequest) (*TransactionResponse, error)
+	List(ctx context.Context, req *ListMessagesRequest) (*ListMessagesResponse, error)
+	ListChan(ctx context.Context, req *ListMessagesRequest) (<-chan Message, <-chan error)
+	Search(ctx context.Context, req SearchMessagesRequest) (*SearchMessagesResponse, error)
+	Get(ctx context.Context, id string) (*Message, error)
+	GetDeliveryReport(ctx context.Context, messageID string) (*DeliveryReport, error)
+	Schedule(ctx context.Context, req *ScheduleMessageRequest) (*ScheduledMessage, error)
+	ListScheduled(ctx context.Context, req *ListScheduledMessagesRequest) (*ListScheduledMessagesResponse, error)
+	GetScheduled(ctx context.Context, id string) (*ScheduledMessage, error)
+	CancelScheduled(ctx context.Context, id string) (*CancelScheduledMessageResponse, error)
+	SendBatch(ctx context.Context, req *SendBatchRequest) (*BatchMessageResponse, error)
+	SendToSegment(ctx context.Context, segmentID, text string) (*BatchMessageResponse, error)
+	GetBatch(ctx context.Context, batchID string) (*BatchMessageResponse, error)
+	ListBatchResults(ctx context.Context, batchID string, req *ListBatchResultsRequest) (*ListBatchResultsResponse, error)
+	StreamBatchResults(ctx context.Context, batchID string) (*BatchResultIterator, error)
+	ListBatches(ctx context.Context, req *ListBatchesRequest) (*ListBatchesResponse, error)
+	ListBatchesByDate(ctx context.Context, req *ListBatchesByDateRequest) ([]BatchMessageResponse, error)
+	PreviewBatch(ctx context.Context, req *SendBatchRequest) (*BatchPreviewResponse, error)
+	SendBatchFromCSV(ctx context.Context, r io.Reader, opts CSVOptions) (*CSVSendResult, error)
+	CreateBatchDraft(ctx context.Context, req *CreateBatchDraftRequest) (*BatchDraft, error)
+	AddItemsToDraft(ctx context.Context, draftID string, items []BatchMessageItem) (*BatchDraft, error)
+	LaunchDraft(ctx context.Context, draftID string) (*BatchMessageResponse, error)
+}
+
+// WebhooksAPI is the set of webhook management operations exposed by the client.
+// It is implemented by *WebhooksService and can be mocked in tests.
+type WebhooksAPI interface {
+	Create(ctx context.Context, req CreateWebhookRequest) (*WebhookCreatedResponse, error)
+	List(ctx context.Context) ([]Webhook, error)
+	Get(ctx context.Context, webhookID string) (*Webhook, error)
+	Update(ctx context.Context, webhookID string, req UpdateWebhookRequest) (*Webhook, error)
+	Delete(ctx context.Context, webhookID string) error
+	Test(ctx context.Context, webhookID string) (*WebhookTestResult, error)
+	RotateSecret(ctx context.Context, webhookID string) (*WebhookSecretRotation, error)
+	GetDeliveries(ctx context.Context, webhookID string) ([]WebhookDelivery, error)
+	RetryDelivery(ctx context.Context, webhookID, deliveryID string) error
+	ListEventTypes(ctx context.Context) ([]string, error)
+}
+
+// AccountAPI is the set of account operations exposed by the client.
+// It is implemented by *AccountService and can be mocked in tests.
+type AccountAPI interface {
+	Get(ctx context.Context) (*Account, error)
+	Update(ctx context.Context, req UpdateAccountRequest) (*Account, error)
+	GetCredits(ctx context.Context) (*Credits, error)
+	WatchCredits(ctx context.Context, opts WatchOptions) (<-chan Credits, error)
+	GetCreditTransactions(ctx context.Context, opts *ListCreditTransactionsOptions) ([]CreditTransaction, error)
+	ListCreditTransactions(ctx context.Context, opts *ListCreditTransactionsOptions) (*ListCreditTransactionsResponse, error)
+	IterateCreditTransactions(opts *ListCreditTransactionsOptions) *CreditTransactionIterator
+	GetAutoTopUp(ctx context.Context) (*AutoTopUpConfig, error)
+	SetAutoTopUp(ctx context.Context, config AutoTopUpConfig) (*AutoTopUpConfig, error)
+	ListAPIKeys(ctx context.Context) ([]APIKey, error)
+	GetAPIKey(ctx context.Context, keyID string) (*APIKey, error)
+	GetCurrentAPIKey(ctx context.Context) (*APIKey, error)
+	GetAPIKeyUsage(ctx context.Context, keyID string) (*APIKeyUsage, error)
+	CreateAPIKey(ctx context.Context, name string) (*CreateAPIKeyResponse, error)
+	CreateAPIKeyWithOptions(ctx context.Context, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	UpdateAPIKey(ctx context.Context, keyID string, req UpdateAPIKeyRequest) (*APIKey, error)
+	RevokeAPIKey(ctx context.Context, keyID string) error
+	StartEmailVerification(ctx context.Context, email string) (*ContactVerification, error)
+	StartTestNumberVerification(ctx context.Context, phone string) (*ContactVerification, error)
+	ConfirmVerification(ctx context.Context, verificationID, code string) (*ContactVerification, error)
+}
+
+// SubAccountsAPI is the set of sub-account operations exposed by the client.
+// It is implemented by *SubAccountsService and can be mocked in tests.
+type SubAccountsAPI interface {
+	Create(ctx context.Context, req CreateSubAccountRequest) (*SubAccount, error)
+	List(ctx context.Context, req *ListSubAccountsRequest) (*ListSubAccountsResponse, error)
+	Get(ctx context.Context, id string) (*SubAccount, error)
+	Suspend(ctx context.Context, id string) (*SubAccount, error)
+	Reactivate(ctx context.Context, id string) (*SubAccount, error)
+	GetUsage(ctx context.Context, id string) (*SubAccountUsage, error)
+	TransferCredits(ctx context.Context, id string, amount int) (*CreditTransfer, error)
+}
+
+// ComplianceAPI is the set of data protection operations exposed by the
+// client. It is implemented by *ComplianceService and can be mocked in tests.
+type ComplianceAPI interface {
+	GetRecipientData(ctx context.Context, phone string) (*RecipientData, error)
+	DeleteRecipientData(ctx context.Context, phone string) (*DataDeletionJob, error)
+	GetDeletionJob(ctx context.Context, jobID string) (*DataDeletionJob, error)
+	WaitForDeletion(ctx context.Context, jobID string, pollInterval time.Duration) (*DataDeletionJob, error)
+}
+
+// SegmentsAPI is the set of audience segment operations exposed by the
+// client. It is implemented by *SegmentsService and can be mocked in tests.
+type SegmentsAPI interface {
+	Create(ctx context.Context, req CreateSegmentRequest) (*AudienceSegment, error)
+	List(ctx context.Context, req *ListSegmentsRequest) (*ListSegmentsResponse, error)
+	Get(ctx context.Context, id string) (*AudienceSegment, error)
+	Update(ctx context.Context, id string, req UpdateSegmentRequest) (*AudienceSegment, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// LinksAPI is the set of short link operations exposed by the client.
+// It is implemented by *LinksService and can be mocked in tests.
+type LinksAPI interface {
+	Create(ctx context.Context, targetURL string) (*ShortLink, error)
+	Get(ctx context.Context, id string) (*ShortLink, error)
+	List(ctx context.Context, req *ListLinksRequest) (*ListLinksResponse, error)
+}
+
+// OptOutsAPI is the set of opt-out list operations exposed by the client.
+// It is implemented by *OptOutsService and can be mocked in tests.
+type OptOutsAPI interface {
+	Add(ctx context.Context, phone string, source OptOutSource) (*OptOut, error)
+	Remove(ctx context.Context, phone string) error
+	Get(ctx context.Context, phone string) (*OptOut, error)
+	List(ctx context.Context, req *ListOptOutsRequest) (*ListOptOutsResponse, error)
+}
+
+// NumbersAPI is the set of number provisioning operations exposed by the
+// client. It is implemented by *NumbersService and can be mocked in tests.
+type NumbersAPI interface {
+	Search(ctx context.Context, req SearchNumbersRequest) ([]AvailableNumber, error)
+	Purchase(ctx context.Context, number string) (*PhoneNumber, error)
+	List(ctx context.Context, req *ListNumbersRequest) (*ListNumbersResponse, error)
+	Get(ctx context.Context, id string) (*PhoneNumber, error)
+	Update(ctx context.Context, id string, req UpdateNumberRequest) (*PhoneNumber, error)
+	Release(ctx context.Context, id string) error
+}
+
+// RegistrationsAPI is the set of carrier registration operations exposed by
+// the client. It is implemented by *RegistrationsService and can be mocked in
+// tests.
+type RegistrationsAPI interface {
+	RegisterBrand(ctx context.Context, req RegisterBrandRequest) (*Brand, error)
+	GetBrand(ctx context.Context, id string) (*Brand, error)
+	WaitForBrand(ctx context.Context, id string, pollInterval time.Duration) (*Brand, error)
+	RegisterCampaign(ctx context.Context, req RegisterCampaignRequest) (*Campaign, error)
+	GetCampaign(ctx context.Context, id string) (*Campaign, error)
+	WaitForCampaign(ctx context.Context, id string, pollInterval time.Duration) (*Campaign, error)
+	SubmitTollFreeVerification(ctx context.Context, req SubmitTollFreeVerificationRequest) (*TollFreeVerification, error)
+	GetTollFreeVerification(ctx context.Context, id string) (*TollFreeVerification, error)
+	WaitForTollFreeVerification(ctx context.Context, id string, pollInterval time.Duration) (*TollFreeVerification, error)
+}
+
+// EmailAPI is the set of transactional email operations exposed by the
+// client. It is implemented by *EmailService and can be mocked in tests.
+type EmailAPI interface {
+	Send(ctx context.Context, req EmailRequest) (*Email, error)
+	Get(ctx context.Context, id string) (*Email, error)
+}
+
+// Compile-time checks that the concrete services satisfy their interfaces.
+var (
+	_ MessagesAPI      = (*MessagesService)(nil)
+	_ WebhooksAPI      = (*WebhooksService)(nil)
+	_ AccountAPI       = (*AccountService)(nil)
+	_ SubAccountsAPI   = (*SubAccountsService)(nil)
+	_ ComplianceAPI    = (*ComplianceService)(nil)
+	_ SegmentsAPI      = (*SegmentsService)(nil)
+	_ LinksAPI         = (*LinksService)(nil)
+	_ OptOutsAPI       = (*OptOutsService)(nil)
+	_ NumbersAPI       = (*NumbersService)(nil)
+	_ RegistrationsAPI = (*RegistrationsService)(nil)
+	_ EmailAPI         = (*EmailService)(nil)
+)
@@ -0,0 +1,76 @@
+package sendly
+
+import (
+	"context"
+	"testing"
+)
+
+// mockMessages overrides Send and delegates everything else to the embedded interface.
+type mockMessages struct {
+	MessagesAPI
+	sent []*SendMessageRequest
+}
+
+func (m *mockMessages) Send(ctx context.Context, req *SendMessageRequest) (*Message, error) {
+	m.sent = append(m.sent, req)
+	return &Message{ID: "msg_mock", To: req.To, Text: req.Text, Status: MessageStatusQueued}, nil
+}
+
+func TestClient_ServicesAreInterfaces(t *testing.T) {
+	client := NewClient("test-api-key")
+
+	if _, ok := client.Messages.(*MessagesService); !ok {
+		t.Errorf("expected Messages to be *MessagesService, got %T", client.Messages)
+	}
+	if _, ok := client.WebhooksService.(*WebhooksService); !ok {
+		t.Errorf("expected WebhooksService to be *WebhooksService, got %T", client.WebhooksService)
+	}
+	if _, ok := client.Account.(*AccountService); !ok {
+		t.Errorf("expected Account to be *AccountService, got %T", client.Account)
+	}
+	if _, ok := client.SubAccounts.(*SubAccountsService); !ok {
+		t.Errorf("expected SubAccounts to be *SubAccountsService, got %T", client.SubAccounts)
+	}
+	if _, ok := client.Compliance.(*ComplianceService); !ok {
+		t.Errorf("expected Compliance to be *ComplianceService, got %T", client.Compliance)
+	}
+	if _, ok := client.Segments.(*SegmentsService); !ok {
+		t.Errorf("expected Segments to be *SegmentsService, got %T", client.Segments)
+	}
+	if _, ok := client.Links.(*LinksService); !ok {
+		t.Errorf("expected Links to be *LinksService, got %T", client.Links)
+	}
+	if _, ok := client.OptOuts.(*OptOutsService); !ok {
+		t.Errorf("expected OptOuts to be *OptOutsService, got %T", client.OptOuts)
+	}
+	if _, ok := client.Numbers.(*NumbersService); !ok {
+		t.Errorf("expected Numbers to be *NumbersService, got %T", client.Numbers)
+	}
+	if _, ok := client.Registrations.(*RegistrationsService); !ok {
+		t.Errorf("expected Registrations to be *RegistrationsService, got %T", client.Registrations)
+	}
+	if _, ok := client.Email.(*EmailService); !ok {
+		t.Errorf("expected Email to be *EmailService, got %T", client.Email)
+	}
+}
+
+func TestClient_InjectMockMessages(t *testing.T) {
+	client := NewClient("test-api-key")
+	mock := &mockMessages{}
+	client.Messages = mock
+
+	msg, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+		To:   "+15551234567",
+		Text: "Hello",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if msg.ID != "msg_mock" {
+		t.Errorf("expected ID to be 'msg_mock', got '%s'", msg.ID)
+	}
+	if len(mock.sent) != 1 {
+		t.Errorf("expected 1 recorded send, got %d", len(mock.sent))
+	}
+}
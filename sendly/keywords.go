@@ -0,0 +1,180 @@
+package sendly
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// Keyword is a compliance keyword a recipient can reply with.
+type Keyword string
+
+const (
+	// KeywordNone means the reply is not a compliance keyword.
+	KeywordNone Keyword = ""
+	// KeywordStop opts the recipient out.
+	KeywordStop Keyword = "STOP"
+	// KeywordStart opts the recipient back in.
+	KeywordStart Keyword = "START"
+	// KeywordHelp asks for help and contact details.
+	KeywordHelp Keyword = "HELP"
+)
+
+// keywordSynonyms maps the words carriers treat as compliance keywords to
+// the keyword they stand for.
+var keywordSynonyms = map[string]Keyword{
+	"STOP":        KeywordStop,
+	"STOPALL":     KeywordStop,
+	"UNSUBSCRIBE": KeywordStop,
+	"CANCEL":      KeywordStop,
+	"END":         KeywordStop,
+	"QUIT":        KeywordStop,
+	"OPTOUT":      KeywordStop,
+	"REVOKE":      KeywordStop,
+	"ARRET":       KeywordStop,
+	"START":       KeywordStart,
+	"UNSTOP":      KeywordStart,
+	"SUBSCRIBE":   KeywordStart,
+	"YES":         KeywordStart,
+	"HELP":        KeywordHelp,
+	"INFO":        KeywordHelp,
+	"AIDE":        KeywordHelp,
+}
+
+// ParseKeyword returns the compliance keyword that text consists of, or
+// KeywordNone. Matching ignores case, surrounding whitespace and
+// punctuation, so "Stop." and " unsubscribe" are recognised, but a keyword
+// inside a longer reply, such as "don't stop", is not.
+func ParseKeyword(text string) Keyword {
+	word := strings.ToUpper(strings.TrimFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}))
+	word = strings.NewReplacer(" ", "", "-", "").Replace(word)
+	return keywordSynonyms[word]
+}
+
+// KeywordReplies are the confirmation replies sent in response to compliance
+// keywords. "{brand}" and "{contact}" are replaced with
+// KeywordHandler.Brand and KeywordHandler.HelpContact.
+type KeywordReplies struct {
+	Stop  string
+	Start string
+	Help  string
+}
+
+// Reply returns the reply for keyword with placeholders filled in, or "" for
+// KeywordNone.
+func (r KeywordReplies) Reply(keyword Keyword, brand, contact string) string {
+	var text string
+	switch keyword {
+	case KeywordStop:
+		text = r.Stop
+	case KeywordStart:
+		text = r.Start
+	case KeywordHelp:
+		text = r.Help
+	}
+	return strings.NewReplacer("{brand}", brand, "{contact}", contact).Replace(text)
+}
+
+// nanpKeywordReplies follow the CTIA guidelines for the US and Canada, which
+// require rate and opt-out disclosures.
+var nanpKeywordReplies = KeywordReplies{
+	Stop:  "{brand}: You are unsubscribed and will receive no further messages. Reply START to resubscribe.",
+	Start: "{brand}: You are resubscribed. Msg & data rates may apply. Reply HELP for help, STOP to unsubscribe.",
+	Help:  "{brand}: For help, contact {contact}. Msg & data rates may apply. Reply STOP to unsubscribe.",
+}
+
+// defaultKeywordReplies are used outside North America.
+var defaultKeywordReplies = KeywordReplies{
+	Stop:  "{brand}: You have opted out and will receive no further messages. Reply START to opt back in.",
+	Start: "{brand}: You have opted back in. Reply STOP to opt out.",
+	Help:  "{brand}: For help, contact {contact}. Reply STOP to opt out.",
+}
+
+// KeywordRepliesFor returns the confirmation replies required in country,
+// an ISO 3166-1 alpha-2 code such as "US".
+func KeywordRepliesFor(country string) KeywordReplies {
+	switch strings.ToUpper(country) {
+	case "US", "CA", "PR", "VI", "GU", "AS", "MP":
+		return nanpKeywordReplies
+	default:
+		return defaultKeywordReplies
+	}
+}
+
+// KeywordResult is the outcome of handling an inbound message.
+type KeywordResult struct {
+	// Keyword is the keyword the message consisted of, or KeywordNone.
+	Keyword Keyword
+	// Phone is the sender of the inbound message.
+	Phone string
+	// Reply is the confirmation to send back to Phone, or "" if the message
+	// was not a keyword. Send it as a transactional message.
+	Reply string
+}
+
+// KeywordHandler processes STOP, START and HELP replies from inbound
+// message.received webhook events: it updates the opt-out list and returns
+// the confirmation reply mandated for the sender's country.
+//
+// Example:
+//
+//	handler := &sendly.KeywordHandler{OptOuts: client.OptOuts, Brand: "Acme", HelpContact: "support@acme.example"}
+//	result, err := handler.Handle(ctx, event)
+//	if err == nil && result.Reply != "" {
+//		client.Messages.Send(ctx, &sendly.SendMessageRequest{
+//			To: result.Phone, Text: result.Reply, MessageType: sendly.MessageTypeTransactional,
+//		})
+//	}
+type KeywordHandler struct {
+	// OptOuts is the opt-out list to update (required).
+	OptOuts OptOutsAPI
+	// Brand names the sender at the start of each reply.
+	Brand string
+	// HelpContact is the support email or phone number given in HELP replies.
+	HelpContact string
+	// Replies overrides the replies, keyed by country code. Countries not
+	// listed use KeywordRepliesFor.
+	Replies map[string]KeywordReplies
+}
+
+// Handle processes an inbound message event. Events other than
+// message.received, and messages that are not keywords, are ignored and
+// return a result with KeywordNone.
+func (h *KeywordHandler) Handle(ctx context.Context, event *WebhookEvent) (*KeywordResult, error) {
+	if event.Type != WebhookEventMessageReceived {
+		return &KeywordResult{}, nil
+	}
+	data, err := event.InboundMessageData()
+	if err != nil {
+		return nil, err
+	}
+	return h.HandleMessage(ctx, data.From, data.Text)
+}
+
+// HandleMessage processes an inbound message from phone with the given text.
+func (h *KeywordHandler) HandleMessage(ctx context.Context, phone, text string) (*KeywordResult, error) {
+	result := &KeywordResult{Keyword: ParseKeyword(text), Phone: phone}
+
+	switch result.Keyword {
+	case KeywordNone:
+		return result, nil
+	case KeywordStop:
+		if _, err := h.OptOuts.Add(ctx, phone, OptOutSourceKeyword); err != nil {
+			return nil, err
+		}
+	case KeywordStart:
+		if err := h.OptOuts.Remove(ctx, phone); err != nil && !IsNotFoundError(err) {
+			return nil, err
+		}
+	}
+
+	country := CountryForNumber(phone)
+	replies, ok := h.Replies[country]
+	if !ok {
+		replies = KeywordRepliesFor(country)
+	}
+	result.Reply = replies.Reply(result.Keyword, h.Brand, h.HelpContact)
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package sendly
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// recordingOptOuts is an OptOutsAPI that records opt-outs in memory.
+type recordingOptOuts struct {
+	OptOutsAPI
+	added   map[string]OptOutSource
+	removed []string
+}
+
+func (r *recordingOptOuts) Add(ctx context.Context, phone string, source OptOutSource) (*OptOut, error) {
+	if r.added == nil {
+		r.added = make(map[string]OptOutSource)
+	}
+	r.added[phone] = source
+	return &OptOut{Phone: phone, Source: source}, nil
+}
+
+func (r *recordingOptOuts) Remove(ctx context.Context, phone string) error {
+	if _, ok := r.added[phone]; !ok {
+		return &NotFoundError{APIError: APIError{Message: "opt-out not found"}}
+	}
+	delete(r.added, phone)
+	r.removed = append(r.removed, phone)
+	return nil
+}
+
+func TestParseKeyword(t *testing.T) {
+	tests := []struct {
+		text string
+		want Keyword
+	}{
+		{"STOP", KeywordStop},
+		{"stop", KeywordStop},
+		{"  Stop. ", KeywordStop},
+		{"Unsubscribe", KeywordStop},
+		{"opt-out", KeywordStop},
+		{"STOP ALL", KeywordStop},
+		{"start", KeywordStart},
+		{"UNSTOP!", KeywordStart},
+		{"help?", KeywordHelp},
+		{"info", KeywordHelp},
+		{"please don't stop", KeywordNone},
+		{"thanks", KeywordNone},
+		{"", KeywordNone},
+	}
+
+	for _, tt := range tests {
+		if got := ParseKeyword(tt.text); got != tt.want {
+			t.Errorf("ParseKeyword(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestKeywordRepliesFor(t *testing.T) {
+	us := KeywordRepliesFor("US").Reply(KeywordHelp, "Acme", "support@acme.example")
+	if !strings.Contains(us, "Msg & data rates may apply") || !strings.Contains(us, "support@acme.example") {
+		t.Errorf("expected US HELP reply to carry rate and contact disclosures, got %q", us)
+	}
+	if !strings.HasPrefix(us, "Acme: ") {
+		t.Errorf("expected reply to start with the brand, got %q", us)
+	}
+
+	gb := KeywordRepliesFor("GB").Reply(KeywordHelp, "Acme", "support@acme.example")
+	if strings.Contains(gb, "Msg & data rates") {
+		t.Errorf("expected GB HELP reply without US rate disclosure, got %q", gb)
+	}
+
+	if got := KeywordRepliesFor("US").Reply(KeywordNone, "Acme", ""); got != "" {
+		t.Errorf("expected no reply for KeywordNone, got %q", got)
+	}
+}
+
+func TestKeywordHandler_Stop(t *testing.T) {
+	optOuts := &recordingOptOuts{}
+	handler := &KeywordHandler{OptOuts: optOuts, Brand: "Acme"}
+
+	result, err := handler.HandleMessage(context.Background(), "+15551234567", "stop")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Keyword != KeywordStop || result.Phone != "+15551234567" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if optOuts.added["+15551234567"] != OptOutSourceKeyword {
+		t.Errorf("expected a keyword opt-out, got %v", optOuts.added)
+	}
+	if !strings.Contains(result.Reply, "unsubscribed") {
+		t.Errorf("unexpected reply %q", result.Reply)
+	}
+}
+
+func TestKeywordHandler_StartWhenNotOptedOut(t *testing.T) {
+	optOuts := &recordingOptOuts{}
+	handler := &KeywordHandler{OptOuts: optOuts, Brand: "Acme"}
+
+	result, err := handler.HandleMessage(context.Background(), "+447911123456", "START")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Keyword != KeywordStart || result.Reply == "" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestKeywordHandler_CustomReplies(t *testing.T) {
+	handler := &KeywordHandler{
+		OptOuts: &recordingOptOuts{},
+		Brand:   "Acme",
+		Replies: map[string]KeywordReplies{"GB": {Help: "{brand} help: call 0800 000 000"}},
+	}
+
+	result, err := handler.HandleMessage(context.Background(), "+447911123456", "HELP")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Reply != "Acme help: call 0800 000 000" {
+		t.Errorf("unexpected reply %q", result.Reply)
+	}
+}
+
+func TestKeywordHandler_HandleEvent(t *testing.T) {
+	payload := `{"id":"evt_1","type":"message.received","created_at":"2024-06-01T00:00:00Z","api_version":"` + CurrentWebhookAPIVersion + `",` +
+		`"data":{"message_id":"msg_in_1","from":"+15551234567","to":"+15550000000","text":"STOP","received_at":"2024-06-01T00:00:00Z"}}`
+
+	event, err := Webhooks{}.ParseEvent(payload, Webhooks{}.GenerateSignature(payload, "whsec_test"), "whsec_test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	optOuts := &recordingOptOuts{}
+	handler := &KeywordHandler{OptOuts: optOuts, Brand: "Acme"}
+	result, err := handler.Handle(context.Background(), event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Keyword != KeywordStop || result.Phone != "+15551234567" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	event.Type = WebhookEventMessageDelivered
+	result, err = handler.Handle(context.Background(), event)
+	if err != nil || result.Keyword != KeywordNone {
+		t.Errorf("expected other events to be ignored, got %+v, %v", result, err)
+	}
+}
+
+func TestWebhookEvent_InboundMessageData(t *testing.T) {
+	event := &WebhookEvent{Type: WebhookEventLinkClicked}
+	if _, err := event.InboundMessageData(); err == nil {
+		t.Error("expected an error for a different event type")
+	}
+}
@@ -0,0 +1,74 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrClientClosed is returned by requests made after Close.
+var ErrClientClosed = errors.New("sendly: client is closed")
+
+// Close shuts the client down. It stops and flushes queues created for the
+// client with NewQueue, rejects new requests with ErrClientClosed, waits for
+// in-flight requests to finish, and closes idle connections. If ctx is done
+// before in-flight requests finish, Close returns ctx.Err().
+//
+// Messages a queue could not send stay in its store. Close is safe to call
+// more than once.
+func (c *Client) Close(ctx context.Context) error {
+	c.lifecycleMu.Lock()
+	if c.closed {
+		c.lifecycleMu.Unlock()
+		return nil
+	}
+	closers := c.closers
+	c.closers = nil
+	c.lifecycleMu.Unlock()
+
+	// Queues flush through the client, so they close before it stops
+	// accepting requests.
+	var errs []error
+	for _, closeFn := range closers {
+		if err := closeFn(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	c.lifecycleMu.Lock()
+	c.closed = true
+	c.lifecycleMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		c.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		errs = append(errs, ctx.Err())
+	}
+
+	c.cfg.httpClient.CloseIdleConnections()
+	return errors.Join(errs...)
+}
+
+// onClose registers fn to run when the client is closed.
+func (c *Client) onClose(fn func(context.Context) error) {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	c.closers = append(c.closers, fn)
+}
+
+// beginRequest records an in-flight request, or returns ErrClientClosed.
+// Callers must call c.inFlight.Done when the request finishes.
+func (c *Client) beginRequest() error {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	if c.closed {
+		return ErrClientClosed
+	}
+	c.inFlight.Add(1)
+	return nil
+}
@@ -0,0 +1,117 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientClose_DrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	requestErr := make(chan error, 1)
+	go func() {
+		_, err := client.Messages.List(ctx, nil)
+		requestErr <- err
+	}()
+	<-started
+
+	closed := make(chan error, 1)
+	go func() { closed <- client.Close(ctx) }()
+
+	select {
+	case <-closed:
+		t.Fatal("expected Close to wait for the in-flight request")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-closed; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := <-requestErr; err != nil {
+		t.Errorf("expected in-flight request to succeed, got %v", err)
+	}
+
+	if _, err := client.Messages.List(ctx, nil); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("expected ErrClientClosed, got %v", err)
+	}
+	if err := client.Close(ctx); err != nil {
+		t.Errorf("expected second Close to succeed, got %v", err)
+	}
+}
+
+func TestClientClose_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(0))
+	go client.Messages.List(context.Background(), nil)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClientClose_FlushesQueues(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SendMessageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		sent = append(sent, req.To)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(Message{ID: "msg_123", To: req.To})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	queue := NewQueue(client.Messages, WithQueueFlushInterval(time.Hour))
+	ctx := context.Background()
+
+	runDone := make(chan error, 1)
+	go func() { runDone <- queue.Run(ctx) }()
+
+	queue.Enqueue(ctx, &SendMessageRequest{To: "+15551234567", Text: "Hello"})
+	if err := client.Close(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case err := <-runDone:
+		if err != nil {
+			t.Errorf("expected Run to return nil after Close, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Run to stop when the client is closed")
+	}
+	if n, _ := queue.Len(ctx); n != 0 {
+		t.Errorf("expected queue to be flushed, got %d queued", n)
+	}
+	if len(sent) != 1 {
+		t.Errorf("expected 1 message sent, got %d", len(sent))
+	}
+}
@@ -0,0 +1,112 @@
+package sendly
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// ShortLink is a branded short URL that redirects to a target URL and counts
+// clicks. Each click also sends a link.clicked webhook event.
+type ShortLink struct {
+	// ID is the link ID (lnk_xxx).
+	ID string `json:"id"`
+	// ShortURL is the branded short URL to share.
+	ShortURL string `json:"shortUrl"`
+	// TargetURL is where the short URL redirects.
+	TargetURL string `json:"targetUrl"`
+	// ClickCount is the number of times the link has been clicked.
+	ClickCount int `json:"clickCount"`
+	// MessageID is the message the link was created for, if it was shortened
+	// from message text with SendMessageRequest.ShortenLinks.
+	MessageID string `json:"messageId,omitempty"`
+	// CreatedAt is when the link was created.
+	CreatedAt string `json:"createdAt"`
+	// LastClickedAt is when the link was last clicked.
+	LastClickedAt *string `json:"lastClickedAt,omitempty"`
+}
+
+// ListLinksRequest is the request to list short links.
+type ListLinksRequest struct {
+	// Limit is the maximum number of links to return (default: 20, max: 100).
+	Limit int
+	// Offset is the number of links to skip.
+	Offset int
+	// MessageID filters to links shortened from a message's text.
+	MessageID string
+}
+
+// ListLinksResponse is the response from listing short links.
+type ListLinksResponse = Page[ShortLink]
+
+// createLinkRequest is the body of a short link creation.
+type createLinkRequest struct {
+	TargetURL string `json:"targetUrl"`
+}
+
+// LinksService creates short links and reports their clicks.
+type LinksService struct {
+	client *Client
+}
+
+// Create creates a branded short link to targetURL, which must be an
+// absolute HTTP or HTTPS URL.
+func (s *LinksService) Create(ctx context.Context, targetURL string) (*ShortLink, error) {
+	if err := validateTargetURL(targetURL); err != nil {
+		return nil, err
+	}
+
+	var resp ShortLink
+	if err := s.client.request(ctx, "POST", "/links", &createLinkRequest{TargetURL: targetURL}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Get retrieves a short link, including its click count.
+func (s *LinksService) Get(ctx context.Context, id string) (*ShortLink, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "link ID is required"}}
+	}
+
+	var resp ShortLink
+	if err := s.client.request(ctx, "GET", "/links/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List retrieves short links, newest first.
+func (s *LinksService) List(ctx context.Context, req *ListLinksRequest) (*ListLinksResponse, error) {
+	params := make(map[string]string)
+	offset := 0
+	if req != nil {
+		if req.Limit > 0 {
+			params["limit"] = strconv.Itoa(req.Limit)
+		}
+		offset = req.Offset
+		if req.Offset > 0 {
+			params["offset"] = strconv.Itoa(req.Offset)
+		}
+		if req.MessageID != "" {
+			params["messageId"] = req.MessageID
+		}
+	}
+
+	var resp ListLinksResponse
+	if err := s.client.request(ctx, "GET", "/links"+buildQueryString(params), nil, &resp); err != nil {
+		return nil, err
+	}
+	resp.fill(offset)
+	return &resp, nil
+}
+
+// validateTargetURL checks that a short link target is an absolute HTTP or
+// HTTPS URL.
+func validateTargetURL(targetURL string) error {
+	u, err := url.Parse(targetURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &ValidationError{APIError: APIError{Message: "target URL must be an absolute HTTP or HTTPS URL"}}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLinksCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/links" {
+			t.Errorf("expected path '/links', got '%s'", r.URL.Path)
+		}
+
+		var req createLinkRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.TargetURL != "https://shop.example.com/sale?utm_source=sms" {
+			t.Errorf("unexpected target URL '%s'", req.TargetURL)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"lnk_1","shortUrl":"https://go.shop.example/abc","targetUrl":"https://shop.example.com/sale?utm_source=sms","clickCount":0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	link, err := client.Links.Create(context.Background(), "https://shop.example.com/sale?utm_source=sms")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if link.ID != "lnk_1" || link.ShortURL != "https://go.shop.example/abc" {
+		t.Errorf("unexpected link %+v", link)
+	}
+}
+
+func TestLinksCreate_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+
+	for _, target := range []string{"", "shop.example.com", "ftp://shop.example.com", "https://"} {
+		if _, err := client.Links.Create(context.Background(), target); !IsValidationError(err) {
+			t.Errorf("expected ValidationError for '%s', got %T", target, err)
+		}
+	}
+}
+
+func TestLinksGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/links/lnk_1" {
+			t.Errorf("expected path '/links/lnk_1', got '%s'", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"lnk_1","clickCount":17,"lastClickedAt":"2025-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	link, err := client.Links.Get(context.Background(), "lnk_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if link.ClickCount != 17 || link.LastClickedAt == nil {
+		t.Errorf("unexpected link %+v", link)
+	}
+}
+
+func TestLinksList_FiltersByMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("messageId"); got != "msg_1" {
+			t.Errorf("expected messageId to be 'msg_1', got '%s'", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"lnk_1","messageId":"msg_1"}],"count":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.Links.List(context.Background(), &ListLinksRequest{MessageID: "msg_1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 1 || resp.Data[0].MessageID != "msg_1" {
+		t.Errorf("unexpected page %+v", resp)
+	}
+}
+
+func TestMessagesSend_ShortenLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["shortenLinks"] != true {
+			t.Errorf("expected shortenLinks to be true, got %v", body["shortenLinks"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","text":"Sale: https://go.shop.example/abc","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	_, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+		To:           "+15551234567",
+		Text:         "Sale: https://shop.example.com/sale",
+		ShortenLinks: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWebhookEvent_LinkClickedData(t *testing.T) {
+	payload := `{"id":"evt_1","type":"link.clicked","created_at":"2024-06-01T00:00:00Z","api_version":"` + CurrentWebhookAPIVersion + `",` +
+		`"data":{"link_id":"lnk_1","short_url":"https://go.shop.example/abc","target_url":"https://shop.example.com/sale","message_id":"msg_1","to":"+15551234567","click_count":3,"clicked_at":"2024-06-01T00:00:00Z"}}`
+
+	event, err := Webhooks{}.ParseEvent(payload, Webhooks{}.GenerateSignature(payload, "whsec_test"), "whsec_test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := event.LinkClickedData()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data.LinkID != "lnk_1" || data.MessageID != "msg_1" || data.ClickCount != 3 {
+		t.Errorf("unexpected click data %+v", data)
+	}
+
+	event.Type = WebhookEventMessageDelivered
+	if _, err := event.LinkClickedData(); err == nil {
+		t.Error("expected an error for a different event type")
+	}
+}
@@ -0,0 +1,36 @@
+package sendly
+
+import "context"
+
+// DefaultStreamPageSize is the page size ListChan requests when
+// ListMessagesRequest.Limit is zero.
+const DefaultStreamPageSize = 100
+
+// ListChan pages through the messages matching req in a background goroutine
+// and sends them on the returned channel in order. req.Limit sets the page
+// size (default: DefaultStreamPageSize) and req.Offset where to start.
+//
+// The message channel is closed when every page has been read, a request
+// fails, or ctx is done. The error channel then receives the error, if any,
+// and is closed. Requests are paced by the client's rate limiter, and no
+// further page is fetched until the previous one has been consumed.
+func (s *MessagesService) ListChan(ctx context.Context, req *ListMessagesRequest) (<-chan Message, <-chan error) {
+	return ListMessagesChan(ctx, s, req)
+}
+
+// ListMessagesChan implements MessagesAPI.ListChan on top of messages.List,
+// so alternative MessagesAPI implementations can share it.
+func ListMessagesChan(ctx context.Context, messages MessagesAPI, req *ListMessagesRequest) (<-chan Message, <-chan error) {
+	page := ListMessagesRequest{}
+	if req != nil {
+		page = *req
+	}
+	if page.Limit <= 0 {
+		page.Limit = DefaultStreamPageSize
+	}
+
+	return PageChan(ctx, page.Offset, func(ctx context.Context, offset int) (*Page[Message], error) {
+		page.Offset = offset
+		return messages.List(ctx, &page)
+	})
+}
@@ -0,0 +1,91 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// pagedMessagesServer serves total messages from /messages using the limit
+// and offset query parameters.
+func pagedMessagesServer(t *testing.T, total int) (*httptest.Server, func() []*http.Request) {
+	return newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		resp := ListMessagesResponse{Count: total}
+		for i := offset; i < total && i < offset+limit; i++ {
+			resp.Data = append(resp.Data, Message{ID: "msg_" + strconv.Itoa(i)})
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(resp)
+	})
+}
+
+func TestMessagesListChan(t *testing.T) {
+	server, pages := pagedMessagesServer(t, 25)
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	msgs, errc := client.Messages.ListChan(context.Background(), &ListMessagesRequest{Limit: 10})
+
+	var ids []string
+	for msg := range msgs {
+		ids = append(ids, msg.ID)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(ids) != 25 {
+		t.Fatalf("expected 25 messages, got %d", len(ids))
+	}
+	for i, id := range ids {
+		if id != "msg_"+strconv.Itoa(i) {
+			t.Errorf("expected message %d to be 'msg_%d', got '%s'", i, i, id)
+		}
+	}
+	if n := len(pages()); n != 3 {
+		t.Errorf("expected 3 pages, got %d", n)
+	}
+}
+
+func TestMessagesListChan_StopsOnCancel(t *testing.T) {
+	server, pages := pagedMessagesServer(t, 1000)
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	msgs, errc := client.Messages.ListChan(ctx, &ListMessagesRequest{Limit: 10})
+
+	<-msgs
+	cancel()
+	for range msgs {
+	}
+
+	if err := <-errc; err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if n := len(pages()); n > 2 {
+		t.Errorf("expected paging to stop after cancellation, got %d pages", n)
+	}
+}
+
+func TestMessagesListChan_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"unauthorized","message":"Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	msgs, errc := client.Messages.ListChan(context.Background(), nil)
+
+	for range msgs {
+		t.Error("expected no messages")
+	}
+	if err := <-errc; !IsAuthenticationError(err) {
+		t.Errorf("expected AuthenticationError, got %T", err)
+	}
+}
@@ -2,13 +2,18 @@ package sendly
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // MessagesService handles message-related API operations.
 type MessagesService struct {
 	client *Client
+	fresh  freshIDs
 }
 
 // Send sends an SMS message.
@@ -16,30 +21,156 @@ func (s *MessagesService) Send(ctx context.Context, req *SendMessageRequest) (*M
 	if req == nil {
 		return nil, &ValidationError{APIError: APIError{Message: "request is required"}}
 	}
+	if err := s.validateSend(req); err != nil {
+		return nil, err
+	}
+
+	if req.DryRun || s.client.dryRun {
+		return s.dryRunSend(ctx, req)
+	}
+	if err := s.client.budget.check(); err != nil {
+		return nil, err
+	}
+	if s.client.dedupe != nil && !callOptionsFrom(ctx).allowDuplicate {
+		return s.sendOnce(ctx, req)
+	}
+	return s.send(ctx, req)
+}
+
+// validateSend checks a message before it is sent by Send or
+// SendTransaction.
+func (s *MessagesService) validateSend(req *SendMessageRequest) error {
 	if req.To == "" {
-		return nil, &ValidationError{APIError: APIError{Message: "to is required"}}
+		return &ValidationError{APIError: APIError{Message: "to is required"}}
 	}
 	if req.Text == "" {
-		return nil, &ValidationError{APIError: APIError{Message: "text is required"}}
+		return &ValidationError{APIError: APIError{Message: "text is required"}}
+	}
+	if len(req.ThreadKey) > MaxThreadKeyLength {
+		return &ValidationError{APIError: APIError{Message: "threadKey must be at most " + strconv.Itoa(MaxThreadKeyLength) + " bytes"}}
+	}
+	if err := ValidateMetadata(req.Metadata, s.client.metadataLimits); err != nil {
+		return err
+	}
+	if err := validateStatusCallbackURL(req.StatusCallbackURL); err != nil {
+		return err
+	}
+	return validateValidityPeriod(req.ValidityPeriod)
+}
+
+// send sends a validated message, deferring it to the send window if needed.
+func (s *MessagesService) send(ctx context.Context, req *SendMessageRequest) (*Message, error) {
+	if w := s.sendWindowFor(req); w != nil {
+		if msg, err := s.deferToWindow(ctx, req, w); msg != nil || err != nil {
+			return msg, err
+		}
 	}
 
 	var resp Message
 	err := s.client.request(ctx, "POST", "/messages", req, &resp)
+	if err != nil {
+		if s.client.degraded != nil && IsCircuitOpenError(err) && !callOptionsFrom(ctx).noDegrade {
+			return s.acceptLocally(ctx, req)
+		}
+		return nil, err
+	}
+
+	if resp.ClientID == "" {
+		resp.ClientID = req.ClientID
+	}
+	if resp.ThreadKey == "" {
+		resp.ThreadKey = req.ThreadKey
+	}
+	if resp.Metadata == nil {
+		resp.Metadata = req.Metadata
+	}
+
+	s.client.recordCredits(resp.CreditsUsed)
+	if s.client.readYourWritesWindow > 0 {
+		s.fresh.add(resp.ID, time.Now(), s.client.readYourWritesWindow)
+	}
+
+	return &resp, nil
+}
+
+// SendTransaction sends several messages with all-or-nothing semantics: either
+// every message is accepted or none is. If the API cannot honor atomicity and
+// accepts only some messages, a *PartialAcceptanceError is returned.
+//
+// Each message is validated as for Send before anything is sent. If any
+// message has DryRun set, or the client was created WithDryRun, the whole
+// transaction is a dry run. With WithDedupeWindow, a duplicate of any
+// message fails the whole transaction with a *DuplicateMessageError.
+func (s *MessagesService) SendTransaction(ctx context.Context, reqs []SendMessageRequest) (*TransactionResponse, error) {
+	if len(reqs) == 0 {
+		return nil, &ValidationError{APIError: APIError{Message: "messages are required"}}
+	}
+
+	dryRun := s.client.dryRun
+	for i := range reqs {
+		if err := s.validateSend(&reqs[i]); err != nil {
+			var verr *ValidationError
+			if errors.As(err, &verr) {
+				verr.Message += " for message at index " + strconv.Itoa(i)
+				if verr.Err != nil {
+					verr.Err = fmt.Errorf("message at index %d: %w", i, verr.Err)
+				}
+			}
+			return nil, err
+		}
+		dryRun = dryRun || reqs[i].DryRun
+	}
+
+	if dryRun {
+		return s.dryRunSendTransaction(ctx, reqs)
+	}
+	if err := s.client.budget.check(); err != nil {
+		return nil, err
+	}
+	if s.client.dedupe != nil && !callOptionsFrom(ctx).allowDuplicate {
+		return s.sendTransactionOnce(ctx, reqs)
+	}
+	return s.sendTransaction(ctx, reqs)
+}
+
+// sendTransaction sends validated messages as one transaction.
+func (s *MessagesService) sendTransaction(ctx context.Context, reqs []SendMessageRequest) (*TransactionResponse, error) {
+	var resp TransactionResponse
+	err := s.client.request(ctx, "POST", "/messages/transaction", &sendTransactionRequest{Messages: reqs}, &resp)
 	if err != nil {
 		return nil, err
 	}
 
+	// Accepted messages went out even if others were rejected, so they
+	// count against the budget and are read back consistently either way.
+	s.client.recordCredits(resp.CreditsUsed)
+	if s.client.readYourWritesWindow > 0 {
+		now := time.Now()
+		for _, msg := range resp.Messages {
+			s.fresh.add(msg.ID, now, s.client.readYourWritesWindow)
+		}
+	}
+
+	if resp.Status == TransactionStatusPartial {
+		return nil, &PartialAcceptanceError{
+			APIError: APIError{Code: "PARTIAL_ACCEPTANCE", Message: "transaction could not be applied atomically"},
+			Response: &resp,
+		}
+	}
+
 	return &resp, nil
 }
 
 // List retrieves a list of messages.
 func (s *MessagesService) List(ctx context.Context, req *ListMessagesRequest) (*ListMessagesResponse, error) {
 	params := make(map[string]string)
+	offset := 0
 
 	if req != nil {
 		if req.Limit > 0 {
 			params["limit"] = strconv.Itoa(req.Limit)
 		}
+		offset = req.Offset
 		if req.Offset > 0 {
 			params["offset"] = strconv.Itoa(req.Offset)
 		}
@@ -49,6 +180,24 @@ func (s *MessagesService) List(ctx context.Context, req *ListMessagesRequest) (*
 		if req.To != "" {
 			params["to"] = req.To
 		}
+		if req.From != "" {
+			params["from"] = req.From
+		}
+		if req.ThreadKey != "" {
+			params["threadKey"] = req.ThreadKey
+		}
+		if req.BatchID != "" {
+			params["batch_id"] = req.BatchID
+		}
+		if !req.CreatedAfter.IsZero() && !req.CreatedBefore.IsZero() && !req.CreatedAfter.Before(req.CreatedBefore) {
+			return nil, &ValidationError{APIError: APIError{Message: "createdAfter must be before createdBefore"}}
+		}
+		if !req.CreatedAfter.IsZero() {
+			params["createdAfter"] = req.CreatedAfter.UTC().Format(time.RFC3339Nano)
+		}
+		if !req.CreatedBefore.IsZero() {
+			params["createdBefore"] = req.CreatedBefore.UTC().Format(time.RFC3339Nano)
+		}
 	}
 
 	path := "/messages" + buildQueryString(params)
@@ -59,6 +208,7 @@ func (s *MessagesService) List(ctx context.Context, req *ListMessagesRequest) (*
 		return nil, err
 	}
 
+	resp.fill(offset)
 	return &resp, nil
 }
 
@@ -72,7 +222,9 @@ func (s *MessagesService) Get(ctx context.Context, id string) (*Message, error)
 	path := "/messages/" + url.PathEscape(id)
 
 	var resp Message
-	err := s.client.request(ctx, "GET", path, nil, &resp)
+	err := s.retryFresh(ctx, id, func() error {
+		return s.client.request(ctx, "GET", path, nil, &resp)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +232,9 @@ func (s *MessagesService) Get(ctx context.Context, id string) (*Message, error)
 	return &resp, nil
 }
 
-// Schedule schedules an SMS message for future delivery.
+// Schedule schedules an SMS message for future delivery. A send time in the
+// past or more than MaxScheduleHorizon ahead fails with a *ScheduleTimeError
+// without contacting the API.
 func (s *MessagesService) Schedule(ctx context.Context, req *ScheduleMessageRequest) (*ScheduledMessage, error) {
 	if req == nil {
 		return nil, &ValidationError{APIError: APIError{Message: "request is required"}}
@@ -91,27 +245,55 @@ func (s *MessagesService) Schedule(ctx context.Context, req *ScheduleMessageRequ
 	if req.Text == "" {
 		return nil, &ValidationError{APIError: APIError{Message: "text is required"}}
 	}
-	if req.ScheduledAt == "" {
-		return nil, &ValidationError{APIError: APIError{Message: "scheduledAt is required"}}
+	if err := ValidateMetadata(req.Metadata, s.client.metadataLimits); err != nil {
+		return nil, err
+	}
+	if err := validateStatusCallbackURL(req.StatusCallbackURL); err != nil {
+		return nil, err
+	}
+	req, err := req.resolveSendTime(time.Now())
+	if err != nil {
+		return nil, err
 	}
+	if w := s.windowFor(req.MessageType); w != nil {
+		if err := req.fitToWindow(w); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.DryRun || s.client.dryRun {
+		return s.dryRunSchedule(ctx, req)
+	}
+	if err := s.client.budget.check(); err != nil {
+		return nil, err
+	}
+	return s.schedule(ctx, req)
+}
 
+// schedule creates a validated scheduled message. The credits it reserves
+// count as spent.
+func (s *MessagesService) schedule(ctx context.Context, req *ScheduleMessageRequest) (*ScheduledMessage, error) {
 	var resp ScheduledMessage
 	err := s.client.request(ctx, "POST", "/messages/schedule", req, &resp)
 	if err != nil {
 		return nil, err
 	}
 
+	s.client.recordCredits(resp.CreditsReserved)
+
 	return &resp, nil
 }
 
 // ListScheduled retrieves a list of scheduled messages.
 func (s *MessagesService) ListScheduled(ctx context.Context, req *ListScheduledMessagesRequest) (*ListScheduledMessagesResponse, error) {
 	params := make(map[string]string)
+	offset := 0
 
 	if req != nil {
 		if req.Limit > 0 {
 			params["limit"] = strconv.Itoa(req.Limit)
 		}
+		offset = req.Offset
 		if req.Offset > 0 {
 			params["offset"] = strconv.Itoa(req.Offset)
 		}
@@ -128,6 +310,7 @@ func (s *MessagesService) ListScheduled(ctx context.Context, req *ListScheduledM
 		return nil, err
 	}
 
+	resp.fill(offset)
 	return &resp, nil
 }
 
@@ -174,14 +357,24 @@ func (s *MessagesService) SendBatch(ctx context.Context, req *SendBatchRequest)
 		return nil, &ValidationError{APIError: APIError{Message: "messages are required"}}
 	}
 
-	// Validate each message
-	for i, msg := range req.Messages {
-		if msg.To == "" {
-			return nil, &ValidationError{APIError: APIError{Message: "to is required for message at index " + strconv.Itoa(i)}}
-		}
-		if msg.Text == "" {
-			return nil, &ValidationError{APIError: APIError{Message: "text is required for message at index " + strconv.Itoa(i)}}
-		}
+	if err := validateBatchItems(req.Messages, req.TemplateID); err != nil {
+		return nil, err
+	}
+	if err := validateBatchMetadata(req.Messages, s.client.metadataLimits); err != nil {
+		return nil, err
+	}
+	if err := validateStatusCallbackURL(req.StatusCallbackURL); err != nil {
+		return nil, err
+	}
+
+	if req.DryRun || s.client.dryRun {
+		return s.dryRunSendBatch(ctx, req)
+	}
+	if err := s.checkSendWindow(req.MessageType); err != nil {
+		return nil, err
+	}
+	if err := s.client.budget.check(); err != nil {
+		return nil, err
 	}
 
 	var resp BatchMessageResponse
@@ -190,9 +383,26 @@ func (s *MessagesService) SendBatch(ctx context.Context, req *SendBatchRequest)
 		return nil, err
 	}
 
+	s.rememberBatch(&resp)
+
 	return &resp, nil
 }
 
+// rememberBatch records a just-sent batch's credits and, for
+// read-your-writes, its message IDs.
+func (s *MessagesService) rememberBatch(resp *BatchMessageResponse) {
+	s.client.recordCredits(resp.CreditsUsed)
+	if s.client.readYourWritesWindow <= 0 {
+		return
+	}
+	now := time.Now()
+	for _, result := range resp.Messages {
+		if result.MessageID != nil {
+			s.fresh.add(*result.MessageID, now, s.client.readYourWritesWindow)
+		}
+	}
+}
+
 // GetBatch retrieves the status of a batch by ID.
 func (s *MessagesService) GetBatch(ctx context.Context, batchID string) (*BatchMessageResponse, error) {
 	if batchID == "" {
@@ -213,17 +423,25 @@ func (s *MessagesService) GetBatch(ctx context.Context, batchID string) (*BatchM
 // ListBatches retrieves a list of batches.
 func (s *MessagesService) ListBatches(ctx context.Context, req *ListBatchesRequest) (*ListBatchesResponse, error) {
 	params := make(map[string]string)
+	offset := 0
 
 	if req != nil {
 		if req.Limit > 0 {
 			params["limit"] = strconv.Itoa(req.Limit)
 		}
+		offset = req.Offset
 		if req.Offset > 0 {
 			params["offset"] = strconv.Itoa(req.Offset)
 		}
 		if req.Status != "" {
 			params["status"] = string(req.Status)
 		}
+		if req.CreatedAfter != "" {
+			params["createdAfter"] = req.CreatedAfter
+		}
+		if req.CreatedBefore != "" {
+			params["createdBefore"] = req.CreatedBefore
+		}
 	}
 
 	path := "/messages/batches" + buildQueryString(params)
@@ -234,9 +452,114 @@ func (s *MessagesService) ListBatches(ctx context.Context, req *ListBatchesReque
 		return nil, err
 	}
 
+	resp.fill(offset)
 	return &resp, nil
 }
 
+// MaxListOffset is the deepest offset the API accepts when paginating lists.
+const MaxListOffset = 10000
+
+// ListBatchesByDate lists every batch created in a date range. The range is
+// partitioned into date windows, and any window holding more batches than can
+// be paged through within MaxListOffset is split in half until it fits, so
+// exports of large histories don't fail with offset errors. Results are
+// grouped by window, oldest window first. If more than MaxListOffset batches
+// were created within one second, they cannot all be listed and an error is
+// returned rather than a truncated list.
+func (s *MessagesService) ListBatchesByDate(ctx context.Context, req *ListBatchesByDateRequest) ([]BatchMessageResponse, error) {
+	if req == nil || req.Start.IsZero() {
+		return nil, &ValidationError{APIError: APIError{Message: "start is required"}}
+	}
+
+	end := req.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if !end.After(req.Start) {
+		return nil, &ValidationError{APIError: APIError{Message: "end must be after start"}}
+	}
+
+	window := req.Window
+	if window <= 0 {
+		window = 24 * time.Hour
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 100
+	}
+
+	l := &batchLister{service: s, status: req.Status, pageSize: pageSize, seen: make(map[string]bool)}
+	for from := req.Start; from.Before(end); from = from.Add(window) {
+		to := from.Add(window)
+		if to.After(end) {
+			to = end
+		}
+		if err := l.list(ctx, from, to); err != nil {
+			return nil, err
+		}
+	}
+
+	return l.batches, nil
+}
+
+// batchLister collects batches for ListBatchesByDate.
+type batchLister struct {
+	service  *MessagesService
+	status   BatchStatus
+	pageSize int
+	seen     map[string]bool
+	batches  []BatchMessageResponse
+}
+
+// list pages through one date window, splitting it if it is too deep.
+func (l *batchLister) list(ctx context.Context, from, to time.Time) error {
+	page := &ListBatchesRequest{
+		Limit:         l.pageSize,
+		Status:        l.status,
+		CreatedAfter:  from.UTC().Format(time.RFC3339Nano),
+		CreatedBefore: to.UTC().Format(time.RFC3339Nano),
+	}
+
+	resp, err := l.service.ListBatches(ctx, page)
+	if err != nil {
+		return err
+	}
+
+	if resp.Count > MaxListOffset {
+		if to.Sub(from) <= time.Second {
+			return fmt.Errorf("sendly: %d batches created between %s and %s, more than can be paged through (%d); narrow the filter",
+				resp.Count, page.CreatedAfter, page.CreatedBefore, MaxListOffset)
+		}
+		mid := from.Add(to.Sub(from) / 2)
+		if err := l.list(ctx, from, mid); err != nil {
+			return err
+		}
+		return l.list(ctx, mid, to)
+	}
+
+	for {
+		l.add(resp.Data)
+		page.Offset += l.pageSize
+		if len(resp.Data) == 0 || page.Offset >= resp.Count || page.Offset > MaxListOffset {
+			return nil
+		}
+		if resp, err = l.service.ListBatches(ctx, page); err != nil {
+			return err
+		}
+	}
+}
+
+// add appends batches not already collected from a neighbouring window.
+func (l *batchLister) add(batches []BatchMessageResponse) {
+	for _, b := range batches {
+		if l.seen[b.BatchID] {
+			continue
+		}
+		l.seen[b.BatchID] = true
+		l.batches = append(l.batches, b)
+	}
+}
+
 // PreviewBatch previews a batch without sending (dry run).
 func (s *MessagesService) PreviewBatch(ctx context.Context, req *SendBatchRequest) (*BatchPreviewResponse, error) {
 	if req == nil {
@@ -246,14 +569,14 @@ func (s *MessagesService) PreviewBatch(ctx context.Context, req *SendBatchReques
 		return nil, &ValidationError{APIError: APIError{Message: "messages are required"}}
 	}
 
-	// Validate each message
-	for i, msg := range req.Messages {
-		if msg.To == "" {
-			return nil, &ValidationError{APIError: APIError{Message: "to is required for message at index " + strconv.Itoa(i)}}
-		}
-		if msg.Text == "" {
-			return nil, &ValidationError{APIError: APIError{Message: "text is required for message at index " + strconv.Itoa(i)}}
-		}
+	if err := validateBatchItems(req.Messages, req.TemplateID); err != nil {
+		return nil, err
+	}
+	if err := validateBatchMetadata(req.Messages, s.client.metadataLimits); err != nil {
+		return nil, err
+	}
+	if err := validateStatusCallbackURL(req.StatusCallbackURL); err != nil {
+		return nil, err
 	}
 
 	var resp BatchPreviewResponse
@@ -261,6 +584,134 @@ func (s *MessagesService) PreviewBatch(ctx context.Context, req *SendBatchReques
 	if err != nil {
 		return nil, err
 	}
+	if resp.ByCountry == nil {
+		resp.ByCountry = resp.CostByCountry()
+	}
 
 	return &resp, nil
 }
+
+// CreateBatchDraft creates a draft batch that can be filled across several
+// AddItemsToDraft calls and sent with LaunchDraft.
+func (s *MessagesService) CreateBatchDraft(ctx context.Context, req *CreateBatchDraftRequest) (*BatchDraft, error) {
+	if req == nil {
+		req = &CreateBatchDraftRequest{}
+	}
+	if err := validateBatchItems(req.Messages, ""); err != nil {
+		return nil, err
+	}
+	if err := validateBatchMetadata(req.Messages, s.client.metadataLimits); err != nil {
+		return nil, err
+	}
+
+	var resp BatchDraft
+	err := s.client.request(ctx, "POST", "/messages/batch/drafts", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// AddItemsToDraft appends items to a draft batch.
+func (s *MessagesService) AddItemsToDraft(ctx context.Context, draftID string, items []BatchMessageItem) (*BatchDraft, error) {
+	if draftID == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "draft ID is required"}}
+	}
+	if len(items) == 0 {
+		return nil, &ValidationError{APIError: APIError{Message: "messages are required"}}
+	}
+	if err := validateBatchItems(items, ""); err != nil {
+		return nil, err
+	}
+	if err := validateBatchMetadata(items, s.client.metadataLimits); err != nil {
+		return nil, err
+	}
+
+	path := "/messages/batch/drafts/" + url.PathEscape(draftID) + "/items"
+
+	var resp BatchDraft
+	err := s.client.request(ctx, "POST", path, &addDraftItemsRequest{Messages: items}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// LaunchDraft sends every item in a draft batch as a single batch. It fails
+// on a WithDryRun client, as a draft cannot be priced without launching it.
+func (s *MessagesService) LaunchDraft(ctx context.Context, draftID string) (*BatchMessageResponse, error) {
+	if draftID == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "draft ID is required"}}
+	}
+	if s.client.dryRun {
+		return nil, errDryRunUnsupported("LaunchDraft")
+	}
+
+	if err := s.checkSendWindow(MessageTypeMarketing); err != nil {
+		return nil, err
+	}
+	if err := s.client.budget.check(); err != nil {
+		return nil, err
+	}
+
+	path := "/messages/batch/drafts/" + url.PathEscape(draftID) + "/launch"
+
+	var resp BatchMessageResponse
+	err := s.client.request(ctx, "POST", path, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rememberBatch(&resp)
+
+	return &resp, nil
+}
+
+// validateBatchItems checks that every batch item has a recipient, text or
+// template variables but not both, and an HTTPS status callback URL if it has
+// one. templateID is the batch's SendBatchRequest.TemplateID, if any.
+func validateBatchItems(items []BatchMessageItem, templateID string) error {
+	for i, msg := range items {
+		if msg.To == "" {
+			return &ValidationError{APIError: APIError{Message: "to is required for message at index " + strconv.Itoa(i)}}
+		}
+		switch {
+		case templateID == "" && msg.Text == "":
+			return &ValidationError{APIError: APIError{Message: "text is required for message at index " + strconv.Itoa(i)}}
+		case templateID == "" && len(msg.Variables) > 0:
+			return &ValidationError{APIError: APIError{Message: "variables require templateId for message at index " + strconv.Itoa(i)}}
+		case templateID != "" && msg.Text != "":
+			return &ValidationError{APIError: APIError{Message: "text cannot be combined with templateId for message at index " + strconv.Itoa(i)}}
+		}
+		if msg.StatusCallbackURL != "" && !strings.HasPrefix(msg.StatusCallbackURL, "https://") {
+			return &ValidationError{APIError: APIError{Message: "statusCallbackUrl must be HTTPS for message at index " + strconv.Itoa(i)}}
+		}
+	}
+	return nil
+}
+
+// validateStatusCallbackURL checks that a status callback URL, if set, is HTTPS.
+func validateStatusCallbackURL(u string) error {
+	if u != "" && !strings.HasPrefix(u, "https://") {
+		return &ValidationError{APIError: APIError{Message: "statusCallbackUrl must be HTTPS"}}
+	}
+	return nil
+}
+
+// validateBatchMetadata checks every batch item's metadata against limits,
+// naming the index of the first offending item.
+func validateBatchMetadata(items []BatchMessageItem, limits MetadataLimits) error {
+	for i, msg := range items {
+		if err := ValidateMetadata(msg.Metadata, limits); err != nil {
+			var verr *ValidationError
+			if errors.As(err, &verr) {
+				verr.Message = "message at index " + strconv.Itoa(i) + ": " + verr.Message
+				verr.Err = fmt.Errorf("message at index %d: %w", i, verr.Err)
+			}
+			return err
+		}
+	}
+	return nil
+}
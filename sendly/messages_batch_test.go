@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestMessagesSendBatch_Success(t *testing.T) {
@@ -138,6 +140,76 @@ func TestMessagesSendBatch_ValidationErrors(t *testing.T) {
 	}
 }
 
+func TestMessagesSendBatch_Template(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		if body["templateId"] != "tpl_welcome" {
+			t.Errorf("expected templateId 'tpl_welcome', got %v", body["templateId"])
+		}
+		item := body["messages"].([]any)[0].(map[string]any)
+		if _, ok := item["text"]; ok {
+			t.Error("expected text to be omitted for a templated item")
+		}
+		if vars := item["variables"].(map[string]any); vars["name"] != "Ada" {
+			t.Errorf("unexpected variables %v", vars)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"batchId":"batch_1","status":"processing","total":2,"queued":2}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	_, err := client.Messages.SendBatch(context.Background(), &SendBatchRequest{
+		TemplateID: "tpl_welcome",
+		Messages: []BatchMessageItem{
+			{To: "+15551234567", Variables: map[string]string{"name": "Ada"}},
+			{To: "+15557654321", Variables: map[string]string{"name": "Grace"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMessagesSendBatch_TemplateValidation(t *testing.T) {
+	client := NewClient("test-api-key")
+
+	tests := []struct {
+		name string
+		req  *SendBatchRequest
+		want string
+	}{
+		{
+			name: "text with template",
+			req: &SendBatchRequest{TemplateID: "tpl_welcome", Messages: []BatchMessageItem{
+				{To: "+15551234567", Text: "Hi", Variables: map[string]string{"name": "Ada"}},
+			}},
+			want: "text cannot be combined with templateId",
+		},
+		{
+			name: "variables without template",
+			req: &SendBatchRequest{Messages: []BatchMessageItem{
+				{To: "+15551234567", Text: "Hi", Variables: map[string]string{"name": "Ada"}},
+			}},
+			want: "variables require templateId",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Messages.SendBatch(context.Background(), tt.req)
+			if !IsValidationError(err) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected ValidationError containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
 func TestMessagesSendBatch_InvalidPhoneFormat(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
@@ -628,3 +700,147 @@ func TestMessagesListBatches_ServerError(t *testing.T) {
 		t.Errorf("expected status code 500, got %d", sendlyErr.StatusCode)
 	}
 }
+
+func TestMessagesListBatchesByDate_SplitsDeepWindows(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 25,000 batches one second apart, all within a single day.
+	const total = 25000
+	created := make([]time.Time, total)
+	for i := range created {
+		created[i] = start.Add(time.Duration(i) * time.Second)
+	}
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		q := r.URL.Query()
+		after, _ := time.Parse(time.RFC3339Nano, q.Get("createdAfter"))
+		before, _ := time.Parse(time.RFC3339Nano, q.Get("createdBefore"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+
+		if offset > MaxListOffset {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_request","message":"offset too large"}`))
+			return
+		}
+
+		var matched []BatchMessageResponse
+		for i, at := range created {
+			if !at.Before(after) && at.Before(before) {
+				matched = append(matched, BatchMessageResponse{BatchID: "batch_" + strconv.Itoa(i)})
+			}
+		}
+		end := offset + limit
+		if end > len(matched) {
+			end = len(matched)
+		}
+		if offset > end {
+			offset = end
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(ListBatchesResponse{Data: matched[offset:end], Count: len(matched)})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithoutRateLimit())
+
+	batches, err := client.Messages.ListBatchesByDate(context.Background(), &ListBatchesByDateRequest{
+		Start: start,
+		End:   start.Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(batches) != total {
+		t.Errorf("expected %d batches, got %d", total, len(batches))
+	}
+	seen := make(map[string]bool)
+	for _, b := range batches {
+		if seen[b.BatchID] {
+			t.Fatalf("batch %s returned twice", b.BatchID)
+		}
+		seen[b.BatchID] = true
+	}
+}
+
+func TestMessagesListBatchesByDate_TooDeepToSplit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(ListBatchesResponse{
+			Data:  []BatchMessageResponse{{BatchID: "batch_1"}},
+			Count: MaxListOffset + 1,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithoutRateLimit())
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := client.Messages.ListBatchesByDate(context.Background(), &ListBatchesByDateRequest{
+		Start: start,
+		End:   start.Add(time.Second),
+	})
+	if err == nil {
+		t.Fatal("expected an error instead of a truncated list")
+	}
+	for _, want := range []string{"10001 batches", "2023-01-01T00:00:00Z", "2023-01-01T00:00:01Z"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %q", want, err.Error())
+		}
+	}
+}
+
+func TestMessagesListBatchesByDate_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		req  *ListBatchesByDateRequest
+	}{
+		{"nil request", nil},
+		{"missing start", &ListBatchesByDateRequest{End: start}},
+		{"end before start", &ListBatchesByDateRequest{Start: start, End: start.Add(-time.Hour)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Messages.ListBatchesByDate(context.Background(), tt.req)
+			if !IsValidationError(err) {
+				t.Errorf("expected ValidationError, got %T", err)
+			}
+		})
+	}
+}
+
+func TestMessagesList_BatchID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("batch_id"); got != "batch_123" {
+			t.Errorf("expected batch_id to be 'batch_123', got '%s'", got)
+		}
+		if got := query.Get("status"); got != "failed" {
+			t.Errorf("expected status to be 'failed', got '%s'", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"msg_1","to":"+15551234567","status":"failed","batchId":"batch_123"}],"count":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.Messages.List(context.Background(), &ListMessagesRequest{
+		BatchID: "batch_123",
+		Status:  MessageStatusFailed,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].BatchID != "batch_123" {
+		t.Errorf("expected one message from batch_123, got %+v", resp.Data)
+	}
+}
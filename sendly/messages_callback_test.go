@@ -0,0 +1,95 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMessagesSend_StatusCallbackURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SendMessageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.StatusCallbackURL != "https://tenant.example.com/hooks" {
+			t.Errorf("expected StatusCallbackURL to be 'https://tenant.example.com/hooks', got '%s'", req.StatusCallbackURL)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_123","status":"queued","statusCallbackUrl":"https://tenant.example.com/hooks"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	msg, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+		To:                "+15551234567",
+		Text:              "Hello",
+		StatusCallbackURL: "https://tenant.example.com/hooks",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.StatusCallbackURL != "https://tenant.example.com/hooks" {
+		t.Errorf("expected message StatusCallbackURL to be set, got '%s'", msg.StatusCallbackURL)
+	}
+}
+
+func TestMessagesSend_StatusCallbackURLMustBeHTTPS(t *testing.T) {
+	client := NewClient("test-api-key")
+	_, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+		To:                "+15551234567",
+		Text:              "Hello",
+		StatusCallbackURL: "http://tenant.example.com/hooks",
+	})
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+
+	_, err = client.Messages.Schedule(context.Background(), &ScheduleMessageRequest{
+		To:                "+15551234567",
+		Text:              "Hello",
+		ScheduledAt:       scheduledAtFixture,
+		StatusCallbackURL: "http://tenant.example.com/hooks",
+	})
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError for schedule, got %T", err)
+	}
+}
+
+func TestMessagesSendBatch_StatusCallbackURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SendBatchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.StatusCallbackURL != "https://a.example.com/hooks" {
+			t.Errorf("expected batch StatusCallbackURL to be 'https://a.example.com/hooks', got '%s'", req.StatusCallbackURL)
+		}
+		if req.Messages[1].StatusCallbackURL != "https://b.example.com/hooks" {
+			t.Errorf("expected item StatusCallbackURL to be 'https://b.example.com/hooks', got '%s'", req.Messages[1].StatusCallbackURL)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"batchId":"batch_1","status":"processing"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	_, err := client.Messages.SendBatch(context.Background(), &SendBatchRequest{
+		Messages: []BatchMessageItem{
+			{To: "+15551234567", Text: "Hi"},
+			{To: "+15551234568", Text: "Hi", StatusCallbackURL: "https://b.example.com/hooks"},
+		},
+		StatusCallbackURL: "https://a.example.com/hooks",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.Messages.SendBatch(context.Background(), &SendBatchRequest{
+		Messages: []BatchMessageItem{{To: "+15551234567", Text: "Hi", StatusCallbackURL: "ftp://x"}},
+	})
+	if !IsValidationError(err) || !strings.Contains(err.Error(), "index 0") {
+		t.Errorf("expected ValidationError naming index 0, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMessagesBatchDraft_Lifecycle(t *testing.T) {
+	items := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST request, got %s", r.Method)
+		}
+
+		switch r.URL.Path {
+		case "/messages/batch/drafts":
+			var req CreateBatchDraftRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			items += len(req.Messages)
+			json.NewEncoder(w).Encode(BatchDraft{ID: "draft_123", Status: BatchDraftStatusDraft, ItemCount: items})
+		case "/messages/batch/drafts/draft_123/items":
+			var req addDraftItemsRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			items += len(req.Messages)
+			json.NewEncoder(w).Encode(BatchDraft{ID: "draft_123", Status: BatchDraftStatusDraft, ItemCount: items})
+		case "/messages/batch/drafts/draft_123/launch":
+			json.NewEncoder(w).Encode(BatchMessageResponse{
+				BatchID: "batch_123",
+				Status:  BatchStatusProcessing,
+				Total:   items,
+				Queued:  items,
+			})
+		default:
+			t.Errorf("unexpected path '%s'", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	draft, err := client.Messages.CreateBatchDraft(ctx, &CreateBatchDraftRequest{
+		Messages: []BatchMessageItem{{To: "+15551234567", Text: "One"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if draft.Status != BatchDraftStatusDraft {
+		t.Errorf("expected Status to be 'draft', got '%s'", draft.Status)
+	}
+
+	draft, err = client.Messages.AddItemsToDraft(ctx, draft.ID, []BatchMessageItem{
+		{To: "+15551234568", Text: "Two"},
+		{To: "+15551234569", Text: "Three"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if draft.ItemCount != 3 {
+		t.Errorf("expected ItemCount to be 3, got %d", draft.ItemCount)
+	}
+
+	batch, err := client.Messages.LaunchDraft(ctx, draft.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batch.BatchID != "batch_123" {
+		t.Errorf("expected BatchID to be 'batch_123', got '%s'", batch.BatchID)
+	}
+	if batch.Total != 3 {
+		t.Errorf("expected Total to be 3, got %d", batch.Total)
+	}
+}
+
+func TestMessagesBatchDraft_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"add without draft ID", func() error {
+			_, err := client.Messages.AddItemsToDraft(ctx, "", []BatchMessageItem{{To: "+15551234567", Text: "Hi"}})
+			return err
+		}},
+		{"add without items", func() error {
+			_, err := client.Messages.AddItemsToDraft(ctx, "draft_123", nil)
+			return err
+		}},
+		{"add item without text", func() error {
+			_, err := client.Messages.AddItemsToDraft(ctx, "draft_123", []BatchMessageItem{{To: "+15551234567"}})
+			return err
+		}},
+		{"launch without draft ID", func() error {
+			_, err := client.Messages.LaunchDraft(ctx, "")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !IsValidationError(err) {
+				t.Errorf("expected ValidationError, got %T", err)
+			}
+		})
+	}
+}
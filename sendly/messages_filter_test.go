@@ -0,0 +1,52 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMessagesList_DateRangeAndSender(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("createdAfter"); got != "2025-01-06T00:00:00Z" {
+			t.Errorf("expected createdAfter to be '2025-01-06T00:00:00Z', got '%s'", got)
+		}
+		if got := q.Get("createdBefore"); got != "2025-01-13T00:00:00Z" {
+			t.Errorf("expected createdBefore to be '2025-01-13T00:00:00Z', got '%s'", got)
+		}
+		if got := q.Get("from"); got != "ACME" {
+			t.Errorf("expected from to be 'ACME', got '%s'", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	// Times are sent in UTC whatever their location.
+	est := time.FixedZone("EST", -5*60*60)
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	_, err := client.Messages.List(context.Background(), &ListMessagesRequest{
+		From:          "ACME",
+		CreatedAfter:  time.Date(2025, 1, 5, 19, 0, 0, 0, est),
+		CreatedBefore: time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMessagesList_InvertedDateRange(t *testing.T) {
+	client := NewClient("test-api-key")
+	now := time.Now()
+	_, err := client.Messages.List(context.Background(), &ListMessagesRequest{
+		CreatedAfter:  now,
+		CreatedBefore: now.Add(-time.Hour),
+	})
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
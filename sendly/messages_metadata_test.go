@@ -0,0 +1,110 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMessagesSend_Metadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SendMessageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Metadata["orderId"] != "ord_42" {
+			t.Errorf("expected metadata orderId to be 'ord_42', got '%v'", req.Metadata["orderId"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_123","to":"+15551234567","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	msg, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+		To:       "+15551234567",
+		Text:     "Your order has shipped",
+		Metadata: map[string]interface{}{"orderId": "ord_42"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The response omits metadata, so the request's is echoed.
+	if msg.Metadata["orderId"] != "ord_42" {
+		t.Errorf("expected message metadata orderId to be 'ord_42', got '%v'", msg.Metadata["orderId"])
+	}
+}
+
+func TestMessagesSend_InvalidMetadata(t *testing.T) {
+	client := NewClient("test-api-key")
+	_, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+		To:       "+15551234567",
+		Text:     "Hello",
+		Metadata: map[string]interface{}{"bad key": "x"},
+	})
+
+	var metaErr *MetadataError
+	if !errors.As(err, &metaErr) {
+		t.Fatalf("expected MetadataError, got %T", err)
+	}
+}
+
+func TestMessagesSchedule_Metadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ScheduleMessageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Metadata["userId"] != "u_7" {
+			t.Errorf("expected metadata userId to be 'u_7', got '%v'", req.Metadata["userId"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"sched_1","status":"scheduled","metadata":{"userId":"u_7"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	scheduled, err := client.Messages.Schedule(context.Background(), &ScheduleMessageRequest{
+		To:          "+15551234567",
+		Text:        "Reminder",
+		ScheduledAt: scheduledAtFixture,
+		Metadata:    map[string]interface{}{"userId": "u_7"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scheduled.Metadata["userId"] != "u_7" {
+		t.Errorf("expected scheduled metadata userId to be 'u_7', got '%v'", scheduled.Metadata["userId"])
+	}
+}
+
+func TestMessagesSendBatch_InvalidItemMetadata(t *testing.T) {
+	client := NewClient("test-api-key")
+	_, err := client.Messages.SendBatch(context.Background(), &SendBatchRequest{
+		Messages: []BatchMessageItem{
+			{To: "+15551234567", Text: "Hi"},
+			{To: "+15551234568", Text: "Hi", Metadata: map[string]interface{}{"bad key": "x"}},
+		},
+	})
+
+	if !IsValidationError(err) {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+	if !strings.Contains(err.Error(), "index 1") {
+		t.Errorf("expected error to name index 1, got '%s'", err.Error())
+	}
+}
+
+func TestWebhookEvent_Metadata(t *testing.T) {
+	payload := `{"id":"evt_1","type":"message.delivered","data":{"message_id":"msg_1","status":"delivered","metadata":{"orderId":"ord_42"}}}`
+
+	var event WebhookEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Data.Metadata["orderId"] != "ord_42" {
+		t.Errorf("expected event metadata orderId to be 'ord_42', got '%v'", event.Data.Metadata["orderId"])
+	}
+}
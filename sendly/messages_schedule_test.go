@@ -3,12 +3,17 @@ package sendly
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
+// scheduledAtFixture is a send time within MaxScheduleHorizon.
+var scheduledAtFixture = time.Now().Add(7 * 24 * time.Hour).UTC().Format(time.RFC3339)
+
 func TestMessagesSchedule_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "POST" {
@@ -29,8 +34,8 @@ func TestMessagesSchedule_Success(t *testing.T) {
 		if req.Text != "Scheduled message" {
 			t.Errorf("expected Text to be 'Scheduled message', got '%s'", req.Text)
 		}
-		if req.ScheduledAt != "2024-12-31T23:59:59Z" {
-			t.Errorf("expected ScheduledAt to be '2024-12-31T23:59:59Z', got '%s'", req.ScheduledAt)
+		if req.ScheduledAt != scheduledAtFixture {
+			t.Errorf("expected ScheduledAt to be '%s', got '%s'", scheduledAtFixture, req.ScheduledAt)
 		}
 
 		resp := ScheduledMessage{
@@ -54,7 +59,7 @@ func TestMessagesSchedule_Success(t *testing.T) {
 	msg, err := client.Messages.Schedule(ctx, &ScheduleMessageRequest{
 		To:          "+1234567890",
 		Text:        "Scheduled message",
-		ScheduledAt: "2024-12-31T23:59:59Z",
+		ScheduledAt: scheduledAtFixture,
 	})
 
 	if err != nil {
@@ -96,7 +101,7 @@ func TestMessagesSchedule_ValidationErrors(t *testing.T) {
 			req: &ScheduleMessageRequest{
 				To:          "",
 				Text:        "Test",
-				ScheduledAt: "2024-12-31T23:59:59Z",
+				ScheduledAt: scheduledAtFixture,
 			},
 			expectedErr: "to is required",
 		},
@@ -105,7 +110,7 @@ func TestMessagesSchedule_ValidationErrors(t *testing.T) {
 			req: &ScheduleMessageRequest{
 				To:          "+1234567890",
 				Text:        "",
-				ScheduledAt: "2024-12-31T23:59:59Z",
+				ScheduledAt: scheduledAtFixture,
 			},
 			expectedErr: "text is required",
 		},
@@ -152,7 +157,7 @@ func TestMessagesSchedule_InvalidPhoneFormat(t *testing.T) {
 	_, err := client.Messages.Schedule(ctx, &ScheduleMessageRequest{
 		To:          "invalid-phone",
 		Text:        "Test message",
-		ScheduledAt: "2024-12-31T23:59:59Z",
+		ScheduledAt: scheduledAtFixture,
 	})
 
 	if err == nil {
@@ -179,7 +184,7 @@ func TestMessagesSchedule_AuthenticationError(t *testing.T) {
 	_, err := client.Messages.Schedule(ctx, &ScheduleMessageRequest{
 		To:          "+1234567890",
 		Text:        "Test message",
-		ScheduledAt: "2024-12-31T23:59:59Z",
+		ScheduledAt: scheduledAtFixture,
 	})
 
 	if err == nil {
@@ -206,7 +211,7 @@ func TestMessagesSchedule_InsufficientCredits(t *testing.T) {
 	_, err := client.Messages.Schedule(ctx, &ScheduleMessageRequest{
 		To:          "+1234567890",
 		Text:        "Test message",
-		ScheduledAt: "2024-12-31T23:59:59Z",
+		ScheduledAt: scheduledAtFixture,
 	})
 
 	if err == nil {
@@ -582,3 +587,86 @@ func TestMessagesCancelScheduled_ServerError(t *testing.T) {
 		t.Errorf("expected status code 500, got %d", sendlyErr.StatusCode)
 	}
 }
+
+func TestMessagesSchedule_SendTimeOutOfRange(t *testing.T) {
+	client := NewClient("test-api-key", WithBaseURL("http://127.0.0.1:0"))
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		at     time.Time
+		inPast bool
+	}{
+		{"past", time.Now().Add(-time.Minute), true},
+		{"beyond horizon", time.Now().Add(MaxScheduleHorizon + time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Messages.Schedule(ctx, &ScheduleMessageRequest{
+				To:     "+1234567890",
+				Text:   "Test",
+				SendAt: tt.at,
+			})
+			var schedErr *ScheduleTimeError
+			if !errors.As(err, &schedErr) {
+				t.Fatalf("expected ScheduleTimeError, got %v", err)
+			}
+			if schedErr.InPast != tt.inPast {
+				t.Errorf("expected InPast %v, got %v", tt.inPast, schedErr.InPast)
+			}
+			if !errors.Is(err, ErrInvalidScheduleTime) {
+				t.Error("expected error to match ErrInvalidScheduleTime")
+			}
+		})
+	}
+}
+
+func TestMessagesSchedule_SendAt(t *testing.T) {
+	at := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ScheduleMessageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if want := at.UTC().Format(time.RFC3339); req.ScheduledAt != want {
+			t.Errorf("expected ScheduledAt to be '%s', got '%s'", want, req.ScheduledAt)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"sched_123","status":"scheduled"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	_, err := client.Messages.Schedule(context.Background(), &ScheduleMessageRequest{
+		To:     "+1234567890",
+		Text:   "Scheduled message",
+		SendAt: at,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.Messages.Schedule(context.Background(), &ScheduleMessageRequest{
+		To:          "+1234567890",
+		Text:        "Scheduled message",
+		SendAt:      at,
+		ScheduledAt: scheduledAtFixture,
+	})
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError when both are set, got %v", err)
+	}
+}
+
+func TestMessagesSchedule_MalformedScheduledAt(t *testing.T) {
+	client := NewClient("test-api-key")
+	_, err := client.Messages.Schedule(context.Background(), &ScheduleMessageRequest{
+		To:          "+1234567890",
+		Text:        "Test",
+		ScheduledAt: "tomorrow",
+	})
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMessagesSend_ThreadKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SendMessageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.ThreadKey != "order-123" {
+			t.Errorf("expected ThreadKey to be 'order-123', got '%s'", req.ThreadKey)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_123","to":"+15551234567","status":"queued","threadKey":"order-123"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	msg, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+		To:        "+15551234567",
+		Text:      "Your order has shipped",
+		ThreadKey: "order-123",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.ThreadKey != "order-123" {
+		t.Errorf("expected message ThreadKey to be 'order-123', got '%s'", msg.ThreadKey)
+	}
+}
+
+func TestMessagesSend_ThreadKeyTooLong(t *testing.T) {
+	client := NewClient("test-api-key")
+	_, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+		To:        "+15551234567",
+		Text:      "Hello",
+		ThreadKey: strings.Repeat("k", MaxThreadKeyLength+1),
+	})
+
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
+
+func TestMessagesList_ThreadKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("threadKey"); got != "order-123" {
+			t.Errorf("expected threadKey to be 'order-123', got '%s'", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"msg_1","threadKey":"order-123"}],"count":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.Messages.List(context.Background(), &ListMessagesRequest{ThreadKey: "order-123"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].ThreadKey != "order-123" {
+		t.Errorf("expected one message in thread 'order-123', got %+v", resp.Data)
+	}
+}
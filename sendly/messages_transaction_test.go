@@ -0,0 +1,250 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMessagesSendTransaction_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/messages/transaction" {
+			t.Errorf("expected path '/messages/transaction', got '%s'", r.URL.Path)
+		}
+
+		var req sendTransactionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if len(req.Messages) != 2 {
+			t.Errorf("expected 2 messages, got %d", len(req.Messages))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(TransactionResponse{
+			TransactionID: "txn_123",
+			Status:        TransactionStatusAccepted,
+			Messages: []Message{
+				{ID: "msg_1", To: req.Messages[0].To, Status: MessageStatusQueued},
+				{ID: "msg_2", To: req.Messages[1].To, Status: MessageStatusQueued},
+			},
+			CreditsUsed: 2,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+
+	resp, err := client.Messages.SendTransaction(context.Background(), []SendMessageRequest{
+		{To: "+15551234567", Text: "Your code is 123456"},
+		{To: "+15551234567", Text: "Enter it within 5 minutes"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.TransactionID != "txn_123" {
+		t.Errorf("expected TransactionID to be 'txn_123', got '%s'", resp.TransactionID)
+	}
+	if len(resp.Messages) != 2 {
+		t.Errorf("expected 2 messages, got %d", len(resp.Messages))
+	}
+}
+
+func TestMessagesSendTransaction_PartialAcceptance(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(TransactionResponse{
+			TransactionID: "txn_123",
+			Status:        TransactionStatusPartial,
+			Messages:      []Message{{ID: "msg_1", Status: MessageStatusQueued}},
+			Rejected:      []TransactionRejection{{Index: 1, To: "+15005550001", Error: "invalid_number"}},
+			CreditsUsed:   1,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithCreditBudget(10), WithReadYourWrites(time.Minute))
+
+	_, err := client.Messages.SendTransaction(context.Background(), []SendMessageRequest{
+		{To: "+15551234567", Text: "One"},
+		{To: "+15005550001", Text: "Two"},
+	})
+	if !IsPartialAcceptanceError(err) {
+		t.Fatalf("expected PartialAcceptanceError, got %T", err)
+	}
+
+	partial := err.(*PartialAcceptanceError)
+	if len(partial.Response.Rejected) != 1 || partial.Response.Rejected[0].Index != 1 {
+		t.Errorf("expected rejection at index 1, got %+v", partial.Response.Rejected)
+	}
+	if !strings.Contains(err.Error(), "1 accepted, 1 rejected") {
+		t.Errorf("unexpected error message: %s", err.Error())
+	}
+	if got := client.CreditsSpent(); got != 1 {
+		t.Errorf("expected the accepted message's credits to count, got %d", got)
+	}
+	if _, ok := client.Messages.(*MessagesService).fresh.deadline("msg_1", time.Minute); !ok {
+		t.Error("expected the accepted message to be tracked for read-your-writes")
+	}
+}
+
+func TestMessagesSendTransaction_ValidationErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("should not make request with validation error")
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		reqs        []SendMessageRequest
+		expectedErr string
+	}{
+		{
+			name:        "no messages",
+			reqs:        nil,
+			expectedErr: "messages are required",
+		},
+		{
+			name:        "second message missing text",
+			reqs:        []SendMessageRequest{{To: "+15551234567", Text: "One"}, {To: "+15551234567"}},
+			expectedErr: "text is required for message at index 1",
+		},
+		{
+			name:        "plain HTTP status callback",
+			reqs:        []SendMessageRequest{{To: "+15551234567", Text: "One", StatusCallbackURL: "http://example.com/status"}},
+			expectedErr: "statusCallbackUrl must be HTTPS for message at index 0",
+		},
+		{
+			name:        "negative validity period",
+			reqs:        []SendMessageRequest{{To: "+15551234567", Text: "One"}, {To: "+15551234567", Text: "Two", ValidityPeriod: -time.Second}},
+			expectedErr: "validityPeriod cannot be negative for message at index 1",
+		},
+		{
+			name:        "thread key too long",
+			reqs:        []SendMessageRequest{{To: "+15551234567", Text: "One", ThreadKey: strings.Repeat("k", MaxThreadKeyLength+1)}},
+			expectedErr: "for message at index 0",
+		},
+		{
+			name:        "metadata too large",
+			reqs:        []SendMessageRequest{{To: "+15551234567", Text: "One", Metadata: map[string]interface{}{"note": strings.Repeat("x", 10000)}}},
+			expectedErr: "message at index 0: invalid metadata",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Messages.SendTransaction(ctx, tt.reqs)
+			if !IsValidationError(err) {
+				t.Fatalf("expected ValidationError, got %T", err)
+			}
+			if !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("expected error to contain '%s', got '%s'", tt.expectedErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestMessagesSendTransaction_DryRun(t *testing.T) {
+	server := previewServer(t, BatchPreviewResponse{
+		CanSend:          true,
+		TotalMessages:    2,
+		WillSend:         2,
+		CreditsNeeded:    3,
+		CurrentBalance:   100,
+		HasEnoughCredits: true,
+		Messages: []BatchPreviewItem{
+			{To: "+15551234567", Segments: 1, Credits: 1, CanSend: true},
+			{To: "+15559876543", Segments: 2, Credits: 2, CanSend: true},
+		},
+	})
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+
+	resp, err := client.Messages.SendTransaction(context.Background(), []SendMessageRequest{
+		{To: "+15551234567", Text: "One"},
+		{To: "+15559876543", Text: "Two", DryRun: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.DryRun || resp.Status != TransactionStatusAccepted {
+		t.Errorf("expected an accepted dry run, got %+v", resp)
+	}
+	if resp.CreditsUsed != 3 {
+		t.Errorf("expected CreditsUsed to be 3, got %d", resp.CreditsUsed)
+	}
+	if len(resp.Messages) != 2 || resp.Messages[1].To != "+15559876543" || resp.Messages[1].Segments != 2 || !resp.Messages[1].DryRun {
+		t.Errorf("unexpected messages %+v", resp.Messages)
+	}
+}
+
+func TestMessagesSendTransaction_DryRunBlocked(t *testing.T) {
+	reason := "opted_out"
+	server := previewServer(t, BatchPreviewResponse{
+		TotalMessages:  2,
+		CurrentBalance: 100,
+		Messages: []BatchPreviewItem{
+			{To: "+15551234567", Credits: 1, CanSend: true},
+			{To: "+15559876543", CanSend: false, BlockReason: &reason},
+		},
+	})
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithDryRun())
+
+	_, err := client.Messages.SendTransaction(context.Background(), []SendMessageRequest{
+		{To: "+15551234567", Text: "One"},
+		{To: "+15559876543", Text: "Two"},
+	})
+	if !IsValidationError(err) || !strings.Contains(err.Error(), "opted_out for message at index 1") {
+		t.Errorf("expected the blocked message to fail the transaction, got %v", err)
+	}
+}
+
+func TestMessagesSendTransaction_Dedupe(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"transactionId":"txn_1","status":"accepted","messages":[{"id":"msg_1"},{"id":"msg_2"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithDedupeWindow(time.Minute))
+	ctx := context.Background()
+
+	if _, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+15551234567", Text: "One"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reqs := []SendMessageRequest{
+		{To: "+15559876543", Text: "Two"},
+		{To: "+15551234567", Text: "One"},
+	}
+	if _, err := client.Messages.SendTransaction(ctx, reqs); !IsDuplicateMessageError(err) {
+		t.Fatalf("expected DuplicateMessageError, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected the transaction not to be sent, got %d requests", got)
+	}
+
+	// The refused transaction must not hold its other messages.
+	if _, err := client.Messages.SendTransaction(ctx, reqs[:1]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+15559876543", Text: "Two"})
+	var dup *DuplicateMessageError
+	if !errors.As(err, &dup) || dup.OriginalID != "msg_1" {
+		t.Errorf("expected the transaction's message to be remembered, got %v", err)
+	}
+}
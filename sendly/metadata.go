@@ -0,0 +1,127 @@
+package sendly
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MetadataLimits bounds custom metadata before it is sent. The API truncates
+// metadata beyond its own limits without reporting an error, so checking on
+// the client surfaces the problem instead. Zero values disable a check.
+type MetadataLimits struct {
+	// MaxKeys is the maximum number of top-level keys.
+	MaxKeys int
+	// MaxKeyLength is the maximum length of a key in bytes.
+	MaxKeyLength int
+	// MaxBytes is the maximum size of the JSON-encoded metadata.
+	MaxBytes int
+	// KeyPattern, if set, must match every key.
+	KeyPattern *regexp.Regexp
+}
+
+// DefaultMetadataLimits matches the limits enforced by the API.
+var DefaultMetadataLimits = MetadataLimits{
+	MaxKeys:      50,
+	MaxKeyLength: 40,
+	MaxBytes:     8192,
+	KeyPattern:   regexp.MustCompile(`^[A-Za-z0-9_.-]+$`),
+}
+
+// WithMetadataLimits sets the limits metadata is checked against before
+// requests are sent (default: DefaultMetadataLimits). Pass MetadataLimits{}
+// to disable the checks.
+func WithMetadataLimits(limits MetadataLimits) ClientOption {
+	return func(c *Client) {
+		c.metadataLimits = limits
+	}
+}
+
+// MetadataViolation describes one way metadata exceeds its limits.
+type MetadataViolation struct {
+	// Key is the offending key, or empty for violations of the whole object.
+	Key string `json:"key,omitempty"`
+	// Reason describes the violation.
+	Reason string `json:"reason"`
+}
+
+// MetadataError lists every violation found in a metadata object. It is
+// wrapped in the ValidationError returned by ValidateMetadata.
+type MetadataError struct {
+	Violations []MetadataViolation
+}
+
+func (e *MetadataError) Error() string {
+	reasons := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		if v.Key != "" {
+			reasons[i] = fmt.Sprintf("%q: %s", v.Key, v.Reason)
+		} else {
+			reasons[i] = v.Reason
+		}
+	}
+	return "invalid metadata: " + strings.Join(reasons, "; ")
+}
+
+// ValidateMetadata checks metadata against limits. It returns a
+// ValidationError with code INVALID_METADATA wrapping a *MetadataError that
+// lists every violation, or nil if the metadata is within limits.
+func ValidateMetadata(metadata map[string]interface{}, limits MetadataLimits) error {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	var violations []MetadataViolation
+
+	if limits.MaxKeys > 0 && len(metadata) > limits.MaxKeys {
+		violations = append(violations, MetadataViolation{
+			Reason: fmt.Sprintf("has %d keys, maximum is %d", len(metadata), limits.MaxKeys),
+		})
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if limits.MaxKeyLength > 0 && len(key) > limits.MaxKeyLength {
+			violations = append(violations, MetadataViolation{
+				Key:    key,
+				Reason: fmt.Sprintf("key is %d bytes, maximum is %d", len(key), limits.MaxKeyLength),
+			})
+		}
+		if limits.KeyPattern != nil && !limits.KeyPattern.MatchString(key) {
+			violations = append(violations, MetadataViolation{
+				Key:    key,
+				Reason: fmt.Sprintf("key must match %s", limits.KeyPattern),
+			})
+		}
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		violations = append(violations, MetadataViolation{Reason: "cannot be encoded as JSON: " + err.Error()})
+	} else if limits.MaxBytes > 0 && len(encoded) > limits.MaxBytes {
+		violations = append(violations, MetadataViolation{
+			Reason: fmt.Sprintf("is %d bytes encoded, maximum is %d", len(encoded), limits.MaxBytes),
+		})
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	metaErr := &MetadataError{Violations: violations}
+	return &ValidationError{
+		APIError: APIError{
+			Code:    "INVALID_METADATA",
+			Message: metaErr.Error(),
+			Details: map[string]interface{}{"violations": violations},
+		},
+		Err: metaErr,
+	}
+}
@@ -0,0 +1,101 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		limits   MetadataLimits
+		wantKeys []string
+	}{
+		{
+			name:     "within limits",
+			metadata: map[string]interface{}{"order_id": "123", "region": "eu"},
+			limits:   DefaultMetadataLimits,
+		},
+		{
+			name:     "too many keys",
+			metadata: map[string]interface{}{"a": 1, "b": 2, "c": 3},
+			limits:   MetadataLimits{MaxKeys: 2},
+			wantKeys: []string{""},
+		},
+		{
+			name:     "key too long and invalid characters",
+			metadata: map[string]interface{}{"order id": 1, strings.Repeat("k", 41): 2},
+			limits:   DefaultMetadataLimits,
+			wantKeys: []string{strings.Repeat("k", 41), "order id"},
+		},
+		{
+			name:     "too large",
+			metadata: map[string]interface{}{"note": strings.Repeat("x", 100)},
+			limits:   MetadataLimits{MaxBytes: 64},
+			wantKeys: []string{""},
+		},
+		{
+			name:     "disabled limits",
+			metadata: map[string]interface{}{"any key!": strings.Repeat("x", 10000)},
+			limits:   MetadataLimits{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMetadata(tt.metadata, tt.limits)
+			if len(tt.wantKeys) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !IsValidationError(err) {
+				t.Fatalf("expected ValidationError, got %T", err)
+			}
+			if code := err.(*ValidationError).Code; code != "INVALID_METADATA" {
+				t.Errorf("expected code to be 'INVALID_METADATA', got '%s'", code)
+			}
+			var metaErr *MetadataError
+			if !errors.As(err, &metaErr) {
+				t.Fatalf("expected error to wrap MetadataError, got %v", err)
+			}
+			if len(metaErr.Violations) != len(tt.wantKeys) {
+				t.Fatalf("expected %d violations, got %+v", len(tt.wantKeys), metaErr.Violations)
+			}
+			for i, key := range tt.wantKeys {
+				if metaErr.Violations[i].Key != key {
+					t.Errorf("expected violation %d for key '%s', got '%s'", i, key, metaErr.Violations[i].Key)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhooksCreate_RejectsOversizedMetadata(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMetadataLimits(MetadataLimits{MaxKeys: 1}))
+	_, err := client.WebhooksService.Create(context.Background(), CreateWebhookRequest{
+		URL:      "https://example.com/webhooks",
+		Events:   []string{"message.delivered"},
+		Metadata: map[string]interface{}{"a": 1, "b": 2},
+	})
+
+	if !IsValidationError(err) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if called {
+		t.Error("expected request not to be sent")
+	}
+}
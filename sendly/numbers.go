@@ -0,0 +1,204 @@
+package sendly
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// NumberCapabilities are the kinds of traffic a phone number can carry.
+type NumberCapabilities struct {
+	SMS   bool `json:"sms"`
+	MMS   bool `json:"mms"`
+	Voice bool `json:"voice"`
+}
+
+// AvailableNumber is a dedicated number that can be purchased.
+type AvailableNumber struct {
+	// Number is the phone number in E.164 format.
+	Number string `json:"number"`
+	// Country is the ISO 3166-1 alpha-2 country code, such as "US".
+	Country string `json:"country"`
+	// Region is the state, province or city the number belongs to.
+	Region string `json:"region,omitempty"`
+	// Capabilities are the kinds of traffic the number supports.
+	Capabilities NumberCapabilities `json:"capabilities"`
+	// MonthlyCredits is the monthly rental cost in credits.
+	MonthlyCredits int `json:"monthlyCredits"`
+}
+
+// PhoneNumber is a dedicated number owned by the account.
+type PhoneNumber struct {
+	// ID is the number ID (num_xxx).
+	ID string `json:"id"`
+	// Number is the phone number in E.164 format.
+	Number string `json:"number"`
+	// Country is the ISO 3166-1 alpha-2 country code, such as "US".
+	Country string `json:"country"`
+	// Capabilities are the kinds of traffic enabled on the number.
+	Capabilities NumberCapabilities `json:"capabilities"`
+	// WebhookURL receives the number's inbound messages, overriding the
+	// account's webhooks.
+	WebhookURL string `json:"webhookUrl,omitempty"`
+	// MonthlyCredits is the monthly rental cost in credits.
+	MonthlyCredits int `json:"monthlyCredits"`
+	// CreatedAt is when the number was purchased.
+	CreatedAt string `json:"createdAt"`
+}
+
+// SearchNumbersRequest is the request to search for available numbers.
+type SearchNumbersRequest struct {
+	// Country is the ISO 3166-1 alpha-2 country code to search (required).
+	Country string
+	// AreaCode restricts results to an area code, such as "415".
+	AreaCode string
+	// Contains restricts results to numbers containing these digits.
+	Contains string
+	// MMS restricts results to numbers that support MMS.
+	MMS bool
+	// Limit is the maximum number of results (default: 20, max: 100).
+	Limit int
+}
+
+// purchaseNumberRequest is the body of a number purchase.
+type purchaseNumberRequest struct {
+	Number string `json:"number"`
+}
+
+// UpdateNumberRequest is the request to configure a number. Nil fields are
+// left unchanged.
+type UpdateNumberRequest struct {
+	// WebhookURL is the HTTPS URL to send the number's inbound messages to.
+	// Set it to "" to fall back to the account's webhooks.
+	WebhookURL *string `json:"webhookUrl,omitempty"`
+	// Capabilities replaces the traffic enabled on the number. Capabilities
+	// the number does not support cannot be enabled.
+	Capabilities *NumberCapabilities `json:"capabilities,omitempty"`
+}
+
+// ListNumbersRequest is the request to list the account's numbers.
+type ListNumbersRequest struct {
+	// Limit is the maximum number of numbers to return (default: 20, max: 100).
+	Limit int
+	// Offset is the number of numbers to skip.
+	Offset int
+	// Country restricts results to a country, such as "US".
+	Country string
+}
+
+// ListNumbersResponse is the response from listing the account's numbers.
+type ListNumbersResponse = Page[PhoneNumber]
+
+// NumbersService provisions dedicated phone numbers, such as one per tenant
+// on a multi-tenant platform. Sub-account scoped calls provision numbers for
+// that sub-account.
+type NumbersService struct {
+	client *Client
+}
+
+// Search finds numbers available to purchase.
+func (s *NumbersService) Search(ctx context.Context, req SearchNumbersRequest) ([]AvailableNumber, error) {
+	if len(req.Country) != 2 {
+		return nil, &ValidationError{APIError: APIError{Message: "country must be a 2-letter ISO code"}}
+	}
+
+	params := map[string]string{"country": strings.ToUpper(req.Country)}
+	if req.AreaCode != "" {
+		params["areaCode"] = req.AreaCode
+	}
+	if req.Contains != "" {
+		params["contains"] = req.Contains
+	}
+	if req.MMS {
+		params["mms"] = "true"
+	}
+	if req.Limit > 0 {
+		params["limit"] = strconv.Itoa(req.Limit)
+	}
+
+	var resp struct {
+		Data []AvailableNumber `json:"data"`
+	}
+	if err := s.client.request(ctx, "GET", "/numbers/available"+buildQueryString(params), nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// Purchase buys an available number. The first month's rental is charged
+// immediately.
+func (s *NumbersService) Purchase(ctx context.Context, number string) (*PhoneNumber, error) {
+	if !strings.HasPrefix(number, "+") {
+		return nil, &ValidationError{APIError: APIError{Message: "phone number must be in E.164 format"}}
+	}
+
+	var resp PhoneNumber
+	if err := s.client.request(ctx, "POST", "/numbers", &purchaseNumberRequest{Number: number}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List retrieves the account's numbers.
+func (s *NumbersService) List(ctx context.Context, req *ListNumbersRequest) (*ListNumbersResponse, error) {
+	params := make(map[string]string)
+	offset := 0
+	if req != nil {
+		if req.Limit > 0 {
+			params["limit"] = strconv.Itoa(req.Limit)
+		}
+		offset = req.Offset
+		if req.Offset > 0 {
+			params["offset"] = strconv.Itoa(req.Offset)
+		}
+		if req.Country != "" {
+			params["country"] = strings.ToUpper(req.Country)
+		}
+	}
+
+	var resp ListNumbersResponse
+	if err := s.client.request(ctx, "GET", "/numbers"+buildQueryString(params), nil, &resp); err != nil {
+		return nil, err
+	}
+	resp.fill(offset)
+	return &resp, nil
+}
+
+// Get retrieves one of the account's numbers by ID.
+func (s *NumbersService) Get(ctx context.Context, id string) (*PhoneNumber, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "number ID is required"}}
+	}
+
+	var resp PhoneNumber
+	if err := s.client.request(ctx, "GET", "/numbers/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Update configures a number's webhook URL or capabilities.
+func (s *NumbersService) Update(ctx context.Context, id string, req UpdateNumberRequest) (*PhoneNumber, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "number ID is required"}}
+	}
+	if req.WebhookURL != nil && *req.WebhookURL != "" && !strings.HasPrefix(*req.WebhookURL, "https://") {
+		return nil, &ValidationError{APIError: APIError{Message: "webhookUrl must be HTTPS"}}
+	}
+
+	var resp PhoneNumber
+	if err := s.client.request(ctx, "PATCH", "/numbers/"+url.PathEscape(id), req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Release gives up a number. It stops receiving messages immediately and
+// cannot be sent from; rental already paid is not refunded.
+func (s *NumbersService) Release(ctx context.Context, id string) error {
+	if id == "" {
+		return &ValidationError{APIError: APIError{Message: "number ID is required"}}
+	}
+	return s.client.request(ctx, "DELETE", "/numbers/"+url.PathEscape(id), nil, nil)
+}
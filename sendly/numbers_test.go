@@ -0,0 +1,128 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNumbersSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/numbers/available" {
+			t.Errorf("expected path '/numbers/available', got '%s'", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("country") != "US" || q.Get("areaCode") != "415" || q.Get("mms") != "true" {
+			t.Errorf("unexpected query '%s'", r.URL.RawQuery)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"number":"+14155550100","country":"US","region":"CA","capabilities":{"sms":true,"mms":true},"monthlyCredits":100}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	numbers, err := client.Numbers.Search(context.Background(), SearchNumbersRequest{Country: "us", AreaCode: "415", MMS: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(numbers) != 1 || numbers[0].Number != "+14155550100" || !numbers[0].Capabilities.MMS {
+		t.Errorf("unexpected numbers %+v", numbers)
+	}
+}
+
+func TestNumbersSearch_RequiresCountry(t *testing.T) {
+	client := NewClient("test-api-key")
+
+	if _, err := client.Numbers.Search(context.Background(), SearchNumbersRequest{AreaCode: "415"}); !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
+
+func TestNumbersPurchase(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/numbers" {
+			t.Errorf("expected POST /numbers, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var req purchaseNumberRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Number != "+14155550100" {
+			t.Errorf("unexpected number '%s'", req.Number)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"num_1","number":"+14155550100","country":"US","capabilities":{"sms":true}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	number, err := client.Numbers.Purchase(context.Background(), "+14155550100")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if number.ID != "num_1" {
+		t.Errorf("expected ID 'num_1', got '%s'", number.ID)
+	}
+}
+
+func TestNumbersUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/numbers/num_1" {
+			t.Errorf("expected PATCH /numbers/num_1, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["webhookUrl"] != "https://tenant.example.com/inbound" {
+			t.Errorf("unexpected webhookUrl %v", body["webhookUrl"])
+		}
+		if _, ok := body["capabilities"]; ok {
+			t.Error("expected unset capabilities to be omitted")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"num_1","webhookUrl":"https://tenant.example.com/inbound"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	webhookURL := "https://tenant.example.com/inbound"
+	number, err := client.Numbers.Update(context.Background(), "num_1", UpdateNumberRequest{WebhookURL: &webhookURL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if number.WebhookURL != webhookURL {
+		t.Errorf("unexpected webhook URL '%s'", number.WebhookURL)
+	}
+}
+
+func TestNumbersUpdate_RequiresHTTPS(t *testing.T) {
+	client := NewClient("test-api-key")
+	webhookURL := "http://tenant.example.com/inbound"
+
+	if _, err := client.Numbers.Update(context.Background(), "num_1", UpdateNumberRequest{WebhookURL: &webhookURL}); !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
+
+func TestNumbersRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/numbers/num_1" {
+			t.Errorf("expected DELETE /numbers/num_1, got %s %s", r.Method, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	if err := client.Numbers.Release(context.Background(), "num_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package sendly
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestEvent describes one HTTP attempt made by the client. A call that is
+// retried produces one event per attempt.
+type RequestEvent struct {
+	// Method is the HTTP method.
+	Method string
+	// Path is the request path, including any query string.
+	Path string
+	// Endpoint is Path without the query string and with IDs replaced by
+	// ":id", such as "/messages/:id", for use as a low-cardinality label.
+	Endpoint string
+	// Attempt is 1 for the first attempt and increases with each retry.
+	Attempt int
+	// StatusCode is the HTTP status, or 0 if no response was received.
+	StatusCode int
+	// Duration is how long the attempt took.
+	Duration time.Duration
+	// Err is the attempt's error, if any.
+	Err error
+	// CreditsUsed is the number of credits a successful send reported
+	// consuming, or 0.
+	CreditsUsed int
+}
+
+// WithRequestObserver calls fn after every HTTP attempt, for metrics and
+// tracing. It may be given more than once; observers run in order, on the
+// goroutine that made the call, and should return quickly.
+func WithRequestObserver(fn func(RequestEvent)) ClientOption {
+	return func(c *Client) {
+		c.observers = append(c.observers, fn)
+	}
+}
+
+// creditsReporter is implemented by responses that report credits used.
+type creditsReporter interface {
+	creditsConsumed() int
+}
+
+func (m *Message) creditsConsumed() int { return m.CreditsUsed }
+
+func (r *BatchMessageResponse) creditsConsumed() int { return r.CreditsUsed }
+
+func (r *TransactionResponse) creditsConsumed() int { return r.CreditsUsed }
+
+// observe reports an attempt to the client's observers.
+func (c *Client) observe(method, path string, attempt, status int, start time.Time, result interface{}, err error) {
+	if len(c.observers) == 0 {
+		return
+	}
+
+	event := RequestEvent{
+		Method:     method,
+		Path:       path,
+		Endpoint:   endpointTemplate(path),
+		Attempt:    attempt,
+		StatusCode: status,
+		Duration:   time.Since(start),
+		Err:        err,
+	}
+	// Reads of a message report the credits of the original send; only
+	// count them when they were consumed by this call.
+	if r, ok := result.(creditsReporter); ok && err == nil && method != http.MethodGet {
+		event.CreditsUsed = r.creditsConsumed()
+	}
+	for _, fn := range c.observers {
+		fn(event)
+	}
+}
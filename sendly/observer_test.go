@@ -0,0 +1,106 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithRequestObserver(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"rate_limited","message":"Too many requests"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","status":"queued","creditsUsed":2}`))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var events []RequestEvent
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithMaxRetries(1),
+		WithRequestObserver(func(e RequestEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, e)
+		}),
+	)
+
+	req := &SendMessageRequest{To: "+15551234567", Text: "Hello"}
+	if _, err := client.Messages.Send(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	first, second := events[0], events[1]
+	if first.Attempt != 1 || first.StatusCode != http.StatusTooManyRequests || !IsRateLimitError(first.Err) {
+		t.Errorf("expected a rate limited first attempt, got %+v", first)
+	}
+	if first.CreditsUsed != 0 {
+		t.Errorf("expected no credits on a failed attempt, got %d", first.CreditsUsed)
+	}
+	if second.Attempt != 2 || second.StatusCode != http.StatusOK || second.Err != nil {
+		t.Errorf("expected a successful second attempt, got %+v", second)
+	}
+	if second.CreditsUsed != 2 {
+		t.Errorf("expected 2 credits used, got %d", second.CreditsUsed)
+	}
+	if second.Method != "POST" || second.Endpoint != "/messages" {
+		t.Errorf("expected POST /messages, got %s %s", second.Method, second.Endpoint)
+	}
+}
+
+func TestWithRequestObserver_EndpointTemplate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_abc123","status":"delivered","creditsUsed":1}`))
+	}))
+	defer server.Close()
+
+	var event RequestEvent
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithRequestObserver(func(e RequestEvent) { event = e }),
+	)
+
+	if _, err := client.Messages.Get(context.Background(), "msg_abc123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Path != "/messages/msg_abc123" {
+		t.Errorf("expected Path to be '/messages/msg_abc123', got '%s'", event.Path)
+	}
+	if event.Endpoint != "/messages/:id" {
+		t.Errorf("expected Endpoint to be '/messages/:id', got '%s'", event.Endpoint)
+	}
+	if event.CreditsUsed != 0 {
+		t.Errorf("expected reads not to report credits, got %d", event.CreditsUsed)
+	}
+}
+
+func TestWithRequestObserver_NetworkError(t *testing.T) {
+	var event RequestEvent
+	client := NewClient("test-api-key",
+		WithBaseURL("http://127.0.0.1:1"),
+		WithMaxRetries(0),
+		WithRequestObserver(func(e RequestEvent) { event = e }),
+	)
+
+	client.Messages.List(context.Background(), nil)
+	if event.StatusCode != 0 {
+		t.Errorf("expected status 0 without a response, got %d", event.StatusCode)
+	}
+	if !IsNetworkError(event.Err) {
+		t.Errorf("expected a network error, got %v", event.Err)
+	}
+}
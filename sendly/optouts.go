@@ -0,0 +1,112 @@
+package sendly
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// OptOutSource is how a recipient came to be opted out.
+type OptOutSource string
+
+const (
+	// OptOutSourceKeyword means the recipient replied with a STOP keyword.
+	OptOutSourceKeyword OptOutSource = "keyword"
+	// OptOutSourceAPI means the opt-out was added through the API.
+	OptOutSourceAPI OptOutSource = "api"
+)
+
+// OptOut is a recipient who must not be sent marketing messages.
+type OptOut struct {
+	// Phone is the opted-out phone number.
+	Phone string `json:"phone"`
+	// Source is how the recipient was opted out.
+	Source OptOutSource `json:"source"`
+	// CreatedAt is when the recipient opted out.
+	CreatedAt string `json:"createdAt"`
+}
+
+// ListOptOutsRequest is the request to list opted-out recipients.
+type ListOptOutsRequest struct {
+	// Limit is the maximum number of opt-outs to return (default: 20, max: 100).
+	Limit int
+	// Offset is the number of opt-outs to skip.
+	Offset int
+}
+
+// ListOptOutsResponse is the response from listing opted-out recipients.
+type ListOptOutsResponse = Page[OptOut]
+
+// addOptOutRequest is the body of an opt-out.
+type addOptOutRequest struct {
+	Phone  string       `json:"phone"`
+	Source OptOutSource `json:"source"`
+}
+
+// OptOutsService manages the account's opt-out list. See KeywordHandler for
+// processing STOP and START replies.
+type OptOutsService struct {
+	client *Client
+}
+
+// Add opts phone out of further messages.
+func (s *OptOutsService) Add(ctx context.Context, phone string, source OptOutSource) (*OptOut, error) {
+	if !strings.HasPrefix(phone, "+") {
+		return nil, &ValidationError{APIError: APIError{Message: "phone number must be in E.164 format"}}
+	}
+	if source == "" {
+		source = OptOutSourceAPI
+	}
+
+	var resp OptOut
+	if err := s.client.request(ctx, "POST", "/opt-outs", &addOptOutRequest{Phone: phone, Source: source}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Remove opts phone back in. It returns a *NotFoundError if phone is not
+// opted out.
+func (s *OptOutsService) Remove(ctx context.Context, phone string) error {
+	if !strings.HasPrefix(phone, "+") {
+		return &ValidationError{APIError: APIError{Message: "phone number must be in E.164 format"}}
+	}
+	return s.client.request(ctx, "DELETE", "/opt-outs/"+url.PathEscape(phone), nil, nil)
+}
+
+// Get retrieves the opt-out for phone. It returns a *NotFoundError if phone
+// is not opted out.
+func (s *OptOutsService) Get(ctx context.Context, phone string) (*OptOut, error) {
+	if !strings.HasPrefix(phone, "+") {
+		return nil, &ValidationError{APIError: APIError{Message: "phone number must be in E.164 format"}}
+	}
+
+	var resp OptOut
+	if err := s.client.request(ctx, "GET", "/opt-outs/"+url.PathEscape(phone), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List retrieves opted-out recipients, newest first.
+func (s *OptOutsService) List(ctx context.Context, req *ListOptOutsRequest) (*ListOptOutsResponse, error) {
+	params := make(map[string]string)
+	offset := 0
+	if req != nil {
+		if req.Limit > 0 {
+			params["limit"] = strconv.Itoa(req.Limit)
+		}
+		offset = req.Offset
+		if req.Offset > 0 {
+			params["offset"] = strconv.Itoa(req.Offset)
+		}
+	}
+
+	var resp ListOptOutsResponse
+	if err := s.client.request(ctx, "GET", "/opt-outs"+buildQueryString(params), nil, &resp); err != nil {
+		return nil, err
+	}
+	resp.fill(offset)
+	return &resp, nil
+}
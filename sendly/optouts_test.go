@@ -0,0 +1,96 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOptOutsAdd(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/opt-outs" {
+			t.Errorf("expected path '/opt-outs', got '%s'", r.URL.Path)
+		}
+
+		var req addOptOutRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Phone != "+15551234567" || req.Source != OptOutSourceAPI {
+			t.Errorf("unexpected request %+v", req)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"phone":"+15551234567","source":"api","createdAt":"2025-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	optOut, err := client.OptOuts.Add(context.Background(), "+15551234567", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if optOut.Phone != "+15551234567" || optOut.Source != OptOutSourceAPI {
+		t.Errorf("unexpected opt-out %+v", optOut)
+	}
+}
+
+func TestOptOutsRemove(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/opt-outs/+15551234567" {
+			t.Errorf("expected path '/opt-outs/+15551234567', got '%s'", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	if err := client.OptOuts.Remove(context.Background(), "+15551234567"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOptOutsList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("expected limit to be '2', got '%s'", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"phone":"+15551234567","source":"keyword"}],"count":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.OptOuts.List(context.Background(), &ListOptOutsRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 1 || resp.Data[0].Source != OptOutSourceKeyword {
+		t.Errorf("unexpected opt-outs %+v", resp.Data)
+	}
+}
+
+func TestOptOuts_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+	ctx := context.Background()
+
+	if _, err := client.OptOuts.Add(ctx, "5551234567", ""); !IsValidationError(err) {
+		t.Errorf("expected ValidationError from Add, got %T", err)
+	}
+	if err := client.OptOuts.Remove(ctx, "5551234567"); !IsValidationError(err) {
+		t.Errorf("expected ValidationError from Remove, got %T", err)
+	}
+	if _, err := client.OptOuts.Get(ctx, "5551234567"); !IsValidationError(err) {
+		t.Errorf("expected ValidationError from Get, got %T", err)
+	}
+}
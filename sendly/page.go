@@ -0,0 +1,145 @@
+package sendly
+
+import "context"
+
+// Page is one page of a list response. Every List method returns a Page, so
+// pagination code can be written once for all of them.
+type Page[T any] struct {
+	// Data contains the items on this page.
+	Data []T `json:"data"`
+	// Count is the total number of items matching the query.
+	Count int `json:"count"`
+	// HasMore reports whether more items follow this page.
+	HasMore bool `json:"hasMore"`
+	// NextOffset is the offset of the next page, or zero if there is none.
+	NextOffset int `json:"nextOffset,omitempty"`
+}
+
+// NewPage returns the page of data that starts at offset in a result of
+// count items, with HasMore and NextOffset set. It is useful for
+// implementing the API interfaces, for example in fakes.
+func NewPage[T any](data []T, count, offset int) *Page[T] {
+	p := &Page[T]{Data: data, Count: count}
+	p.fill(offset)
+	return p
+}
+
+// fill sets HasMore and NextOffset for a page that starts at offset, where
+// the API did not report them.
+func (p *Page[T]) fill(offset int) {
+	next := offset + len(p.Data)
+	if !p.HasMore {
+		p.HasMore = len(p.Data) > 0 && next < p.Count
+	}
+	switch {
+	case !p.HasMore:
+		p.NextOffset = 0
+	case p.NextOffset == 0:
+		p.NextOffset = next
+	}
+}
+
+// PageFunc fetches the page that starts at offset.
+type PageFunc[T any] func(ctx context.Context, offset int) (*Page[T], error)
+
+// PageIterator walks every item of a paginated list, fetching pages as
+// needed. Use it like bufio.Scanner:
+//
+//	it := sendly.NewPageIterator(0, func(ctx context.Context, offset int) (*sendly.Page[sendly.Message], error) {
+//		return client.Messages.List(ctx, &sendly.ListMessagesRequest{Limit: 100, Offset: offset})
+//	})
+//	for it.Next(ctx) {
+//		process(it.Item())
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type PageIterator[T any] struct {
+	fetch   PageFunc[T]
+	offset  int
+	page    []T
+	index   int
+	current T
+	done    bool
+	err     error
+}
+
+// NewPageIterator returns an iterator over the items fetch returns, starting
+// at offset. Pages that leave HasMore unset are followed while Count says
+// more items remain.
+func NewPageIterator[T any](offset int, fetch PageFunc[T]) *PageIterator[T] {
+	return &PageIterator[T]{fetch: fetch, offset: offset}
+}
+
+// Next advances to the next item, fetching the next page if needed. It
+// returns false when there are no more items or a request fails; check Err
+// to tell the two apart.
+func (it *PageIterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+		resp, err := it.fetch(ctx, it.offset)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		resp.fill(it.offset)
+		it.page = resp.Data
+		it.index = 0
+		it.done = !resp.HasMore || len(resp.Data) == 0
+		if resp.NextOffset > it.offset {
+			it.offset = resp.NextOffset
+		} else {
+			it.offset += len(resp.Data)
+		}
+	}
+
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Item returns the item Next advanced to.
+func (it *PageIterator[T]) Item() T {
+	return it.current
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// PageChan pages through the items fetch returns, starting at offset, in a
+// background goroutine and sends them on the returned channel in order.
+//
+// The item channel is closed when every page has been read, a request fails,
+// or ctx is done. The error channel then receives the error, if any, and is
+// closed. No further page is fetched until the previous one has been
+// consumed.
+func PageChan[T any](ctx context.Context, offset int, fetch PageFunc[T]) (<-chan T, <-chan error) {
+	out := make(chan T)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(out)
+
+		it := NewPageIterator(offset, fetch)
+		for it.Next(ctx) {
+			select {
+			case out <- it.Item():
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			errc <- err
+		}
+	}()
+	return out, errc
+}
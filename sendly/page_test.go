@@ -0,0 +1,125 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewPage(t *testing.T) {
+	p := NewPage([]int{3, 4}, 10, 2)
+	if !p.HasMore || p.NextOffset != 4 {
+		t.Errorf("expected HasMore with NextOffset 4, got %+v", p)
+	}
+
+	last := NewPage([]int{8, 9}, 10, 8)
+	if last.HasMore || last.NextOffset != 0 {
+		t.Errorf("expected last page without more, got %+v", last)
+	}
+
+	empty := NewPage([]int{}, 10, 20)
+	if empty.HasMore {
+		t.Errorf("expected empty page without more, got %+v", empty)
+	}
+}
+
+func TestMessagesList_PageCursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"msg_1"},{"id":"msg_2"}],"count":5}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.Messages.List(context.Background(), &ListMessagesRequest{Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.HasMore || resp.NextOffset != 4 {
+		t.Errorf("expected HasMore with NextOffset 4, got HasMore=%v NextOffset=%d", resp.HasMore, resp.NextOffset)
+	}
+}
+
+func TestMessagesList_PageCursorFromAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"msg_1"}],"count":0,"hasMore":true,"nextOffset":50}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.Messages.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.HasMore || resp.NextOffset != 50 {
+		t.Errorf("expected the API's cursor to be kept, got HasMore=%v NextOffset=%d", resp.HasMore, resp.NextOffset)
+	}
+}
+
+func TestPageIterator(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	var offsets []int
+	fetch := func(ctx context.Context, offset int) (*Page[string], error) {
+		offsets = append(offsets, offset)
+		end := offset + 2
+		if end > len(items) {
+			end = len(items)
+		}
+		// Leave HasMore unset, as older API versions do.
+		return &Page[string]{Data: items[offset:end], Count: len(items)}, nil
+	}
+
+	it := NewPageIterator(1, fetch)
+	var got []string
+	for it.Next(context.Background()) {
+		got = append(got, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 4 || got[0] != "b" || got[3] != "e" {
+		t.Errorf("expected [b c d e], got %v", got)
+	}
+	if len(offsets) != 2 || offsets[1] != 3 {
+		t.Errorf("expected pages at offsets [1 3], got %v", offsets)
+	}
+}
+
+func TestPageIterator_Error(t *testing.T) {
+	boom := errors.New("boom")
+	it := NewPageIterator(0, func(ctx context.Context, offset int) (*Page[int], error) {
+		if offset > 0 {
+			return nil, boom
+		}
+		return &Page[int]{Data: []int{1}, Count: 2}, nil
+	})
+
+	n := 0
+	for it.Next(context.Background()) {
+		n++
+	}
+	if n != 1 || !errors.Is(it.Err(), boom) {
+		t.Errorf("expected 1 item then the error, got %d items and %v", n, it.Err())
+	}
+}
+
+func TestPageChan(t *testing.T) {
+	items, errc := PageChan(context.Background(), 0, func(ctx context.Context, offset int) (*Page[int], error) {
+		return NewPage([]int{offset, offset + 1}, 6, offset), nil
+	})
+
+	var got []int
+	for item := range items {
+		got = append(got, item)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 6 || got[5] != 5 {
+		t.Errorf("expected [0 1 2 3 4 5], got %v", got)
+	}
+}
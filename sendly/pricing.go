@@ -0,0 +1,73 @@
+package sendly
+
+import "sort"
+
+// CostByCountry groups the sendable messages in the preview by destination
+// country, most expensive first. PreviewBatch stores the result in ByCountry.
+func (r *BatchPreviewResponse) CostByCountry() []CountryCost {
+	byCountry := make(map[string]*CountryCost)
+	total := 0
+	for _, item := range r.Messages {
+		if !item.CanSend {
+			continue
+		}
+		country := ""
+		if item.Country != nil {
+			country = *item.Country
+		}
+		c, ok := byCountry[country]
+		if !ok {
+			c = &CountryCost{Country: country}
+			byCountry[country] = c
+		}
+		if item.PricingTier != nil {
+			c.PricingTier = *item.PricingTier
+		}
+		c.Messages++
+		c.Segments += item.Segments
+		c.Credits += item.Credits
+		total += item.Credits
+	}
+
+	costs := make([]CountryCost, 0, len(byCountry))
+	for _, c := range byCountry {
+		if total > 0 {
+			c.Share = float64(c.Credits) / float64(total)
+		}
+		costs = append(costs, *c)
+	}
+	sort.Slice(costs, func(i, j int) bool {
+		if costs[i].Credits != costs[j].Credits {
+			return costs[i].Credits > costs[j].Credits
+		}
+		return costs[i].Country < costs[j].Country
+	})
+	return costs
+}
+
+// ExpensiveDestinations returns the countries whose credits per segment are
+// more than factor times the batch average, for review before launch.
+func (r *BatchPreviewResponse) ExpensiveDestinations(factor float64) []CountryCost {
+	costs := r.ByCountry
+	if costs == nil {
+		costs = r.CostByCountry()
+	}
+
+	credits, segments := 0, 0
+	for _, c := range costs {
+		credits += c.Credits
+		segments += c.Segments
+	}
+	if segments == 0 {
+		return nil
+	}
+	average := float64(credits) / float64(segments)
+
+	var expensive []CountryCost
+	for _, c := range costs {
+		if c.CreditsPerSegment() > average*factor {
+			expensive = append(expensive, c)
+		}
+	}
+	return expensive
+}
@@ -0,0 +1,82 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const previewResponse = `{
+	"canSend": true,
+	"totalMessages": 5,
+	"willSend": 4,
+	"blocked": 1,
+	"creditsNeeded": 38,
+	"messages": [
+		{"to": "+15551234567", "segments": 1, "credits": 1, "canSend": true, "country": "US", "pricingTier": "domestic"},
+		{"to": "+15557654321", "segments": 2, "credits": 2, "canSend": true, "country": "US", "pricingTier": "domestic"},
+		{"to": "+4915112345678", "segments": 1, "credits": 16, "canSend": true, "country": "DE", "pricingTier": "tier3"},
+		{"to": "+447700900123", "segments": 1, "credits": 8, "canSend": true, "country": "GB", "pricingTier": "tier1"},
+		{"to": "+33612345678", "segments": 1, "credits": 12, "canSend": false, "country": "FR", "blockReason": "opted_out"}
+	]
+}`
+
+func TestMessagesPreviewBatch_CostByCountry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages/batch/preview" {
+			t.Errorf("expected path '/messages/batch/preview', got '%s'", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(previewResponse))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	preview, err := client.Messages.PreviewBatch(context.Background(), &SendBatchRequest{
+		Messages: []BatchMessageItem{{To: "+15551234567", Text: "Hi"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(preview.ByCountry) != 3 {
+		t.Fatalf("expected 3 countries, got %+v", preview.ByCountry)
+	}
+
+	de := preview.ByCountry[0]
+	if de.Country != "DE" || de.Credits != 16 || de.PricingTier != "tier3" {
+		t.Errorf("expected DE to be most expensive, got %+v", de)
+	}
+	if de.Share != 16.0/27.0 {
+		t.Errorf("expected DE share to be 16/27, got %v", de.Share)
+	}
+
+	us := preview.ByCountry[2]
+	if us.Country != "US" || us.Messages != 2 || us.Segments != 3 || us.Credits != 3 {
+		t.Errorf("unexpected US breakdown: %+v", us)
+	}
+	if us.CreditsPerSegment() != 1 {
+		t.Errorf("expected US credits per segment to be 1, got %v", us.CreditsPerSegment())
+	}
+}
+
+func TestBatchPreviewResponse_ExpensiveDestinations(t *testing.T) {
+	de, gb, us := "DE", "GB", "US"
+	preview := &BatchPreviewResponse{Messages: []BatchPreviewItem{
+		{Segments: 10, Credits: 10, CanSend: true, Country: &us},
+		{Segments: 1, Credits: 16, CanSend: true, Country: &de},
+		{Segments: 1, Credits: 8, CanSend: true, Country: &gb},
+	}}
+
+	// The average is 34 credits over 12 segments, about 2.8 per segment.
+	expensive := preview.ExpensiveDestinations(4)
+	if len(expensive) != 1 || expensive[0].Country != "DE" {
+		t.Errorf("expected only DE to be flagged, got %+v", expensive)
+	}
+
+	expensive = preview.ExpensiveDestinations(2)
+	if len(expensive) != 2 {
+		t.Errorf("expected DE and GB to be flagged, got %+v", expensive)
+	}
+}
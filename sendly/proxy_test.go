@@ -0,0 +1,224 @@
+package sendly
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithProxy_HTTP(t *testing.T) {
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		if !r.URL.IsAbs() {
+			t.Errorf("expected an absolute request URL, got '%s'", r.URL)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer proxy.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL("http://api.sendly.invalid/api/v1"),
+		WithProxy(proxy.URL),
+	)
+	if _, err := client.Messages.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if atomic.LoadInt32(&proxied) != 1 {
+		t.Errorf("expected 1 proxied request, got %d", proxied)
+	}
+}
+
+func TestWithProxy_SOCKS5(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	socks, connects := startSOCKS5Server(t)
+
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithProxy("socks5://"+socks),
+	)
+	if _, err := client.Messages.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if atomic.LoadInt32(connects) != 1 {
+		t.Errorf("expected 1 SOCKS5 connection, got %d", *connects)
+	}
+}
+
+func TestWithNoProxy_BypassesProxy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer proxy.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithProxy(proxy.URL),
+		WithNoProxy("127.0.0.0/8"),
+	)
+	if _, err := client.Messages.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if atomic.LoadInt32(&proxied) != 0 {
+		t.Errorf("expected no proxied requests, got %d", proxied)
+	}
+}
+
+func TestWithProxy_InvalidURL(t *testing.T) {
+	client := NewClient("test-api-key", WithProxy("ftp://proxy.example.com"), WithMaxRetries(0))
+	_, err := client.Messages.List(context.Background(), nil)
+
+	if !IsNetworkError(err) {
+		t.Errorf("expected NetworkError, got %T: %v", err, err)
+	}
+}
+
+func TestWithProxy_LeavesCallerClientUntouched(t *testing.T) {
+	httpClient := &http.Client{Transport: &http.Transport{}}
+	client := NewClient("test-api-key", WithHTTPClient(httpClient), WithProxy("http://proxy.example.com:3128"))
+
+	if client.HTTPClient == httpClient {
+		t.Error("expected the client to use a copy of the supplied http.Client")
+	}
+	if httpClient.Transport.(*http.Transport).Proxy != nil {
+		t.Error("expected the supplied transport not to be modified")
+	}
+}
+
+func TestMatchNoProxy(t *testing.T) {
+	patterns := []string{"internal.example.com", ".corp.example", "*.svc.local", "10.0.0.0/8", "192.168.1.5", "metrics.example.com:8443"}
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://internal.example.com/api", true},
+		{"https://a.internal.example.com/api", true},
+		{"https://notinternal.example.com/api", false},
+		{"https://corp.example/api", false},
+		{"https://host.corp.example/api", true},
+		{"https://api.svc.local/api", true},
+		{"https://10.1.2.3/api", true},
+		{"https://11.1.2.3/api", false},
+		{"http://192.168.1.5/api", true},
+		{"https://metrics.example.com:8443/api", true},
+		{"https://metrics.example.com/api", false},
+		{"https://sendly.live/api/v1", false},
+	}
+
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.url)
+		if got := matchNoProxy(patterns, u); got != tt.want {
+			t.Errorf("matchNoProxy(%s) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+
+	u, _ := url.Parse("https://sendly.live")
+	if !matchNoProxy([]string{"*"}, u) {
+		t.Error("expected '*' to match every host")
+	}
+}
+
+// startSOCKS5Server runs a minimal unauthenticated SOCKS5 proxy that supports
+// CONNECT, returning its address and a counter of connections made.
+func startSOCKS5Server(t *testing.T) (string, *int32) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	var connects int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				target, err := socks5Handshake(conn)
+				if err != nil {
+					return
+				}
+				upstream, err := net.Dial("tcp", target)
+				if err != nil {
+					return
+				}
+				defer upstream.Close()
+				atomic.AddInt32(&connects, 1)
+				conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+				go io.Copy(upstream, conn)
+				io.Copy(conn, upstream)
+			}()
+		}
+	}()
+	return ln.Addr().String(), &connects
+}
+
+// socks5Handshake performs the server side of a no-auth SOCKS5 CONNECT and
+// returns the requested target address.
+func socks5Handshake(conn net.Conn) (string, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return "", err
+	}
+	if _, err := io.ReadFull(conn, make([]byte, header[1])); err != nil {
+		return "", err
+	}
+	if _, err := conn.Write([]byte{5, 0}); err != nil {
+		return "", err
+	}
+
+	req := make([]byte, 4)
+	if _, err := io.ReadFull(conn, req); err != nil {
+		return "", err
+	}
+	var host string
+	switch req[3] {
+	case 1:
+		addr := make([]byte, 4)
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			return "", err
+		}
+		host = net.IP(addr).String()
+	case 3:
+		n := make([]byte, 1)
+		if _, err := io.ReadFull(conn, n); err != nil {
+			return "", err
+		}
+		name := make([]byte, n[0])
+		if _, err := io.ReadFull(conn, name); err != nil {
+			return "", err
+		}
+		host = string(name)
+	default:
+		return "", io.ErrUnexpectedEOF
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+}
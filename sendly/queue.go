@@ -0,0 +1,305 @@
+package sendly
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// QueuedMessage is a send request waiting in a Queue.
+type QueuedMessage struct {
+	// ID identifies the entry in the queue.
+	ID string `json:"id"`
+	// Request is the message to send.
+	Request SendMessageRequest `json:"request"`
+	// Attempts is the number of failed send attempts so far.
+	Attempts int `json:"attempts"`
+	// EnqueuedAt is when the message was queued.
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+	// LastError is the error from the most recent attempt.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// QueueStore persists queued messages. Implementations must be safe for
+// concurrent use.
+type QueueStore interface {
+	// Put inserts or replaces a message by ID.
+	Put(ctx context.Context, msg QueuedMessage) error
+	// List returns all queued messages, oldest first.
+	List(ctx context.Context) ([]QueuedMessage, error)
+	// Delete removes a message by ID.
+	Delete(ctx context.Context, id string) error
+}
+
+// MemoryQueueStore is an in-memory QueueStore. Messages are lost when the
+// process exits; use a persistent store for durability across restarts.
+type MemoryQueueStore struct {
+	mu       sync.Mutex
+	messages map[string]QueuedMessage
+}
+
+// NewMemoryQueueStore creates an empty in-memory store.
+func NewMemoryQueueStore() *MemoryQueueStore {
+	return &MemoryQueueStore{messages: make(map[string]QueuedMessage)}
+}
+
+// Put inserts or replaces a message by ID.
+func (s *MemoryQueueStore) Put(ctx context.Context, msg QueuedMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages[msg.ID] = msg
+	return nil
+}
+
+// List returns all queued messages, oldest first.
+func (s *MemoryQueueStore) List(ctx context.Context) ([]QueuedMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]QueuedMessage, 0, len(s.messages))
+	for _, msg := range s.messages {
+		list = append(list, msg)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].EnqueuedAt.Equal(list[j].EnqueuedAt) {
+			return list[i].ID < list[j].ID
+		}
+		return list[i].EnqueuedAt.Before(list[j].EnqueuedAt)
+	})
+	return list, nil
+}
+
+// Delete removes a message by ID.
+func (s *MemoryQueueStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.messages, id)
+	return nil
+}
+
+// Queue buffers Send requests and delivers them when the API is reachable,
+// for deployments with unreliable connectivity. Messages stay in the store
+// across failed flushes until they are sent, rejected by the API, or exceed
+// the maximum number of attempts.
+type Queue struct {
+	messages      MessagesAPI
+	store         QueueStore
+	maxAttempts   int
+	flushInterval time.Duration
+	onSent        func(QueuedMessage, *Message)
+	onFailed      func(QueuedMessage, error)
+	flushMu       sync.Mutex
+	closeOnce     sync.Once
+	done          chan struct{}
+}
+
+// QueueOption configures a Queue.
+type QueueOption func(*Queue)
+
+// WithQueueStore sets where queued messages are kept (default: in memory).
+func WithQueueStore(store QueueStore) QueueOption {
+	return func(q *Queue) {
+		q.store = store
+	}
+}
+
+// WithQueueMaxAttempts sets how many transient failures a message may have
+// before it is dropped and reported to the failure callback (default: 10).
+func WithQueueMaxAttempts(n int) QueueOption {
+	return func(q *Queue) {
+		q.maxAttempts = n
+	}
+}
+
+// WithQueueFlushInterval sets how often Run attempts a flush (default: 30 seconds).
+func WithQueueFlushInterval(d time.Duration) QueueOption {
+	return func(q *Queue) {
+		q.flushInterval = d
+	}
+}
+
+// WithQueueOnSent sets a callback for each message the API accepts.
+func WithQueueOnSent(fn func(QueuedMessage, *Message)) QueueOption {
+	return func(q *Queue) {
+		q.onSent = fn
+	}
+}
+
+// WithQueueOnFailed sets a callback for each message dropped from the queue,
+// either because the API rejected it or it ran out of attempts.
+func WithQueueOnFailed(fn func(QueuedMessage, error)) QueueOption {
+	return func(q *Queue) {
+		q.onFailed = fn
+	}
+}
+
+// NewQueue creates a queue that sends through messages, typically
+// client.Messages. When it is, closing the client also closes the queue.
+func NewQueue(messages MessagesAPI, opts ...QueueOption) *Queue {
+	q := &Queue{
+		messages:      messages,
+		store:         NewMemoryQueueStore(),
+		maxAttempts:   10,
+		flushInterval: 30 * time.Second,
+		done:          make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	if svc, ok := messages.(*MessagesService); ok {
+		svc.client.onClose(q.Close)
+	}
+	return q
+}
+
+// Enqueue validates req and stores it for the next flush, returning its queue ID.
+func (q *Queue) Enqueue(ctx context.Context, req *SendMessageRequest) (string, error) {
+	if req == nil {
+		return "", &ValidationError{APIError: APIError{Message: "request is required"}}
+	}
+	if req.To == "" {
+		return "", &ValidationError{APIError: APIError{Message: "to is required"}}
+	}
+	if req.Text == "" {
+		return "", &ValidationError{APIError: APIError{Message: "text is required"}}
+	}
+
+	id, err := newQueueID()
+	if err != nil {
+		return "", err
+	}
+
+	msg := QueuedMessage{ID: id, Request: *req, EnqueuedAt: time.Now()}
+	if err := q.store.Put(ctx, msg); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Len returns the number of messages waiting to be sent.
+func (q *Queue) Len(ctx context.Context) (int, error) {
+	list, err := q.store.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(list), nil
+}
+
+// Flush sends queued messages oldest first. It stops at the first transient
+// failure, such as a network error or outage, leaving the rest queued, and
+// returns that error. Messages the API rejects are removed and reported.
+// Each message is sent with its queue ID as the idempotency key, so it is
+// safe to resend after a failure, and skips the WithDedupeWindow check,
+// which applied when it was queued.
+func (q *Queue) Flush(ctx context.Context) error {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	pending, err := q.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range pending {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// The queue ID is the idempotency key on every attempt, so a send
+		// that timed out after the API accepted it is not sent twice. A
+		// message accepted locally was already recorded by the duplicate
+		// check, so it must not be refused as a duplicate of itself.
+		req := msg.Request
+		sendCtx := WithCallOptions(ctx, withoutDegradedMode(), WithIdempotencyKey(msg.ID), WithAllowDuplicate())
+		sent, err := q.messages.Send(sendCtx, &req)
+		if err == nil {
+			if err := q.store.Delete(ctx, msg.ID); err != nil {
+				return err
+			}
+			if q.onSent != nil {
+				q.onSent(msg, sent)
+			}
+			continue
+		}
+
+		if !isTransientError(err) {
+			if err := q.store.Delete(ctx, msg.ID); err != nil {
+				return err
+			}
+			if q.onFailed != nil {
+				q.onFailed(msg, err)
+			}
+			continue
+		}
+
+		msg.Attempts++
+		msg.LastError = err.Error()
+		if msg.Attempts >= q.maxAttempts {
+			if delErr := q.store.Delete(ctx, msg.ID); delErr != nil {
+				return delErr
+			}
+			if q.onFailed != nil {
+				q.onFailed(msg, err)
+			}
+		} else if putErr := q.store.Put(ctx, msg); putErr != nil {
+			return putErr
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Run flushes the queue every flush interval until ctx is done or the queue
+// is closed. Flush errors are expected while offline and are not returned.
+func (q *Queue) Run(ctx context.Context) error {
+	ticker := time.NewTicker(q.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		q.Flush(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-q.done:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close stops Run and makes a final flush attempt, returning its error.
+// Messages that could not be sent stay in the store.
+func (q *Queue) Close(ctx context.Context) error {
+	first := false
+	q.closeOnce.Do(func() {
+		close(q.done)
+		first = true
+	})
+	if !first {
+		return nil
+	}
+	return q.Flush(ctx)
+}
+
+// isTransientError reports whether a send may succeed if tried again later.
+func isTransientError(err error) bool {
+	if IsNetworkError(err) || IsRateLimitError(err) || IsCircuitOpenError(err) {
+		return true
+	}
+	var apiErr *SendlyError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
+}
+
+func newQueueID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "q_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,160 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newQueueTestServer(t *testing.T, online *atomic.Bool) *httptest.Server {
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !online.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":"unavailable","message":"service unavailable"}`))
+			return
+		}
+
+		var req SendMessageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.To == "+10000000000" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(APIError{Code: "INVALID_PHONE_NUMBER", Message: "invalid number"})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(Message{ID: "msg_" + req.To, To: req.To, Text: req.Text, Status: MessageStatusQueued})
+	})
+	return server
+}
+
+func TestQueue_FlushesWhenOnline(t *testing.T) {
+	var online atomic.Bool
+	server := newQueueTestServer(t, &online)
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(0))
+	var sent []string
+	queue := NewQueue(client.Messages, WithQueueOnSent(func(m QueuedMessage, msg *Message) {
+		sent = append(sent, msg.ID)
+	}))
+	ctx := context.Background()
+
+	for _, to := range []string{"+15551234567", "+15557654321"} {
+		if _, err := queue.Enqueue(ctx, &SendMessageRequest{To: to, Text: "Hello"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := queue.Flush(ctx); err == nil {
+		t.Fatal("expected flush to fail while offline")
+	}
+	if n, _ := queue.Len(ctx); n != 2 {
+		t.Errorf("expected 2 queued messages, got %d", n)
+	}
+	list, _ := queue.store.List(ctx)
+	if list[0].Attempts != 1 || list[0].LastError == "" {
+		t.Errorf("expected first message to record the failed attempt, got %+v", list[0])
+	}
+	if list[1].Attempts != 0 {
+		t.Errorf("expected flush to stop after the first failure, got %d attempts", list[1].Attempts)
+	}
+
+	online.Store(true)
+	if err := queue.Flush(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := queue.Len(ctx); n != 0 {
+		t.Errorf("expected empty queue, got %d", n)
+	}
+	if len(sent) != 2 || sent[0] != "msg_+15551234567" || sent[1] != "msg_+15557654321" {
+		t.Errorf("expected messages sent in order, got %v", sent)
+	}
+}
+
+func TestQueue_DropsRejectedMessages(t *testing.T) {
+	var online atomic.Bool
+	online.Store(true)
+	server := newQueueTestServer(t, &online)
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(0))
+	var failed []error
+	var sent int
+	queue := NewQueue(client.Messages,
+		WithQueueOnFailed(func(m QueuedMessage, err error) { failed = append(failed, err) }),
+		WithQueueOnSent(func(QueuedMessage, *Message) { sent++ }),
+	)
+	ctx := context.Background()
+
+	queue.Enqueue(ctx, &SendMessageRequest{To: "+10000000000", Text: "Hello"})
+	queue.Enqueue(ctx, &SendMessageRequest{To: "+15551234567", Text: "Hello"})
+
+	if err := queue.Flush(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(failed) != 1 || !IsValidationError(failed[0]) {
+		t.Errorf("expected one validation failure, got %v", failed)
+	}
+	if sent != 1 {
+		t.Errorf("expected the valid message to be sent, got %d", sent)
+	}
+}
+
+func TestQueue_MaxAttempts(t *testing.T) {
+	var online atomic.Bool
+	server := newQueueTestServer(t, &online)
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(0))
+	var dropped QueuedMessage
+	queue := NewQueue(client.Messages,
+		WithQueueMaxAttempts(2),
+		WithQueueOnFailed(func(m QueuedMessage, err error) { dropped = m }),
+	)
+	ctx := context.Background()
+
+	id, _ := queue.Enqueue(ctx, &SendMessageRequest{To: "+15551234567", Text: "Hello"})
+	queue.Flush(ctx)
+	queue.Flush(ctx)
+
+	if dropped.ID != id || dropped.Attempts != 2 {
+		t.Errorf("expected message to be dropped after 2 attempts, got %+v", dropped)
+	}
+	if n, _ := queue.Len(ctx); n != 0 {
+		t.Errorf("expected empty queue, got %d", n)
+	}
+}
+
+func TestQueue_EnqueueValidation(t *testing.T) {
+	queue := NewQueue(nil)
+	_, err := queue.Enqueue(context.Background(), &SendMessageRequest{To: "+15551234567"})
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
+
+func TestQueue_FlushUsesStableIdempotencyKey(t *testing.T) {
+	server, reqs := failingServer(t, http.StatusBadGateway)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(0))
+	q := NewQueue(client.Messages)
+	ctx := context.Background()
+
+	id, err := q.Enqueue(ctx, &SendMessageRequest{To: "+15551234567", Text: "Hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := q.Flush(ctx); err == nil {
+		t.Fatal("expected the first flush to fail")
+	}
+	if err := q.Flush(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := reqs()
+	if len(got) != 2 || got[0].Header.Get(idempotencyKeyHeader) != id || got[1].Header.Get(idempotencyKeyHeader) != id {
+		t.Errorf("expected both of %d attempts to use idempotency key %s", len(got), id)
+	}
+}
@@ -0,0 +1,76 @@
+package sendly
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Rate limit headers returned by the API on every response.
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimitState is the server-side rate limit as of the most recent response.
+type RateLimitState struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is when the current window ends.
+	Reset time.Time
+	// ObservedAt is when the headers were received.
+	ObservedAt time.Time
+}
+
+// RateLimitState returns the rate limit reported by the most recent response,
+// or nil if no response has carried rate limit headers yet.
+func (c *Client) RateLimitState() *RateLimitState {
+	c.rateStateMu.Lock()
+	defer c.rateStateMu.Unlock()
+
+	if c.rateState == nil {
+		return nil
+	}
+	state := *c.rateState
+	return &state
+}
+
+// observeRateLimit records the rate limit headers of a response, returning
+// the parsed state or nil if the response had none.
+func (c *Client) observeRateLimit(header http.Header) *RateLimitState {
+	state := parseRateLimitHeaders(header, time.Now())
+	if state == nil {
+		return nil
+	}
+
+	c.rateStateMu.Lock()
+	c.rateState = state
+	c.rateStateMu.Unlock()
+
+	copied := *state
+	return &copied
+}
+
+// parseRateLimitHeaders reads X-RateLimit-* headers. Reset may be either a
+// Unix timestamp or a number of seconds from now.
+func parseRateLimitHeaders(header http.Header, now time.Time) *RateLimitState {
+	limit, limitErr := strconv.Atoi(header.Get(rateLimitLimitHeader))
+	remaining, remainingErr := strconv.Atoi(header.Get(rateLimitRemainingHeader))
+	if limitErr != nil && remainingErr != nil {
+		return nil
+	}
+
+	state := &RateLimitState{Limit: limit, Remaining: remaining, ObservedAt: now}
+	if reset, err := strconv.ParseInt(header.Get(rateLimitResetHeader), 10, 64); err == nil {
+		// Anything past 2001-09-09 is a timestamp rather than a delay.
+		if reset > 1_000_000_000 {
+			state.Reset = time.Unix(reset, 0)
+		} else {
+			state.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+	return state
+}
@@ -0,0 +1,73 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestClient_RateLimitState(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	if client.RateLimitState() != nil {
+		t.Error("expected no rate limit state before any request")
+	}
+
+	if _, err := client.Messages.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state := client.RateLimitState()
+	if state == nil {
+		t.Fatal("expected rate limit state")
+	}
+	if state.Limit != 100 {
+		t.Errorf("expected Limit to be 100, got %d", state.Limit)
+	}
+	if state.Remaining != 42 {
+		t.Errorf("expected Remaining to be 42, got %d", state.Remaining)
+	}
+	if state.Reset.Unix() != reset {
+		t.Errorf("expected Reset to be %d, got %d", reset, state.Reset.Unix())
+	}
+}
+
+func TestRateLimitError_State(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "10")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"rate_limit_exceeded","message":"Too many requests"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(0))
+
+	_, err := client.Messages.List(context.Background(), nil)
+	rateLimitErr, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("expected RateLimitError, got %T", err)
+	}
+	if rateLimitErr.State == nil {
+		t.Fatal("expected State to be set")
+	}
+	if rateLimitErr.State.Remaining != 0 {
+		t.Errorf("expected Remaining to be 0, got %d", rateLimitErr.State.Remaining)
+	}
+	if until := time.Until(rateLimitErr.State.Reset); until < 25*time.Second || until > 30*time.Second {
+		t.Errorf("expected Reset about 30s from now, got %v", until)
+	}
+}
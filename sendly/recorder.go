@@ -0,0 +1,286 @@
+package sendly
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecordMode controls how the recorder treats HTTP interactions.
+type RecordMode int
+
+const (
+	// RecordModeReplay serves responses from the cassette file and never
+	// touches the network. Unmatched requests fail.
+	RecordModeReplay RecordMode = iota
+	// RecordModeRecord sends requests to the API and writes every
+	// interaction to the cassette file, replacing its contents.
+	RecordModeRecord
+	// RecordModeAuto replays if the cassette file exists and records otherwise.
+	RecordModeAuto
+)
+
+// redactedValue replaces credentials in recorded interactions.
+const redactedValue = "[REDACTED]"
+
+// WithRecorder captures HTTP interactions to a cassette file at path, or
+// replays them from it, so tests can run deterministically without hitting
+// the live API. API keys are redacted from recorded requests. Clients
+// derived with Clone share the cassette, so their interactions land in the
+// same file instead of overwriting each other.
+func WithRecorder(path string, mode RecordMode) ClientOption {
+	t := &tape{path: path, mode: mode}
+	return func(c *Client) {
+		c.recorder = &recorder{tape: t}
+	}
+}
+
+// cassette is the on-disk format of recorded interactions.
+type cassette struct {
+	Interactions []interaction `json:"interactions"`
+}
+
+// interaction is a single recorded request and its response.
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// recorder is an http.RoundTripper that records or replays interactions.
+type recorder struct {
+	next   http.RoundTripper
+	apiKey string
+	*tape
+}
+
+// tape is the cassette state behind a WithRecorder option, shared by every
+// client the option is applied to.
+type tape struct {
+	path string
+	mode RecordMode
+
+	mu        sync.Mutex
+	loaded    bool
+	replaying bool
+	cassette  cassette
+	used      []bool
+}
+
+// wrap returns a copy of httpClient whose transport goes through the recorder.
+// apiKey is redacted wherever it appears in recorded interactions.
+func (r *recorder) wrap(httpClient *http.Client, apiKey string) *http.Client {
+	r.apiKey = apiKey
+	r.next = httpClient.Transport
+	if r.next == nil {
+		r.next = http.DefaultTransport
+	}
+	wrapped := *httpClient
+	wrapped.Transport = r
+	return &wrapped
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	if err := r.load(); err != nil {
+		r.mu.Unlock()
+		return nil, err
+	}
+	replaying := r.replaying
+	r.mu.Unlock()
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		// Cassettes hold the JSON the client sent, not its gzipped form.
+		if body, err = decodeBody(req.Header.Get("Content-Encoding"), body); err != nil {
+			return nil, err
+		}
+	}
+
+	if replaying {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+// load decides between replaying and recording and reads the cassette.
+// Callers must hold r.mu.
+func (r *recorder) load() error {
+	if r.loaded {
+		return nil
+	}
+
+	data, err := os.ReadFile(r.path)
+	switch {
+	case r.mode == RecordModeRecord:
+		r.replaying = false
+	case err == nil:
+		r.replaying = true
+	case r.mode == RecordModeAuto && os.IsNotExist(err):
+		r.replaying = false
+	default:
+		return fmt.Errorf("sendly: failed to read cassette %s: %w", r.path, err)
+	}
+
+	if r.replaying {
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return fmt.Errorf("sendly: failed to parse cassette %s: %w", r.path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	r.loaded = true
+	return nil
+}
+
+// replay returns the first unused recorded response matching the request.
+func (r *recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	target := req.URL.RequestURI()
+	for i, it := range r.cassette.Interactions {
+		if r.used[i] || it.Request.Method != req.Method || it.Request.URL != target {
+			continue
+		}
+		if it.Request.Body != "" && !jsonEqual(it.Request.Body, string(body)) {
+			continue
+		}
+		r.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", it.Response.StatusCode, http.StatusText(it.Response.StatusCode)),
+			StatusCode:    it.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        it.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(it.Response.Body)),
+			ContentLength: int64(len(it.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("sendly: no recorded interaction for %s %s", req.Method, target)
+}
+
+// record performs the request and appends the interaction to the cassette.
+func (r *recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cassettes store plain text, so decode compressed bodies before saving.
+	respBody, err := readBody(resp)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = int64(len(respBody))
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	it := interaction{
+		Request: recordedRequest{
+			Method:  req.Method,
+			URL:     req.URL.RequestURI(),
+			Headers: r.redactHeaders(req.Header),
+			Body:    r.redact(string(body)),
+		},
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.redactHeaders(resp.Header),
+			Body:       r.redact(string(respBody)),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, it)
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes the cassette to disk. Callers must hold r.mu.
+func (r *recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(r.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("sendly: failed to create cassette directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(r.path, data, 0o644); err != nil {
+		return fmt.Errorf("sendly: failed to write cassette %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// redactHeaders copies h with credentials replaced.
+func (r *recorder) redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for k, values := range out {
+		for i, v := range values {
+			values[i] = r.redact(v)
+		}
+		out[k] = values
+	}
+	if out.Get("Authorization") != "" {
+		out.Set("Authorization", "Bearer "+redactedValue)
+	}
+	return out
+}
+
+// redact replaces the API key wherever it appears in s.
+func (r *recorder) redact(s string) string {
+	if r.apiKey == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, r.apiKey, redactedValue)
+}
+
+// jsonEqual reports whether two bodies are equal, comparing JSON semantically.
+func jsonEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var va, vb interface{}
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return bytes.Equal(ja, jb)
+}
@@ -0,0 +1,87 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_RecordThenReplay(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(Message{ID: "msg_rec", To: "+15551234567", Status: MessageStatusQueued})
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "send.json")
+	ctx := context.Background()
+	req := &SendMessageRequest{To: "+15551234567", Text: "Recorded"}
+
+	recording := NewClient("sk_test_v1_secret", WithBaseURL(server.URL), WithRecorder(path, RecordModeRecord))
+	if _, err := recording.Messages.Send(ctx, req); err != nil {
+		t.Fatalf("unexpected error while recording: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected cassette to be written: %v", err)
+	}
+	if strings.Contains(string(data), "sk_test_v1_secret") {
+		t.Error("expected API key to be redacted from the cassette")
+	}
+
+	// Replay against an unreachable base URL to prove the network is not used.
+	replaying := NewClient("sk_test_v1_secret", WithBaseURL("http://127.0.0.1:1"), WithRecorder(path, RecordModeReplay))
+	msg, err := replaying.Messages.Send(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error while replaying: %v", err)
+	}
+	if msg.ID != "msg_rec" {
+		t.Errorf("expected ID to be 'msg_rec', got '%s'", msg.ID)
+	}
+	if hits != 1 {
+		t.Errorf("expected 1 request to the server, got %d", hits)
+	}
+}
+
+func TestRecorder_ReplayUnmatched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+	if err := os.WriteFile(path, []byte(`{"interactions":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient("test-api-key", WithBaseURL("http://127.0.0.1:1"), WithRecorder(path, RecordModeReplay), WithMaxRetries(0))
+
+	_, err := client.Messages.Get(context.Background(), "msg_missing")
+	if !IsNetworkError(err) {
+		t.Fatalf("expected NetworkError, got %T", err)
+	}
+	if !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("expected unmatched interaction error, got '%s'", err.Error())
+	}
+}
+
+func TestRecorder_AutoRecordsWhenMissing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(Message{ID: "msg_auto"})
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "auto.json")
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithRecorder(path, RecordModeAuto))
+
+	if _, err := client.Messages.Get(context.Background(), "msg_auto"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected cassette to be created: %v", err)
+	}
+}
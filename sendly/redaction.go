@@ -0,0 +1,148 @@
+package sendly
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RedactionPolicy controls how phone numbers and message text appear in
+// debug logs and in the messages, raw bodies, and snippets of errors. The
+// zero value, which is the default, masks all but the last 4 digits of phone
+// numbers and replaces message text with "[REDACTED]".
+type RedactionPolicy struct {
+	// ShowPhoneNumbers leaves phone numbers unmasked.
+	ShowPhoneNumbers bool
+	// VisibleDigits is how many trailing digits of a masked phone number stay
+	// visible. Zero means 4; a negative value masks every digit.
+	VisibleDigits int
+	// ShowText leaves message text unredacted.
+	ShowText bool
+}
+
+// NoRedaction shows phone numbers and message text in full.
+var NoRedaction = RedactionPolicy{ShowPhoneNumbers: true, ShowText: true}
+
+// WithRedaction sets how phone numbers and message text are masked in debug
+// logs and error messages. Without it, the default RedactionPolicy applies.
+func WithRedaction(policy RedactionPolicy) ClientOption {
+	return func(c *Client) {
+		c.redaction = policy
+	}
+}
+
+var (
+	// phonePattern matches E.164 phone numbers.
+	phonePattern = regexp.MustCompile(`\+[1-9]\d{6,14}`)
+	// textFieldPattern matches a JSON "text" member and its string value.
+	textFieldPattern = regexp.MustCompile(`"text"\s*:\s*"(?:[^"\\]|\\.)*"`)
+)
+
+// Redact applies the policy to s, which may be free text or JSON.
+func (p RedactionPolicy) Redact(s string) string {
+	if !p.ShowText {
+		s = textFieldPattern.ReplaceAllString(s, `"text":"[REDACTED]"`)
+	}
+	if !p.ShowPhoneNumbers {
+		s = phonePattern.ReplaceAllStringFunc(s, p.maskPhone)
+	}
+	return s
+}
+
+// maskPhone replaces all but the visible trailing digits of phone with '*'.
+func (p RedactionPolicy) maskPhone(phone string) string {
+	visible := p.VisibleDigits
+	if visible == 0 {
+		visible = 4
+	}
+	if visible < 0 {
+		visible = 0
+	}
+
+	digits := len(phone) - 1 // after the leading '+'
+	if visible > digits {
+		visible = digits
+	}
+	return "+" + strings.Repeat("*", digits-visible) + phone[len(phone)-visible:]
+}
+
+// redactPath applies the policy to a request path and its query string. A
+// search query is treated as message text, since it usually quotes some.
+func (p RedactionPolicy) redactPath(path string) string {
+	base, query, ok := strings.Cut(path, "?")
+	base = p.Redact(base)
+	if !ok {
+		return base
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return base + "?" + redactedValue
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		for _, v := range values[k] {
+			if b.Len() > 0 {
+				b.WriteByte('&')
+			}
+			if k == "q" && !p.ShowText {
+				v = redactedValue
+			} else {
+				v = p.Redact(v)
+			}
+			b.WriteString(k + "=" + v)
+		}
+	}
+	return base + "?" + b.String()
+}
+
+// redactAPIError masks PII in an error returned by the API.
+func (p RedactionPolicy) redactAPIError(e *APIError) {
+	e.Message = p.Redact(e.Message)
+	for k, v := range e.Details {
+		if str, ok := v.(string); ok {
+			e.Details[k] = p.Redact(str)
+		}
+	}
+	if len(e.RawBody) > 0 {
+		e.RawBody = []byte(p.Redact(string(e.RawBody)))
+	}
+}
+
+// debugLog logs one attempt when debug mode is on, redacting its path, body
+// and error.
+func (c *Client) debugLog(method, path string, body interface{}, attempt, status int, start time.Time, err error) {
+	if !c.cfg.debug {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "sendly: %s %s", method, c.redaction.redactPath(path))
+	if body != nil {
+		if data, marshalErr := json.Marshal(body); marshalErr == nil {
+			b.WriteString(" " + c.redaction.Redact(string(data)))
+		}
+	}
+	if attempt > 1 {
+		fmt.Fprintf(&b, " (retry %d)", attempt-1)
+	}
+	if status != 0 {
+		fmt.Fprintf(&b, " -> %d", status)
+	}
+	fmt.Fprintf(&b, " in %v", time.Since(start).Round(time.Millisecond))
+	if err != nil {
+		b.WriteString(": " + c.redaction.Redact(err.Error()))
+	}
+	log.Print(b.String())
+}
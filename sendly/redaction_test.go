@@ -0,0 +1,132 @@
+package sendly
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedactionPolicy_Redact(t *testing.T) {
+	const input = `{"to":"+15551234567","text":"Your code is \"4821\"","from":"+447700900123"}`
+
+	tests := []struct {
+		name   string
+		policy RedactionPolicy
+		want   string
+	}{
+		{"default", RedactionPolicy{}, `{"to":"+*******4567","text":"[REDACTED]","from":"+********0123"}`},
+		{"two digits", RedactionPolicy{VisibleDigits: 2}, `{"to":"+*********67","text":"[REDACTED]","from":"+**********23"}`},
+		{"no digits", RedactionPolicy{VisibleDigits: -1}, `{"to":"+***********","text":"[REDACTED]","from":"+************"}`},
+		{"show text", RedactionPolicy{ShowText: true}, `{"to":"+*******4567","text":"Your code is \"4821\"","from":"+********0123"}`},
+		{"none", NoRedaction, input},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Redact(input); got != tt.want {
+				t.Errorf("expected '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRedactionPolicy_FreeText(t *testing.T) {
+	got := RedactionPolicy{}.Redact("Invalid recipient +15551234567 for message msg_123")
+	if got != "Invalid recipient +*******4567 for message msg_123" {
+		t.Errorf("expected the number to be masked, got '%s'", got)
+	}
+}
+
+// rejectingServer rejects every request with an error echoing the recipient.
+func rejectingServer(t *testing.T) *httptest.Server {
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"INVALID_RECIPIENT","message":"Cannot send to +15551234567","details":{"to":"+15551234567"}}`))
+	})
+	return server
+}
+
+func TestClient_RedactsErrors(t *testing.T) {
+	client := NewClient("test-api-key", WithBaseURL(rejectingServer(t).URL))
+
+	_, err := client.Messages.Send(context.Background(), &SendMessageRequest{To: "+15551234567", Text: "Hello"})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "+15551234567") {
+		t.Errorf("expected the number to be masked in '%s'", err.Error())
+	}
+	if strings.Contains(string(validationErr.RawBody), "+15551234567") {
+		t.Errorf("expected the number to be masked in the raw body '%s'", validationErr.RawBody)
+	}
+	if validationErr.Details["to"] != "+*******4567" {
+		t.Errorf("expected the number to be masked in details, got '%v'", validationErr.Details["to"])
+	}
+}
+
+func TestWithRedaction_None(t *testing.T) {
+	client := NewClient("test-api-key", WithBaseURL(rejectingServer(t).URL), WithRedaction(NoRedaction))
+
+	_, err := client.Messages.Send(context.Background(), &SendMessageRequest{To: "+15551234567", Text: "Hello"})
+	if err == nil || !strings.Contains(err.Error(), "+15551234567") {
+		t.Errorf("expected the number in full, got %v", err)
+	}
+}
+
+func TestClient_DebugLogRedacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	original := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(original)
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithDebug(true))
+	req := &SendMessageRequest{To: "+15551234567", Text: "Your code is 4821"}
+	if _, err := client.Messages.Send(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "sendly: POST /messages") || !strings.Contains(out, "-> 200") {
+		t.Errorf("expected the request to be logged, got '%s'", out)
+	}
+	if strings.Contains(out, "+15551234567") || strings.Contains(out, "4821") {
+		t.Errorf("expected the number and text to be redacted, got '%s'", out)
+	}
+	if !strings.Contains(out, "+*******4567") {
+		t.Errorf("expected the masked number, got '%s'", out)
+	}
+}
+
+func TestRedactionPolicy_RedactPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RedactionPolicy
+		path   string
+		want   string
+	}{
+		{"phone segment", RedactionPolicy{}, "/opt-outs/+15551234567", "/opt-outs/+*******4567"},
+		{"search query", RedactionPolicy{}, "/messages/search?limit=10&q=your+code+4821", "/messages/search?limit=10&q=[REDACTED]"},
+		{"escaped phone", RedactionPolicy{ShowText: true}, "/messages/search?q=%2B15551234567", "/messages/search?q=+*******4567"},
+		{"none", NoRedaction, "/messages/search?q=hello", "/messages/search?q=hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.redactPath(tt.path); got != tt.want {
+				t.Errorf("expected '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
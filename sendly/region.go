@@ -0,0 +1,162 @@
+package sendly
+
+import (
+	"sync"
+	"time"
+)
+
+// Region is a Sendly data region. Requests sent to a region's endpoint are
+// processed and stored in that region.
+type Region string
+
+const (
+	RegionUS   Region = "us"
+	RegionEU   Region = "eu"
+	RegionAPAC Region = "apac"
+)
+
+// BaseURL returns the API base URL for the region.
+func (r Region) BaseURL() string {
+	return "https://" + string(r) + ".sendly.live/api/v1"
+}
+
+// WithRegion sends requests to the region's endpoint instead of
+// DefaultBaseURL, for data residency. It replaces any earlier WithBaseURL.
+func WithRegion(region Region) ClientOption {
+	return func(c *Client) {
+		c.region = region
+		c.BaseURL = region.BaseURL()
+	}
+}
+
+// RegionFailoverConfig configures WithRegionFailover.
+type RegionFailoverConfig struct {
+	// Secondary is the region to fail over to (required).
+	Secondary Region
+	// FailureThreshold is the number of consecutive requests to the primary
+	// endpoint that get no response, such as from DNS, connection or TLS
+	// failures or timeouts, before failing over (default: 5).
+	FailureThreshold int
+	// RetryPrimaryAfter is how long requests go to Secondary before the
+	// primary endpoint is tried again (default: 5 minutes).
+	RetryPrimaryAfter time.Duration
+	// OnFailover is called whenever requests move from one region to the
+	// other. from is empty if the primary endpoint is not a Region.
+	OnFailover func(from, to Region)
+}
+
+// WithRegionFailover moves requests to a secondary region while the primary
+// endpoint is unreachable. Requests that reach the API, even with an error
+// status, count as success: only connectivity failures trigger failover.
+//
+// Failover is opt-in because data sent while failed over is processed in the
+// secondary region. Only enable it where that is allowed.
+func WithRegionFailover(config RegionFailoverConfig) ClientOption {
+	return func(c *Client) {
+		if config.FailureThreshold <= 0 {
+			config.FailureThreshold = 5
+		}
+		if config.RetryPrimaryAfter <= 0 {
+			config.RetryPrimaryAfter = 5 * time.Minute
+		}
+		c.failover = &regionFailover{config: config, secondaryURL: config.Secondary.BaseURL(), now: time.Now}
+	}
+}
+
+// Region returns the region requests are currently sent to: the secondary
+// region while failed over, otherwise the one passed to WithRegion. It is
+// empty for a client using DefaultBaseURL or WithBaseURL.
+func (c *Client) Region() Region {
+	if c.failover != nil {
+		if _, secondary := c.failover.baseURL(); secondary {
+			return c.failover.config.Secondary
+		}
+	}
+	return c.region
+}
+
+// requestBaseURL returns the base URL for the next attempt, and whether it
+// is the failover region's.
+func (c *Client) requestBaseURL() (string, bool) {
+	if c.failover == nil {
+		return c.cfg.baseURL, false
+	}
+	return c.failover.baseURL()
+}
+
+// regionFailover tracks connectivity to the primary endpoint.
+type regionFailover struct {
+	config       RegionFailoverConfig
+	primary      Region
+	primaryURL   string
+	secondaryURL string
+	now          func() time.Time
+
+	mu           sync.Mutex
+	failures     int
+	failedOverAt time.Time
+}
+
+// attach records the client's primary endpoint once every option has been
+// applied.
+func (f *regionFailover) attach(c *Client) {
+	f.primary = c.region
+	f.primaryURL = c.BaseURL
+}
+
+// baseURL returns the base URL requests should use, moving back to the
+// primary endpoint once RetryPrimaryAfter has passed.
+func (f *regionFailover) baseURL() (string, bool) {
+	f.mu.Lock()
+	var change func()
+	defer func() {
+		f.mu.Unlock()
+		if change != nil {
+			change()
+		}
+	}()
+
+	if f.failedOverAt.IsZero() {
+		return f.primaryURL, false
+	}
+	if f.now().Sub(f.failedOverAt) < f.config.RetryPrimaryAfter {
+		return f.secondaryURL, true
+	}
+	f.failedOverAt = time.Time{}
+	f.failures = 0
+	change = f.notify(f.config.Secondary, f.primary)
+	return f.primaryURL, false
+}
+
+// record updates the failure count with the outcome of a request to the
+// primary endpoint. err is the transport error, or nil if a response was
+// received. Requests the caller gave up on are not recorded.
+func (f *regionFailover) record(err error) {
+	f.mu.Lock()
+	var change func()
+	defer func() {
+		f.mu.Unlock()
+		if change != nil {
+			change()
+		}
+	}()
+
+	if err == nil {
+		f.failures = 0
+		return
+	}
+	f.failures++
+	if f.failures >= f.config.FailureThreshold && f.failedOverAt.IsZero() {
+		f.failedOverAt = f.now()
+		change = f.notify(f.primary, f.config.Secondary)
+	}
+}
+
+// notify returns the OnFailover callback to run after unlocking. Callers
+// must hold f.mu.
+func (f *regionFailover) notify(from, to Region) func() {
+	if f.config.OnFailover == nil {
+		return nil
+	}
+	return func() { f.config.OnFailover(from, to) }
+}
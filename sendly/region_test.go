@@ -0,0 +1,102 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRegion(t *testing.T) {
+	client := NewClient("test-api-key", WithRegion(RegionEU))
+
+	if got := client.Config().BaseURL(); got != "https://eu.sendly.live/api/v1" {
+		t.Errorf("expected the EU endpoint, got %s", got)
+	}
+	if client.Region() != RegionEU {
+		t.Errorf("expected RegionEU, got %q", client.Region())
+	}
+	if NewClient("test-api-key").Region() != "" {
+		t.Error("expected no region for the default endpoint")
+	}
+}
+
+// unreachableURL returns the URL of a server that has been shut down.
+func unreachableURL(t *testing.T) string {
+	t.Helper()
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	return server.URL
+}
+
+func TestWithRegionFailover(t *testing.T) {
+	var secondaryCalls int32
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&secondaryCalls, 1)
+		w.Write([]byte(`{"id":"msg_1","status":"delivered"}`))
+	}))
+	defer secondary.Close()
+
+	var switches []Region
+	client := NewClient("test-api-key",
+		WithBaseURL(unreachableURL(t)),
+		WithMaxRetries(0),
+		WithRegionFailover(RegionFailoverConfig{
+			Secondary:        RegionUS,
+			FailureThreshold: 2,
+			OnFailover: func(from, to Region) {
+				switches = append(switches, to)
+			},
+		}),
+	)
+	client.failover.secondaryURL = secondary.URL
+	now := time.Now()
+	client.failover.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Messages.Get(context.Background(), "msg_1"); !IsNetworkError(err) {
+			t.Fatalf("expected a network error from the primary endpoint, got %v", err)
+		}
+	}
+	if client.Region() != RegionUS {
+		t.Fatalf("expected to fail over to RegionUS, got %q", client.Region())
+	}
+
+	if _, err := client.Messages.Get(context.Background(), "msg_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if secondaryCalls != 1 {
+		t.Errorf("expected 1 call to the secondary region, got %d", secondaryCalls)
+	}
+
+	now = now.Add(5 * time.Minute)
+	if client.Region() != "" {
+		t.Errorf("expected to move back to the primary endpoint, got %q", client.Region())
+	}
+	if len(switches) != 2 || switches[0] != RegionUS || switches[1] != "" {
+		t.Errorf("expected a switch to RegionUS and back, got %v", switches)
+	}
+}
+
+func TestWithRegionFailover_ErrorResponsesDoNotCount(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"server_error","message":"Something went wrong"}`))
+	}))
+	defer primary.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(primary.URL),
+		WithMaxRetries(0),
+		WithRegionFailover(RegionFailoverConfig{Secondary: RegionUS, FailureThreshold: 1}),
+	)
+
+	for i := 0; i < 3; i++ {
+		client.Messages.Get(context.Background(), "msg_1")
+	}
+	if client.Region() != "" {
+		t.Errorf("expected to stay on the primary endpoint, got %q", client.Region())
+	}
+}
@@ -0,0 +1,371 @@
+package sendly
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultRegistrationPollInterval is how often the WaitFor methods check a
+// registration when no interval is given. Reviews take hours to days.
+const defaultRegistrationPollInterval = time.Minute
+
+// RegistrationStatus is the review status of a brand, campaign or toll-free
+// verification.
+type RegistrationStatus string
+
+const (
+	// RegistrationStatusPending means the submission is queued for review.
+	RegistrationStatusPending RegistrationStatus = "pending"
+	// RegistrationStatusInReview means carriers are reviewing the submission.
+	RegistrationStatusInReview RegistrationStatus = "in_review"
+	// RegistrationStatusApproved means traffic may be sent.
+	RegistrationStatusApproved RegistrationStatus = "approved"
+	// RegistrationStatusRejected means the submission was refused; see
+	// Rejections for why. Fix the problems and submit again.
+	RegistrationStatusRejected RegistrationStatus = "rejected"
+)
+
+// RejectionReason is why a registration was rejected.
+type RejectionReason string
+
+const (
+	// RejectionReasonInvalidTaxID means the EIN or tax ID could not be verified.
+	RejectionReasonInvalidTaxID RejectionReason = "invalid_tax_id"
+	// RejectionReasonBrandMismatch means the legal name does not match the
+	// tax records for the tax ID.
+	RejectionReasonBrandMismatch RejectionReason = "brand_mismatch"
+	// RejectionReasonWebsiteUnverifiable means the website is unreachable or
+	// does not represent the business.
+	RejectionReasonWebsiteUnverifiable RejectionReason = "website_unverifiable"
+	// RejectionReasonInsufficientOptIn means the opt-in description does not
+	// show how recipients consent.
+	RejectionReasonInsufficientOptIn RejectionReason = "insufficient_opt_in"
+	// RejectionReasonSampleMismatch means the sample messages do not match the
+	// use case or lack the brand name and opt-out language.
+	RejectionReasonSampleMismatch RejectionReason = "sample_mismatch"
+	// RejectionReasonProhibitedContent means the use case is not allowed, such
+	// as cannabis or third-party lead generation.
+	RejectionReasonProhibitedContent RejectionReason = "prohibited_content"
+	// RejectionReasonOther covers reasons not listed above; see the message.
+	RejectionReasonOther RejectionReason = "other"
+)
+
+// Rejection is one reason a registration was rejected.
+type Rejection struct {
+	// Reason categorises the problem.
+	Reason RejectionReason `json:"reason"`
+	// Field is the request field at fault, if any, such as "ein".
+	Field string `json:"field,omitempty"`
+	// Message is the reviewer's explanation.
+	Message string `json:"message"`
+}
+
+// RegistrationReview is the review state shared by brands, campaigns and
+// toll-free verifications.
+type RegistrationReview struct {
+	// Status is the review status.
+	Status RegistrationStatus `json:"status"`
+	// Rejections explain a rejected status.
+	Rejections []Rejection `json:"rejections,omitempty"`
+	// SubmittedAt is when the registration was submitted.
+	SubmittedAt string `json:"submittedAt"`
+	// ReviewedAt is when the registration was approved or rejected.
+	ReviewedAt *string `json:"reviewedAt,omitempty"`
+}
+
+// Done reports whether the registration has been approved or rejected.
+func (r *RegistrationReview) Done() bool {
+	return r.Status == RegistrationStatusApproved || r.Status == RegistrationStatusRejected
+}
+
+// Approved reports whether the registration has been approved.
+func (r *RegistrationReview) Approved() bool {
+	return r.Status == RegistrationStatusApproved
+}
+
+// Brand is a business registered for 10DLC messaging.
+type Brand struct {
+	RegistrationReview
+
+	// ID is the brand ID (brd_xxx).
+	ID string `json:"id"`
+	// LegalName is the registered business name.
+	LegalName string `json:"legalName"`
+	// Country is the ISO 3166-1 alpha-2 country of registration.
+	Country string `json:"country"`
+	// EIN is the US employer identification number or other tax ID.
+	EIN string `json:"ein,omitempty"`
+	// Website is the business website.
+	Website string `json:"website,omitempty"`
+	// Vertical is the business's industry, such as "retail".
+	Vertical string `json:"vertical,omitempty"`
+}
+
+// RegisterBrandRequest is the request to register a 10DLC brand.
+type RegisterBrandRequest struct {
+	// LegalName is the business name exactly as on tax records (required).
+	LegalName string `json:"legalName"`
+	// Country is the ISO 3166-1 alpha-2 country of registration (required).
+	Country string `json:"country"`
+	// EIN is the tax ID (required for US businesses).
+	EIN string `json:"ein,omitempty"`
+	// Website is the business website.
+	Website string `json:"website,omitempty"`
+	// Vertical is the business's industry, such as "retail".
+	Vertical string `json:"vertical,omitempty"`
+	// ContactEmail receives carrier correspondence (required).
+	ContactEmail string `json:"contactEmail"`
+}
+
+// CampaignUseCase is what a 10DLC campaign's messages are for.
+type CampaignUseCase string
+
+const (
+	CampaignUseCaseTwoFactor           CampaignUseCase = "2fa"
+	CampaignUseCaseAccountNotification CampaignUseCase = "account_notification"
+	CampaignUseCaseCustomerCare        CampaignUseCase = "customer_care"
+	CampaignUseCaseMarketing           CampaignUseCase = "marketing"
+	CampaignUseCaseMixed               CampaignUseCase = "mixed"
+)
+
+// maxSampleMessages is the most sample messages a registration may carry.
+const maxSampleMessages = 5
+
+// Campaign is a registered 10DLC messaging use case for a brand.
+type Campaign struct {
+	RegistrationReview
+
+	// ID is the campaign ID (cmp_xxx).
+	ID string `json:"id"`
+	// BrandID is the brand the campaign belongs to.
+	BrandID string `json:"brandId"`
+	// UseCase is what the campaign's messages are for.
+	UseCase CampaignUseCase `json:"useCase"`
+	// Description describes the campaign.
+	Description string `json:"description"`
+	// SampleMessages are examples of the campaign's messages.
+	SampleMessages []string `json:"sampleMessages"`
+	// OptInDescription explains how recipients consent.
+	OptInDescription string `json:"optInDescription"`
+	// NumberIDs are the numbers assigned to the campaign.
+	NumberIDs []string `json:"numberIds,omitempty"`
+}
+
+// RegisterCampaignRequest is the request to register a 10DLC campaign.
+type RegisterCampaignRequest struct {
+	// BrandID is an approved brand (required).
+	BrandID string `json:"brandId"`
+	// UseCase is what the campaign's messages are for (required).
+	UseCase CampaignUseCase `json:"useCase"`
+	// Description describes the campaign (required).
+	Description string `json:"description"`
+	// SampleMessages are 1 to 5 examples of the campaign's messages,
+	// including the brand name and opt-out language (required).
+	SampleMessages []string `json:"sampleMessages"`
+	// OptInDescription explains how recipients consent (required).
+	OptInDescription string `json:"optInDescription"`
+	// NumberIDs are the numbers to send the campaign from.
+	NumberIDs []string `json:"numberIds,omitempty"`
+}
+
+// TollFreeVerification is a verification submission for a toll-free number.
+type TollFreeVerification struct {
+	RegistrationReview
+
+	// ID is the verification ID (tfv_xxx).
+	ID string `json:"id"`
+	// Number is the toll-free number being verified.
+	Number string `json:"number"`
+	// BusinessName is the business sending from the number.
+	BusinessName string `json:"businessName"`
+	// UseCase is what the number's messages are for.
+	UseCase CampaignUseCase `json:"useCase"`
+	// MonthlyVolume is the expected number of messages per month.
+	MonthlyVolume int `json:"monthlyVolume,omitempty"`
+}
+
+// SubmitTollFreeVerificationRequest is the request to verify a toll-free
+// number.
+type SubmitTollFreeVerificationRequest struct {
+	// Number is the toll-free number in E.164 format (required).
+	Number string `json:"number"`
+	// BusinessName is the business sending from the number (required).
+	BusinessName string `json:"businessName"`
+	// Website is the business website.
+	Website string `json:"website,omitempty"`
+	// UseCase is what the number's messages are for (required).
+	UseCase CampaignUseCase `json:"useCase"`
+	// SampleMessages are 1 to 5 examples of the number's messages (required).
+	SampleMessages []string `json:"sampleMessages"`
+	// OptInDescription explains how recipients consent (required).
+	OptInDescription string `json:"optInDescription"`
+	// MonthlyVolume is the expected number of messages per month.
+	MonthlyVolume int `json:"monthlyVolume,omitempty"`
+}
+
+// RegistrationsService submits the carrier registrations US traffic
+// requires: 10DLC brands and campaigns for local numbers, and verification
+// for toll-free numbers. Unregistered US traffic is blocked by carriers.
+type RegistrationsService struct {
+	client *Client
+}
+
+// RegisterBrand submits a business for 10DLC brand registration.
+func (s *RegistrationsService) RegisterBrand(ctx context.Context, req RegisterBrandRequest) (*Brand, error) {
+	if req.LegalName == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "legal name is required"}}
+	}
+	if len(req.Country) != 2 {
+		return nil, &ValidationError{APIError: APIError{Message: "country must be a 2-letter ISO code"}}
+	}
+	if strings.EqualFold(req.Country, "US") && req.EIN == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "EIN is required for US brands"}}
+	}
+	if req.ContactEmail == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "contact email is required"}}
+	}
+
+	var resp Brand
+	if err := s.client.request(ctx, "POST", "/registrations/brands", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetBrand retrieves a brand registration by ID.
+func (s *RegistrationsService) GetBrand(ctx context.Context, id string) (*Brand, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "brand ID is required"}}
+	}
+
+	var resp Brand
+	if err := s.client.request(ctx, "GET", "/registrations/brands/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RegisterCampaign submits a 10DLC campaign for an approved brand.
+func (s *RegistrationsService) RegisterCampaign(ctx context.Context, req RegisterCampaignRequest) (*Campaign, error) {
+	if req.BrandID == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "brand ID is required"}}
+	}
+	if req.Description == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "description is required"}}
+	}
+	if err := validateUseCaseSubmission(req.UseCase, req.SampleMessages, req.OptInDescription); err != nil {
+		return nil, err
+	}
+
+	var resp Campaign
+	if err := s.client.request(ctx, "POST", "/registrations/campaigns", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetCampaign retrieves a campaign registration by ID.
+func (s *RegistrationsService) GetCampaign(ctx context.Context, id string) (*Campaign, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "campaign ID is required"}}
+	}
+
+	var resp Campaign
+	if err := s.client.request(ctx, "GET", "/registrations/campaigns/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SubmitTollFreeVerification submits a toll-free number for verification.
+func (s *RegistrationsService) SubmitTollFreeVerification(ctx context.Context, req SubmitTollFreeVerificationRequest) (*TollFreeVerification, error) {
+	if !strings.HasPrefix(req.Number, "+") {
+		return nil, &ValidationError{APIError: APIError{Message: "phone number must be in E.164 format"}}
+	}
+	if req.BusinessName == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "business name is required"}}
+	}
+	if err := validateUseCaseSubmission(req.UseCase, req.SampleMessages, req.OptInDescription); err != nil {
+		return nil, err
+	}
+
+	var resp TollFreeVerification
+	if err := s.client.request(ctx, "POST", "/registrations/toll-free", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetTollFreeVerification retrieves a toll-free verification by ID.
+func (s *RegistrationsService) GetTollFreeVerification(ctx context.Context, id string) (*TollFreeVerification, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "verification ID is required"}}
+	}
+
+	var resp TollFreeVerification
+	if err := s.client.request(ctx, "GET", "/registrations/toll-free/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// WaitForBrand polls a brand every pollInterval (default: 1 minute) until
+// it is approved or rejected, and returns the final brand. A rejected brand
+// is returned without an error; check its Status and Rejections.
+func (s *RegistrationsService) WaitForBrand(ctx context.Context, id string, pollInterval time.Duration) (*Brand, error) {
+	return waitForReview(ctx, pollInterval, func() (*Brand, error) { return s.GetBrand(ctx, id) })
+}
+
+// WaitForCampaign polls a campaign like WaitForBrand.
+func (s *RegistrationsService) WaitForCampaign(ctx context.Context, id string, pollInterval time.Duration) (*Campaign, error) {
+	return waitForReview(ctx, pollInterval, func() (*Campaign, error) { return s.GetCampaign(ctx, id) })
+}
+
+// WaitForTollFreeVerification polls a toll-free verification like
+// WaitForBrand.
+func (s *RegistrationsService) WaitForTollFreeVerification(ctx context.Context, id string, pollInterval time.Duration) (*TollFreeVerification, error) {
+	return waitForReview(ctx, pollInterval, func() (*TollFreeVerification, error) { return s.GetTollFreeVerification(ctx, id) })
+}
+
+// waitForReview calls get every pollInterval until the registration it
+// returns is done.
+func waitForReview[T interface{ Done() bool }](ctx context.Context, pollInterval time.Duration, get func() (T, error)) (T, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultRegistrationPollInterval
+	}
+
+	for {
+		reg, err := get()
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		if reg.Done() {
+			return reg, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// validateUseCaseSubmission checks the fields campaigns and toll-free
+// verifications share.
+func validateUseCaseSubmission(useCase CampaignUseCase, samples []string, optIn string) error {
+	if useCase == "" {
+		return &ValidationError{APIError: APIError{Message: "use case is required"}}
+	}
+	if len(samples) == 0 || len(samples) > maxSampleMessages {
+		return &ValidationError{APIError: APIError{Message: "between 1 and 5 sample messages are required"}}
+	}
+	if optIn == "" {
+		return &ValidationError{APIError: APIError{Message: "opt-in description is required"}}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRegistrationsRegisterBrand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/registrations/brands" {
+			t.Errorf("expected POST /registrations/brands, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var req RegisterBrandRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.LegalName != "Acme Inc" || req.EIN != "12-3456789" {
+			t.Errorf("unexpected request %+v", req)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"brd_1","legalName":"Acme Inc","country":"US","status":"pending","submittedAt":"2025-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	brand, err := client.Registrations.RegisterBrand(context.Background(), RegisterBrandRequest{
+		LegalName:    "Acme Inc",
+		Country:      "US",
+		EIN:          "12-3456789",
+		ContactEmail: "compliance@acme.example",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if brand.ID != "brd_1" || brand.Status != RegistrationStatusPending || brand.Done() {
+		t.Errorf("unexpected brand %+v", brand)
+	}
+}
+
+func TestRegistrationsRegisterBrand_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+
+	tests := []RegisterBrandRequest{
+		{Country: "US", EIN: "12-3456789", ContactEmail: "a@b.example"},
+		{LegalName: "Acme Inc", Country: "USA", EIN: "12-3456789", ContactEmail: "a@b.example"},
+		{LegalName: "Acme Inc", Country: "US", ContactEmail: "a@b.example"},
+		{LegalName: "Acme Inc", Country: "US", EIN: "12-3456789"},
+	}
+	for _, req := range tests {
+		if _, err := client.Registrations.RegisterBrand(context.Background(), req); !IsValidationError(err) {
+			t.Errorf("expected ValidationError for %+v, got %T", req, err)
+		}
+	}
+}
+
+func TestRegistrationsRegisterCampaign_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+	valid := RegisterCampaignRequest{
+		BrandID:          "brd_1",
+		UseCase:          CampaignUseCaseAccountNotification,
+		Description:      "Order updates",
+		SampleMessages:   []string{"Acme: your order shipped. Reply STOP to opt out."},
+		OptInDescription: "Customers tick a box at checkout",
+	}
+
+	noSamples := valid
+	noSamples.SampleMessages = nil
+	tooManySamples := valid
+	tooManySamples.SampleMessages = make([]string, 6)
+	noOptIn := valid
+	noOptIn.OptInDescription = ""
+
+	for _, req := range []RegisterCampaignRequest{noSamples, tooManySamples, noOptIn} {
+		if _, err := client.Registrations.RegisterCampaign(context.Background(), req); !IsValidationError(err) {
+			t.Errorf("expected ValidationError for %+v, got %T", req, err)
+		}
+	}
+}
+
+func TestRegistrationsWaitForTollFreeVerification_Rejected(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/registrations/toll-free/tfv_1" {
+			t.Errorf("expected path '/registrations/toll-free/tfv_1', got '%s'", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Write([]byte(`{"id":"tfv_1","status":"in_review"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"tfv_1","status":"rejected","rejections":[{"reason":"insufficient_opt_in","field":"optInDescription","message":"Opt-in flow is not shown"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	verification, err := client.Registrations.WaitForTollFreeVerification(context.Background(), "tfv_1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if verification.Status != RegistrationStatusRejected || verification.Approved() {
+		t.Errorf("expected a rejected verification, got %+v", verification)
+	}
+	if len(verification.Rejections) != 1 || verification.Rejections[0].Reason != RejectionReasonInsufficientOptIn {
+		t.Errorf("unexpected rejections %+v", verification.Rejections)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 polls, got %d", calls)
+	}
+}
+
+func TestRegistrationsWaitForBrand_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"brd_1","status":"pending"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Registrations.WaitForBrand(ctx, "brd_1", 5*time.Millisecond); err == nil {
+		t.Error("expected an error once the context is done")
+	}
+}
@@ -0,0 +1,37 @@
+package sendly
+
+import (
+	"context"
+	"net/url"
+)
+
+// tagsHeader carries the caller's tags, URL-encoded.
+const tagsHeader = "X-Sendly-Tags"
+
+// ContextWithRequestID returns a context whose requests carry id in the
+// X-Request-Id header. Sendly records it in its request logs, so a trace or
+// log line in your application can be matched to the API calls it made.
+//
+// Example:
+//
+//	ctx = sendly.ContextWithRequestID(ctx, span.SpanContext().TraceID().String())
+//	msg, err := client.Messages.Send(ctx, req)
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return WithCallOptions(ctx, func(o *callOptions) {
+		o.requestID = id
+	})
+}
+
+// ContextWithTag returns a context whose requests carry the tag key=value in
+// the X-Sendly-Tags header, alongside any tags already on ctx. Setting a key
+// again replaces its value.
+func ContextWithTag(ctx context.Context, key, value string) context.Context {
+	return WithCallOptions(ctx, func(o *callOptions) {
+		tags := make(url.Values, len(o.tags)+1)
+		for k, v := range o.tags {
+			tags[k] = v
+		}
+		tags.Set(key, value)
+		o.tags = tags
+	})
+}
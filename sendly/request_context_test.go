@@ -0,0 +1,61 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContextWithRequestIDAndTags(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	ctx := ContextWithRequestID(context.Background(), "trace-123")
+	ctx = ContextWithTag(ctx, "tenant", "acme")
+	ctx = ContextWithTag(ctx, "job", "nightly reminders")
+
+	if _, err := client.Messages.Send(ctx, &SendMessageRequest{To: "+15551234567", Text: "Hello"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := header.Get("X-Request-Id"); got != "trace-123" {
+		t.Errorf("expected X-Request-Id trace-123, got %q", got)
+	}
+	if got := header.Get("X-Sendly-Tags"); got != "job=nightly+reminders&tenant=acme" {
+		t.Errorf("unexpected X-Sendly-Tags %q", got)
+	}
+}
+
+func TestContextWithTag_DoesNotModifyParent(t *testing.T) {
+	parent := ContextWithTag(context.Background(), "tenant", "acme")
+	child := ContextWithTag(parent, "tenant", "globex")
+
+	if got := callOptionsFrom(parent).tags.Get("tenant"); got != "acme" {
+		t.Errorf("expected the parent tag to stay acme, got %q", got)
+	}
+	if got := callOptionsFrom(child).tags.Get("tenant"); got != "globex" {
+		t.Errorf("expected the child tag to be globex, got %q", got)
+	}
+}
+
+func TestContextWithRequestID_NotSetByDefault(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	if _, err := client.Messages.Get(context.Background(), "msg_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if header.Get("X-Request-Id") != "" || header.Get("X-Sendly-Tags") != "" {
+		t.Errorf("expected no correlation headers, got %v", header)
+	}
+}
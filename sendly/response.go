@@ -0,0 +1,111 @@
+package sendly
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// decodeSnippetSize is how much of a response body DecodeError reports.
+const decodeSnippetSize = 512
+
+// WithMaxResponseBytes caps the decoded size of a response body (default:
+// unlimited). Larger responses fail with a NetworkError wrapping
+// ErrResponseTooLarge instead of exhausting memory, and are not retried.
+func WithMaxResponseBytes(n int64) ClientOption {
+	return func(c *Client) {
+		c.maxResponseBytes = n
+	}
+}
+
+// WithMaxResponseSize is an alias for WithMaxResponseBytes.
+func WithMaxResponseSize(bytes int64) ClientOption {
+	return WithMaxResponseBytes(bytes)
+}
+
+// responseBody returns a reader that decompresses resp.Body as it is read
+// and enforces the client's response size limit. Closing it closes only the
+// decompressor; resp.Body is closed by the caller.
+func (c *Client) responseBody(resp *http.Response) (io.ReadCloser, error) {
+	body, err := newBodyReader(resp.Header.Get("Content-Encoding"), resp.Body)
+	if err == io.EOF {
+		// A compressed response with an empty body.
+		return io.NopCloser(http.NoBody), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.maxResponseBytes <= 0 {
+		return body, nil
+	}
+	return &limitedBody{ReadCloser: body, remaining: c.maxResponseBytes}, nil
+}
+
+// limitedBody fails with ErrResponseTooLarge once more than remaining bytes
+// have been read. Unlike io.LimitReader, it distinguishes a body that ends
+// exactly at the limit from one that exceeds it.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		var probe [1]byte
+		n, err := l.ReadCloser.Read(probe[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.ReadCloser.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// isDecodeFailure reports whether a json.Decoder error means the body was
+// not valid JSON for the result, as opposed to a failure to read it.
+func isDecodeFailure(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || err == io.ErrUnexpectedEOF
+}
+
+// snippetWriter keeps the first decodeSnippetSize bytes written to it, so a
+// streamed body can still be quoted when it fails to decode.
+type snippetWriter struct {
+	buf       []byte
+	truncated bool
+}
+
+func (w *snippetWriter) Write(p []byte) (int, error) {
+	room := decodeSnippetSize - len(w.buf)
+	if len(p) > room {
+		w.buf = append(w.buf, p[:room]...)
+		w.truncated = true
+	} else {
+		w.buf = append(w.buf, p...)
+	}
+	return len(p), nil
+}
+
+// fill reads from r until the snippet is full or r is exhausted. The decoder
+// stops at the first bad token, which may be well short of a useful snippet.
+func (w *snippetWriter) fill(r io.Reader) {
+	if !w.truncated {
+		io.Copy(w, io.LimitReader(r, int64(decodeSnippetSize-len(w.buf)+1)))
+	}
+}
+
+// String returns the captured bytes, marking them as truncated if the body
+// was longer.
+func (w *snippetWriter) String() string {
+	if w.truncated {
+		return string(w.buf) + "..."
+	}
+	return string(w.buf)
+}
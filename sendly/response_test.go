@@ -0,0 +1,198 @@
+package sendly
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// listBody returns a ListMessages response with n messages.
+func listBody(n int) string {
+	var b strings.Builder
+	b.WriteString(`{"data":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"id":"msg_%d","to":"+15551234567","status":"delivered"}`, i)
+	}
+	fmt.Fprintf(&b, `],"count":%d}`, n)
+	return b.String()
+}
+
+func TestClientRequest_StreamsLargeResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(listBody(5000)))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+
+	resp, err := client.Messages.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 5000 {
+		t.Errorf("expected 5000 messages, got %d", len(resp.Data))
+	}
+	if resp.Data[4999].ID != "msg_4999" {
+		t.Errorf("expected last ID to be 'msg_4999', got '%s'", resp.Data[4999].ID)
+	}
+}
+
+func TestClientRequest_EmptyResponseBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+
+	if err := client.WebhooksService.Delete(context.Background(), "whk_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWithMaxResponseBytes(t *testing.T) {
+	body := listBody(100)
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxResponseBytes(1024))
+
+	_, err := client.Messages.List(context.Background(), nil)
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got %v", err)
+	}
+	if !IsNetworkError(err) {
+		t.Errorf("expected a NetworkError, got %T", err)
+	}
+	if atomic.LoadInt32(&hits) != 1 {
+		t.Errorf("expected 1 request without retries, got %d", hits)
+	}
+}
+
+func TestWithMaxResponseBytes_ExactLimit(t *testing.T) {
+	body := listBody(3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxResponseBytes(int64(len(body))))
+
+	resp, err := client.Messages.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 3 {
+		t.Errorf("expected 3 messages, got %d", len(resp.Data))
+	}
+}
+
+func TestWithMaxResponseBytes_AppliesToDecompressedSize(t *testing.T) {
+	body := listBody(100)
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(body))
+	zw.Close()
+	if buf.Len() >= 1024 {
+		t.Fatalf("expected the compressed body to be under 1024 bytes, got %d", buf.Len())
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxResponseBytes(1024))
+
+	if _, err := client.Messages.List(context.Background(), nil); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got %v", err)
+	}
+}
+
+func TestClientRequest_DecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("<html><body>Bad Gateway</body></html>"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(0))
+
+	_, err := client.Messages.Get(context.Background(), "msg_1")
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a DecodeError, got %T: %v", err, err)
+	}
+	if !errors.Is(err, ErrDecode) {
+		t.Error("expected errors.Is to match ErrDecode")
+	}
+	if decodeErr.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", decodeErr.StatusCode)
+	}
+	if decodeErr.ContentType != "text/html" {
+		t.Errorf("expected content type 'text/html', got '%s'", decodeErr.ContentType)
+	}
+	if decodeErr.Snippet != "<html><body>Bad Gateway</body></html>" {
+		t.Errorf("expected the body as snippet, got '%s'", decodeErr.Snippet)
+	}
+	if !strings.Contains(err.Error(), "text/html") || !strings.Contains(err.Error(), "Bad Gateway") {
+		t.Errorf("expected the message to include content type and body, got '%s'", err.Error())
+	}
+}
+
+func TestClientRequest_DecodeErrorTruncatesSnippet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": ` + strings.Repeat("x", 4096)))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(0))
+
+	_, err := client.Messages.Get(context.Background(), "msg_1")
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a DecodeError, got %T: %v", err, err)
+	}
+	if len(decodeErr.Snippet) != decodeSnippetSize+len("...") {
+		t.Errorf("expected a %d byte snippet, got %d", decodeSnippetSize+3, len(decodeErr.Snippet))
+	}
+	if !strings.HasSuffix(decodeErr.Snippet, "...") {
+		t.Error("expected the snippet to be marked as truncated")
+	}
+}
+
+func TestWithMaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(listBody(100)))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxResponseSize(512))
+
+	if _, err := client.Messages.List(context.Background(), nil); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got %v", err)
+	}
+}
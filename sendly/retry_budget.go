@@ -0,0 +1,30 @@
+package sendly
+
+import "time"
+
+// WithRetryBudget bounds the total time a request spends waiting between
+// attempts, summing exponential backoff and Retry-After delays (default:
+// unbounded). When the next wait would exceed the budget, the request fails
+// with the last error instead of waiting. The time spent on the attempts
+// themselves is governed by WithTimeout and the context.
+func WithRetryBudget(maxTotalWait time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retryBudget = maxTotalWait
+	}
+}
+
+// retryWaits tracks the time one request has spent waiting to retry.
+type retryWaits struct {
+	budget time.Duration
+	spent  time.Duration
+}
+
+// allow reports whether waiting d more stays within the budget, and if so
+// counts it as spent.
+func (w *retryWaits) allow(d time.Duration) bool {
+	if w.budget > 0 && w.spent+d > w.budget {
+		return false
+	}
+	w.spent += d
+	return true
+}
@@ -0,0 +1,78 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRetryBudget_RetryAfterExceedsBudget(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"rate_limited","message":"Too many requests"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithRetryBudget(5*time.Second))
+
+	start := time.Now()
+	_, err := client.Messages.List(context.Background(), nil)
+	if !IsRateLimitError(err) {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected to fail without waiting, took %v", elapsed)
+	}
+	if atomic.LoadInt32(&hits) != 1 {
+		t.Errorf("expected 1 request, got %d", hits)
+	}
+}
+
+func TestWithRetryBudget_BoundsBackoff(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":"unavailable","message":"Try again"}`))
+	}))
+	defer server.Close()
+
+	// Backoffs are 1s, 2s, 4s: only the first fits in the budget.
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithMaxRetries(3),
+		WithRetryBudget(1500*time.Millisecond),
+	)
+
+	start := time.Now()
+	if _, err := client.Messages.List(context.Background(), nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 1500*time.Millisecond {
+		t.Errorf("expected to stay within the budget, took %v", elapsed)
+	}
+	if atomic.LoadInt32(&hits) != 2 {
+		t.Errorf("expected 2 requests, got %d", hits)
+	}
+}
+
+func TestRetryWaits_Allow(t *testing.T) {
+	unbounded := retryWaits{}
+	if !unbounded.allow(time.Hour) {
+		t.Error("expected a zero budget to allow any wait")
+	}
+
+	waits := retryWaits{budget: 3 * time.Second}
+	if !waits.allow(time.Second) || !waits.allow(2*time.Second) {
+		t.Error("expected waits within the budget to be allowed")
+	}
+	if waits.allow(time.Millisecond) {
+		t.Error("expected a wait beyond the budget to be refused")
+	}
+}
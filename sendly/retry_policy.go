@@ -0,0 +1,63 @@
+package sendly
+
+import (
+	"errors"
+	"net"
+	"net/http"
+)
+
+// idempotencyKeyHeader lets the API recognise a retried request and return
+// the original result instead of acting on it twice.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// WithIdempotencyKey sends key with the call, so a POST such as Send can be
+// retried safely after a server error or timeout: the API applies a key at
+// most once. Use a fresh key, for example from NewClientID, per logical
+// operation, and reuse it only when repeating that same operation.
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// WithUnsafeRetries retries non-idempotent calls such as Send after server
+// errors and timeouts even without an idempotency key. If the first attempt
+// succeeded server-side, the message is sent twice; use it only when an
+// occasional duplicate is acceptable.
+func WithUnsafeRetries() ClientOption {
+	return func(c *Client) {
+		c.unsafeRetries = true
+	}
+}
+
+// canRetry reports whether a failed attempt may be repeated. GET, PUT, and
+// DELETE calls can always be retried, as can POST and PATCH calls carrying an
+// idempotency key. Other calls are retried only when the error shows the API
+// did not act on the request.
+func (c *Client) canRetry(method string, call callOptions, err error) bool {
+	if c.unsafeRetries || call.idempotencyKey != "" || isIdempotentMethod(method) {
+		return true
+	}
+	return notProcessed(err)
+}
+
+// isIdempotentMethod reports whether repeating a request with method has the
+// same effect as sending it once.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// notProcessed reports whether err shows the request was not acted on: it
+// was rate limited, or the connection was never established.
+func notProcessed(err error) bool {
+	if IsRateLimitError(err) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
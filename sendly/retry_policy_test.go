@@ -0,0 +1,120 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// failingServer responds to the first request with status and to later ones
+// with a sent message.
+func failingServer(t *testing.T, status int) (*httptest.Server, func() []*http.Request) {
+	var calls int32
+	return newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"error":"server_error","message":"Something went wrong"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","status":"queued","data":[],"count":0}`))
+	})
+}
+
+var retryPolicyRequest = &SendMessageRequest{To: "+15551234567", Text: "Hello"}
+
+func TestRetryPolicy_PostNotRetriedOnServerError(t *testing.T) {
+	server, reqs := failingServer(t, http.StatusInternalServerError)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(1))
+
+	_, err := client.Messages.Send(context.Background(), retryPolicyRequest)
+	if err == nil {
+		t.Fatal("expected the server error to be returned")
+	}
+	if n := len(reqs()); n != 1 {
+		t.Errorf("expected 1 attempt, got %d", n)
+	}
+}
+
+func TestRetryPolicy_PostRetriedWithIdempotencyKey(t *testing.T) {
+	server, reqs := failingServer(t, http.StatusInternalServerError)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(1))
+
+	ctx := WithCallOptions(context.Background(), WithIdempotencyKey("key_123"))
+	if _, err := client.Messages.Send(ctx, retryPolicyRequest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := reqs()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(got))
+	}
+	for i, r := range got {
+		if key := r.Header.Get(idempotencyKeyHeader); key != "key_123" {
+			t.Errorf("expected attempt %d to send key 'key_123', got '%s'", i+1, key)
+		}
+	}
+}
+
+func TestRetryPolicy_PostRetriedWithUnsafeRetries(t *testing.T) {
+	server, reqs := failingServer(t, http.StatusBadGateway)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(1), WithUnsafeRetries())
+
+	if _, err := client.Messages.Send(context.Background(), retryPolicyRequest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(reqs()); n != 2 {
+		t.Errorf("expected 2 attempts, got %d", n)
+	}
+}
+
+func TestRetryPolicy_PostRetriedWhenRateLimited(t *testing.T) {
+	server, reqs := failingServer(t, http.StatusTooManyRequests)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(1))
+
+	if _, err := client.Messages.Send(context.Background(), retryPolicyRequest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(reqs()); n != 2 {
+		t.Errorf("expected 2 attempts, got %d", n)
+	}
+}
+
+func TestRetryPolicy_GetRetriedOnServerError(t *testing.T) {
+	server, reqs := failingServer(t, http.StatusInternalServerError)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(1))
+
+	if _, err := client.Messages.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(reqs()); n != 2 {
+		t.Errorf("expected 2 attempts, got %d", n)
+	}
+}
+
+func TestNotProcessed(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"rate limited", &RateLimitError{}, true},
+		{"dial failure", &NetworkError{Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, true},
+		{"read failure", &NetworkError{Err: &net.OpError{Op: "read", Err: errors.New("connection reset")}}, false},
+		{"server error", &SendlyError{StatusCode: 500}, false},
+		{"wrapped dial failure", fmt.Errorf("send: %w", &NetworkError{Err: &net.OpError{Op: "dial"}}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notProcessed(tt.err); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
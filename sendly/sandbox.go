@@ -0,0 +1,65 @@
+package sendly
+
+import (
+	"net/http"
+	"strings"
+)
+
+// sandboxHeader asks the API to process a request in sandbox mode, and is
+// echoed on responses that were handled by the sandbox.
+const sandboxHeader = "X-Sendly-Sandbox"
+
+// WithSandbox forces every request into sandbox mode, regardless of the API
+// key type, so staging environments cannot accidentally send real SMS.
+func WithSandbox(sandbox bool) ClientOption {
+	return func(c *Client) {
+		c.sandbox = sandbox
+	}
+}
+
+// IsSandbox reports whether requests from this client are processed in
+// sandbox mode, either because WithSandbox was set or a test key is used.
+func (c *Client) IsSandbox() bool {
+	return c.sandbox || strings.HasPrefix(c.cfg.apiKey, "sk_test_")
+}
+
+// sandboxMarker is implemented by response types that carry an IsSandbox flag.
+type sandboxMarker interface {
+	markSandbox()
+}
+
+// markSandbox flags result as a sandbox response when the client or the
+// server indicates sandbox mode.
+func (c *Client) markSandbox(header http.Header, result interface{}) {
+	if !c.IsSandbox() && header.Get(sandboxHeader) != "true" {
+		return
+	}
+	if m, ok := result.(sandboxMarker); ok {
+		m.markSandbox()
+	}
+}
+
+func (m *Message) markSandbox() { m.IsSandbox = true }
+
+func (m *ScheduledMessage) markSandbox() { m.IsSandbox = true }
+
+func (r *CancelScheduledMessageResponse) markSandbox() { r.IsSandbox = true }
+
+func (r *BatchMessageResponse) markSandbox() { r.IsSandbox = true }
+
+func (r *BatchPreviewResponse) markSandbox() { r.IsSandbox = true }
+
+// markSandbox flags every item on the page that carries an IsSandbox flag.
+func (p *Page[T]) markSandbox() {
+	for i := range p.Data {
+		if m, ok := any(&p.Data[i]).(sandboxMarker); ok {
+			m.markSandbox()
+		}
+	}
+}
+
+func (r *TransactionResponse) markSandbox() {
+	for i := range r.Messages {
+		r.Messages[i].IsSandbox = true
+	}
+}
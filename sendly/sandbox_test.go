@@ -0,0 +1,105 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithSandbox_SendsHeaderAndMarksResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Sendly-Sandbox") != "true" {
+			t.Errorf("expected X-Sendly-Sandbox header to be 'true', got '%s'", r.Header.Get("X-Sendly-Sandbox"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/messages/schedule":
+			json.NewEncoder(w).Encode(ScheduledMessage{ID: "sched_1", Status: ScheduledMessageStatusScheduled})
+		case "/messages/batches":
+			json.NewEncoder(w).Encode(ListBatchesResponse{Data: []BatchMessageResponse{{BatchID: "batch_1"}}, Count: 1})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_live_v1_key", WithBaseURL(server.URL), WithSandbox(true))
+	ctx := context.Background()
+
+	if !client.IsSandbox() {
+		t.Error("expected client to report sandbox mode")
+	}
+
+	scheduled, err := client.Messages.Schedule(ctx, &ScheduleMessageRequest{
+		To:          "+15551234567",
+		Text:        "Later",
+		ScheduledAt: scheduledAtFixture,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !scheduled.IsSandbox {
+		t.Error("expected scheduled message to be marked as sandbox")
+	}
+
+	batches, err := client.Messages.ListBatches(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !batches.Data[0].IsSandbox {
+		t.Error("expected listed batch to be marked as sandbox")
+	}
+}
+
+func TestSandbox_ResponseHeaderMarksResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Sendly-Sandbox") != "" {
+			t.Error("expected no sandbox header without WithSandbox")
+		}
+		w.Header().Set("X-Sendly-Sandbox", "true")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(BatchMessageResponse{BatchID: "batch_1"})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_live_v1_key", WithBaseURL(server.URL))
+
+	batch, err := client.Messages.GetBatch(context.Background(), "batch_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !batch.IsSandbox {
+		t.Error("expected batch to be marked as sandbox from response header")
+	}
+}
+
+func TestSandbox_LiveKeyNotMarked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(BatchMessageResponse{BatchID: "batch_1"})
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_live_v1_key", WithBaseURL(server.URL))
+
+	if client.IsSandbox() {
+		t.Error("expected live key client not to report sandbox mode")
+	}
+
+	batch, err := client.Messages.GetBatch(context.Background(), "batch_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batch.IsSandbox {
+		t.Error("expected live batch not to be marked as sandbox")
+	}
+}
+
+func TestSandbox_TestKeyImpliesSandbox(t *testing.T) {
+	client := NewClient("sk_test_v1_key")
+
+	if !client.IsSandbox() {
+		t.Error("expected test key client to report sandbox mode")
+	}
+}
@@ -0,0 +1,99 @@
+package sendly
+
+import "time"
+
+// MaxScheduleHorizon is the furthest ahead the API accepts a scheduled
+// message.
+const MaxScheduleHorizon = 30 * 24 * time.Hour
+
+// localTimestampLayout is the layout of a ScheduledAt wall-clock time without
+// an offset, accepted with SendAtRecipientLocalTime.
+const localTimestampLayout = "2006-01-02T15:04:05"
+
+// ScheduledAtLocal sets ScheduledAt to the wall-clock time of t in loc,
+// regardless of t's own location, and returns r for chaining. For example,
+// 9am in New York:
+//
+//	ny, _ := time.LoadLocation("America/New_York")
+//	req.ScheduledAtLocal(time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC), ny)
+//
+// A nil loc uses t's location.
+func (r *ScheduleMessageRequest) ScheduledAtLocal(t time.Time, loc *time.Location) *ScheduleMessageRequest {
+	r.ScheduledAt = inLocation(t, loc).UTC().Format(time.RFC3339)
+	r.SendAt = time.Time{}
+	return r
+}
+
+// SendTime returns the instant the message is scheduled for, from SendAt or
+// ScheduledAt, resolving SendAtRecipientLocalTime against the recipient's
+// time zone.
+func (r *ScheduleMessageRequest) SendTime() (time.Time, error) {
+	var t time.Time
+	switch {
+	case !r.SendAt.IsZero() && r.ScheduledAt != "":
+		return time.Time{}, &ValidationError{APIError: APIError{Message: "only one of scheduledAt and SendAt may be set"}}
+	case !r.SendAt.IsZero():
+		t = r.SendAt
+	case r.ScheduledAt == "":
+		return time.Time{}, &ValidationError{APIError: APIError{Message: "scheduledAt is required"}}
+	default:
+		var err error
+		t, err = time.Parse(time.RFC3339, r.ScheduledAt)
+		if err != nil && r.SendAtRecipientLocalTime {
+			t, err = time.Parse(localTimestampLayout, r.ScheduledAt)
+		}
+		if err != nil {
+			return time.Time{}, &ValidationError{APIError: APIError{Message: "scheduledAt must be an ISO 8601 timestamp"}}
+		}
+	}
+
+	if !r.SendAtRecipientLocalTime {
+		return t, nil
+	}
+	loc, err := LocationForNumber(r.To)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return inLocation(t, loc), nil
+}
+
+// CheckSendTime returns a *ScheduleTimeError if the message's send time is
+// before now or more than MaxScheduleHorizon after it, and a
+// *ValidationError if the send time cannot be resolved.
+func (r *ScheduleMessageRequest) CheckSendTime(now time.Time) error {
+	at, err := r.SendTime()
+	if err != nil {
+		return err
+	}
+	if at.Before(now) {
+		return &ScheduleTimeError{ScheduledAt: at, InPast: true}
+	}
+	if at.After(now.Add(MaxScheduleHorizon)) {
+		return &ScheduleTimeError{ScheduledAt: at}
+	}
+	return nil
+}
+
+// resolveSendTime checks the send time against now and returns a copy of r
+// with it as an absolute ScheduledAt.
+func (r *ScheduleMessageRequest) resolveSendTime(now time.Time) (*ScheduleMessageRequest, error) {
+	if err := r.CheckSendTime(now); err != nil {
+		return nil, err
+	}
+	at, _ := r.SendTime()
+	resolved := *r
+	resolved.ScheduledAt = at.UTC().Format(time.RFC3339)
+	resolved.SendAt = time.Time{}
+	resolved.SendAtRecipientLocalTime = false
+	return &resolved, nil
+}
+
+// inLocation returns the time with t's wall clock in loc, or t if loc is nil.
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	return time.Date(year, month, day, hour, min, sec, t.Nanosecond(), loc)
+}
@@ -0,0 +1,108 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestScheduleMessageRequest_ScheduledAtLocal(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	req := (&ScheduleMessageRequest{To: "+15551234567", Text: "Hi"}).
+		ScheduledAtLocal(time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC), tokyo)
+	if req.ScheduledAt != "2025-03-14T00:00:00Z" {
+		t.Errorf("expected 9am Tokyo to be 2025-03-14T00:00:00Z, got %s", req.ScheduledAt)
+	}
+
+	req.ScheduledAtLocal(time.Date(2025, 3, 14, 9, 0, 0, 0, tokyo), nil)
+	if req.ScheduledAt != "2025-03-14T00:00:00Z" {
+		t.Errorf("expected a nil location to keep t's location, got %s", req.ScheduledAt)
+	}
+}
+
+func TestScheduleMessageRequest_SendTime(t *testing.T) {
+	tests := []struct {
+		name        string
+		to          string
+		scheduledAt string
+		want        string
+	}{
+		{"london winter", "+447911123456", "2025-01-10T09:00:00", "2025-01-10T09:00:00Z"},
+		{"london summer", "+447911123456", "2025-07-10T09:00:00", "2025-07-10T08:00:00Z"},
+		{"offset ignored", "+819012345678", "2025-07-10T09:00:00-05:00", "2025-07-10T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &ScheduleMessageRequest{To: tt.to, ScheduledAt: tt.scheduledAt, SendAtRecipientLocalTime: true}
+			got, err := req.SendTime()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if s := got.UTC().Format(time.RFC3339); s != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, s)
+			}
+		})
+	}
+}
+
+func TestMessagesSchedule_RecipientLocalTime(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	day := time.Now().AddDate(0, 0, 7)
+	local := time.Date(day.Year(), day.Month(), day.Day(), 9, 0, 0, 0, time.UTC).Format("2006-01-02T15:04:05")
+	want := time.Date(day.Year(), day.Month(), day.Day(), 9, 0, 0, 0, london).UTC().Format(time.RFC3339)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if body["scheduledAt"] != want {
+			t.Errorf("expected scheduledAt %s, got %v", want, body["scheduledAt"])
+		}
+		if _, ok := body["sendAtRecipientLocalTime"]; ok {
+			t.Error("expected SendAtRecipientLocalTime not to be sent")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"sched_1","to":"+447911123456","status":"scheduled"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	req := &ScheduleMessageRequest{
+		To:                       "+447911123456",
+		Text:                     "Good morning",
+		ScheduledAt:              local,
+		SendAtRecipientLocalTime: true,
+	}
+	if _, err := client.Messages.Schedule(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.ScheduledAt != local {
+		t.Errorf("expected the request not to be modified, got %s", req.ScheduledAt)
+	}
+}
+
+func TestMessagesSchedule_RecipientLocalTimeUnknownCountry(t *testing.T) {
+	client := NewClient("test-api-key")
+	_, err := client.Messages.Schedule(context.Background(), &ScheduleMessageRequest{
+		To:                       "+0001234567",
+		Text:                     "Good morning",
+		ScheduledAt:              "2025-07-10T09:00:00",
+		SendAtRecipientLocalTime: true,
+	})
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+}
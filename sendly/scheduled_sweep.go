@@ -0,0 +1,90 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// SweepOptions configures SweepStaleScheduled.
+type SweepOptions struct {
+	// GracePeriod is how long past its ScheduledAt a message may still be
+	// scheduled before it counts as stale (default: 5 minutes).
+	GracePeriod time.Duration
+	// Cancel cancels stale messages, releasing their reserved credits.
+	Cancel bool
+	// OnStale, if set, is called for each stale message, for example to
+	// raise an alert. It is called before the message is cancelled.
+	OnStale func(ScheduledMessage)
+	// Now returns the current time (default: time.Now).
+	Now func() time.Time
+}
+
+// SweepResult is the outcome of SweepStaleScheduled.
+type SweepResult struct {
+	// Stale lists scheduled messages whose send time has passed by more than
+	// the grace period.
+	Stale []ScheduledMessage
+	// Cancelled lists the IDs of stale messages that were cancelled.
+	Cancelled []string
+}
+
+func (o SweepOptions) withDefaults() SweepOptions {
+	if o.GracePeriod <= 0 {
+		o.GracePeriod = 5 * time.Minute
+	}
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+	return o
+}
+
+// SweepStaleScheduled finds scheduled messages that are still waiting to be
+// sent well after their ScheduledAt, which means they are stuck and will
+// never be sent. Each stale message is passed to opts.OnStale and, if
+// opts.Cancel is set, cancelled. Errors cancelling individual messages are
+// joined and returned along with the result. Run it periodically so stuck
+// messages are noticed instead of silently never being sent.
+func SweepStaleScheduled(ctx context.Context, messages MessagesAPI, opts SweepOptions) (*SweepResult, error) {
+	opts = opts.withDefaults()
+	cutoff := opts.Now().Add(-opts.GracePeriod)
+
+	// Collect every stale message before cancelling any, since cancelling
+	// shifts the offsets of later pages.
+	result := &SweepResult{}
+	req := ListScheduledMessagesRequest{Limit: 100, Status: ScheduledMessageStatusScheduled}
+	it := NewPageIterator(0, func(ctx context.Context, offset int) (*Page[ScheduledMessage], error) {
+		req.Offset = offset
+		return messages.ListScheduled(ctx, &req)
+	})
+	for it.Next(ctx) {
+		msg := it.Item()
+		at, err := time.Parse(time.RFC3339, msg.ScheduledAt)
+		if err != nil || msg.Status != ScheduledMessageStatusScheduled {
+			continue
+		}
+		if at.Before(cutoff) {
+			result.Stale = append(result.Stale, msg)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, msg := range result.Stale {
+		if opts.OnStale != nil {
+			opts.OnStale(msg)
+		}
+		if !opts.Cancel {
+			continue
+		}
+		if _, err := messages.CancelScheduled(ctx, msg.ID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result.Cancelled = append(result.Cancelled, msg.ID)
+	}
+
+	return result, errors.Join(errs...)
+}
@@ -0,0 +1,129 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// scheduledStub serves scheduled messages from memory. Cancelling removes a
+// message from the scheduled list, as the API does.
+type scheduledStub struct {
+	MessagesAPI
+	scheduled []ScheduledMessage
+	cancelErr map[string]error
+}
+
+func (s *scheduledStub) ListScheduled(ctx context.Context, req *ListScheduledMessagesRequest) (*ListScheduledMessagesResponse, error) {
+	var matched []ScheduledMessage
+	for _, msg := range s.scheduled {
+		if req.Status == "" || msg.Status == req.Status {
+			matched = append(matched, msg)
+		}
+	}
+	start, end := req.Offset, req.Offset+req.Limit
+	if start > len(matched) {
+		start = len(matched)
+	}
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return &ListScheduledMessagesResponse{Data: matched[start:end], Count: len(matched)}, nil
+}
+
+func (s *scheduledStub) CancelScheduled(ctx context.Context, id string) (*CancelScheduledMessageResponse, error) {
+	if err := s.cancelErr[id]; err != nil {
+		return nil, err
+	}
+	for i := range s.scheduled {
+		if s.scheduled[i].ID == id {
+			s.scheduled[i].Status = ScheduledMessageStatusCancelled
+		}
+	}
+	return &CancelScheduledMessageResponse{ID: id, Status: ScheduledMessageStatusCancelled}, nil
+}
+
+func TestSweepStaleScheduled(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	stub := &scheduledStub{}
+	// 150 overdue by an hour, 10 overdue by a minute, 10 in the future.
+	for i := 0; i < 170; i++ {
+		at := now.Add(-time.Hour)
+		if i >= 150 {
+			at = now.Add(-time.Minute)
+		}
+		if i >= 160 {
+			at = now.Add(time.Hour)
+		}
+		stub.scheduled = append(stub.scheduled, ScheduledMessage{
+			ID:          "sched_" + strconv.Itoa(i),
+			ScheduledAt: at.Format(time.RFC3339),
+			Status:      ScheduledMessageStatusScheduled,
+		})
+	}
+
+	alerted := 0
+	result, err := SweepStaleScheduled(context.Background(), stub, SweepOptions{
+		Cancel:  true,
+		OnStale: func(ScheduledMessage) { alerted++ },
+		Now:     func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Stale) != 150 {
+		t.Errorf("expected 150 stale messages, got %d", len(result.Stale))
+	}
+	if len(result.Cancelled) != 150 {
+		t.Errorf("expected 150 cancelled messages, got %d", len(result.Cancelled))
+	}
+	if alerted != 150 {
+		t.Errorf("expected OnStale to be called 150 times, got %d", alerted)
+	}
+	if stub.scheduled[155].Status != ScheduledMessageStatusScheduled {
+		t.Error("expected messages within the grace period not to be cancelled")
+	}
+}
+
+func TestSweepStaleScheduled_ReportOnly(t *testing.T) {
+	now := time.Now()
+	stub := &scheduledStub{scheduled: []ScheduledMessage{{
+		ID:          "sched_1",
+		ScheduledAt: now.Add(-time.Hour).Format(time.RFC3339),
+		Status:      ScheduledMessageStatusScheduled,
+	}}}
+
+	result, err := SweepStaleScheduled(context.Background(), stub, SweepOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Stale) != 1 || len(result.Cancelled) != 0 {
+		t.Errorf("expected 1 stale and none cancelled, got %+v", result)
+	}
+	if stub.scheduled[0].Status != ScheduledMessageStatusScheduled {
+		t.Error("expected message not to be cancelled")
+	}
+}
+
+func TestSweepStaleScheduled_CancelErrors(t *testing.T) {
+	past := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	notFound := &NotFoundError{APIError: APIError{Message: "not found"}}
+	stub := &scheduledStub{
+		scheduled: []ScheduledMessage{
+			{ID: "sched_1", ScheduledAt: past, Status: ScheduledMessageStatusScheduled},
+			{ID: "sched_2", ScheduledAt: past, Status: ScheduledMessageStatusScheduled},
+		},
+		cancelErr: map[string]error{"sched_1": notFound},
+	}
+
+	result, err := SweepStaleScheduled(context.Background(), stub, SweepOptions{Cancel: true})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if len(result.Cancelled) != 1 || result.Cancelled[0] != "sched_2" {
+		t.Errorf("expected only sched_2 to be cancelled, got %v", result.Cancelled)
+	}
+}
@@ -0,0 +1,89 @@
+package sendly
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateRange bounds a query by creation time. Zero values leave that side
+// open.
+type DateRange struct {
+	// After only includes items created at or after this time.
+	After time.Time
+	// Before only includes items created before this time.
+	Before time.Time
+}
+
+// validate checks that a closed range is not empty.
+func (r DateRange) validate() error {
+	if !r.After.IsZero() && !r.Before.IsZero() && !r.After.Before(r.Before) {
+		return &ValidationError{APIError: APIError{Message: "date range start must be before its end"}}
+	}
+	return nil
+}
+
+// SearchMessagesRequest is the request to search messages.
+type SearchMessagesRequest struct {
+	// Query is matched against message text, recipients, senders and IDs,
+	// including partial words (required).
+	Query string
+	// Status filters by message status.
+	Status MessageStatus
+	// DateRange filters by creation time.
+	DateRange DateRange
+	// Limit is the maximum number of results to return (default: 20, max: 100).
+	Limit int
+	// Offset is the number of results to skip.
+	Offset int
+}
+
+// MessageSearchResult is a message that matched a search.
+type MessageSearchResult struct {
+	Message
+	// Score is the relevance of the match; higher is more relevant.
+	Score float64 `json:"score"`
+	// Highlights are fragments of the message text around the matches.
+	Highlights []string `json:"highlights,omitempty"`
+}
+
+// SearchMessagesResponse is the response from searching messages. Results
+// are ordered by relevance, most relevant first.
+type SearchMessagesResponse = Page[MessageSearchResult]
+
+// Search finds messages by full-text search over their text, recipients,
+// senders and IDs, ordered by relevance.
+func (s *MessagesService) Search(ctx context.Context, req SearchMessagesRequest) (*SearchMessagesResponse, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "search query is required"}}
+	}
+	if err := req.DateRange.validate(); err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{"q": req.Query}
+	if req.Status != "" {
+		params["status"] = string(req.Status)
+	}
+	if !req.DateRange.After.IsZero() {
+		params["createdAfter"] = req.DateRange.After.UTC().Format(time.RFC3339Nano)
+	}
+	if !req.DateRange.Before.IsZero() {
+		params["createdBefore"] = req.DateRange.Before.UTC().Format(time.RFC3339Nano)
+	}
+	if req.Limit > 0 {
+		params["limit"] = strconv.Itoa(req.Limit)
+	}
+	if req.Offset > 0 {
+		params["offset"] = strconv.Itoa(req.Offset)
+	}
+
+	var resp SearchMessagesResponse
+	if err := s.client.request(ctx, "GET", "/messages/search"+buildQueryString(params), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	resp.fill(req.Offset)
+	return &resp, nil
+}
@@ -0,0 +1,75 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMessagesSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages/search" {
+			t.Errorf("expected path '/messages/search', got '%s'", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if got := q.Get("q"); got != "refund" {
+			t.Errorf("expected q to be 'refund', got '%s'", got)
+		}
+		if got := q.Get("status"); got != "delivered" {
+			t.Errorf("expected status to be 'delivered', got '%s'", got)
+		}
+		if got := q.Get("createdAfter"); got != "2025-01-01T00:00:00Z" {
+			t.Errorf("expected createdAfter to be '2025-01-01T00:00:00Z', got '%s'", got)
+		}
+		if q.Has("createdBefore") {
+			t.Errorf("expected no createdBefore, got '%s'", q.Get("createdBefore"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[
+			{"id":"msg_2","text":"Your refund was issued","status":"delivered","score":2.5,"highlights":["Your <em>refund</em> was issued"]},
+			{"id":"msg_1","text":"Refund pending","status":"delivered","score":1.1}
+		],"count":2}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.Messages.Search(context.Background(), SearchMessagesRequest{
+		Query:     "refund",
+		Status:    MessageStatusDelivered,
+		DateRange: DateRange{After: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 2 || resp.Data[0].ID != "msg_2" {
+		t.Fatalf("expected msg_2 first, got %+v", resp.Data)
+	}
+	if resp.Data[0].Score != 2.5 || len(resp.Data[0].Highlights) != 1 {
+		t.Errorf("expected score and highlights to be decoded, got %+v", resp.Data[0])
+	}
+	if resp.HasMore {
+		t.Error("expected no more results")
+	}
+}
+
+func TestMessagesSearch_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+	ctx := context.Background()
+
+	if _, err := client.Messages.Search(ctx, SearchMessagesRequest{Query: "  "}); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for blank query, got %T", err)
+	}
+
+	now := time.Now()
+	_, err := client.Messages.Search(ctx, SearchMessagesRequest{
+		Query:     "x",
+		DateRange: DateRange{After: now, Before: now},
+	})
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError for empty range, got %T", err)
+	}
+}
@@ -0,0 +1,55 @@
+package sendly
+
+import "strings"
+
+// gsm7Basic is the GSM 03.38 basic character set.
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extended is the GSM 03.38 extension table; each character costs two septets.
+const gsm7Extended = "^{}\\[~]|€\f"
+
+// CountSegments returns the number of SMS segments needed to send text.
+// GSM-7 text fits 160 characters in one segment (153 per part when split);
+// anything else is sent as UCS-2 with 70 characters (67 per part).
+func CountSegments(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	septets, gsm := 0, true
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune(gsm7Basic, r):
+			septets++
+		case strings.ContainsRune(gsm7Extended, r):
+			septets += 2
+		default:
+			gsm = false
+		}
+		if !gsm {
+			break
+		}
+	}
+
+	if gsm {
+		if septets <= 160 {
+			return 1
+		}
+		return (septets + 152) / 153
+	}
+
+	// UCS-2 counts UTF-16 code units; characters outside the BMP take two.
+	units := 0
+	for _, r := range text {
+		if r > 0xFFFF {
+			units += 2
+		} else {
+			units++
+		}
+	}
+	if units <= 70 {
+		return 1
+	}
+	return (units + 66) / 67
+}
@@ -0,0 +1,32 @@
+package sendly
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCountSegments(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"empty", "", 0},
+		{"short GSM", "Hello", 1},
+		{"full GSM segment", strings.Repeat("a", 160), 1},
+		{"two GSM segments", strings.Repeat("a", 161), 2},
+		{"extended chars count double", strings.Repeat("€", 80), 1},
+		{"extended chars overflow", strings.Repeat("€", 81), 2},
+		{"short unicode", "Hello 👋", 1},
+		{"full UCS-2 segment", strings.Repeat("ж", 70), 1},
+		{"two UCS-2 segments", strings.Repeat("ж", 71), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountSegments(tt.text); got != tt.want {
+				t.Errorf("expected %d segments, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Package sendlyhttp verifies Sendly webhook deliveries in HTTP servers.
+//
+// Its handlers and middleware are plain net/http, so they work with any
+// router without extra dependencies. Chi uses them directly:
+//
+//	r.With(sendlyhttp.Middleware(secret)).Post("/webhooks/sendly", func(w http.ResponseWriter, r *http.Request) {
+//		event, _ := sendlyhttp.EventFromContext(r.Context())
+//		// ...
+//	})
+//
+// Gin and Echo wrap them with their own adapters:
+//
+//	router.POST("/webhooks/sendly", gin.WrapH(sendlyhttp.Handler(secret, handleEvent)))
+//	e.POST("/webhooks/sendly", echo.WrapHandler(sendlyhttp.Handler(secret, handleEvent)))
+//	e.Group("/webhooks", echo.WrapMiddleware(sendlyhttp.Middleware(secret)))
+package sendlyhttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// DefaultMaxBodySize is the largest delivery body read when WithMaxBodySize
+// is not set.
+const DefaultMaxBodySize = 1 << 20
+
+// ErrBodyTooLarge is passed to the error handler when a delivery body exceeds
+// the maximum size.
+var ErrBodyTooLarge = errors.New("webhook body too large")
+
+// ErrMethodNotAllowed is passed to the error handler when a delivery is not a
+// POST request.
+var ErrMethodNotAllowed = errors.New("webhook deliveries must be POST requests")
+
+// Option configures Middleware and Handler.
+type Option func(*options)
+
+type options struct {
+	previous    []string
+	maxBodySize int64
+	onError     func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// WithPreviousSecrets also accepts deliveries signed with secrets, such as
+// the old secret while a rotation's grace period lasts.
+func WithPreviousSecrets(secrets ...string) Option {
+	return func(o *options) {
+		o.previous = append(o.previous, secrets...)
+	}
+}
+
+// WithMaxBodySize sets the largest delivery body read (default:
+// DefaultMaxBodySize).
+func WithMaxBodySize(n int64) Option {
+	return func(o *options) {
+		o.maxBodySize = n
+	}
+}
+
+// WithErrorHandler replaces the response written when a delivery is
+// rejected. err is sendly.ErrInvalidSignature, ErrBodyTooLarge,
+// ErrMethodNotAllowed, or a parse error. By default the response is 401 for
+// an invalid signature, 413 for a large body, 405 for a method other than
+// POST, and 400 otherwise.
+func WithErrorHandler(fn func(w http.ResponseWriter, r *http.Request, err error)) Option {
+	return func(o *options) {
+		o.onError = fn
+	}
+}
+
+type contextKey struct{}
+
+// EventFromContext returns the event Middleware verified for the request.
+func EventFromContext(ctx context.Context) (*sendly.WebhookEvent, bool) {
+	event, ok := ctx.Value(contextKey{}).(*sendly.WebhookEvent)
+	return event, ok
+}
+
+// Middleware verifies each request's signature with secret and adds the
+// parsed event to its context, to be read with EventFromContext. The body is
+// left readable for next. Rejected requests do not reach next.
+func Middleware(secret string, opts ...Option) func(http.Handler) http.Handler {
+	o := &options{maxBodySize: DefaultMaxBodySize, onError: writeError}
+	for _, opt := range opts {
+		opt(o)
+	}
+	secrets := append([]string{secret}, o.previous...)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				o.onError(w, r, ErrMethodNotAllowed)
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, o.maxBodySize+1))
+			if err != nil {
+				o.onError(w, r, err)
+				return
+			}
+			if int64(len(body)) > o.maxBodySize {
+				o.onError(w, r, ErrBodyTooLarge)
+				return
+			}
+
+			event, err := sendly.Webhooks{}.ParseEventWithSecrets(string(body), r.Header.Get(sendly.WebhookSignatureHeader), secrets...)
+			if err != nil {
+				o.onError(w, r, err)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, event)))
+		})
+	}
+}
+
+// Handler verifies deliveries like Middleware and calls fn with each event.
+// It responds 200 if fn succeeds and 500 if it fails, so Sendly retries the
+// delivery.
+func Handler(secret string, fn sendly.WebhookHandlerFunc, opts ...Option) http.Handler {
+	return Middleware(secret, opts...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, _ := EventFromContext(r.Context())
+		if err := fn(r.Context(), event); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+// writeError is the default error handler.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, sendly.ErrInvalidSignature):
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Is(err, ErrBodyTooLarge):
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	case errors.Is(err, ErrMethodNotAllowed):
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
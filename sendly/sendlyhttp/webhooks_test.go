@@ -0,0 +1,129 @@
+package sendlyhttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+const testPayload = `{"id":"evt_1","type":"message.delivered","created_at":"2024-06-01T00:00:00Z","data":{"message_id":"msg_1","status":"delivered","to":"+15551234567"}}`
+
+func delivery(payload, secret string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/sendly", strings.NewReader(payload))
+	req.Header.Set(sendly.WebhookSignatureHeader, sendly.Webhooks{}.GenerateSignature(payload, secret))
+	return req
+}
+
+func TestMiddleware(t *testing.T) {
+	var got *sendly.WebhookEvent
+	var body string
+	handler := Middleware("secret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = EventFromContext(r.Context())
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, delivery(testPayload, "secret"))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected the next handler's status, got %d", rec.Code)
+	}
+	if got == nil || got.ID != "evt_1" || got.Data.MessageID != "msg_1" {
+		t.Errorf("expected evt_1 in the context, got %+v", got)
+	}
+	if body != testPayload {
+		t.Error("expected the body to remain readable")
+	}
+}
+
+func TestMiddleware_Rejections(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *http.Request
+		opts []Option
+		want int
+	}{
+		{"invalid signature", delivery(testPayload, "other"), nil, http.StatusUnauthorized},
+		{"not POST", httptest.NewRequest(http.MethodGet, "/webhooks/sendly", nil), nil, http.StatusMethodNotAllowed},
+		{"too large", delivery(testPayload, "secret"), []Option{WithMaxBodySize(10)}, http.StatusRequestEntityTooLarge},
+		{"malformed", delivery(`{"id":`, "secret"), nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := Middleware("secret", tt.opts...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tt.req)
+			if rec.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, rec.Code)
+			}
+			if called {
+				t.Error("expected the next handler not to be called")
+			}
+		})
+	}
+}
+
+func TestMiddleware_PreviousSecrets(t *testing.T) {
+	handler := Middleware("new-secret", WithPreviousSecrets("old-secret"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, delivery(testPayload, "old-secret"))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected a delivery signed with the old secret to pass, got %d", rec.Code)
+	}
+}
+
+func TestMiddleware_ErrorHandler(t *testing.T) {
+	var gotErr error
+	handler := Middleware("secret", WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+		gotErr = err
+		w.WriteHeader(http.StatusForbidden)
+	}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, delivery(testPayload, "other"))
+	if rec.Code != http.StatusForbidden || !errors.Is(gotErr, sendly.ErrInvalidSignature) {
+		t.Errorf("expected the custom handler to get ErrInvalidSignature, got %d and %v", rec.Code, gotErr)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var failNext bool
+	handler := Handler("secret", func(ctx context.Context, event *sendly.WebhookEvent) error {
+		if failNext {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, delivery(testPayload, "secret"))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+
+	failNext = true
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, delivery(testPayload, "secret"))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500 when the handler fails, got %d", rec.Code)
+	}
+}
+
+func TestEventFromContext_Missing(t *testing.T) {
+	if _, ok := EventFromContext(context.Background()); ok {
+		t.Error("expected no event in an empty context")
+	}
+}
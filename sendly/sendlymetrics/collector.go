@@ -0,0 +1,145 @@
+// Package sendlymetrics exports Sendly client metrics to Prometheus.
+//
+//	collector := sendlymetrics.NewCollector()
+//	prometheus.MustRegister(collector)
+//	client := sendly.NewClient(apiKey, collector.ClientOption())
+package sendlymetrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// Collector is a prometheus.Collector fed by a client's request observer.
+// One Collector may observe several clients.
+type Collector struct {
+	requests    *prometheus.CounterVec
+	retries     *prometheus.CounterVec
+	rateLimited *prometheus.CounterVec
+	credits     prometheus.Counter
+	latency     *prometheus.HistogramVec
+}
+
+// Option configures a Collector.
+type Option func(*options)
+
+type options struct {
+	namespace   string
+	constLabels prometheus.Labels
+	buckets     []float64
+}
+
+// WithNamespace sets the metric name prefix (default: "sendly").
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithConstLabels adds labels with fixed values to every metric, for example
+// to tell apart collectors for several accounts.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(o *options) {
+		o.constLabels = labels
+	}
+}
+
+// WithBuckets sets the latency histogram buckets, in seconds (default:
+// prometheus.DefBuckets).
+func WithBuckets(buckets []float64) Option {
+	return func(o *options) {
+		o.buckets = buckets
+	}
+}
+
+// NewCollector creates a Collector. Register it with a prometheus.Registerer
+// and pass ClientOption to sendly.NewClient.
+func NewCollector(opts ...Option) *Collector {
+	o := options{namespace: "sendly", buckets: prometheus.DefBuckets}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Name:        "requests_total",
+			Help:        "HTTP requests made to the Sendly API, by endpoint and status.",
+			ConstLabels: o.constLabels,
+		}, []string{"method", "endpoint", "status"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Name:        "retries_total",
+			Help:        "Requests to the Sendly API that were retries of a failed attempt.",
+			ConstLabels: o.constLabels,
+		}, []string{"method", "endpoint"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Name:        "rate_limited_total",
+			Help:        "Requests to the Sendly API rejected with 429 Too Many Requests.",
+			ConstLabels: o.constLabels,
+		}, []string{"method", "endpoint"}),
+		credits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Name:        "credits_consumed_total",
+			Help:        "Credits consumed by sends, as reported in API responses.",
+			ConstLabels: o.constLabels,
+		}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   o.namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of requests to the Sendly API.",
+			ConstLabels: o.constLabels,
+			Buckets:     o.buckets,
+		}, []string{"method", "endpoint"}),
+	}
+}
+
+// ClientOption returns the sendly.ClientOption that feeds the collector.
+func (c *Collector) ClientOption() sendly.ClientOption {
+	return sendly.WithRequestObserver(c.Observe)
+}
+
+// Observe records one request attempt. It is called by the client when the
+// collector is installed with ClientOption.
+func (c *Collector) Observe(e sendly.RequestEvent) {
+	status := "error"
+	if e.StatusCode != 0 {
+		status = strconv.Itoa(e.StatusCode)
+	}
+
+	c.requests.WithLabelValues(e.Method, e.Endpoint, status).Inc()
+	c.latency.WithLabelValues(e.Method, e.Endpoint).Observe(e.Duration.Seconds())
+	if e.Attempt > 1 {
+		c.retries.WithLabelValues(e.Method, e.Endpoint).Inc()
+	}
+	if e.StatusCode == 429 {
+		c.rateLimited.WithLabelValues(e.Method, e.Endpoint).Inc()
+	}
+	if e.CreditsUsed > 0 {
+		c.credits.Add(float64(e.CreditsUsed))
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.retries.Describe(ch)
+	c.rateLimited.Describe(ch)
+	c.credits.Describe(ch)
+	c.latency.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.retries.Collect(ch)
+	c.rateLimited.Collect(ch)
+	c.credits.Collect(ch)
+	c.latency.Collect(ch)
+}
+
+var _ prometheus.Collector = (*Collector)(nil)
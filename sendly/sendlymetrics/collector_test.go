@@ -0,0 +1,100 @@
+package sendlymetrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+func TestCollector(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"rate_limited","message":"Too many requests"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","status":"queued","creditsUsed":3}`))
+	}))
+	defer server.Close()
+
+	collector := NewCollector()
+	client := sendly.NewClient("test-api-key",
+		sendly.WithBaseURL(server.URL),
+		sendly.WithMaxRetries(1),
+		collector.ClientOption(),
+	)
+
+	req := &sendly.SendMessageRequest{To: "+15551234567", Text: "Hello"}
+	if _, err := client.Messages.Send(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `
+# HELP sendly_credits_consumed_total Credits consumed by sends, as reported in API responses.
+# TYPE sendly_credits_consumed_total counter
+sendly_credits_consumed_total 3
+# HELP sendly_rate_limited_total Requests to the Sendly API rejected with 429 Too Many Requests.
+# TYPE sendly_rate_limited_total counter
+sendly_rate_limited_total{endpoint="/messages",method="POST"} 1
+# HELP sendly_requests_total HTTP requests made to the Sendly API, by endpoint and status.
+# TYPE sendly_requests_total counter
+sendly_requests_total{endpoint="/messages",method="POST",status="200"} 1
+sendly_requests_total{endpoint="/messages",method="POST",status="429"} 1
+# HELP sendly_retries_total Requests to the Sendly API that were retries of a failed attempt.
+# TYPE sendly_retries_total counter
+sendly_retries_total{endpoint="/messages",method="POST"} 1
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"sendly_credits_consumed_total", "sendly_rate_limited_total", "sendly_requests_total", "sendly_retries_total")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if n := testutil.CollectAndCount(collector, "sendly_request_duration_seconds"); n != 1 {
+		t.Errorf("expected 1 latency series, got %d", n)
+	}
+}
+
+func TestCollector_NetworkErrorStatus(t *testing.T) {
+	collector := NewCollector()
+	collector.Observe(sendly.RequestEvent{Method: "GET", Endpoint: "/messages/:id", Attempt: 1, Duration: time.Millisecond})
+
+	count := testutil.ToFloat64(collector.requests.WithLabelValues("GET", "/messages/:id", "error"))
+	if count != 1 {
+		t.Errorf("expected 1 request with status 'error', got %v", count)
+	}
+}
+
+func TestCollector_Options(t *testing.T) {
+	collector := NewCollector(
+		WithNamespace("sms"),
+		WithConstLabels(prometheus.Labels{"account": "primary"}),
+		WithBuckets([]float64{0.1, 1}),
+	)
+	collector.Observe(sendly.RequestEvent{Method: "GET", Endpoint: "/credits", StatusCode: 200, Attempt: 1})
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatalf("unexpected error registering: %v", err)
+	}
+
+	expected := `
+# HELP sms_requests_total HTTP requests made to the Sendly API, by endpoint and status.
+# TYPE sms_requests_total counter
+sms_requests_total{account="primary",endpoint="/credits",method="GET",status="200"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "sms_requests_total"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,455 @@
+package sendlytest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// FakeAccount is an in-memory implementation of sendly.AccountAPI.
+type FakeAccount struct {
+	fake *FakeClient
+}
+
+var _ sendly.AccountAPI = (*FakeAccount)(nil)
+
+// Get returns the fake account.
+func (s *FakeAccount) Get(ctx context.Context) (*sendly.Account, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	account := f.account
+	return &account, nil
+}
+
+// Update applies the non-nil fields of req to the fake account.
+func (s *FakeAccount) Update(ctx context.Context, req sendly.UpdateAccountRequest) (*sendly.Account, error) {
+	if req.Name == nil && req.NotificationEmail == nil && req.Timezone == nil {
+		return nil, validationError("at least one field to update is required")
+	}
+	if req.Name != nil && *req.Name == "" {
+		return nil, validationError("account name cannot be empty")
+	}
+	if req.NotificationEmail != nil && !strings.Contains(*req.NotificationEmail, "@") {
+		return nil, validationError("notification email must be an email address")
+	}
+	if req.Timezone != nil && *req.Timezone == "" {
+		return nil, validationError("timezone cannot be empty")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := *req.Name
+		f.account.Name = &name
+	}
+	if req.NotificationEmail != nil {
+		email := *req.NotificationEmail
+		f.account.NotificationEmail = &email
+	}
+	if req.Timezone != nil {
+		f.account.Timezone = *req.Timezone
+	}
+	account := f.account
+	return &account, nil
+}
+
+// GetCredits returns the current fake balance.
+func (s *FakeAccount) GetCredits(ctx context.Context) (*sendly.Credits, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	f.dispatchDue()
+
+	return &sendly.Credits{
+		Balance:          f.credits + f.reserved,
+		ReservedBalance:  f.reserved,
+		AvailableBalance: f.credits,
+	}, nil
+}
+
+// WatchCredits polls the fake balance using sendly.WatchCredits.
+func (s *FakeAccount) WatchCredits(ctx context.Context, opts sendly.WatchOptions) (<-chan sendly.Credits, error) {
+	return sendly.WatchCredits(ctx, s, opts)
+}
+
+// GetCreditTransactions returns recorded transactions, newest first.
+func (s *FakeAccount) GetCreditTransactions(ctx context.Context, opts *sendly.ListCreditTransactionsOptions) ([]sendly.CreditTransaction, error) {
+	resp, err := s.ListCreditTransactions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// ListCreditTransactions returns a page of recorded transactions matching
+// opts, newest first.
+func (s *FakeAccount) ListCreditTransactions(ctx context.Context, opts *sendly.ListCreditTransactionsOptions) (*sendly.ListCreditTransactionsResponse, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &sendly.ListCreditTransactionsOptions{}
+	}
+
+	txns := make([]sendly.CreditTransaction, 0, len(f.transactions))
+	for i := len(f.transactions) - 1; i >= 0; i-- {
+		txn := f.transactions[i]
+		if opts.Type != "" && txn.Type != opts.Type {
+			continue
+		}
+		if opts.MessageID != "" && (txn.MessageID == nil || *txn.MessageID != opts.MessageID) {
+			continue
+		}
+		if !createdBetween(txn.CreatedAt, opts.CreatedAfter, opts.CreatedBefore) {
+			continue
+		}
+		txns = append(txns, txn)
+	}
+
+	start, end := paginate(len(txns), opts.Limit, opts.Offset)
+	return sendly.NewPage(append([]sendly.CreditTransaction{}, txns[start:end]...), len(txns), start), nil
+}
+
+// IterateCreditTransactions iterates recorded transactions using
+// sendly.NewCreditTransactionIterator.
+func (s *FakeAccount) IterateCreditTransactions(opts *sendly.ListCreditTransactionsOptions) *sendly.CreditTransactionIterator {
+	return sendly.NewCreditTransactionIterator(s, opts)
+}
+
+// GetAutoTopUp returns the fake auto top-up configuration.
+func (s *FakeAccount) GetAutoTopUp(ctx context.Context) (*sendly.AutoTopUpConfig, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	config := f.autoTopUp
+	return &config, nil
+}
+
+// SetAutoTopUp replaces the fake auto top-up configuration. While enabled,
+// sends that leave the available balance below Threshold add Amount credits
+// and record a purchase transaction.
+func (s *FakeAccount) SetAutoTopUp(ctx context.Context, config sendly.AutoTopUpConfig) (*sendly.AutoTopUpConfig, error) {
+	if config.Threshold < 0 {
+		return nil, validationError("auto top-up threshold cannot be negative")
+	}
+	if config.Amount < 0 {
+		return nil, validationError("auto top-up amount cannot be negative")
+	}
+	if config.Enabled && config.Amount == 0 {
+		return nil, validationError("auto top-up amount is required when enabled")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	f.autoTopUp = config
+	return &config, nil
+}
+
+// ListAPIKeys returns keys created through the fake.
+func (s *FakeAccount) ListAPIKeys(ctx context.Context) ([]sendly.APIKey, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	return append([]sendly.APIKey{}, f.keys...), nil
+}
+
+// GetAPIKey returns a key created through the fake.
+func (s *FakeAccount) GetAPIKey(ctx context.Context, keyID string) (*sendly.APIKey, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	for _, k := range f.keys {
+		if k.ID == keyID {
+			key := k
+			return &key, nil
+		}
+	}
+	return nil, notFound("API key", keyID)
+}
+
+// SetCurrentAPIKey sets the key GetCurrentAPIKey returns, such as a revoked
+// or expired key to test startup checks.
+func (f *FakeClient) SetCurrentAPIKey(key sendly.APIKey) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.currentKey = &key
+}
+
+// GetCurrentAPIKey returns the key set with SetCurrentAPIKey, or a test key
+// with no expiry.
+func (s *FakeAccount) GetCurrentAPIKey(ctx context.Context) (*sendly.APIKey, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	if f.currentKey != nil {
+		key := *f.currentKey
+		return &key, nil
+	}
+	return &sendly.APIKey{
+		ID:        "key_fake",
+		Name:      "Fake key",
+		Type:      string(sendly.APIKeyTypeTest),
+		Prefix:    "sk_test_v1_",
+		LastFour:  "fake",
+		CreatedAt: f.account.CreatedAt,
+	}, nil
+}
+
+// GetAPIKeyUsage returns usage computed from all messages sent through the fake.
+func (s *FakeAccount) GetAPIKeyUsage(ctx context.Context, keyID string) (*sendly.APIKeyUsage, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	usage := &sendly.APIKeyUsage{KeyID: keyID, PeriodEnd: f.now().Format(time.RFC3339)}
+	now := f.now()
+	for _, m := range f.messages {
+		msg := f.snapshot(m, now)
+		usage.MessagesSent++
+		usage.CreditsUsed += msg.CreditsUsed
+		switch msg.Status {
+		case sendly.MessageStatusDelivered:
+			usage.MessagesDelivered++
+		case sendly.MessageStatusFailed, sendly.MessageStatusExpired:
+			usage.MessagesFailed++
+		}
+	}
+	if len(f.messages) > 0 {
+		usage.PeriodStart = f.messages[0].msg.CreatedAt
+	}
+	return usage, nil
+}
+
+// CreateAPIKey creates a fake test key.
+func (s *FakeAccount) CreateAPIKey(ctx context.Context, name string) (*sendly.CreateAPIKeyResponse, error) {
+	return s.CreateAPIKeyWithOptions(ctx, sendly.CreateAPIKeyRequest{Name: name})
+}
+
+// CreateAPIKeyWithOptions creates a fake key of req.Type (default test) with
+// req.Permissions.
+func (s *FakeAccount) CreateAPIKeyWithOptions(ctx context.Context, req sendly.CreateAPIKeyRequest) (*sendly.CreateAPIKeyResponse, error) {
+	if req.Name == "" {
+		return nil, validationError("API key name is required")
+	}
+	keyType := req.Type
+	if keyType == "" {
+		keyType = sendly.APIKeyTypeTest
+	}
+	if keyType != sendly.APIKeyTypeTest && keyType != sendly.APIKeyTypeLive {
+		return nil, validationError(fmt.Sprintf("invalid API key type %q", req.Type))
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	id := f.nextID("key")
+	prefix := "sk_" + string(keyType) + "_v1_"
+	secret := prefix + id
+	key := sendly.APIKey{
+		ID:          id,
+		Name:        req.Name,
+		Type:        string(keyType),
+		Prefix:      prefix,
+		LastFour:    secret[len(secret)-4:],
+		Permissions: append([]string(nil), req.Permissions...),
+		CreatedAt:   f.now().Format(time.RFC3339),
+		ExpiresAt:   req.ExpiresAt,
+	}
+	f.keys = append(f.keys, key)
+
+	return &sendly.CreateAPIKeyResponse{APIKey: key, Key: secret}, nil
+}
+
+// UpdateAPIKey applies the non-nil fields of req to a key created through the fake.
+func (s *FakeAccount) UpdateAPIKey(ctx context.Context, keyID string, req sendly.UpdateAPIKeyRequest) (*sendly.APIKey, error) {
+	if keyID == "" {
+		return nil, validationError("API key ID is required")
+	}
+	if req.Name != nil && *req.Name == "" {
+		return nil, validationError("API key name cannot be empty")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	for i := range f.keys {
+		if f.keys[i].ID != keyID {
+			continue
+		}
+		if req.Name != nil {
+			f.keys[i].Name = *req.Name
+		}
+		if req.Permissions != nil {
+			f.keys[i].Permissions = append([]string{}, (*req.Permissions)...)
+		}
+		if req.ExpiresAt != nil {
+			expiresAt := *req.ExpiresAt
+			f.keys[i].ExpiresAt = &expiresAt
+		}
+		key := f.keys[i]
+		return &key, nil
+	}
+	return nil, notFound("API key", keyID)
+}
+
+// RevokeAPIKey marks a key created through the fake as revoked.
+func (s *FakeAccount) RevokeAPIKey(ctx context.Context, keyID string) error {
+	if keyID == "" {
+		return validationError("API key ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return err
+	}
+	for i := range f.keys {
+		if f.keys[i].ID == keyID {
+			f.keys[i].IsRevoked = true
+			return nil
+		}
+	}
+	return notFound("API key", keyID)
+}
+
+// StartEmailVerification records a pending email verification. Confirm it
+// with VerificationCode.
+func (s *FakeAccount) StartEmailVerification(ctx context.Context, email string) (*sendly.ContactVerification, error) {
+	if !strings.Contains(email, "@") {
+		return nil, validationError("a valid email address is required")
+	}
+	return s.startVerification(sendly.VerificationChannelEmail, email)
+}
+
+// StartTestNumberVerification records a pending test number verification.
+// Confirm it with VerificationCode.
+func (s *FakeAccount) StartTestNumberVerification(ctx context.Context, phone string) (*sendly.ContactVerification, error) {
+	if !strings.HasPrefix(phone, "+") {
+		return nil, validationError("phone number must be in E.164 format")
+	}
+	return s.startVerification(sendly.VerificationChannelSMS, phone)
+}
+
+func (s *FakeAccount) startVerification(channel sendly.VerificationChannel, target string) (*sendly.ContactVerification, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	v := &sendly.ContactVerification{
+		ID:        f.nextID("ver"),
+		Channel:   channel,
+		Target:    target,
+		Status:    sendly.VerificationStatusPending,
+		ExpiresAt: f.now().Add(10 * time.Minute).Format(time.RFC3339),
+	}
+	f.verifications = append(f.verifications, v)
+
+	verification := *v
+	return &verification, nil
+}
+
+// ConfirmVerification verifies a pending verification if code is
+// VerificationCode and it has not expired on the fake clock.
+func (s *FakeAccount) ConfirmVerification(ctx context.Context, verificationID, code string) (*sendly.ContactVerification, error) {
+	if verificationID == "" {
+		return nil, validationError("verification ID is required")
+	}
+	if code == "" {
+		return nil, validationError("verification code is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	for _, v := range f.verifications {
+		if v.ID != verificationID {
+			continue
+		}
+		if v.Status == sendly.VerificationStatusPending {
+			expiresAt, _ := time.Parse(time.RFC3339, v.ExpiresAt)
+			if !f.now().Before(expiresAt) {
+				v.Status = sendly.VerificationStatusExpired
+			}
+		}
+		switch {
+		case v.Status == sendly.VerificationStatusExpired:
+			return nil, validationError("verification has expired")
+		case v.Status == sendly.VerificationStatusPending && code != VerificationCode:
+			return nil, validationError("verification code is incorrect")
+		case v.Status == sendly.VerificationStatusPending:
+			v.Status = sendly.VerificationStatusVerified
+			verifiedAt := f.now().Format(time.RFC3339)
+			v.VerifiedAt = &verifiedAt
+		}
+		verification := *v
+		return &verification, nil
+	}
+	return nil, notFound("verification", verificationID)
+}
@@ -0,0 +1,506 @@
+package sendlytest
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+const (
+	// DefaultCredits is the starting credit balance of a FakeClient.
+	DefaultCredits = 1000
+	// DefaultSentAfter is how long a fake message stays queued before it is sent.
+	DefaultSentAfter = 1 * time.Second
+	// DefaultDeliveredAfter is how long after creation a fake message reaches its final status.
+	DefaultDeliveredAfter = 3 * time.Second
+	// VerificationCode is the code that confirms every fake contact verification.
+	VerificationCode = "123456"
+)
+
+// outcome is the final delivery result forced for a recipient.
+type outcome struct {
+	status sendly.MessageStatus
+	err    string
+}
+
+// sandboxOutcomes mirrors the behaviour of Sendly's sandbox test numbers.
+var sandboxOutcomes = map[string]outcome{
+	"+15005550001": {status: sendly.MessageStatusFailed, err: "invalid_number"},
+	"+15005550002": {status: sendly.MessageStatusFailed, err: "unroutable_destination"},
+	"+15005550003": {status: sendly.MessageStatusFailed, err: "queue_full"},
+	"+15005550004": {status: sendly.MessageStatusFailed, err: "rate_limit_exceeded"},
+	"+15005550006": {status: sendly.MessageStatusFailed, err: "carrier_violation"},
+}
+
+// FakeClient is an in-memory implementation of the Sendly services for tests.
+// It records sent messages, simulates delivery transitions over time, and can
+// be configured to return rate limit, insufficient credit, or arbitrary errors.
+//
+// A FakeClient is safe for concurrent use.
+type FakeClient struct {
+	// Messages implements sendly.MessagesAPI.
+	Messages *FakeMessages
+	// Webhooks implements sendly.WebhooksAPI.
+	Webhooks *FakeWebhooks
+	// Account implements sendly.AccountAPI.
+	Account *FakeAccount
+	// SubAccounts implements sendly.SubAccountsAPI.
+	SubAccounts *FakeSubAccounts
+	// Compliance implements sendly.ComplianceAPI.
+	Compliance *FakeCompliance
+	// Segments implements sendly.SegmentsAPI.
+	Segments *FakeSegments
+	// Links implements sendly.LinksAPI.
+	Links *FakeLinks
+	// OptOuts implements sendly.OptOutsAPI.
+	OptOuts *FakeOptOuts
+	// Numbers implements sendly.NumbersAPI.
+	Numbers *FakeNumbers
+	// Registrations implements sendly.RegistrationsAPI.
+	Registrations *FakeRegistrations
+	// Email implements sendly.EmailAPI.
+	Email *FakeEmail
+
+	mu             sync.Mutex
+	offset         time.Duration
+	credits        int
+	reserved       int
+	sentAfter      time.Duration
+	deliveredAfter time.Duration
+	outcomes       map[string]outcome
+	senderOutcomes map[string]outcome
+	rateLimit      int
+	rateWindow     time.Duration
+	calls          []time.Time
+	failNext       []error
+	seq            int
+
+	account       sendly.Account
+	messages      []*fakeMessage
+	scheduled     []*sendly.ScheduledMessage
+	batches       []*fakeBatch
+	drafts        []*fakeDraft
+	webhooks      []*fakeWebhook
+	transactions  []sendly.CreditTransaction
+	keys          []sendly.APIKey
+	currentKey    *sendly.APIKey
+	verifications []*sendly.ContactVerification
+	autoTopUp     sendly.AutoTopUpConfig
+	subAccounts   []*sendly.SubAccount
+	deletions     []*sendly.DataDeletionJob
+	segments      []*fakeSegment
+	links         []*sendly.ShortLink
+	optOuts       []sendly.OptOut
+	available     []sendly.AvailableNumber
+	numbers       []*sendly.PhoneNumber
+	brands        []*sendly.Brand
+	campaigns     []*sendly.Campaign
+	tollFree      []*sendly.TollFreeVerification
+	emails        []sendly.Email
+	templates     map[string]string
+}
+
+// fakeMessage is a sent message along with its simulated delivery outcome.
+type fakeMessage struct {
+	msg      sendly.Message
+	created  time.Time
+	outcome  outcome
+	validity time.Duration
+}
+
+// fakeBatch is a batch and the IDs of the messages it produced.
+type fakeBatch struct {
+	resp       sendly.BatchMessageResponse
+	messageIDs []string
+}
+
+// fakeDraft is a draft batch and the items added to it so far.
+type fakeDraft struct {
+	draft sendly.BatchDraft
+	items []sendly.BatchMessageItem
+}
+
+// fakeWebhook is a webhook along with its signing secret.
+type fakeWebhook struct {
+	webhook sendly.Webhook
+	secret  string
+}
+
+// NewFakeClient creates a FakeClient with DefaultCredits and the default delivery timeline.
+func NewFakeClient() *FakeClient {
+	f := &FakeClient{
+		credits:        DefaultCredits,
+		sentAfter:      DefaultSentAfter,
+		deliveredAfter: DefaultDeliveredAfter,
+		outcomes:       make(map[string]outcome),
+		senderOutcomes: make(map[string]outcome),
+		account: sendly.Account{
+			ID:        "user_fake",
+			Email:     "test@example.com",
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+	for to, o := range sandboxOutcomes {
+		f.outcomes[to] = o
+	}
+
+	f.Messages = &FakeMessages{fake: f}
+	f.Webhooks = &FakeWebhooks{fake: f}
+	f.Account = &FakeAccount{fake: f}
+	f.SubAccounts = &FakeSubAccounts{fake: f}
+	f.Compliance = &FakeCompliance{fake: f}
+	f.Segments = &FakeSegments{fake: f}
+	f.Links = &FakeLinks{fake: f}
+	f.OptOuts = &FakeOptOuts{fake: f}
+	f.Numbers = &FakeNumbers{fake: f}
+	f.Registrations = &FakeRegistrations{fake: f}
+	f.Email = &FakeEmail{fake: f}
+	return f
+}
+
+// Client returns a *sendly.Client whose services are backed by the fake,
+// for code under test that accepts a concrete client.
+func (f *FakeClient) Client() *sendly.Client {
+	c := sendly.NewClient("sk_test_v1_fake")
+	c.Messages = f.Messages
+	c.WebhooksService = f.Webhooks
+	c.Account = f.Account
+	c.SubAccounts = f.SubAccounts
+	c.Compliance = f.Compliance
+	c.Segments = f.Segments
+	c.Links = f.Links
+	c.OptOuts = f.OptOuts
+	c.Numbers = f.Numbers
+	c.Registrations = f.Registrations
+	c.Email = f.Email
+	return c
+}
+
+// Advance moves the fake clock forward, progressing delivery transitions and
+// making due scheduled messages send.
+func (f *FakeClient) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.offset += d
+}
+
+// SetCredits sets the available credit balance.
+func (f *FakeClient) SetCredits(credits int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.credits = credits
+}
+
+// SetDeliveryTimeline sets how long after creation messages become sent and
+// reach their final status.
+func (f *FakeClient) SetDeliveryTimeline(sentAfter, deliveredAfter time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sentAfter = sentAfter
+	f.deliveredAfter = deliveredAfter
+}
+
+// SetOutcome forces the final status (and error) for messages sent to a recipient.
+func (f *FakeClient) SetOutcome(to string, status sendly.MessageStatus, errMsg string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.outcomes[to] = outcome{status: status, err: errMsg}
+}
+
+// SetSenderOutcome forces the final status (and error) for messages sent from
+// a sender ID or number, such as a route that is down. It takes precedence
+// over SetOutcome.
+func (f *FakeClient) SetSenderOutcome(from string, status sendly.MessageStatus, errMsg string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.senderOutcomes[from] = outcome{status: status, err: errMsg}
+}
+
+// SetRateLimit limits the fake to limit calls per window, returning a
+// *sendly.RateLimitError beyond that. A limit of 0 disables rate limiting.
+func (f *FakeClient) SetRateLimit(limit int, window time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rateLimit = limit
+	f.rateWindow = window
+	f.calls = nil
+}
+
+// FailNext makes the next calls return the given errors, in order.
+func (f *FakeClient) FailNext(errs ...error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failNext = append(f.failNext, errs...)
+}
+
+// SentMessages returns all messages sent so far, with their current status.
+func (f *FakeClient) SentMessages() []sendly.Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	msgs := make([]sendly.Message, len(f.messages))
+	for i, m := range f.messages {
+		msgs[i] = f.snapshot(m, now)
+	}
+	return msgs
+}
+
+// Reset clears all recorded state and restores the defaults.
+func (f *FakeClient) Reset() {
+	fresh := NewFakeClient()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.offset = 0
+	f.credits = fresh.credits
+	f.reserved = 0
+	f.sentAfter = fresh.sentAfter
+	f.deliveredAfter = fresh.deliveredAfter
+	f.outcomes = fresh.outcomes
+	f.senderOutcomes = fresh.senderOutcomes
+	f.rateLimit = 0
+	f.rateWindow = 0
+	f.calls = nil
+	f.failNext = nil
+	f.messages = nil
+	f.scheduled = nil
+	f.batches = nil
+	f.drafts = nil
+	f.webhooks = nil
+	f.transactions = nil
+	f.keys = nil
+	f.currentKey = nil
+	f.verifications = nil
+	f.autoTopUp = sendly.AutoTopUpConfig{}
+	f.subAccounts = nil
+	f.deletions = nil
+	f.segments = nil
+	f.links = nil
+	f.optOuts = nil
+	f.available = nil
+	f.numbers = nil
+	f.brands = nil
+	f.campaigns = nil
+	f.tollFree = nil
+	f.emails = nil
+	f.templates = nil
+}
+
+// now returns the fake clock time. Callers must hold f.mu.
+func (f *FakeClient) now() time.Time {
+	return time.Now().Add(f.offset).UTC()
+}
+
+// nextID returns a new unique ID with the given prefix. Callers must hold f.mu.
+func (f *FakeClient) nextID(prefix string) string {
+	f.seq++
+	return prefix + "_fake_" + strconv.Itoa(f.seq)
+}
+
+// enter records a call and returns any injected or rate limit error.
+// Callers must hold f.mu.
+func (f *FakeClient) enter() error {
+	if len(f.failNext) > 0 {
+		err := f.failNext[0]
+		f.failNext = f.failNext[1:]
+		return err
+	}
+
+	if f.rateLimit <= 0 {
+		return nil
+	}
+
+	now := f.now()
+	cutoff := now.Add(-f.rateWindow)
+	kept := f.calls[:0]
+	for _, t := range f.calls {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	f.calls = kept
+
+	if len(f.calls) >= f.rateLimit {
+		retryAfter := int(math.Ceil(f.calls[0].Add(f.rateWindow).Sub(now).Seconds()))
+		return &sendly.RateLimitError{
+			APIError:   sendly.APIError{Code: "RATE_LIMIT_EXCEEDED", Message: "Too many requests"},
+			RetryAfter: retryAfter,
+		}
+	}
+
+	f.calls = append(f.calls, now)
+	return nil
+}
+
+// snapshot returns the message with its status at time now. Callers must hold f.mu.
+func (f *FakeClient) snapshot(m *fakeMessage, now time.Time) sendly.Message {
+	msg := m.msg
+	elapsed := now.Sub(m.created)
+
+	switch {
+	case m.validity > 0 && m.validity < f.deliveredAfter && elapsed >= m.validity:
+		// The validity period lapsed before the message would have been
+		// delivered.
+		msg.Status = sendly.MessageStatusExpired
+		expiredAt := m.created.Add(m.validity).Format(time.RFC3339)
+		msg.ExpiredAt = &expiredAt
+	case elapsed < f.sentAfter:
+		msg.Status = sendly.MessageStatusQueued
+	case elapsed < f.deliveredAfter:
+		msg.Status = sendly.MessageStatusSent
+	default:
+		msg.Status = m.outcome.status
+		if m.outcome.err != "" {
+			errMsg := m.outcome.err
+			msg.Error = &errMsg
+		} else if m.outcome.status == sendly.MessageStatusDelivered {
+			deliveredAt := m.created.Add(f.deliveredAfter).Format(time.RFC3339)
+			msg.DeliveredAt = &deliveredAt
+		}
+	}
+	return msg
+}
+
+// send records a message and charges its credits. Callers must hold f.mu and
+// have already checked the balance.
+func (f *FakeClient) send(to, text, from string) *fakeMessage {
+	now := f.now()
+	segments := sendly.CountSegments(text)
+
+	o, ok := f.senderOutcomes[from]
+	if !ok {
+		o, ok = f.outcomes[to]
+	}
+	if !ok {
+		o = outcome{status: sendly.MessageStatusDelivered}
+	}
+
+	m := &fakeMessage{
+		msg: sendly.Message{
+			ID:          f.nextID("msg"),
+			To:          to,
+			From:        from,
+			Text:        text,
+			Status:      sendly.MessageStatusQueued,
+			Direction:   "outbound",
+			Segments:    segments,
+			CreditsUsed: segments,
+			IsSandbox:   true,
+			SenderType:  string(sendly.SenderTypeSandbox),
+			CreatedAt:   now.Format(time.RFC3339),
+		},
+		created: now,
+		outcome: o,
+	}
+	f.messages = append(f.messages, m)
+
+	messageID := m.msg.ID
+	f.credits -= segments
+	f.transactions = append(f.transactions, sendly.CreditTransaction{
+		ID:           f.nextID("txn"),
+		Type:         sendly.TransactionTypeUsage,
+		Amount:       -segments,
+		BalanceAfter: f.credits,
+		Description:  "SMS to " + to,
+		MessageID:    &messageID,
+		CreatedAt:    now.Format(time.RFC3339),
+	})
+	f.applyAutoTopUp()
+	return m
+}
+
+// applyAutoTopUp purchases credits if auto top-up is enabled and the
+// available balance has fallen below its threshold. Callers must hold f.mu.
+func (f *FakeClient) applyAutoTopUp() {
+	if !f.autoTopUp.Enabled || f.credits >= f.autoTopUp.Threshold {
+		return
+	}
+
+	f.credits += f.autoTopUp.Amount
+	f.transactions = append(f.transactions, sendly.CreditTransaction{
+		ID:           f.nextID("txn"),
+		Type:         sendly.TransactionTypePurchase,
+		Amount:       f.autoTopUp.Amount,
+		BalanceAfter: f.credits,
+		Description:  "Auto top-up",
+		CreatedAt:    f.now().Format(time.RFC3339),
+	})
+}
+
+// findMessage returns the message with the given ID. Callers must hold f.mu.
+func (f *FakeClient) findMessage(id string) *fakeMessage {
+	for _, m := range f.messages {
+		if m.msg.ID == id {
+			return m
+		}
+	}
+	return nil
+}
+
+// dispatchDue sends scheduled messages whose time has come. Callers must hold f.mu.
+func (f *FakeClient) dispatchDue() {
+	now := f.now()
+	for _, s := range f.scheduled {
+		if s.Status != sendly.ScheduledMessageStatusScheduled {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, s.ScheduledAt)
+		if err != nil || now.Before(at) {
+			continue
+		}
+
+		f.reserved -= s.CreditsReserved
+		f.credits += s.CreditsReserved
+		m := f.send(s.To, s.Text, s.From)
+		m.msg.Metadata = s.Metadata
+		m.msg.StatusCallbackURL = s.StatusCallbackURL
+
+		sentAt := now.Format(time.RFC3339)
+		messageID := m.msg.ID
+		s.Status = sendly.ScheduledMessageStatusSent
+		s.SentAt = &sentAt
+		s.MessageID = &messageID
+	}
+}
+
+// insufficientCredits returns the error the API uses when the balance is too low.
+func insufficientCredits(needed, available int) error {
+	return &sendly.InsufficientCreditsError{
+		APIError: sendly.APIError{
+			Code:    "INSUFFICIENT_CREDITS",
+			Message: fmt.Sprintf("%d credits needed, %d available", needed, available),
+		},
+	}
+}
+
+// notFound returns the error the API uses for a missing resource.
+func notFound(what, id string) error {
+	return &sendly.NotFoundError{
+		APIError: sendly.APIError{Code: "NOT_FOUND", Message: what + " not found: " + id},
+	}
+}
+
+// validationError returns a client-side validation error.
+func validationError(msg string) error {
+	return &sendly.ValidationError{APIError: sendly.APIError{Message: msg}}
+}
+
+// paginate returns the [offset, offset+limit) bounds within n items.
+func paginate(n, limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset > n {
+		offset = n
+	}
+	end := offset + limit
+	if end > n {
+		end = n
+	}
+	return offset, end
+}
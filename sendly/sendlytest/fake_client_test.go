@@ -0,0 +1,1262 @@
+package sendlytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+func TestFakeClient_SendAndDeliver(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	msg, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{
+		To:   "+15551234567",
+		Text: "Hello",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Status != sendly.MessageStatusQueued {
+		t.Errorf("expected Status to be 'queued', got '%s'", msg.Status)
+	}
+
+	fake.Advance(DefaultSentAfter)
+	got, err := fake.Messages.Get(ctx, msg.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != sendly.MessageStatusSent {
+		t.Errorf("expected Status to be 'sent', got '%s'", got.Status)
+	}
+
+	fake.Advance(DefaultDeliveredAfter)
+	got, err = fake.Messages.Get(ctx, msg.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != sendly.MessageStatusDelivered {
+		t.Errorf("expected Status to be 'delivered', got '%s'", got.Status)
+	}
+
+	sent := fake.SentMessages()
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 sent message, got %d", len(sent))
+	}
+	if sent[0].To != "+15551234567" {
+		t.Errorf("expected To to be '+15551234567', got '%s'", sent[0].To)
+	}
+}
+
+func TestFakeClient_SandboxFailureNumber(t *testing.T) {
+	fake := NewFakeClient()
+	fake.SetDeliveryTimeline(0, 0)
+	ctx := context.Background()
+
+	msg, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15005550001", Text: "Hi"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := fake.Messages.Get(ctx, msg.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != sendly.MessageStatusFailed {
+		t.Errorf("expected Status to be 'failed', got '%s'", got.Status)
+	}
+	if got.Error == nil || *got.Error != "invalid_number" {
+		t.Errorf("expected Error to be 'invalid_number', got %v", got.Error)
+	}
+}
+
+func TestFakeClient_InsufficientCredits(t *testing.T) {
+	fake := NewFakeClient()
+	fake.SetCredits(1)
+	ctx := context.Background()
+
+	if _, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "One"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Two"})
+	if !sendly.IsInsufficientCreditsError(err) {
+		t.Errorf("expected InsufficientCreditsError, got %T", err)
+	}
+
+	credits, err := fake.Account.GetCredits(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if credits.AvailableBalance != 0 {
+		t.Errorf("expected AvailableBalance to be 0, got %d", credits.AvailableBalance)
+	}
+}
+
+func TestFakeClient_RateLimit(t *testing.T) {
+	fake := NewFakeClient()
+	fake.SetRateLimit(2, time.Second)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := fake.Account.Get(ctx); err != nil {
+			t.Fatalf("unexpected error on call %d: %v", i, err)
+		}
+	}
+
+	_, err := fake.Account.Get(ctx)
+	if !sendly.IsRateLimitError(err) {
+		t.Fatalf("expected RateLimitError, got %T", err)
+	}
+	if err.(*sendly.RateLimitError).RetryAfter != 1 {
+		t.Errorf("expected RetryAfter to be 1, got %d", err.(*sendly.RateLimitError).RetryAfter)
+	}
+
+	fake.Advance(time.Second)
+	if _, err := fake.Account.Get(ctx); err != nil {
+		t.Errorf("expected call to succeed after window, got %v", err)
+	}
+}
+
+func TestFakeClient_FailNext(t *testing.T) {
+	fake := NewFakeClient()
+	boom := errors.New("boom")
+	fake.FailNext(boom)
+	ctx := context.Background()
+
+	if _, err := fake.Messages.List(ctx, nil); err != boom {
+		t.Errorf("expected injected error, got %v", err)
+	}
+	if _, err := fake.Messages.List(ctx, nil); err != nil {
+		t.Errorf("expected second call to succeed, got %v", err)
+	}
+}
+
+func TestFakeClient_ScheduleDispatchAndCancel(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+	at := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	first, err := fake.Messages.Schedule(ctx, &sendly.ScheduleMessageRequest{To: "+15551234567", Text: "Later", ScheduledAt: at})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := fake.Messages.Schedule(ctx, &sendly.ScheduleMessageRequest{To: "+15551234567", Text: "Later", ScheduledAt: at})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cancelled, err := fake.Messages.CancelScheduled(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cancelled.CreditsRefunded != 1 {
+		t.Errorf("expected CreditsRefunded to be 1, got %d", cancelled.CreditsRefunded)
+	}
+
+	fake.Advance(2 * time.Hour)
+	got, err := fake.Messages.GetScheduled(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != sendly.ScheduledMessageStatusSent {
+		t.Errorf("expected Status to be 'sent', got '%s'", got.Status)
+	}
+	if got.MessageID == nil {
+		t.Error("expected MessageID to be set after dispatch")
+	}
+	if len(fake.SentMessages()) != 1 {
+		t.Errorf("expected 1 sent message, got %d", len(fake.SentMessages()))
+	}
+}
+
+func TestFakeClient_BatchLifecycle(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	batch, err := fake.Messages.SendBatch(ctx, &sendly.SendBatchRequest{
+		Messages: []sendly.BatchMessageItem{
+			{To: "+15551234567", Text: "One"},
+			{To: "+15005550002", Text: "Two"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batch.Status != sendly.BatchStatusProcessing {
+		t.Errorf("expected Status to be 'processing', got '%s'", batch.Status)
+	}
+
+	fake.Advance(DefaultDeliveredAfter)
+	got, err := fake.Messages.GetBatch(ctx, batch.BatchID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != sendly.BatchStatusPartialFailure {
+		t.Errorf("expected Status to be 'partial_failure', got '%s'", got.Status)
+	}
+	if got.Failed != 1 {
+		t.Errorf("expected Failed to be 1, got %d", got.Failed)
+	}
+}
+
+func TestFakeClient_ClientWiring(t *testing.T) {
+	fake := NewFakeClient()
+	client := fake.Client()
+
+	if _, err := client.Messages.Send(context.Background(), &sendly.SendMessageRequest{To: "+15551234567", Text: "Hi"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fake.SentMessages()) != 1 {
+		t.Errorf("expected 1 sent message, got %d", len(fake.SentMessages()))
+	}
+}
+
+func TestFakeClient_Webhooks(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	created, err := fake.Webhooks.Create(ctx, sendly.CreateWebhookRequest{
+		URL:    "https://example.com/hook",
+		Events: []string{"message.delivered"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.Secret == "" {
+		t.Error("expected Secret to be set")
+	}
+
+	if err := fake.Webhooks.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fake.Webhooks.Get(ctx, created.ID); !sendly.IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %T", err)
+	}
+}
+
+func TestFakeClient_DryRun(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	msg, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Hi", DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !msg.DryRun {
+		t.Error("expected DryRun to be true")
+	}
+	if len(fake.SentMessages()) != 0 {
+		t.Errorf("expected no sent messages, got %d", len(fake.SentMessages()))
+	}
+
+	credits, err := fake.Account.GetCredits(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if credits.AvailableBalance != DefaultCredits {
+		t.Errorf("expected AvailableBalance to be %d, got %d", DefaultCredits, credits.AvailableBalance)
+	}
+}
+
+func TestFakeClient_BatchDraft(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	draft, err := fake.Messages.CreateBatchDraft(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := fake.Messages.AddItemsToDraft(ctx, draft.ID, []sendly.BatchMessageItem{{To: "+15551234567", Text: "Hi"}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(fake.SentMessages()) != 0 {
+		t.Errorf("expected no sent messages before launch, got %d", len(fake.SentMessages()))
+	}
+
+	batch, err := fake.Messages.LaunchDraft(ctx, draft.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batch.Total != 3 {
+		t.Errorf("expected Total to be 3, got %d", batch.Total)
+	}
+
+	if _, err := fake.Messages.LaunchDraft(ctx, draft.ID); !sendly.IsValidationError(err) {
+		t.Errorf("expected ValidationError on second launch, got %T", err)
+	}
+}
+
+func TestFakeClient_ListBatchesByDate(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+	start := time.Now().Add(-time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if _, err := fake.Messages.SendBatch(ctx, &sendly.SendBatchRequest{
+			Messages: []sendly.BatchMessageItem{{To: "+15551234567", Text: "Hi"}},
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	batches, err := fake.Messages.ListBatchesByDate(ctx, &sendly.ListBatchesByDateRequest{Start: start})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(batches) != 3 {
+		t.Errorf("expected 3 batches, got %d", len(batches))
+	}
+}
+
+func TestFakeClient_ContactVerification(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	v, err := fake.Account.StartTestNumberVerification(ctx, "+15551234567")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fake.Account.ConfirmVerification(ctx, v.ID, "000000"); !sendly.IsValidationError(err) {
+		t.Errorf("expected ValidationError for wrong code, got %T", err)
+	}
+
+	v, err = fake.Account.ConfirmVerification(ctx, v.ID, VerificationCode)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Status != sendly.VerificationStatusVerified || v.VerifiedAt == nil {
+		t.Errorf("expected verification to be verified, got %+v", v)
+	}
+
+	expired, _ := fake.Account.StartEmailVerification(ctx, "ops@example.com")
+	fake.Advance(11 * time.Minute)
+	if _, err := fake.Account.ConfirmVerification(ctx, expired.ID, VerificationCode); !sendly.IsValidationError(err) {
+		t.Errorf("expected ValidationError for expired code, got %T", err)
+	}
+}
+
+func TestFakeClient_CanarySend(t *testing.T) {
+	fake := NewFakeClient()
+	fake.SetDeliveryTimeline(0, 0)
+	ctx := context.Background()
+
+	items := make([]sendly.BatchMessageItem, 50)
+	for i := range items {
+		items[i] = sendly.BatchMessageItem{To: fmt.Sprintf("+1555123%04d", i), Text: "Hi"}
+	}
+
+	result, err := sendly.SendBatchWithCanary(ctx, fake.Messages, &sendly.SendBatchRequest{Messages: items}, sendly.CanaryOptions{
+		Percent:      10,
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Sample.Total != 5 || result.Remainder == nil || result.Remainder.Total != 45 {
+		t.Errorf("expected a sample of 5 and remainder of 45, got %+v", result)
+	}
+	if got := len(fake.SentMessages()); got != 50 {
+		t.Errorf("expected 50 messages sent, got %d", got)
+	}
+}
+
+func TestFakeClient_UpdateAPIKey(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	created, err := fake.Account.CreateAPIKey(ctx, "CI")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	name := "Deploys"
+	permissions := []string{"sms:send"}
+	if _, err := fake.Account.UpdateAPIKey(ctx, created.APIKey.ID, sendly.UpdateAPIKeyRequest{Name: &name, Permissions: &permissions}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	key, _ := fake.Account.GetAPIKey(ctx, created.APIKey.ID)
+	if key.Name != "Deploys" || len(key.Permissions) != 1 {
+		t.Errorf("expected key to be updated, got %+v", key)
+	}
+	if _, err := fake.Account.UpdateAPIKey(ctx, "key_missing", sendly.UpdateAPIKeyRequest{Name: &name}); !sendly.IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %T", err)
+	}
+}
+
+func TestFakeClient_EnsureWebhook(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+	req := sendly.CreateWebhookRequest{
+		URL:    "https://example.com/webhooks",
+		Events: []string{"message.delivered"},
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := sendly.EnsureWebhook(ctx, fake.Webhooks, req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	webhooks, _ := fake.Webhooks.List(ctx)
+	if len(webhooks) != 1 {
+		t.Errorf("expected 1 webhook, got %d", len(webhooks))
+	}
+}
+
+func TestFakeClient_CreateScopedAPIKey(t *testing.T) {
+	fake := NewFakeClient()
+
+	resp, err := fake.Account.CreateAPIKeyWithOptions(context.Background(), sendly.CreateAPIKeyRequest{
+		Name:        "Dashboards",
+		Type:        sendly.APIKeyTypeLive,
+		Permissions: sendly.ReadOnlyPermissions,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.APIKey.Type != "live" || resp.APIKey.Prefix != "sk_live_v1_" {
+		t.Errorf("expected a live key, got %+v", resp.APIKey)
+	}
+	if len(resp.APIKey.Permissions) != len(sendly.ReadOnlyPermissions) {
+		t.Errorf("expected read-only permissions, got %v", resp.APIKey.Permissions)
+	}
+}
+
+func TestFakeClient_SendEchoesClientID(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+	clientID := sendly.NewClientID()
+
+	msg, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Hi", ClientID: clientID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ := fake.Messages.Get(ctx, msg.ID)
+	if msg.ClientID != clientID || got.ClientID != clientID {
+		t.Errorf("expected ClientID '%s' on sent and stored message, got '%s' and '%s'", clientID, msg.ClientID, got.ClientID)
+	}
+}
+
+func TestFakeClient_AutoTopUp(t *testing.T) {
+	fake := NewFakeClient()
+	fake.SetCredits(3)
+	ctx := context.Background()
+
+	if _, err := fake.Account.SetAutoTopUp(ctx, sendly.AutoTopUpConfig{Enabled: true, Threshold: 2, Amount: 100}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Hi"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	credits, _ := fake.Account.GetCredits(ctx)
+	if credits.AvailableBalance != 101 {
+		t.Errorf("expected balance 101 after one top-up, got %d", credits.AvailableBalance)
+	}
+	txns, _ := fake.Account.GetCreditTransactions(ctx, nil)
+	if txns[0].Type != sendly.TransactionTypePurchase || txns[0].Amount != 100 {
+		t.Errorf("expected a purchase transaction of 100, got %+v", txns[0])
+	}
+
+	config, _ := fake.Account.GetAutoTopUp(ctx)
+	if !config.Enabled || config.Threshold != 2 {
+		t.Errorf("expected stored config, got %+v", config)
+	}
+}
+
+func TestFakeClient_WatchCredits(t *testing.T) {
+	fake := NewFakeClient()
+	fake.SetCredits(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	low := make(chan sendly.Credits, 1)
+	updates, err := fake.Account.WatchCredits(ctx, sendly.WatchOptions{
+		Interval:  time.Millisecond,
+		Threshold: 5,
+		OnLow:     func(c sendly.Credits) { low <- c },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c := <-updates; c.AvailableBalance != 10 {
+		t.Errorf("expected initial balance 10, got %d", c.AvailableBalance)
+	}
+
+	fake.SetCredits(3)
+	select {
+	case c := <-low:
+		if c.AvailableBalance != 3 {
+			t.Errorf("expected OnLow with balance 3, got %d", c.AvailableBalance)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected OnLow to be called")
+	}
+}
+
+func TestFakeClient_ListChan(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+	for i := 0; i < 7; i++ {
+		fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Hi"})
+	}
+
+	msgs, errc := fake.Messages.ListChan(ctx, &sendly.ListMessagesRequest{Limit: 3})
+	count := 0
+	for range msgs {
+		count++
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 7 {
+		t.Errorf("expected 7 messages, got %d", count)
+	}
+}
+
+func TestFakeClient_ListCreditTransactions(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+	var last *sendly.Message
+	for i := 0; i < 5; i++ {
+		last, _ = fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Hi"})
+	}
+
+	resp, err := fake.Account.ListCreditTransactions(ctx, &sendly.ListCreditTransactionsOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Count != 5 || !resp.HasMore || len(resp.Data) != 2 {
+		t.Errorf("expected first page of 2 out of 5, got %+v", resp)
+	}
+
+	byMessage, _ := fake.Account.ListCreditTransactions(ctx, &sendly.ListCreditTransactionsOptions{MessageID: last.ID})
+	if byMessage.Count != 1 || *byMessage.Data[0].MessageID != last.ID {
+		t.Errorf("expected one transaction for %s, got %+v", last.ID, byMessage.Data)
+	}
+
+	it := fake.Account.IterateCreditTransactions(&sendly.ListCreditTransactionsOptions{Type: sendly.TransactionTypeUsage, Limit: 2})
+	count := 0
+	for it.Next(ctx) {
+		count++
+	}
+	if it.Err() != nil || count != 5 {
+		t.Errorf("expected to iterate 5 transactions, got %d (err %v)", count, it.Err())
+	}
+}
+
+func TestFakeClient_UpdateAccount(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	name := "Acme"
+	timezone := "America/New_York"
+	if _, err := fake.Account.Update(ctx, sendly.UpdateAccountRequest{Name: &name, Timezone: &timezone}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	account, _ := fake.Account.Get(ctx)
+	if account.Name == nil || *account.Name != "Acme" || account.Timezone != timezone {
+		t.Errorf("expected account to be updated, got %+v", account)
+	}
+}
+
+func TestFakeClient_ListByThreadKey(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+	fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Order placed", ThreadKey: "order-1"})
+	fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Other", ThreadKey: "order-2"})
+	fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Order shipped", ThreadKey: "order-1"})
+
+	resp, err := fake.Messages.List(ctx, &sendly.ListMessagesRequest{ThreadKey: "order-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Count != 2 || resp.Data[0].Text != "Order shipped" {
+		t.Errorf("expected the 2 messages in thread order-1, newest first, got %+v", resp.Data)
+	}
+}
+
+func TestFakeClient_SubAccounts(t *testing.T) {
+	fake := NewFakeClient()
+	fake.SetCredits(1000)
+	ctx := context.Background()
+
+	sub, err := fake.SubAccounts.Create(ctx, sendly.CreateSubAccountRequest{Name: "Acme", InitialCredits: 300})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transfer, err := fake.SubAccounts.TransferCredits(ctx, sub.ID, -100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transfer.ParentBalanceAfter != 800 || transfer.SubAccountBalanceAfter != 200 {
+		t.Errorf("expected balances 800 and 200, got %+v", transfer)
+	}
+	if _, err := fake.SubAccounts.TransferCredits(ctx, sub.ID, 5000); !sendly.IsInsufficientCreditsError(err) {
+		t.Errorf("expected InsufficientCreditsError, got %T", err)
+	}
+
+	if _, err := fake.SubAccounts.Suspend(ctx, sub.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	suspended, _ := fake.SubAccounts.List(ctx, &sendly.ListSubAccountsRequest{Status: sendly.SubAccountStatusSuspended})
+	if suspended.Count != 1 || suspended.Data[0].SuspendedAt == nil {
+		t.Errorf("expected 1 suspended sub-account, got %+v", suspended)
+	}
+
+	if _, err := fake.SubAccounts.Get(ctx, "sub_missing"); !sendly.IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %T", err)
+	}
+}
+
+func TestFakeClient_MessageMetadata(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	sent, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{
+		To:       "+15551234567",
+		Text:     "Hello",
+		Metadata: map[string]interface{}{"orderId": "ord_42"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := fake.Messages.Get(ctx, sent.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Metadata["orderId"] != "ord_42" {
+		t.Errorf("expected metadata orderId to be 'ord_42', got '%v'", got.Metadata["orderId"])
+	}
+
+	_, err = fake.Messages.Send(ctx, &sendly.SendMessageRequest{
+		To:       "+15551234567",
+		Text:     "Hello",
+		Metadata: map[string]interface{}{"bad key": "x"},
+	})
+	if !sendly.IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
+
+func TestFakeClient_StatusCallbackURL(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	resp, err := fake.Messages.SendBatch(ctx, &sendly.SendBatchRequest{
+		Messages: []sendly.BatchMessageItem{
+			{To: "+15551234567", Text: "Hi"},
+			{To: "+15551234568", Text: "Hi", StatusCallbackURL: "https://b.example.com/hooks"},
+		},
+		StatusCallbackURL: "https://a.example.com/hooks",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"https://a.example.com/hooks", "https://b.example.com/hooks"}
+	for i, result := range resp.Messages {
+		msg, err := fake.Messages.Get(ctx, *result.MessageID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if msg.StatusCallbackURL != expected[i] {
+			t.Errorf("expected message %d StatusCallbackURL to be '%s', got '%s'", i, expected[i], msg.StatusCallbackURL)
+		}
+	}
+
+	_, err = fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Hi", StatusCallbackURL: "http://insecure"})
+	if !sendly.IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
+
+func TestFakeClient_ValidityPeriodExpires(t *testing.T) {
+	fake := NewFakeClient()
+	fake.SetDeliveryTimeline(time.Second, 10*time.Minute)
+	ctx := context.Background()
+
+	sent, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{
+		To:             "+15551234567",
+		Text:           "Your code is 123456",
+		ValidityPeriod: 5 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent.ExpiresAt == nil {
+		t.Fatal("expected ExpiresAt to be set")
+	}
+
+	fake.Advance(2 * time.Minute)
+	msg, _ := fake.Messages.Get(ctx, sent.ID)
+	if msg.Status != sendly.MessageStatusSent {
+		t.Errorf("expected status to be 'sent' before expiry, got '%s'", msg.Status)
+	}
+
+	fake.Advance(5 * time.Minute)
+	msg, _ = fake.Messages.Get(ctx, sent.ID)
+	if msg.Status != sendly.MessageStatusExpired || msg.ExpiredAt == nil {
+		t.Errorf("expected message to expire, got status '%s'", msg.Status)
+	}
+}
+
+func TestFakeClient_GetDeliveryReport(t *testing.T) {
+	fake := NewFakeClient()
+	fake.SetDeliveryTimeline(time.Second, 5*time.Second)
+	fake.SetOutcome("+15551234568", sendly.MessageStatusFailed, "subscriber_absent")
+	ctx := context.Background()
+
+	delivered, _ := fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Hi"})
+	failed, _ := fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234568", Text: "Hi"})
+
+	report, err := fake.Messages.GetDeliveryReport(ctx, delivered.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Hops) != 1 || report.Hops[0].Stage != sendly.DeliveryStageAccepted {
+		t.Errorf("expected only the accepted hop, got %+v", report.Hops)
+	}
+
+	fake.Advance(5 * time.Second)
+	report, _ = fake.Messages.GetDeliveryReport(ctx, delivered.ID)
+	if report.CarrierStatusCode != "DELIVRD" || len(report.Hops) != 4 {
+		t.Errorf("expected a delivered report with 4 hops, got %+v", report)
+	}
+
+	report, _ = fake.Messages.GetDeliveryReport(ctx, failed.ID)
+	if report.ErrorCode != "subscriber_absent" || report.CarrierStatusCode != "UNDELIV" {
+		t.Errorf("expected a failed report, got %+v", report)
+	}
+
+	if _, err := fake.Messages.GetDeliveryReport(ctx, "msg_missing"); !sendly.IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %T", err)
+	}
+}
+
+func TestFakeClient_ListMessagesByDateRange(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "old"})
+	fake.Advance(48 * time.Hour)
+	// The fake clock is 48 hours ahead, so this falls between the two sends.
+	cutoff := time.Now().Add(47 * time.Hour)
+	fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "new"})
+
+	resp, err := fake.Messages.List(ctx, &sendly.ListMessagesRequest{CreatedAfter: cutoff})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Count != 1 || resp.Data[0].Text != "new" {
+		t.Errorf("expected only the new message, got %+v", resp.Data)
+	}
+
+	resp, _ = fake.Messages.List(ctx, &sendly.ListMessagesRequest{CreatedBefore: cutoff})
+	if resp.Count != 1 || resp.Data[0].Text != "old" {
+		t.Errorf("expected only the old message, got %+v", resp.Data)
+	}
+}
+
+func TestFakeClient_SearchMessages(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Your refund is pending"})
+	fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234568", Text: "Refund issued. Refund ref #42"})
+	fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234569", Text: "Hello"})
+
+	resp, err := fake.Messages.Search(ctx, sendly.SearchMessagesRequest{Query: "refund"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Count != 2 {
+		t.Fatalf("expected 2 results, got %d", resp.Count)
+	}
+	if resp.Data[0].To != "+15551234568" {
+		t.Errorf("expected the message with two matches first, got %s", resp.Data[0].To)
+	}
+
+	resp, _ = fake.Messages.Search(ctx, sendly.SearchMessagesRequest{Query: "4569"})
+	if resp.Count != 1 || resp.Data[0].Text != "Hello" {
+		t.Errorf("expected a match on the recipient, got %+v", resp.Data)
+	}
+}
+
+func TestFakeClient_Compliance(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Hello"})
+	fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15559876543", Text: "Hi"})
+	batch, _ := fake.Messages.SendBatch(ctx, &sendly.SendBatchRequest{Messages: []sendly.BatchMessageItem{
+		{To: "+15551234567", Text: "Batch"},
+		{To: "+15559876543", Text: "Batch"},
+	}})
+
+	data, err := fake.Compliance.GetRecipientData(ctx, "+15551234567")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data.Messages) != 2 || data.Messages[0].Text != "Batch" {
+		t.Errorf("expected 2 messages, newest first, got %+v", data.Messages)
+	}
+
+	job, err := fake.Compliance.DeleteRecipientData(ctx, "+15551234567")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !job.Done() || job.MessagesDeleted != 2 {
+		t.Errorf("expected a completed job deleting 2 messages, got %+v", job)
+	}
+
+	if len(fake.SentMessages()) != 2 {
+		t.Errorf("expected 2 remaining messages, got %d", len(fake.SentMessages()))
+	}
+	remaining, err := fake.Messages.GetBatch(ctx, batch.BatchID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining.Total != 1 {
+		t.Errorf("expected the batch to keep 1 message, got %d", remaining.Total)
+	}
+
+	waited, err := fake.Compliance.WaitForDeletion(ctx, job.ID, 0)
+	if err != nil || waited.Status != sendly.DataDeletionStatusCompleted {
+		t.Errorf("expected the completed job, got %+v, %v", waited, err)
+	}
+	if _, err := fake.Compliance.GetDeletionJob(ctx, "del_missing"); !sendly.IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %T", err)
+	}
+}
+
+func TestFakeClient_SendWindow(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	window := &sendly.SendWindow{Start: "00:00", End: "23:59", Days: []time.Weekday{now.Add(24 * time.Hour).Weekday()}}
+
+	msg, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Sale", SendWindow: window})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Status != sendly.MessageStatusScheduled {
+		t.Errorf("expected status 'scheduled', got '%s'", msg.Status)
+	}
+	if len(fake.SentMessages()) != 0 {
+		t.Errorf("expected nothing sent yet, got %d messages", len(fake.SentMessages()))
+	}
+
+	fake.Advance(48 * time.Hour)
+	scheduled, err := fake.Messages.GetScheduled(ctx, msg.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scheduled.Status != sendly.ScheduledMessageStatusSent {
+		t.Errorf("expected the deferred message to be sent, got '%s'", scheduled.Status)
+	}
+
+	window.Reject = true
+	if _, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15551234567", Text: "Sale", SendWindow: window}); !sendly.IsOutsideSendWindowError(err) {
+		t.Errorf("expected OutsideSendWindowError, got %T", err)
+	}
+}
+
+func TestFakeClient_Segments(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	seg, err := fake.Segments.Create(ctx, sendly.CreateSegmentRequest{
+		Name:   "VIPs",
+		Filter: sendly.SegmentFilter{Tags: []string{"vip"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fake.SetSegmentContacts(seg.ID, "+15551234567", "+15559876543"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, _ := fake.Segments.Get(ctx, seg.ID)
+	if got.ContactCount != 2 {
+		t.Errorf("expected 2 contacts, got %d", got.ContactCount)
+	}
+
+	batch, err := fake.Messages.SendToSegment(ctx, seg.ID, "Spring sale!")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batch.Total != 2 || len(fake.SentMessages()) != 2 {
+		t.Errorf("expected 2 messages sent, got %+v", batch)
+	}
+
+	if err := fake.Segments.Delete(ctx, seg.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fake.Messages.SendToSegment(ctx, seg.ID, "Hello"); !sendly.IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %T", err)
+	}
+}
+
+func TestFakeClient_Links(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	msg, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{
+		To:           "+15551234567",
+		Text:         "Sale today: https://shop.example.com/sale",
+		ShortenLinks: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(msg.Text, "shop.example.com") {
+		t.Errorf("expected the link to be shortened, got '%s'", msg.Text)
+	}
+
+	links, _ := fake.Links.List(ctx, &sendly.ListLinksRequest{MessageID: msg.ID})
+	if links.Count != 1 || links.Data[0].TargetURL != "https://shop.example.com/sale" {
+		t.Fatalf("expected 1 link for the message, got %+v", links)
+	}
+	if !strings.Contains(msg.Text, links.Data[0].ShortURL) {
+		t.Errorf("expected the text to contain '%s', got '%s'", links.Data[0].ShortURL, msg.Text)
+	}
+
+	if err := fake.Click(links.Data[0].ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	link, _ := fake.Links.Get(ctx, links.Data[0].ID)
+	if link.ClickCount != 1 || link.LastClickedAt == nil {
+		t.Errorf("expected 1 click, got %+v", link)
+	}
+
+	if _, err := fake.Links.Create(ctx, "not a url"); !sendly.IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %T", err)
+	}
+}
+
+func TestFakeClient_OptOutKeywords(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+	handler := &sendly.KeywordHandler{OptOuts: fake.OptOuts, Brand: "Acme"}
+
+	if _, err := handler.HandleMessage(ctx, "+15551234567", "STOP"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	optOut, err := fake.OptOuts.Get(ctx, "+15551234567")
+	if err != nil {
+		t.Fatalf("expected an opt-out, got %v", err)
+	}
+	if optOut.Source != sendly.OptOutSourceKeyword {
+		t.Errorf("expected source 'keyword', got '%s'", optOut.Source)
+	}
+
+	if _, err := handler.HandleMessage(ctx, "+15551234567", "START"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fake.OptOuts.Get(ctx, "+15551234567"); !sendly.IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError after START, got %v", err)
+	}
+	if err := fake.OptOuts.Remove(ctx, "+15551234567"); !sendly.IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError removing a missing opt-out, got %v", err)
+	}
+}
+
+func TestFakeClient_Numbers(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+	fake.SetCredits(150)
+	fake.SetAvailableNumbers(
+		sendly.AvailableNumber{Number: "+14155550100", Country: "US", Capabilities: sendly.NumberCapabilities{SMS: true}, MonthlyCredits: 100},
+		sendly.AvailableNumber{Number: "+12125550100", Country: "US", Capabilities: sendly.NumberCapabilities{SMS: true}, MonthlyCredits: 100},
+	)
+
+	found, err := fake.Numbers.Search(ctx, sendly.SearchNumbersRequest{Country: "US", AreaCode: "415"})
+	if err != nil || len(found) != 1 {
+		t.Fatalf("expected 1 number in 415, got %v, %v", found, err)
+	}
+
+	number, err := fake.Numbers.Purchase(ctx, found[0].Number)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fake.Numbers.Purchase(ctx, "+12125550100"); !sendly.IsInsufficientCreditsError(err) {
+		t.Errorf("expected InsufficientCreditsError, got %v", err)
+	}
+
+	mms := sendly.NumberCapabilities{SMS: true, MMS: true}
+	updated, err := fake.Numbers.Update(ctx, number.ID, sendly.UpdateNumberRequest{Capabilities: &mms})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Capabilities.MMS {
+		t.Error("expected MMS to stay disabled on an SMS-only number")
+	}
+
+	if err := fake.Numbers.Release(ctx, number.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list, _ := fake.Numbers.List(ctx, nil)
+	if list.Count != 0 {
+		t.Errorf("expected no numbers after release, got %d", list.Count)
+	}
+}
+
+func TestFakeClient_Registrations(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	brand, err := fake.Registrations.RegisterBrand(ctx, sendly.RegisterBrandRequest{
+		LegalName:    "Acme Inc",
+		Country:      "US",
+		EIN:          "12-3456789",
+		ContactEmail: "compliance@acme.example",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	campaign := sendly.RegisterCampaignRequest{
+		BrandID:          brand.ID,
+		UseCase:          sendly.CampaignUseCaseTwoFactor,
+		Description:      "Login codes",
+		SampleMessages:   []string{"Acme: your code is 123456"},
+		OptInDescription: "Users enter their number at sign-up",
+	}
+	if _, err := fake.Registrations.RegisterCampaign(ctx, campaign); !sendly.IsValidationError(err) {
+		t.Errorf("expected ValidationError for an unapproved brand, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		fake.ReviewRegistration(brand.ID, sendly.RegistrationStatusApproved)
+	}()
+	brand, err = fake.Registrations.WaitForBrand(ctx, brand.ID, time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !brand.Approved() || brand.ReviewedAt == nil {
+		t.Errorf("expected an approved brand, got %+v", brand)
+	}
+
+	created, err := fake.Registrations.RegisterCampaign(ctx, campaign)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fake.ReviewRegistration(created.ID, sendly.RegistrationStatusRejected, sendly.Rejection{Reason: sendly.RejectionReasonSampleMismatch})
+	got, _ := fake.Registrations.GetCampaign(ctx, created.ID)
+	if got.Status != sendly.RegistrationStatusRejected || got.Rejections[0].Reason != sendly.RejectionReasonSampleMismatch {
+		t.Errorf("expected a rejected campaign, got %+v", got)
+	}
+}
+
+func TestFakeClient_Email(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	email, err := fake.Email.Send(ctx, sendly.EmailRequest{To: "user@example.com", Subject: "Your code", Text: "123456"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if email.Status != sendly.EmailStatusDelivered {
+		t.Errorf("expected status 'delivered', got '%s'", email.Status)
+	}
+	if sent := fake.SentEmails(); len(sent) != 1 || sent[0].ID != email.ID {
+		t.Errorf("expected 1 sent email, got %+v", sent)
+	}
+
+	fake.FailNext(&sendly.RateLimitError{})
+	if _, err := fake.Email.Send(ctx, sendly.EmailRequest{To: "user@example.com", Subject: "Your code", Text: "123456"}); !sendly.IsRateLimitError(err) {
+		t.Errorf("expected RateLimitError, got %v", err)
+	}
+}
+
+func TestFakeClient_SenderOutcomeFailover(t *testing.T) {
+	fake := NewFakeClient()
+	fake.SetDeliveryTimeline(0, 0)
+	fake.SetSenderOutcome("ACME", sendly.MessageStatusFailed, "route_unavailable")
+
+	result, err := sendly.SendWithFailover(context.Background(), fake.Messages, &sendly.SendMessageRequest{
+		To:   "+15551234567",
+		Text: "Your code is 123456",
+	}, sendly.FailoverPolicy{
+		Senders:      []string{"ACME", "+18005550100"},
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Delivered || len(result.Attempts) != 2 {
+		t.Fatalf("expected delivery on the second attempt, got %+v", result)
+	}
+	if result.Message().From != "+18005550100" {
+		t.Errorf("expected delivery from '+18005550100', got '%s'", result.Message().From)
+	}
+	if len(fake.SentMessages()) != 2 {
+		t.Errorf("expected 2 sent messages, got %d", len(fake.SentMessages()))
+	}
+}
+
+func TestFakeClient_BatchTemplate(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+	fake.SetTemplate("tpl_welcome", "Hi {{name}}, your code is {{ code }}")
+
+	_, err := fake.Messages.SendBatch(ctx, &sendly.SendBatchRequest{
+		TemplateID: "tpl_welcome",
+		Messages: []sendly.BatchMessageItem{
+			{To: "+15551234567", Variables: map[string]string{"name": "Ada", "code": "111"}},
+			{To: "+15557654321", Variables: map[string]string{"name": "Grace", "code": "222"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sent := fake.SentMessages()
+	if len(sent) != 2 || sent[0].Text != "Hi Ada, your code is 111" || sent[1].Text != "Hi Grace, your code is 222" {
+		t.Errorf("unexpected rendered messages %+v", sent)
+	}
+
+	_, err = fake.Messages.SendBatch(ctx, &sendly.SendBatchRequest{
+		TemplateID: "tpl_welcome",
+		Messages:   []sendly.BatchMessageItem{{To: "+15551234567", Variables: map[string]string{"name": "Ada"}}},
+	})
+	if !sendly.IsValidationError(err) {
+		t.Errorf("expected ValidationError for a missing variable, got %v", err)
+	}
+
+	_, err = fake.Messages.SendBatch(ctx, &sendly.SendBatchRequest{
+		TemplateID: "tpl_missing",
+		Messages:   []sendly.BatchMessageItem{{To: "+15551234567"}},
+	})
+	if !sendly.IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError for an unknown template, got %v", err)
+	}
+}
+
+func TestFakeClient_StreamBatchResults(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	items := make([]sendly.BatchMessageItem, 150)
+	for i := range items {
+		items[i] = sendly.BatchMessageItem{To: fmt.Sprintf("+1555000%04d", i), Text: "Hello"}
+	}
+	batch, err := fake.Messages.SendBatch(ctx, &sendly.SendBatchRequest{Messages: items})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	it, err := fake.Messages.StreamBatchResults(ctx, batch.BatchID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	count := 0
+	for it.Next(ctx) {
+		if it.Result().To != items[count].To {
+			t.Fatalf("expected %s at position %d, got %s", items[count].To, count, it.Result().To)
+		}
+		count++
+	}
+	if it.Err() != nil || count != len(items) {
+		t.Errorf("expected %d results, got %d (err %v)", len(items), count, it.Err())
+	}
+
+	if _, err := fake.Messages.StreamBatchResults(ctx, "batch_missing"); !sendly.IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestFakeClient_ListMessagesByBatch(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+
+	if _, err := fake.Messages.Send(ctx, &sendly.SendMessageRequest{To: "+15550000000", Text: "Solo"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	batch, err := fake.Messages.SendBatch(ctx, &sendly.SendBatchRequest{Messages: []sendly.BatchMessageItem{
+		{To: "+15551111111", Text: "One"},
+		{To: "+15552222222", Text: "Two"},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	page, err := fake.Messages.List(ctx, &sendly.ListMessagesRequest{BatchID: batch.BatchID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.Count != 2 {
+		t.Fatalf("expected 2 messages in the batch, got %d", page.Count)
+	}
+	for _, msg := range page.Data {
+		if msg.BatchID != batch.BatchID {
+			t.Errorf("expected BatchID %s, got %q", batch.BatchID, msg.BatchID)
+		}
+	}
+}
+
+func TestFakeClient_ScheduleRecipientLocalTime(t *testing.T) {
+	fake := NewFakeClient()
+	day := time.Now().AddDate(0, 0, 7)
+
+	scheduled, err := fake.Messages.Schedule(context.Background(), &sendly.ScheduleMessageRequest{
+		To:                       "+819012345678",
+		Text:                     "Good morning",
+		ScheduledAt:              day.Format("2006-01-02") + "T09:00:00",
+		SendAtRecipientLocalTime: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := day.Format("2006-01-02") + "T00:00:00Z"; scheduled.ScheduledAt != want {
+		t.Errorf("expected 9am Tokyo to be %s, got %s", want, scheduled.ScheduledAt)
+	}
+}
+
+func TestFakeClient_SchedulePastTime(t *testing.T) {
+	fake := NewFakeClient()
+
+	_, err := fake.Messages.Schedule(context.Background(), &sendly.ScheduleMessageRequest{
+		To:     "+15551234567",
+		Text:   "Too late",
+		SendAt: time.Now().Add(-time.Hour),
+	})
+	if !sendly.IsScheduleTimeError(err) {
+		t.Errorf("expected ScheduleTimeError, got %v", err)
+	}
+}
+
+func TestFakeClient_ValidateKey(t *testing.T) {
+	fake := NewFakeClient()
+	client := fake.Client()
+
+	key, err := client.ValidateKey(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.Type != string(sendly.APIKeyTypeTest) {
+		t.Errorf("expected a test key, got %+v", key)
+	}
+
+	fake.SetCurrentAPIKey(sendly.APIKey{ID: "key_1", Type: "live", IsRevoked: true})
+	if _, err := client.ValidateKey(context.Background()); !sendly.IsAuthenticationError(err) {
+		t.Errorf("expected an authentication error for a revoked key, got %v", err)
+	}
+}
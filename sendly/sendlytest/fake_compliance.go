@@ -0,0 +1,149 @@
+package sendlytest
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// FakeCompliance is an in-memory implementation of sendly.ComplianceAPI.
+// Deletions run immediately: the recipient's messages and pending scheduled
+// messages are removed from the fake and the job is returned completed.
+type FakeCompliance struct {
+	fake *FakeClient
+}
+
+var _ sendly.ComplianceAPI = (*FakeCompliance)(nil)
+
+// GetRecipientData returns the recorded messages and pending scheduled
+// messages to phone, newest first.
+func (s *FakeCompliance) GetRecipientData(ctx context.Context, phone string) (*sendly.RecipientData, error) {
+	if !strings.HasPrefix(phone, "+") {
+		return nil, validationError("phone number must be in E.164 format")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	f.dispatchDue()
+
+	now := f.now()
+	data := &sendly.RecipientData{
+		Phone:       phone,
+		Messages:    []sendly.Message{},
+		GeneratedAt: now.Format(time.RFC3339),
+	}
+	for i := len(f.messages) - 1; i >= 0; i-- {
+		if f.messages[i].msg.To == phone {
+			data.Messages = append(data.Messages, f.snapshot(f.messages[i], now))
+		}
+	}
+	for i := len(f.scheduled) - 1; i >= 0; i-- {
+		sm := f.scheduled[i]
+		if sm.To == phone && sm.Status == sendly.ScheduledMessageStatusScheduled {
+			data.ScheduledMessages = append(data.ScheduledMessages, *sm)
+		}
+	}
+	return data, nil
+}
+
+// DeleteRecipientData removes the recorded messages to phone and cancels its
+// pending scheduled messages, refunding their credits. The returned job is
+// already completed.
+func (s *FakeCompliance) DeleteRecipientData(ctx context.Context, phone string) (*sendly.DataDeletionJob, error) {
+	if !strings.HasPrefix(phone, "+") {
+		return nil, validationError("phone number must be in E.164 format")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	f.dispatchDue()
+
+	deleted := make(map[string]bool)
+	kept := f.messages[:0]
+	for _, m := range f.messages {
+		if m.msg.To == phone {
+			deleted[m.msg.ID] = true
+			continue
+		}
+		kept = append(kept, m)
+	}
+	f.messages = kept
+
+	for _, b := range f.batches {
+		ids := b.messageIDs[:0]
+		for _, id := range b.messageIDs {
+			if !deleted[id] {
+				ids = append(ids, id)
+			}
+		}
+		b.messageIDs = ids
+	}
+
+	scheduled := f.scheduled[:0]
+	for _, sm := range f.scheduled {
+		if sm.To != phone {
+			scheduled = append(scheduled, sm)
+			continue
+		}
+		if sm.Status == sendly.ScheduledMessageStatusScheduled {
+			f.reserved -= sm.CreditsReserved
+			f.credits += sm.CreditsReserved
+		}
+	}
+	f.scheduled = scheduled
+
+	now := f.now().Format(time.RFC3339)
+	job := &sendly.DataDeletionJob{
+		ID:              f.nextID("del"),
+		Phone:           phone,
+		Status:          sendly.DataDeletionStatusCompleted,
+		MessagesDeleted: len(deleted),
+		CreatedAt:       now,
+		CompletedAt:     &now,
+	}
+	f.deletions = append(f.deletions, job)
+
+	resp := *job
+	return &resp, nil
+}
+
+// GetDeletionJob returns a recorded deletion job.
+func (s *FakeCompliance) GetDeletionJob(ctx context.Context, jobID string) (*sendly.DataDeletionJob, error) {
+	if jobID == "" {
+		return nil, validationError("deletion job ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	for _, job := range f.deletions {
+		if job.ID == jobID {
+			resp := *job
+			return &resp, nil
+		}
+	}
+	return nil, notFound("deletion job", jobID)
+}
+
+// WaitForDeletion returns the deletion job, which the fake always completes
+// immediately.
+func (s *FakeCompliance) WaitForDeletion(ctx context.Context, jobID string, pollInterval time.Duration) (*sendly.DataDeletionJob, error) {
+	return s.GetDeletionJob(ctx, jobID)
+}
@@ -0,0 +1,85 @@
+package sendlytest
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// FakeEmail is an in-memory implementation of sendly.EmailAPI. Emails are
+// delivered as soon as they are sent.
+type FakeEmail struct {
+	fake *FakeClient
+}
+
+var _ sendly.EmailAPI = (*FakeEmail)(nil)
+
+// SentEmails returns all emails sent so far.
+func (f *FakeClient) SentEmails() []sendly.Email {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]sendly.Email{}, f.emails...)
+}
+
+// Send records a delivered email.
+func (s *FakeEmail) Send(ctx context.Context, req sendly.EmailRequest) (*sendly.Email, error) {
+	if !strings.Contains(req.To, "@") {
+		return nil, validationError("a valid email address is required")
+	}
+	if req.Subject == "" {
+		return nil, validationError("subject is required")
+	}
+	if req.Text == "" && req.HTML == "" {
+		return nil, validationError("text or html body is required")
+	}
+	if req.ReplyTo != "" && !strings.Contains(req.ReplyTo, "@") {
+		return nil, validationError("replyTo must be a valid email address")
+	}
+	if err := sendly.ValidateMetadata(req.Metadata, sendly.DefaultMetadataLimits); err != nil {
+		return nil, err
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	email := sendly.Email{
+		ID:        f.nextID("eml"),
+		To:        req.To,
+		Subject:   req.Subject,
+		Status:    sendly.EmailStatusDelivered,
+		Metadata:  req.Metadata,
+		CreatedAt: f.now().Format(time.RFC3339),
+	}
+	f.emails = append(f.emails, email)
+	return &email, nil
+}
+
+// Get returns a sent email.
+func (s *FakeEmail) Get(ctx context.Context, id string) (*sendly.Email, error) {
+	if id == "" {
+		return nil, validationError("email ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	for _, e := range f.emails {
+		if e.ID == id {
+			result := e
+			return &result, nil
+		}
+	}
+	return nil, notFound("email", id)
+}
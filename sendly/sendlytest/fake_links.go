@@ -0,0 +1,131 @@
+package sendlytest
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// fakeShortLinkBase is the domain of the fake's short links.
+const fakeShortLinkBase = "https://sndly.test/"
+
+// linkPattern matches the URLs replaced by SendMessageRequest.ShortenLinks.
+var linkPattern = regexp.MustCompile(`https?://[^\s]+`)
+
+// FakeLinks is an in-memory implementation of sendly.LinksAPI. Links are not
+// served, so clicks are simulated with FakeClient.Click.
+type FakeLinks struct {
+	fake *FakeClient
+}
+
+var _ sendly.LinksAPI = (*FakeLinks)(nil)
+
+// Click records a click on a short link, as if a recipient had followed it.
+func (f *FakeClient) Click(linkID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, link := range f.links {
+		if link.ID == linkID {
+			now := f.now().Format(time.RFC3339)
+			link.ClickCount++
+			link.LastClickedAt = &now
+			return nil
+		}
+	}
+	return notFound("link", linkID)
+}
+
+// Create records a short link to targetURL.
+func (s *FakeLinks) Create(ctx context.Context, targetURL string) (*sendly.ShortLink, error) {
+	u, err := url.Parse(targetURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, validationError("target URL must be an absolute HTTP or HTTPS URL")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	link := *f.shortenLink(targetURL, "")
+	return &link, nil
+}
+
+// Get returns a recorded short link.
+func (s *FakeLinks) Get(ctx context.Context, id string) (*sendly.ShortLink, error) {
+	if id == "" {
+		return nil, validationError("link ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	for _, link := range f.links {
+		if link.ID == id {
+			result := *link
+			return &result, nil
+		}
+	}
+	return nil, notFound("link", id)
+}
+
+// List returns recorded short links, newest first.
+func (s *FakeLinks) List(ctx context.Context, req *sendly.ListLinksRequest) (*sendly.ListLinksResponse, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		req = &sendly.ListLinksRequest{}
+	}
+
+	var matched []sendly.ShortLink
+	for i := len(f.links) - 1; i >= 0; i-- {
+		if req.MessageID != "" && f.links[i].MessageID != req.MessageID {
+			continue
+		}
+		matched = append(matched, *f.links[i])
+	}
+
+	start, end := paginate(len(matched), req.Limit, req.Offset)
+	return sendly.NewPage(append([]sendly.ShortLink{}, matched[start:end]...), len(matched), start), nil
+}
+
+// shortenLink records a short link. Callers must hold f.mu.
+func (f *FakeClient) shortenLink(targetURL, messageID string) *sendly.ShortLink {
+	id := f.nextID("lnk")
+	link := &sendly.ShortLink{
+		ID:        id,
+		ShortURL:  fakeShortLinkBase + strings.TrimPrefix(id, "lnk_"),
+		TargetURL: targetURL,
+		MessageID: messageID,
+		CreatedAt: f.now().Format(time.RFC3339),
+	}
+	f.links = append(f.links, link)
+	return link
+}
+
+// shortenLinks replaces each URL in text with a new short link for the
+// message. Callers must hold f.mu.
+func (f *FakeClient) shortenLinks(text, messageID string) string {
+	return linkPattern.ReplaceAllStringFunc(text, func(target string) string {
+		return f.shortenLink(target, messageID).ShortURL
+	})
+}
@@ -0,0 +1,1060 @@
+package sendlytest
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// FakeMessages is an in-memory implementation of sendly.MessagesAPI.
+type FakeMessages struct {
+	fake *FakeClient
+}
+
+var _ sendly.MessagesAPI = (*FakeMessages)(nil)
+
+// Send records a message and charges its credits. Dry runs record nothing.
+// A request whose SendWindow is closed at the fake clock's time is scheduled
+// for when it opens, or rejected.
+func (s *FakeMessages) Send(ctx context.Context, req *sendly.SendMessageRequest) (*sendly.Message, error) {
+	if req == nil {
+		return nil, validationError("request is required")
+	}
+	if req.To == "" {
+		return nil, validationError("to is required")
+	}
+	if req.Text == "" {
+		return nil, validationError("text is required")
+	}
+	if len(req.ThreadKey) > sendly.MaxThreadKeyLength {
+		return nil, validationError("threadKey must be at most " + strconv.Itoa(sendly.MaxThreadKeyLength) + " bytes")
+	}
+	if err := sendly.ValidateMetadata(req.Metadata, sendly.DefaultMetadataLimits); err != nil {
+		return nil, err
+	}
+	if err := validateStatusCallbackURL(req.StatusCallbackURL); err != nil {
+		return nil, err
+	}
+	if req.ValidityPeriod < 0 || req.ValidityPeriod > sendly.MaxValidityPeriod {
+		return nil, validationError("validityPeriod must be between 0 and " + sendly.MaxValidityPeriod.String())
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	needed := sendly.CountSegments(req.Text)
+	if needed > f.credits {
+		return nil, insufficientCredits(needed, f.credits)
+	}
+
+	if req.DryRun {
+		return &sendly.Message{
+			ClientID:    req.ClientID,
+			ThreadKey:   req.ThreadKey,
+			Metadata:    req.Metadata,
+			To:          req.To,
+			Text:        req.Text,
+			Status:      sendly.MessageStatusQueued,
+			Direction:   "outbound",
+			Segments:    needed,
+			CreditsUsed: needed,
+			IsSandbox:   true,
+			DryRun:      true,
+		}, nil
+	}
+
+	if req.SendWindow != nil && req.MessageType != sendly.MessageTypeTransactional {
+		now := f.now()
+		next, err := req.SendWindow.Next(now)
+		if err != nil {
+			return nil, err
+		}
+		if !next.Equal(now) {
+			if req.SendWindow.Reject {
+				return nil, &sendly.OutsideSendWindowError{NextAllowed: next}
+			}
+			scheduled := f.schedule(&sendly.ScheduleMessageRequest{
+				To:                req.To,
+				Text:              req.Text,
+				ScheduledAt:       next.UTC().Format(time.RFC3339),
+				MessageType:       req.MessageType,
+				Metadata:          req.Metadata,
+				StatusCallbackURL: req.StatusCallbackURL,
+			}, needed)
+			return &sendly.Message{
+				ID:                scheduled.ID,
+				ClientID:          req.ClientID,
+				ThreadKey:         req.ThreadKey,
+				Metadata:          scheduled.Metadata,
+				StatusCallbackURL: scheduled.StatusCallbackURL,
+				To:                scheduled.To,
+				Text:              scheduled.Text,
+				Status:            sendly.MessageStatusScheduled,
+				Direction:         "outbound",
+				Segments:          needed,
+				CreditsUsed:       needed,
+				IsSandbox:         true,
+				CreatedAt:         scheduled.CreatedAt,
+				ScheduledAt:       scheduled.ScheduledAt,
+			}, nil
+		}
+	}
+
+	m := f.send(req.To, req.Text, req.From)
+	if req.ShortenLinks {
+		m.msg.Text = f.shortenLinks(m.msg.Text, m.msg.ID)
+	}
+	m.msg.ClientID = req.ClientID
+	m.msg.ThreadKey = req.ThreadKey
+	m.msg.Metadata = req.Metadata
+	m.msg.StatusCallbackURL = req.StatusCallbackURL
+	if req.ValidityPeriod > 0 {
+		m.validity = req.ValidityPeriod
+		expiresAt := m.created.Add(req.ValidityPeriod).Format(time.RFC3339)
+		m.msg.ExpiresAt = &expiresAt
+	}
+	msg := m.msg
+	return &msg, nil
+}
+
+// SendTransaction records all messages if there are enough credits for every
+// one of them, and none otherwise.
+func (s *FakeMessages) SendTransaction(ctx context.Context, reqs []sendly.SendMessageRequest) (*sendly.TransactionResponse, error) {
+	if len(reqs) == 0 {
+		return nil, validationError("messages are required")
+	}
+	for i, msg := range reqs {
+		if msg.To == "" {
+			return nil, validationError("to is required for message at index " + strconv.Itoa(i))
+		}
+		if msg.Text == "" {
+			return nil, validationError("text is required for message at index " + strconv.Itoa(i))
+		}
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	needed := 0
+	for _, msg := range reqs {
+		needed += sendly.CountSegments(msg.Text)
+	}
+	if needed > f.credits {
+		return nil, insufficientCredits(needed, f.credits)
+	}
+
+	resp := &sendly.TransactionResponse{
+		TransactionID: f.nextID("txn"),
+		Status:        sendly.TransactionStatusAccepted,
+	}
+	for _, msg := range reqs {
+		m := f.send(msg.To, msg.Text, "")
+		resp.Messages = append(resp.Messages, m.msg)
+		resp.CreditsUsed += m.msg.CreditsUsed
+	}
+	return resp, nil
+}
+
+// List returns recorded messages, newest first.
+func (s *FakeMessages) List(ctx context.Context, req *sendly.ListMessagesRequest) (*sendly.ListMessagesResponse, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		req = &sendly.ListMessagesRequest{}
+	}
+	if !req.CreatedAfter.IsZero() && !req.CreatedBefore.IsZero() && !req.CreatedAfter.Before(req.CreatedBefore) {
+		return nil, validationError("createdAfter must be before createdBefore")
+	}
+
+	now := f.now()
+	var matched []sendly.Message
+	for i := len(f.messages) - 1; i >= 0; i-- {
+		msg := f.snapshot(f.messages[i], now)
+		if req.Status != "" && msg.Status != req.Status {
+			continue
+		}
+		if req.To != "" && msg.To != req.To {
+			continue
+		}
+		if req.From != "" && msg.From != req.From {
+			continue
+		}
+		if req.ThreadKey != "" && msg.ThreadKey != req.ThreadKey {
+			continue
+		}
+		if req.BatchID != "" && msg.BatchID != req.BatchID {
+			continue
+		}
+		created := f.messages[i].created
+		if !req.CreatedAfter.IsZero() && created.Before(req.CreatedAfter) {
+			continue
+		}
+		if !req.CreatedBefore.IsZero() && !created.Before(req.CreatedBefore) {
+			continue
+		}
+		matched = append(matched, msg)
+	}
+
+	start, end := paginate(len(matched), req.Limit, req.Offset)
+	return sendly.NewPage(append([]sendly.Message{}, matched[start:end]...), len(matched), start), nil
+}
+
+// Search matches the query case-insensitively against message text,
+// recipients, senders and IDs. Messages are scored by the number of matches,
+// with newer messages first among equal scores.
+func (s *FakeMessages) Search(ctx context.Context, req sendly.SearchMessagesRequest) (*sendly.SearchMessagesResponse, error) {
+	query := strings.ToLower(strings.TrimSpace(req.Query))
+	if query == "" {
+		return nil, validationError("search query is required")
+	}
+	after, before := req.DateRange.After, req.DateRange.Before
+	if !after.IsZero() && !before.IsZero() && !after.Before(before) {
+		return nil, validationError("date range start must be before its end")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	now := f.now()
+	var matched []sendly.MessageSearchResult
+	for i := len(f.messages) - 1; i >= 0; i-- {
+		m := f.messages[i]
+		msg := f.snapshot(m, now)
+		if req.Status != "" && msg.Status != req.Status {
+			continue
+		}
+		if (!after.IsZero() && m.created.Before(after)) || (!before.IsZero() && !m.created.Before(before)) {
+			continue
+		}
+
+		score := 0
+		for _, field := range []string{msg.Text, msg.To, msg.From, msg.ID} {
+			score += strings.Count(strings.ToLower(field), query)
+		}
+		if score == 0 {
+			continue
+		}
+
+		result := sendly.MessageSearchResult{Message: msg, Score: float64(score)}
+		if strings.Contains(strings.ToLower(msg.Text), query) {
+			result.Highlights = []string{msg.Text}
+		}
+		matched = append(matched, result)
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].Score > matched[j].Score })
+
+	start, end := paginate(len(matched), req.Limit, req.Offset)
+	return sendly.NewPage(append([]sendly.MessageSearchResult{}, matched[start:end]...), len(matched), start), nil
+}
+
+// ListChan streams recorded messages using sendly.ListMessagesChan.
+func (s *FakeMessages) ListChan(ctx context.Context, req *sendly.ListMessagesRequest) (<-chan sendly.Message, <-chan error) {
+	return sendly.ListMessagesChan(ctx, s, req)
+}
+
+// Get returns a recorded message with its current simulated status.
+func (s *FakeMessages) Get(ctx context.Context, id string) (*sendly.Message, error) {
+	if id == "" {
+		return nil, validationError("message ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	m := f.findMessage(id)
+	if m == nil {
+		return nil, notFound("message", id)
+	}
+	msg := f.snapshot(m, f.now())
+	return &msg, nil
+}
+
+// GetDeliveryReport returns a delivery report that follows the fake's
+// delivery timeline, using SMPP status codes for final states.
+func (s *FakeMessages) GetDeliveryReport(ctx context.Context, messageID string) (*sendly.DeliveryReport, error) {
+	if messageID == "" {
+		return nil, validationError("message ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	m := f.findMessage(messageID)
+	if m == nil {
+		return nil, notFound("message", messageID)
+	}
+	msg := f.snapshot(m, f.now())
+
+	at := func(d time.Duration) string { return m.created.Add(d).Format(time.RFC3339) }
+	report := &sendly.DeliveryReport{
+		MessageID: msg.ID,
+		Status:    msg.Status,
+		Hops:      []sendly.DeliveryHop{{Stage: sendly.DeliveryStageAccepted, At: at(0)}},
+	}
+	elapsed := f.now().Sub(m.created)
+	if elapsed >= f.sentAfter && (m.validity == 0 || m.validity >= f.sentAfter) {
+		report.Hops = append(report.Hops,
+			sendly.DeliveryHop{Stage: sendly.DeliveryStageSubmitted, At: at(f.sentAfter)},
+			sendly.DeliveryHop{Stage: sendly.DeliveryStageCarrierAccepted, At: at(f.sentAfter)},
+		)
+	}
+
+	final := sendly.DeliveryHop{At: at(f.deliveredAfter)}
+	switch msg.Status {
+	case sendly.MessageStatusDelivered:
+		final.Stage, final.StatusCode = sendly.DeliveryStageDelivered, "DELIVRD"
+		final.Detail = "delivered to handset"
+	case sendly.MessageStatusFailed:
+		final.Stage, final.StatusCode = sendly.DeliveryStageFailed, "UNDELIV"
+		final.Detail = "undeliverable: " + m.outcome.err
+		report.ErrorCode = m.outcome.err
+	case sendly.MessageStatusExpired:
+		final.Stage, final.StatusCode = sendly.DeliveryStageExpired, "EXPIRED"
+		final.Detail = "validity period lapsed"
+		final.At = *msg.ExpiredAt
+	default:
+		return report, nil
+	}
+	report.Hops = append(report.Hops, final)
+	report.CarrierStatusCode = final.StatusCode
+	return report, nil
+}
+
+// Schedule records a scheduled message and reserves its credits.
+func (s *FakeMessages) Schedule(ctx context.Context, req *sendly.ScheduleMessageRequest) (*sendly.ScheduledMessage, error) {
+	if req == nil {
+		return nil, validationError("request is required")
+	}
+	if req.To == "" {
+		return nil, validationError("to is required")
+	}
+	if req.Text == "" {
+		return nil, validationError("text is required")
+	}
+	at, err := req.SendTime()
+	if err != nil {
+		return nil, err
+	}
+	if err := sendly.ValidateMetadata(req.Metadata, sendly.DefaultMetadataLimits); err != nil {
+		return nil, err
+	}
+	if err := validateStatusCallbackURL(req.StatusCallbackURL); err != nil {
+		return nil, err
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	if err := req.CheckSendTime(f.now()); err != nil {
+		return nil, err
+	}
+
+	needed := sendly.CountSegments(req.Text)
+	if needed > f.credits {
+		return nil, insufficientCredits(needed, f.credits)
+	}
+
+	resolved := *req
+	resolved.ScheduledAt = at.UTC().Format(time.RFC3339)
+	resolved.SendAt = time.Time{}
+	resolved.SendAtRecipientLocalTime = false
+	resp := *f.schedule(&resolved, needed)
+	return &resp, nil
+}
+
+// schedule records a scheduled message and reserves needed credits for it.
+// Callers must hold f.mu and have checked the balance.
+func (f *FakeClient) schedule(req *sendly.ScheduleMessageRequest, needed int) *sendly.ScheduledMessage {
+	f.credits -= needed
+	f.reserved += needed
+
+	scheduled := &sendly.ScheduledMessage{
+		ID:                f.nextID("sched"),
+		To:                req.To,
+		From:              req.From,
+		Text:              req.Text,
+		ScheduledAt:       req.ScheduledAt,
+		Status:            sendly.ScheduledMessageStatusScheduled,
+		CreditsReserved:   needed,
+		CreatedAt:         f.now().Format(time.RFC3339),
+		IsSandbox:         true,
+		Metadata:          req.Metadata,
+		StatusCallbackURL: req.StatusCallbackURL,
+	}
+	f.scheduled = append(f.scheduled, scheduled)
+	return scheduled
+}
+
+// ListScheduled returns scheduled messages, dispatching any that are due.
+func (s *FakeMessages) ListScheduled(ctx context.Context, req *sendly.ListScheduledMessagesRequest) (*sendly.ListScheduledMessagesResponse, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	f.dispatchDue()
+
+	if req == nil {
+		req = &sendly.ListScheduledMessagesRequest{}
+	}
+
+	var matched []sendly.ScheduledMessage
+	for i := len(f.scheduled) - 1; i >= 0; i-- {
+		if req.Status != "" && f.scheduled[i].Status != req.Status {
+			continue
+		}
+		matched = append(matched, *f.scheduled[i])
+	}
+
+	start, end := paginate(len(matched), req.Limit, req.Offset)
+	return sendly.NewPage(append([]sendly.ScheduledMessage{}, matched[start:end]...), len(matched), start), nil
+}
+
+// GetScheduled returns a scheduled message, dispatching it if it is due.
+func (s *FakeMessages) GetScheduled(ctx context.Context, id string) (*sendly.ScheduledMessage, error) {
+	if id == "" {
+		return nil, validationError("scheduled message ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	f.dispatchDue()
+
+	for _, sm := range f.scheduled {
+		if sm.ID == id {
+			resp := *sm
+			return &resp, nil
+		}
+	}
+	return nil, notFound("scheduled message", id)
+}
+
+// CancelScheduled cancels a pending scheduled message and refunds its credits.
+func (s *FakeMessages) CancelScheduled(ctx context.Context, id string) (*sendly.CancelScheduledMessageResponse, error) {
+	if id == "" {
+		return nil, validationError("scheduled message ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	f.dispatchDue()
+
+	for _, sm := range f.scheduled {
+		if sm.ID != id {
+			continue
+		}
+		if sm.Status != sendly.ScheduledMessageStatusScheduled {
+			return nil, validationError("scheduled message cannot be cancelled in status " + string(sm.Status))
+		}
+
+		now := f.now().Format(time.RFC3339)
+		sm.Status = sendly.ScheduledMessageStatusCancelled
+		sm.CancelledAt = &now
+		f.reserved -= sm.CreditsReserved
+		f.credits += sm.CreditsReserved
+
+		return &sendly.CancelScheduledMessageResponse{
+			ID:              sm.ID,
+			Status:          sm.Status,
+			CreditsRefunded: sm.CreditsReserved,
+			IsSandbox:       true,
+		}, nil
+	}
+	return nil, notFound("scheduled message", id)
+}
+
+// SendBatch records one message per batch item, failing the whole batch if
+// there are not enough credits for every item.
+func (s *FakeMessages) SendBatch(ctx context.Context, req *sendly.SendBatchRequest) (*sendly.BatchMessageResponse, error) {
+	if err := validateBatch(req); err != nil {
+		return nil, err
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	items, err := f.renderBatch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	needed := 0
+	for _, item := range items {
+		needed += sendly.CountSegments(item.Text)
+	}
+	if needed > f.credits {
+		return nil, insufficientCredits(needed, f.credits)
+	}
+
+	if req.DryRun {
+		resp := &sendly.BatchMessageResponse{
+			Status:      sendly.BatchStatusProcessing,
+			Total:       len(items),
+			Queued:      len(items),
+			CreditsUsed: needed,
+			IsSandbox:   true,
+			DryRun:      true,
+		}
+		for _, item := range items {
+			resp.Messages = append(resp.Messages, sendly.BatchMessageResult{
+				To:     item.To,
+				Status: string(sendly.MessageStatusQueued),
+			})
+		}
+		return resp, nil
+	}
+
+	resp := f.sendBatch(items, req.From, req.StatusCallbackURL)
+	return &resp, nil
+}
+
+// SendToSegment records a batch with one message to each of the segment's
+// contacts, as set by SetSegmentContacts.
+func (s *FakeMessages) SendToSegment(ctx context.Context, segmentID, text string) (*sendly.BatchMessageResponse, error) {
+	if segmentID == "" {
+		return nil, validationError("segment ID is required")
+	}
+	if text == "" {
+		return nil, validationError("text is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	seg, err := f.lookupSegment(segmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]sendly.BatchMessageItem, len(seg.contacts))
+	for i, to := range seg.contacts {
+		items[i] = sendly.BatchMessageItem{To: to, Text: text}
+	}
+	needed := len(items) * sendly.CountSegments(text)
+	if needed > f.credits {
+		return nil, insufficientCredits(needed, f.credits)
+	}
+
+	resp := f.sendBatch(items, "", "")
+	return &resp, nil
+}
+
+// sendBatch records one message per item as a new batch. The caller must have
+// checked credits. Callers must hold f.mu.
+func (f *FakeClient) sendBatch(items []sendly.BatchMessageItem, from, statusCallbackURL string) sendly.BatchMessageResponse {
+	batch := &fakeBatch{
+		resp: sendly.BatchMessageResponse{
+			BatchID:   f.nextID("batch"),
+			CreatedAt: f.now().Format(time.RFC3339),
+		},
+	}
+	for _, item := range items {
+		m := f.send(item.To, item.Text, from)
+		m.msg.Metadata = item.Metadata
+		m.msg.BatchID = batch.resp.BatchID
+		m.msg.StatusCallbackURL = statusCallbackURL
+		if item.StatusCallbackURL != "" {
+			m.msg.StatusCallbackURL = item.StatusCallbackURL
+		}
+		batch.messageIDs = append(batch.messageIDs, m.msg.ID)
+	}
+	f.batches = append(f.batches, batch)
+
+	return f.batchSnapshot(batch)
+}
+
+// GetBatch returns a batch with per-message results at their current status.
+func (s *FakeMessages) GetBatch(ctx context.Context, batchID string) (*sendly.BatchMessageResponse, error) {
+	if batchID == "" {
+		return nil, validationError("batch ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	for _, b := range f.batches {
+		if b.resp.BatchID == batchID {
+			resp := f.batchSnapshot(b)
+			return &resp, nil
+		}
+	}
+	return nil, notFound("batch", batchID)
+}
+
+// ListBatchResults returns a page of a batch's per-message results at their
+// current status.
+func (s *FakeMessages) ListBatchResults(ctx context.Context, batchID string, req *sendly.ListBatchResultsRequest) (*sendly.ListBatchResultsResponse, error) {
+	if batchID == "" {
+		return nil, validationError("batch ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	var limit, offset int
+	if req != nil {
+		limit, offset = req.Limit, req.Offset
+	}
+	for _, b := range f.batches {
+		if b.resp.BatchID == batchID {
+			results := f.batchSnapshot(b).Messages
+			start, end := paginate(len(results), limit, offset)
+			return sendly.NewPage(results[start:end], len(results), start), nil
+		}
+	}
+	return nil, notFound("batch", batchID)
+}
+
+// StreamBatchResults implements sendly.MessagesAPI using
+// sendly.NewBatchResultIterator.
+func (s *FakeMessages) StreamBatchResults(ctx context.Context, batchID string) (*sendly.BatchResultIterator, error) {
+	return sendly.NewBatchResultIterator(ctx, s, batchID)
+}
+
+// ListBatches returns recorded batches, newest first.
+func (s *FakeMessages) ListBatches(ctx context.Context, req *sendly.ListBatchesRequest) (*sendly.ListBatchesResponse, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		req = &sendly.ListBatchesRequest{}
+	}
+
+	var matched []sendly.BatchMessageResponse
+	for i := len(f.batches) - 1; i >= 0; i-- {
+		b := f.batchSnapshot(f.batches[i])
+		if req.Status != "" && b.Status != req.Status {
+			continue
+		}
+		if !createdBetween(b.CreatedAt, req.CreatedAfter, req.CreatedBefore) {
+			continue
+		}
+		b.Messages = nil
+		matched = append(matched, b)
+	}
+
+	start, end := paginate(len(matched), req.Limit, req.Offset)
+	return sendly.NewPage(append([]sendly.BatchMessageResponse{}, matched[start:end]...), len(matched), start), nil
+}
+
+// ListBatchesByDate returns every batch created in the range, oldest first.
+// The fake has no offset limit, so the range is not partitioned.
+func (s *FakeMessages) ListBatchesByDate(ctx context.Context, req *sendly.ListBatchesByDateRequest) ([]sendly.BatchMessageResponse, error) {
+	if req == nil || req.Start.IsZero() {
+		return nil, validationError("start is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	end := req.End
+	if end.IsZero() {
+		end = f.now()
+	}
+	after := req.Start.UTC().Format(time.RFC3339Nano)
+	before := end.UTC().Format(time.RFC3339Nano)
+
+	batches := []sendly.BatchMessageResponse{}
+	for _, batch := range f.batches {
+		b := f.batchSnapshot(batch)
+		if req.Status != "" && b.Status != req.Status {
+			continue
+		}
+		if !createdBetween(b.CreatedAt, after, before) {
+			continue
+		}
+		b.Messages = nil
+		batches = append(batches, b)
+	}
+	return batches, nil
+}
+
+// createdBetween reports whether createdAt falls in [after, before). Empty
+// bounds are open.
+func createdBetween(createdAt, after, before string) bool {
+	at, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return true
+	}
+	if t, err := time.Parse(time.RFC3339, after); err == nil && at.Before(t) {
+		return false
+	}
+	if t, err := time.Parse(time.RFC3339, before); err == nil && !at.Before(t) {
+		return false
+	}
+	return true
+}
+
+// PreviewBatch estimates segments and credits without recording anything.
+func (s *FakeMessages) PreviewBatch(ctx context.Context, req *sendly.SendBatchRequest) (*sendly.BatchPreviewResponse, error) {
+	if err := validateBatch(req); err != nil {
+		return nil, err
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	items, err := f.renderBatch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &sendly.BatchPreviewResponse{
+		TotalMessages:  len(items),
+		CurrentBalance: f.credits,
+		IsSandbox:      true,
+	}
+	for _, item := range items {
+		segments := sendly.CountSegments(item.Text)
+		resp.Messages = append(resp.Messages, sendly.BatchPreviewItem{
+			To:       item.To,
+			Text:     item.Text,
+			Segments: segments,
+			Credits:  segments,
+			CanSend:  true,
+		})
+		resp.CreditsNeeded += segments
+		resp.WillSend++
+	}
+	resp.HasEnoughCredits = resp.CreditsNeeded <= f.credits
+	resp.CanSend = resp.HasEnoughCredits
+	resp.ByCountry = resp.CostByCountry()
+	return resp, nil
+}
+
+// SendBatchFromCSV parses r with sendly.ParseRecipientsCSV and sends the rows
+// through SendBatch in chunks of opts.BatchSize.
+func (s *FakeMessages) SendBatchFromCSV(ctx context.Context, r io.Reader, opts sendly.CSVOptions) (*sendly.CSVSendResult, error) {
+	recipients, rowErrors, err := sendly.ParseRecipientsCSV(r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &sendly.CSVSendResult{Rows: len(recipients) + len(rowErrors), Errors: rowErrors}
+
+	size := opts.BatchSize
+	if size <= 0 || size > sendly.MaxBatchSize {
+		size = sendly.MaxBatchSize
+	}
+	for start := 0; start < len(recipients); start += size {
+		end := start + size
+		if end > len(recipients) {
+			end = len(recipients)
+		}
+
+		req := &sendly.SendBatchRequest{From: opts.From, MessageType: opts.MessageType}
+		for _, recipient := range recipients[start:end] {
+			req.Messages = append(req.Messages, recipient.Item)
+		}
+
+		resp, err := s.SendBatch(ctx, req)
+		if err != nil {
+			return result, err
+		}
+		result.Batches = append(result.Batches, resp)
+		result.Queued += resp.Queued
+		result.CreditsUsed += resp.CreditsUsed
+	}
+	return result, nil
+}
+
+// CreateBatchDraft records a draft batch with any initial items.
+func (s *FakeMessages) CreateBatchDraft(ctx context.Context, req *sendly.CreateBatchDraftRequest) (*sendly.BatchDraft, error) {
+	if req == nil {
+		req = &sendly.CreateBatchDraftRequest{}
+	}
+	if err := validateBatchItems(req.Messages, ""); err != nil {
+		return nil, err
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	now := f.now().Format(time.RFC3339)
+	d := &fakeDraft{
+		draft: sendly.BatchDraft{
+			ID:          f.nextID("draft"),
+			Status:      sendly.BatchDraftStatusDraft,
+			ItemCount:   len(req.Messages),
+			From:        req.From,
+			MessageType: req.MessageType,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		},
+		items: append([]sendly.BatchMessageItem{}, req.Messages...),
+	}
+	f.drafts = append(f.drafts, d)
+
+	draft := d.draft
+	return &draft, nil
+}
+
+// AddItemsToDraft appends items to a draft that has not been launched.
+func (s *FakeMessages) AddItemsToDraft(ctx context.Context, draftID string, items []sendly.BatchMessageItem) (*sendly.BatchDraft, error) {
+	if len(items) == 0 {
+		return nil, validationError("messages are required")
+	}
+	if err := validateBatchItems(items, ""); err != nil {
+		return nil, err
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	d, err := f.lookupDraft(draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	d.items = append(d.items, items...)
+	d.draft.ItemCount = len(d.items)
+	d.draft.UpdatedAt = f.now().Format(time.RFC3339)
+
+	draft := d.draft
+	return &draft, nil
+}
+
+// LaunchDraft sends a draft's items as a batch, failing if there are not
+// enough credits for every item.
+func (s *FakeMessages) LaunchDraft(ctx context.Context, draftID string) (*sendly.BatchMessageResponse, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	d, err := f.lookupDraft(draftID)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.items) == 0 {
+		return nil, validationError("draft has no messages")
+	}
+
+	needed := 0
+	for _, item := range d.items {
+		needed += sendly.CountSegments(item.Text)
+	}
+	if needed > f.credits {
+		return nil, insufficientCredits(needed, f.credits)
+	}
+
+	resp := f.sendBatch(d.items, d.draft.From, "")
+	batchID := resp.BatchID
+	d.draft.Status = sendly.BatchDraftStatusLaunched
+	d.draft.BatchID = &batchID
+	d.draft.UpdatedAt = f.now().Format(time.RFC3339)
+	return &resp, nil
+}
+
+// lookupDraft validates the ID, records the call, and finds a draft that can
+// still be changed. Callers must hold f.mu.
+func (f *FakeClient) lookupDraft(draftID string) (*fakeDraft, error) {
+	if draftID == "" {
+		return nil, validationError("draft ID is required")
+	}
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	for _, d := range f.drafts {
+		if d.draft.ID == draftID {
+			if d.draft.Status != sendly.BatchDraftStatusDraft {
+				return nil, validationError("draft has already been launched")
+			}
+			return d, nil
+		}
+	}
+	return nil, notFound("draft", draftID)
+}
+
+// batchSnapshot computes a batch's counts and results at the current time.
+// Callers must hold f.mu.
+func (f *FakeClient) batchSnapshot(b *fakeBatch) sendly.BatchMessageResponse {
+	resp := b.resp
+	resp.Total = len(b.messageIDs)
+	resp.Messages = nil
+	resp.IsSandbox = true
+
+	now := f.now()
+	final := 0
+	for _, id := range b.messageIDs {
+		msg := f.snapshot(f.findMessage(id), now)
+		resp.CreditsUsed += msg.CreditsUsed
+
+		switch msg.Status {
+		case sendly.MessageStatusQueued:
+			resp.Queued++
+		case sendly.MessageStatusSent:
+			resp.Sent++
+		case sendly.MessageStatusDelivered:
+			resp.Sent++
+			final++
+		case sendly.MessageStatusFailed:
+			resp.Failed++
+			final++
+		}
+
+		messageID := msg.ID
+		resp.Messages = append(resp.Messages, sendly.BatchMessageResult{
+			To:        msg.To,
+			MessageID: &messageID,
+			Status:    string(msg.Status),
+			Error:     msg.Error,
+		})
+	}
+
+	switch {
+	case final < resp.Total:
+		resp.Status = sendly.BatchStatusProcessing
+	case resp.Failed == resp.Total:
+		resp.Status = sendly.BatchStatusFailed
+	case resp.Failed > 0:
+		resp.Status = sendly.BatchStatusPartialFailure
+	default:
+		resp.Status = sendly.BatchStatusCompleted
+	}
+	if resp.Status != sendly.BatchStatusProcessing {
+		completedAt := b.resp.CreatedAt
+		resp.CompletedAt = &completedAt
+	}
+	return resp
+}
+
+// validateBatch applies the same checks as the real batch endpoints.
+func validateBatch(req *sendly.SendBatchRequest) error {
+	if req == nil {
+		return validationError("request is required")
+	}
+	if len(req.Messages) == 0 {
+		return validationError("messages are required")
+	}
+	if err := validateStatusCallbackURL(req.StatusCallbackURL); err != nil {
+		return err
+	}
+	return validateBatchItems(req.Messages, req.TemplateID)
+}
+
+// validateBatchItems checks that every batch item has a recipient, text or
+// template variables but not both, valid metadata and an HTTPS status
+// callback URL if it has one.
+func validateBatchItems(items []sendly.BatchMessageItem, templateID string) error {
+	for i, msg := range items {
+		if msg.To == "" {
+			return validationError("to is required for message at index " + strconv.Itoa(i))
+		}
+		switch {
+		case templateID == "" && msg.Text == "":
+			return validationError("text is required for message at index " + strconv.Itoa(i))
+		case templateID == "" && len(msg.Variables) > 0:
+			return validationError("variables require templateId for message at index " + strconv.Itoa(i))
+		case templateID != "" && msg.Text != "":
+			return validationError("text cannot be combined with templateId for message at index " + strconv.Itoa(i))
+		}
+		if err := sendly.ValidateMetadata(msg.Metadata, sendly.DefaultMetadataLimits); err != nil {
+			return err
+		}
+		if msg.StatusCallbackURL != "" && !strings.HasPrefix(msg.StatusCallbackURL, "https://") {
+			return validationError("statusCallbackUrl must be HTTPS for message at index " + strconv.Itoa(i))
+		}
+	}
+	return nil
+}
+
+// validateStatusCallbackURL checks that a status callback URL, if set, is HTTPS.
+func validateStatusCallbackURL(u string) error {
+	if u != "" && !strings.HasPrefix(u, "https://") {
+		return validationError("statusCallbackUrl must be HTTPS")
+	}
+	return nil
+}
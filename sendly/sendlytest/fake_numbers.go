@@ -0,0 +1,217 @@
+package sendlytest
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// FakeNumbers is an in-memory implementation of sendly.NumbersAPI. There is
+// no number inventory until SetAvailableNumbers stocks one.
+type FakeNumbers struct {
+	fake *FakeClient
+}
+
+var _ sendly.NumbersAPI = (*FakeNumbers)(nil)
+
+// SetAvailableNumbers sets the numbers Numbers.Search returns and
+// Numbers.Purchase can buy.
+func (f *FakeClient) SetAvailableNumbers(numbers ...sendly.AvailableNumber) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.available = append([]sendly.AvailableNumber{}, numbers...)
+}
+
+// Search returns stocked numbers matching the request.
+func (s *FakeNumbers) Search(ctx context.Context, req sendly.SearchNumbersRequest) ([]sendly.AvailableNumber, error) {
+	if len(req.Country) != 2 {
+		return nil, validationError("country must be a 2-letter ISO code")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	matched := []sendly.AvailableNumber{}
+	for _, n := range f.available {
+		if !strings.EqualFold(n.Country, req.Country) {
+			continue
+		}
+		if req.AreaCode != "" && !strings.HasPrefix(strings.TrimPrefix(n.Number, "+1"), req.AreaCode) {
+			continue
+		}
+		if req.Contains != "" && !strings.Contains(n.Number, req.Contains) {
+			continue
+		}
+		if req.MMS && !n.Capabilities.MMS {
+			continue
+		}
+		matched = append(matched, n)
+	}
+
+	_, end := paginate(len(matched), req.Limit, 0)
+	return matched[:end], nil
+}
+
+// Purchase buys a stocked number, charging its monthly rental.
+func (s *FakeNumbers) Purchase(ctx context.Context, number string) (*sendly.PhoneNumber, error) {
+	if !strings.HasPrefix(number, "+") {
+		return nil, validationError("phone number must be in E.164 format")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	for i, n := range f.available {
+		if n.Number != number {
+			continue
+		}
+		if n.MonthlyCredits > f.credits {
+			return nil, insufficientCredits(n.MonthlyCredits, f.credits)
+		}
+
+		f.credits -= n.MonthlyCredits
+		f.available = append(f.available[:i], f.available[i+1:]...)
+		owned := &sendly.PhoneNumber{
+			ID:             f.nextID("num"),
+			Number:         n.Number,
+			Country:        n.Country,
+			Capabilities:   n.Capabilities,
+			MonthlyCredits: n.MonthlyCredits,
+			CreatedAt:      f.now().Format(time.RFC3339),
+		}
+		f.numbers = append(f.numbers, owned)
+
+		result := *owned
+		return &result, nil
+	}
+	return nil, notFound("available number", number)
+}
+
+// List returns purchased numbers, oldest first.
+func (s *FakeNumbers) List(ctx context.Context, req *sendly.ListNumbersRequest) (*sendly.ListNumbersResponse, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		req = &sendly.ListNumbersRequest{}
+	}
+
+	var matched []sendly.PhoneNumber
+	for _, n := range f.numbers {
+		if req.Country != "" && !strings.EqualFold(n.Country, req.Country) {
+			continue
+		}
+		matched = append(matched, *n)
+	}
+
+	start, end := paginate(len(matched), req.Limit, req.Offset)
+	return sendly.NewPage(append([]sendly.PhoneNumber{}, matched[start:end]...), len(matched), start), nil
+}
+
+// Get returns a purchased number.
+func (s *FakeNumbers) Get(ctx context.Context, id string) (*sendly.PhoneNumber, error) {
+	if id == "" {
+		return nil, validationError("number ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	n, _, err := f.lookupNumber(id)
+	if err != nil {
+		return nil, err
+	}
+	result := *n
+	return &result, nil
+}
+
+// Update configures a purchased number. Capabilities it was not bought with
+// cannot be enabled.
+func (s *FakeNumbers) Update(ctx context.Context, id string, req sendly.UpdateNumberRequest) (*sendly.PhoneNumber, error) {
+	if id == "" {
+		return nil, validationError("number ID is required")
+	}
+	if req.WebhookURL != nil && *req.WebhookURL != "" && !strings.HasPrefix(*req.WebhookURL, "https://") {
+		return nil, validationError("webhookUrl must be HTTPS")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	n, _, err := f.lookupNumber(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.WebhookURL != nil {
+		n.WebhookURL = *req.WebhookURL
+	}
+	if req.Capabilities != nil {
+		n.Capabilities = sendly.NumberCapabilities{
+			SMS:   req.Capabilities.SMS && n.Capabilities.SMS,
+			MMS:   req.Capabilities.MMS && n.Capabilities.MMS,
+			Voice: req.Capabilities.Voice && n.Capabilities.Voice,
+		}
+	}
+
+	result := *n
+	return &result, nil
+}
+
+// Release removes a purchased number.
+func (s *FakeNumbers) Release(ctx context.Context, id string) error {
+	if id == "" {
+		return validationError("number ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return err
+	}
+
+	_, i, err := f.lookupNumber(id)
+	if err != nil {
+		return err
+	}
+	f.numbers = append(f.numbers[:i], f.numbers[i+1:]...)
+	return nil
+}
+
+// lookupNumber finds a purchased number and its index. Callers must hold f.mu.
+func (f *FakeClient) lookupNumber(id string) (*sendly.PhoneNumber, int, error) {
+	for i, n := range f.numbers {
+		if n.ID == id {
+			return n, i, nil
+		}
+	}
+	return nil, 0, notFound("number", id)
+}
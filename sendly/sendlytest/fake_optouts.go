@@ -0,0 +1,115 @@
+package sendlytest
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// FakeOptOuts is an in-memory implementation of sendly.OptOutsAPI.
+type FakeOptOuts struct {
+	fake *FakeClient
+}
+
+var _ sendly.OptOutsAPI = (*FakeOptOuts)(nil)
+
+// Add records an opt-out. Opting out an already opted-out number returns the
+// existing opt-out.
+func (s *FakeOptOuts) Add(ctx context.Context, phone string, source sendly.OptOutSource) (*sendly.OptOut, error) {
+	if !strings.HasPrefix(phone, "+") {
+		return nil, validationError("phone number must be in E.164 format")
+	}
+	if source == "" {
+		source = sendly.OptOutSourceAPI
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	for _, o := range f.optOuts {
+		if o.Phone == phone {
+			result := o
+			return &result, nil
+		}
+	}
+
+	o := sendly.OptOut{Phone: phone, Source: source, CreatedAt: f.now().Format(time.RFC3339)}
+	f.optOuts = append(f.optOuts, o)
+	return &o, nil
+}
+
+// Remove deletes a recorded opt-out.
+func (s *FakeOptOuts) Remove(ctx context.Context, phone string) error {
+	if !strings.HasPrefix(phone, "+") {
+		return validationError("phone number must be in E.164 format")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return err
+	}
+
+	for i, o := range f.optOuts {
+		if o.Phone == phone {
+			f.optOuts = append(f.optOuts[:i], f.optOuts[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("opt-out", phone)
+}
+
+// Get returns a recorded opt-out.
+func (s *FakeOptOuts) Get(ctx context.Context, phone string) (*sendly.OptOut, error) {
+	if !strings.HasPrefix(phone, "+") {
+		return nil, validationError("phone number must be in E.164 format")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	for _, o := range f.optOuts {
+		if o.Phone == phone {
+			result := o
+			return &result, nil
+		}
+	}
+	return nil, notFound("opt-out", phone)
+}
+
+// List returns recorded opt-outs, newest first.
+func (s *FakeOptOuts) List(ctx context.Context, req *sendly.ListOptOutsRequest) (*sendly.ListOptOutsResponse, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		req = &sendly.ListOptOutsRequest{}
+	}
+
+	matched := make([]sendly.OptOut, 0, len(f.optOuts))
+	for i := len(f.optOuts) - 1; i >= 0; i-- {
+		matched = append(matched, f.optOuts[i])
+	}
+
+	start, end := paginate(len(matched), req.Limit, req.Offset)
+	return sendly.NewPage(matched[start:end], len(matched), start), nil
+}
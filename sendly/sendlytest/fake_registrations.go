@@ -0,0 +1,325 @@
+package sendlytest
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// FakeRegistrations is an in-memory implementation of sendly.RegistrationsAPI.
+// Submissions stay pending until FakeClient.ReviewRegistration approves or
+// rejects them.
+type FakeRegistrations struct {
+	fake *FakeClient
+}
+
+var _ sendly.RegistrationsAPI = (*FakeRegistrations)(nil)
+
+// ReviewRegistration sets the outcome of a brand, campaign or toll-free
+// verification, as if carriers had reviewed it.
+func (f *FakeClient) ReviewRegistration(id string, status sendly.RegistrationStatus, rejections ...sendly.Rejection) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	review, err := f.lookupReview(id)
+	if err != nil {
+		return err
+	}
+	review.Status = status
+	review.Rejections = append([]sendly.Rejection(nil), rejections...)
+	if review.Done() {
+		now := f.now().Format(time.RFC3339)
+		review.ReviewedAt = &now
+	}
+	return nil
+}
+
+// RegisterBrand records a pending brand.
+func (s *FakeRegistrations) RegisterBrand(ctx context.Context, req sendly.RegisterBrandRequest) (*sendly.Brand, error) {
+	if req.LegalName == "" {
+		return nil, validationError("legal name is required")
+	}
+	if len(req.Country) != 2 {
+		return nil, validationError("country must be a 2-letter ISO code")
+	}
+	if strings.EqualFold(req.Country, "US") && req.EIN == "" {
+		return nil, validationError("EIN is required for US brands")
+	}
+	if req.ContactEmail == "" {
+		return nil, validationError("contact email is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	brand := &sendly.Brand{
+		RegistrationReview: f.pendingReview(),
+		ID:                 f.nextID("brd"),
+		LegalName:          req.LegalName,
+		Country:            strings.ToUpper(req.Country),
+		EIN:                req.EIN,
+		Website:            req.Website,
+		Vertical:           req.Vertical,
+	}
+	f.brands = append(f.brands, brand)
+
+	result := *brand
+	return &result, nil
+}
+
+// GetBrand returns a recorded brand.
+func (s *FakeRegistrations) GetBrand(ctx context.Context, id string) (*sendly.Brand, error) {
+	if id == "" {
+		return nil, validationError("brand ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	for _, b := range f.brands {
+		if b.ID == id {
+			result := *b
+			return &result, nil
+		}
+	}
+	return nil, notFound("brand", id)
+}
+
+// WaitForBrand polls the fake until the brand is reviewed.
+func (s *FakeRegistrations) WaitForBrand(ctx context.Context, id string, pollInterval time.Duration) (*sendly.Brand, error) {
+	for {
+		brand, err := s.GetBrand(ctx, id)
+		if err != nil || brand.Done() {
+			return brand, err
+		}
+		if err := sleep(ctx, pollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// RegisterCampaign records a pending campaign. The brand must be approved.
+func (s *FakeRegistrations) RegisterCampaign(ctx context.Context, req sendly.RegisterCampaignRequest) (*sendly.Campaign, error) {
+	if req.BrandID == "" {
+		return nil, validationError("brand ID is required")
+	}
+	if req.Description == "" {
+		return nil, validationError("description is required")
+	}
+	if err := validateUseCaseSubmission(req.UseCase, req.SampleMessages, req.OptInDescription); err != nil {
+		return nil, err
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	var brand *sendly.Brand
+	for _, b := range f.brands {
+		if b.ID == req.BrandID {
+			brand = b
+		}
+	}
+	if brand == nil {
+		return nil, notFound("brand", req.BrandID)
+	}
+	if !brand.Approved() {
+		return nil, validationError("brand must be approved before registering campaigns")
+	}
+
+	campaign := &sendly.Campaign{
+		RegistrationReview: f.pendingReview(),
+		ID:                 f.nextID("cmp"),
+		BrandID:            req.BrandID,
+		UseCase:            req.UseCase,
+		Description:        req.Description,
+		SampleMessages:     append([]string{}, req.SampleMessages...),
+		OptInDescription:   req.OptInDescription,
+		NumberIDs:          append([]string(nil), req.NumberIDs...),
+	}
+	f.campaigns = append(f.campaigns, campaign)
+
+	result := *campaign
+	return &result, nil
+}
+
+// GetCampaign returns a recorded campaign.
+func (s *FakeRegistrations) GetCampaign(ctx context.Context, id string) (*sendly.Campaign, error) {
+	if id == "" {
+		return nil, validationError("campaign ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	for _, c := range f.campaigns {
+		if c.ID == id {
+			result := *c
+			return &result, nil
+		}
+	}
+	return nil, notFound("campaign", id)
+}
+
+// WaitForCampaign polls the fake until the campaign is reviewed.
+func (s *FakeRegistrations) WaitForCampaign(ctx context.Context, id string, pollInterval time.Duration) (*sendly.Campaign, error) {
+	for {
+		campaign, err := s.GetCampaign(ctx, id)
+		if err != nil || campaign.Done() {
+			return campaign, err
+		}
+		if err := sleep(ctx, pollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// SubmitTollFreeVerification records a pending toll-free verification.
+func (s *FakeRegistrations) SubmitTollFreeVerification(ctx context.Context, req sendly.SubmitTollFreeVerificationRequest) (*sendly.TollFreeVerification, error) {
+	if !strings.HasPrefix(req.Number, "+") {
+		return nil, validationError("phone number must be in E.164 format")
+	}
+	if req.BusinessName == "" {
+		return nil, validationError("business name is required")
+	}
+	if err := validateUseCaseSubmission(req.UseCase, req.SampleMessages, req.OptInDescription); err != nil {
+		return nil, err
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	verification := &sendly.TollFreeVerification{
+		RegistrationReview: f.pendingReview(),
+		ID:                 f.nextID("tfv"),
+		Number:             req.Number,
+		BusinessName:       req.BusinessName,
+		UseCase:            req.UseCase,
+		MonthlyVolume:      req.MonthlyVolume,
+	}
+	f.tollFree = append(f.tollFree, verification)
+
+	result := *verification
+	return &result, nil
+}
+
+// GetTollFreeVerification returns a recorded toll-free verification.
+func (s *FakeRegistrations) GetTollFreeVerification(ctx context.Context, id string) (*sendly.TollFreeVerification, error) {
+	if id == "" {
+		return nil, validationError("verification ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	for _, v := range f.tollFree {
+		if v.ID == id {
+			result := *v
+			return &result, nil
+		}
+	}
+	return nil, notFound("toll-free verification", id)
+}
+
+// WaitForTollFreeVerification polls the fake until the verification is
+// reviewed.
+func (s *FakeRegistrations) WaitForTollFreeVerification(ctx context.Context, id string, pollInterval time.Duration) (*sendly.TollFreeVerification, error) {
+	for {
+		verification, err := s.GetTollFreeVerification(ctx, id)
+		if err != nil || verification.Done() {
+			return verification, err
+		}
+		if err := sleep(ctx, pollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// pendingReview returns the review state of a new submission. Callers must
+// hold f.mu.
+func (f *FakeClient) pendingReview() sendly.RegistrationReview {
+	return sendly.RegistrationReview{
+		Status:      sendly.RegistrationStatusPending,
+		SubmittedAt: f.now().Format(time.RFC3339),
+	}
+}
+
+// lookupReview finds the review state of a brand, campaign or toll-free
+// verification. Callers must hold f.mu.
+func (f *FakeClient) lookupReview(id string) (*sendly.RegistrationReview, error) {
+	for _, b := range f.brands {
+		if b.ID == id {
+			return &b.RegistrationReview, nil
+		}
+	}
+	for _, c := range f.campaigns {
+		if c.ID == id {
+			return &c.RegistrationReview, nil
+		}
+	}
+	for _, v := range f.tollFree {
+		if v.ID == id {
+			return &v.RegistrationReview, nil
+		}
+	}
+	return nil, notFound("registration", id)
+}
+
+// validateUseCaseSubmission checks the fields campaigns and toll-free
+// verifications share.
+func validateUseCaseSubmission(useCase sendly.CampaignUseCase, samples []string, optIn string) error {
+	if useCase == "" {
+		return validationError("use case is required")
+	}
+	if len(samples) == 0 || len(samples) > 5 {
+		return validationError("between 1 and 5 sample messages are required")
+	}
+	if optIn == "" {
+		return validationError("opt-in description is required")
+	}
+	return nil
+}
+
+// sleep waits for d (default: 10ms), or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		d = 10 * time.Millisecond
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -0,0 +1,175 @@
+package sendlytest
+
+import (
+	"context"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// FakeSegments is an in-memory implementation of sendly.SegmentsAPI. The
+// fake has no contact list to apply filters to, so a segment's contacts are
+// whatever SetSegmentContacts last set.
+type FakeSegments struct {
+	fake *FakeClient
+}
+
+var _ sendly.SegmentsAPI = (*FakeSegments)(nil)
+
+// fakeSegment is a segment along with the contacts it resolves to.
+type fakeSegment struct {
+	segment  sendly.AudienceSegment
+	contacts []string
+}
+
+// SetSegmentContacts sets the phone numbers a segment resolves to, for
+// ContactCount and Messages.SendToSegment.
+func (f *FakeClient) SetSegmentContacts(segmentID string, phones ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	seg, err := f.lookupSegment(segmentID)
+	if err != nil {
+		return err
+	}
+	seg.contacts = append([]string{}, phones...)
+	seg.segment.ContactCount = len(phones)
+	return nil
+}
+
+// Create records a segment with no contacts.
+func (s *FakeSegments) Create(ctx context.Context, req sendly.CreateSegmentRequest) (*sendly.AudienceSegment, error) {
+	if req.Name == "" {
+		return nil, validationError("segment name is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	seg := &fakeSegment{segment: sendly.AudienceSegment{
+		ID:        f.nextID("seg"),
+		Name:      req.Name,
+		Filter:    req.Filter,
+		CreatedAt: f.now().Format(time.RFC3339),
+	}}
+	f.segments = append(f.segments, seg)
+
+	result := seg.segment
+	return &result, nil
+}
+
+// List returns recorded segments, oldest first.
+func (s *FakeSegments) List(ctx context.Context, req *sendly.ListSegmentsRequest) (*sendly.ListSegmentsResponse, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		req = &sendly.ListSegmentsRequest{}
+	}
+
+	segments := make([]sendly.AudienceSegment, len(f.segments))
+	for i, seg := range f.segments {
+		segments[i] = seg.segment
+	}
+
+	start, end := paginate(len(segments), req.Limit, req.Offset)
+	return sendly.NewPage(segments[start:end], len(segments), start), nil
+}
+
+// Get returns a recorded segment.
+func (s *FakeSegments) Get(ctx context.Context, id string) (*sendly.AudienceSegment, error) {
+	if id == "" {
+		return nil, validationError("segment ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	seg, err := f.lookupSegment(id)
+	if err != nil {
+		return nil, err
+	}
+	result := seg.segment
+	return &result, nil
+}
+
+// Update changes a recorded segment's name or filter.
+func (s *FakeSegments) Update(ctx context.Context, id string, req sendly.UpdateSegmentRequest) (*sendly.AudienceSegment, error) {
+	if id == "" {
+		return nil, validationError("segment ID is required")
+	}
+	if req.Name != nil && *req.Name == "" {
+		return nil, validationError("segment name cannot be empty")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	seg, err := f.lookupSegment(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		seg.segment.Name = *req.Name
+	}
+	if req.Filter != nil {
+		seg.segment.Filter = *req.Filter
+	}
+	seg.segment.UpdatedAt = f.now().Format(time.RFC3339)
+
+	result := seg.segment
+	return &result, nil
+}
+
+// Delete removes a recorded segment.
+func (s *FakeSegments) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return validationError("segment ID is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return err
+	}
+
+	for i, seg := range f.segments {
+		if seg.segment.ID == id {
+			f.segments = append(f.segments[:i], f.segments[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("segment", id)
+}
+
+// lookupSegment returns the segment with the given ID. Callers must hold f.mu.
+func (f *FakeClient) lookupSegment(id string) (*fakeSegment, error) {
+	for _, seg := range f.segments {
+		if seg.segment.ID == id {
+			return seg, nil
+		}
+	}
+	return nil, notFound("segment", id)
+}
@@ -0,0 +1,196 @@
+package sendlytest
+
+import (
+	"context"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// FakeSubAccounts is an in-memory implementation of sendly.SubAccountsAPI.
+// Credit transfers move credits between the fake's balance and the
+// sub-account's. Messages are not attributed to sub-accounts, so usage is
+// always zero.
+type FakeSubAccounts struct {
+	fake *FakeClient
+}
+
+var _ sendly.SubAccountsAPI = (*FakeSubAccounts)(nil)
+
+// Create records a sub-account, transferring InitialCredits to it.
+func (s *FakeSubAccounts) Create(ctx context.Context, req sendly.CreateSubAccountRequest) (*sendly.SubAccount, error) {
+	if req.Name == "" {
+		return nil, validationError("sub-account name is required")
+	}
+	if req.InitialCredits < 0 {
+		return nil, validationError("initial credits cannot be negative")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	if req.InitialCredits > f.credits {
+		return nil, insufficientCredits(req.InitialCredits, f.credits)
+	}
+
+	f.credits -= req.InitialCredits
+	sub := &sendly.SubAccount{
+		ID:            f.nextID("sub"),
+		Name:          req.Name,
+		Email:         req.Email,
+		Status:        sendly.SubAccountStatusActive,
+		CreditBalance: req.InitialCredits,
+		CreatedAt:     f.now().Format(time.RFC3339),
+	}
+	f.subAccounts = append(f.subAccounts, sub)
+
+	result := *sub
+	return &result, nil
+}
+
+// List returns recorded sub-accounts, oldest first.
+func (s *FakeSubAccounts) List(ctx context.Context, req *sendly.ListSubAccountsRequest) (*sendly.ListSubAccountsResponse, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		req = &sendly.ListSubAccountsRequest{}
+	}
+
+	var matched []sendly.SubAccount
+	for _, sub := range f.subAccounts {
+		if req.Status != "" && sub.Status != req.Status {
+			continue
+		}
+		matched = append(matched, *sub)
+	}
+
+	start, end := paginate(len(matched), req.Limit, req.Offset)
+	return sendly.NewPage(append([]sendly.SubAccount{}, matched[start:end]...), len(matched), start), nil
+}
+
+// Get returns a recorded sub-account.
+func (s *FakeSubAccounts) Get(ctx context.Context, id string) (*sendly.SubAccount, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sub, err := f.lookupSubAccount(id)
+	if err != nil {
+		return nil, err
+	}
+	result := *sub
+	return &result, nil
+}
+
+// Suspend marks a sub-account as suspended.
+func (s *FakeSubAccounts) Suspend(ctx context.Context, id string) (*sendly.SubAccount, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sub, err := f.lookupSubAccount(id)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Status != sendly.SubAccountStatusSuspended {
+		now := f.now().Format(time.RFC3339)
+		sub.Status = sendly.SubAccountStatusSuspended
+		sub.SuspendedAt = &now
+	}
+	result := *sub
+	return &result, nil
+}
+
+// Reactivate marks a suspended sub-account as active.
+func (s *FakeSubAccounts) Reactivate(ctx context.Context, id string) (*sendly.SubAccount, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sub, err := f.lookupSubAccount(id)
+	if err != nil {
+		return nil, err
+	}
+	sub.Status = sendly.SubAccountStatusActive
+	sub.SuspendedAt = nil
+	result := *sub
+	return &result, nil
+}
+
+// GetUsage returns zero usage for the current month.
+func (s *FakeSubAccounts) GetUsage(ctx context.Context, id string) (*sendly.SubAccountUsage, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.lookupSubAccount(id); err != nil {
+		return nil, err
+	}
+	now := f.now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return &sendly.SubAccountUsage{
+		SubAccountID: id,
+		PeriodStart:  start.Format(time.RFC3339),
+		PeriodEnd:    start.AddDate(0, 1, 0).Format(time.RFC3339),
+	}, nil
+}
+
+// TransferCredits moves credits between the fake's balance and a sub-account.
+func (s *FakeSubAccounts) TransferCredits(ctx context.Context, id string, amount int) (*sendly.CreditTransfer, error) {
+	if amount == 0 {
+		return nil, validationError("amount must not be zero")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sub, err := f.lookupSubAccount(id)
+	if err != nil {
+		return nil, err
+	}
+	if amount > f.credits {
+		return nil, insufficientCredits(amount, f.credits)
+	}
+	if -amount > sub.CreditBalance {
+		return nil, insufficientCredits(-amount, sub.CreditBalance)
+	}
+
+	f.credits -= amount
+	sub.CreditBalance += amount
+	return &sendly.CreditTransfer{
+		ID:                     f.nextID("xfer"),
+		SubAccountID:           id,
+		Amount:                 amount,
+		ParentBalanceAfter:     f.credits,
+		SubAccountBalanceAfter: sub.CreditBalance,
+		CreatedAt:              f.now().Format(time.RFC3339),
+	}, nil
+}
+
+// lookupSubAccount validates the ID, records the call, and finds the
+// sub-account. Callers must hold f.mu.
+func (f *FakeClient) lookupSubAccount(id string) (*sendly.SubAccount, error) {
+	if id == "" {
+		return nil, validationError("sub-account ID is required")
+	}
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	for _, sub := range f.subAccounts {
+		if sub.ID == id {
+			return sub, nil
+		}
+	}
+	return nil, notFound("sub-account", id)
+}
@@ -0,0 +1,53 @@
+package sendlytest
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// templatePlaceholder matches the {{name}} placeholders of a message template.
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// SetTemplate stores a message template for SendBatchRequest.TemplateID.
+// Placeholders such as {{name}} are filled from each item's Variables.
+func (f *FakeClient) SetTemplate(id, body string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.templates == nil {
+		f.templates = make(map[string]string)
+	}
+	f.templates[id] = body
+}
+
+// renderBatch returns the batch's items with their text rendered from
+// req.TemplateID, or the items unchanged if the batch has no template.
+// Callers must hold f.mu.
+func (f *FakeClient) renderBatch(req *sendly.SendBatchRequest) ([]sendly.BatchMessageItem, error) {
+	if req.TemplateID == "" {
+		return req.Messages, nil
+	}
+	body, ok := f.templates[req.TemplateID]
+	if !ok {
+		return nil, notFound("template", req.TemplateID)
+	}
+
+	items := make([]sendly.BatchMessageItem, len(req.Messages))
+	for i, item := range req.Messages {
+		var missing string
+		item.Text = templatePlaceholder.ReplaceAllStringFunc(body, func(match string) string {
+			name := templatePlaceholder.FindStringSubmatch(match)[1]
+			v, ok := item.Variables[name]
+			if !ok && missing == "" {
+				missing = name
+			}
+			return v
+		})
+		if missing != "" {
+			return nil, validationError("no value for template variable " + strconv.Quote(missing) + " for message at index " + strconv.Itoa(i))
+		}
+		items[i] = item
+	}
+	return items, nil
+}
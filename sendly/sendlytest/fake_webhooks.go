@@ -0,0 +1,264 @@
+package sendlytest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// FakeWebhooks is an in-memory implementation of sendly.WebhooksAPI.
+type FakeWebhooks struct {
+	fake *FakeClient
+}
+
+var _ sendly.WebhooksAPI = (*FakeWebhooks)(nil)
+
+// Create registers a webhook and returns its generated secret.
+func (s *FakeWebhooks) Create(ctx context.Context, req sendly.CreateWebhookRequest) (*sendly.WebhookCreatedResponse, error) {
+	if req.URL == "" || !strings.HasPrefix(req.URL, "https://") {
+		return nil, errors.New("webhook URL must be HTTPS")
+	}
+	if len(req.Events) == 0 {
+		return nil, errors.New("at least one event type is required")
+	}
+	if err := sendly.ValidateMetadata(req.Metadata, sendly.DefaultMetadataLimits); err != nil {
+		return nil, err
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = sendly.WebhookModeAll
+	}
+	now := f.now().Format(time.RFC3339)
+
+	wh := &fakeWebhook{
+		webhook: sendly.Webhook{
+			ID:           f.nextID("whk"),
+			URL:          req.URL,
+			Events:       append([]string{}, req.Events...),
+			Mode:         mode,
+			IsActive:     true,
+			CircuitState: sendly.CircuitStateClosed,
+			APIVersion:   "2024-01-01",
+			Metadata:     req.Metadata,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		},
+		secret: f.nextID("whsec"),
+	}
+	if req.Description != "" {
+		description := req.Description
+		wh.webhook.Description = &description
+	}
+	f.webhooks = append(f.webhooks, wh)
+
+	return &sendly.WebhookCreatedResponse{Webhook: wh.webhook, Secret: wh.secret}, nil
+}
+
+// List returns all registered webhooks.
+func (s *FakeWebhooks) List(ctx context.Context) ([]sendly.Webhook, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]sendly.Webhook, len(f.webhooks))
+	for i, wh := range f.webhooks {
+		webhooks[i] = wh.webhook
+	}
+	return webhooks, nil
+}
+
+// Get returns a registered webhook.
+func (s *FakeWebhooks) Get(ctx context.Context, webhookID string) (*sendly.Webhook, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	wh, err := f.lookupWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	webhook := wh.webhook
+	return &webhook, nil
+}
+
+// Update applies the non-nil fields of req to a registered webhook.
+func (s *FakeWebhooks) Update(ctx context.Context, webhookID string, req sendly.UpdateWebhookRequest) (*sendly.Webhook, error) {
+	if req.URL != nil && !strings.HasPrefix(*req.URL, "https://") {
+		return nil, errors.New("webhook URL must be HTTPS")
+	}
+	if err := sendly.ValidateMetadata(req.Metadata, sendly.DefaultMetadataLimits); err != nil {
+		return nil, err
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	wh, err := f.lookupWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		wh.webhook.URL = *req.URL
+	}
+	if req.Events != nil {
+		wh.webhook.Events = append([]string{}, req.Events...)
+	}
+	if req.Description != nil {
+		description := *req.Description
+		wh.webhook.Description = &description
+	}
+	if req.IsActive != nil {
+		wh.webhook.IsActive = *req.IsActive
+	}
+	if req.Mode != nil {
+		wh.webhook.Mode = *req.Mode
+	}
+	if req.Metadata != nil {
+		wh.webhook.Metadata = req.Metadata
+	}
+	wh.webhook.UpdatedAt = f.now().Format(time.RFC3339)
+
+	webhook := wh.webhook
+	return &webhook, nil
+}
+
+// Delete removes a registered webhook.
+func (s *FakeWebhooks) Delete(ctx context.Context, webhookID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.lookupWebhook(webhookID); err != nil {
+		return err
+	}
+	for i, wh := range f.webhooks {
+		if wh.webhook.ID == webhookID {
+			f.webhooks = append(f.webhooks[:i], f.webhooks[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Test reports a successful test delivery.
+func (s *FakeWebhooks) Test(ctx context.Context, webhookID string) (*sendly.WebhookTestResult, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.lookupWebhook(webhookID); err != nil {
+		return nil, err
+	}
+	statusCode := 200
+	return &sendly.WebhookTestResult{Success: true, StatusCode: &statusCode}, nil
+}
+
+// RotateSecret replaces a webhook's secret.
+func (s *FakeWebhooks) RotateSecret(ctx context.Context, webhookID string) (*sendly.WebhookSecretRotation, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	wh, err := f.lookupWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	wh.secret = f.nextID("whsec")
+
+	return &sendly.WebhookSecretRotation{
+		Webhook:            wh.webhook,
+		NewSecret:          wh.secret,
+		OldSecretExpiresAt: f.now().Add(24 * time.Hour).Format(time.RFC3339),
+		Message:            "The old secret remains valid for 24 hours.",
+	}, nil
+}
+
+// GetDeliveries returns no deliveries; the fake does not deliver webhooks.
+func (s *FakeWebhooks) GetDeliveries(ctx context.Context, webhookID string) ([]sendly.WebhookDelivery, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.lookupWebhook(webhookID); err != nil {
+		return nil, err
+	}
+	return []sendly.WebhookDelivery{}, nil
+}
+
+// RetryDelivery always reports the delivery as not found.
+func (s *FakeWebhooks) RetryDelivery(ctx context.Context, webhookID, deliveryID string) error {
+	if deliveryID == "" || !strings.HasPrefix(deliveryID, "del_") {
+		return errors.New("invalid delivery ID format")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.lookupWebhook(webhookID); err != nil {
+		return err
+	}
+	return notFound("delivery", deliveryID)
+}
+
+// ListEventTypes returns the supported event types.
+func (s *FakeWebhooks) ListEventTypes(ctx context.Context) ([]string, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+
+	return []string{
+		string(sendly.WebhookEventMessageQueued),
+		string(sendly.WebhookEventMessageSent),
+		string(sendly.WebhookEventMessageDelivered),
+		string(sendly.WebhookEventMessageFailed),
+		string(sendly.WebhookEventMessageUndelivered),
+		string(sendly.WebhookEventMessageExpired),
+		string(sendly.WebhookEventScheduledPending),
+		string(sendly.WebhookEventDeliveryFailed),
+		string(sendly.WebhookEventLinkClicked),
+		string(sendly.WebhookEventMessageReceived),
+		string(sendly.WebhookEventCreditLowBalance),
+		string(sendly.WebhookEventCreditPurchased),
+		string(sendly.WebhookEventKeyExpiring),
+	}, nil
+}
+
+// lookupWebhook validates the ID, records the call, and finds the webhook.
+// Callers must hold f.mu.
+func (f *FakeClient) lookupWebhook(webhookID string) (*fakeWebhook, error) {
+	if webhookID == "" || !strings.HasPrefix(webhookID, "whk_") {
+		return nil, errors.New("invalid webhook ID format")
+	}
+	if err := f.enter(); err != nil {
+		return nil, err
+	}
+	for _, wh := range f.webhooks {
+		if wh.webhook.ID == webhookID {
+			return wh, nil
+		}
+	}
+	return nil, notFound("webhook", webhookID)
+}
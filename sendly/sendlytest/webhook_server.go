@@ -0,0 +1,162 @@
+// Package sendlytest provides utilities for testing code that uses the Sendly SDK.
+package sendlytest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+// WebhookServer is a local HTTP endpoint that receives and verifies signed
+// Sendly webhook events. It can also emit synthetic signed events to any URL,
+// which makes it useful for integration tests of webhook consumers.
+type WebhookServer struct {
+	*httptest.Server
+
+	// Secret is the signing secret used to verify and sign events.
+	Secret string
+
+	mu       sync.Mutex
+	events   []sendly.WebhookEvent
+	notify   chan struct{}
+	rejected int
+	seq      int64
+}
+
+// NewWebhookServer starts a webhook server that verifies incoming events with secret.
+// The caller should call Close when finished.
+func NewWebhookServer(secret string) *WebhookServer {
+	s := &WebhookServer{
+		Secret: secret,
+		notify: make(chan struct{}),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// handle verifies and records a single webhook delivery.
+func (s *WebhookServer) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	event, err := sendly.Webhooks{}.ParseEvent(string(body), r.Header.Get(sendly.WebhookSignatureHeader), s.Secret)
+	if err != nil {
+		s.mu.Lock()
+		s.rejected++
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	s.events = append(s.events, *event)
+	close(s.notify)
+	s.notify = make(chan struct{})
+	s.mu.Unlock()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Events returns a copy of all verified events received so far.
+func (s *WebhookServer) Events() []sendly.WebhookEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := make([]sendly.WebhookEvent, len(s.events))
+	copy(events, s.events)
+	return events
+}
+
+// Rejected returns the number of deliveries rejected due to an invalid signature or payload.
+func (s *WebhookServer) Rejected() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rejected
+}
+
+// Reset clears all recorded events and rejection counts.
+func (s *WebhookServer) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = nil
+	s.rejected = 0
+}
+
+// WaitForEvents blocks until at least n events have been received or ctx is done.
+func (s *WebhookServer) WaitForEvents(ctx context.Context, n int) ([]sendly.WebhookEvent, error) {
+	for {
+		s.mu.Lock()
+		if len(s.events) >= n {
+			events := make([]sendly.WebhookEvent, len(s.events))
+			copy(events, s.events)
+			s.mu.Unlock()
+			return events, nil
+		}
+		notify := s.notify
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return s.Events(), ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// Sign returns the signature header value for payload using the server secret.
+func (s *WebhookServer) Sign(payload []byte) string {
+	return sendly.Webhooks{}.GenerateSignature(string(payload), s.Secret)
+}
+
+// NewEvent builds a synthetic message event with a unique ID and the current timestamp.
+func (s *WebhookServer) NewEvent(eventType sendly.WebhookEventType, data sendly.WebhookMessageData) *sendly.WebhookEvent {
+	id := atomic.AddInt64(&s.seq, 1)
+	return &sendly.WebhookEvent{
+		ID:         "evt_test_" + strconv.FormatInt(id, 10),
+		Type:       eventType,
+		Data:       data,
+		CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+		APIVersion: "2024-01-01",
+	}
+}
+
+// Emit signs event with the server secret and POSTs it to targetURL, the way
+// Sendly delivers webhooks. The caller is responsible for closing the response body.
+func (s *WebhookServer) Emit(ctx context.Context, targetURL string, event *sendly.WebhookEvent) (*http.Response, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+	return s.EmitRaw(ctx, targetURL, payload, s.Sign(payload))
+}
+
+// EmitRaw POSTs payload to targetURL with the given signature header, allowing
+// tests to exercise invalid signatures or malformed payloads.
+func (s *WebhookServer) EmitRaw(ctx context.Context, targetURL string, payload []byte, signature string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(sendly.WebhookSignatureHeader, signature)
+
+	return s.Client().Do(req)
+}
@@ -0,0 +1,109 @@
+package sendlytest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sendly-live/sendly-go/sendly"
+)
+
+func TestWebhookServer_EmitToSelf(t *testing.T) {
+	server := NewWebhookServer("whsec_test")
+	defer server.Close()
+
+	ctx := context.Background()
+	event := server.NewEvent(sendly.WebhookEventMessageDelivered, sendly.WebhookMessageData{
+		MessageID: "msg_123",
+		Status:    sendly.WebhookStatusDelivered,
+		To:        "+15551234567",
+	})
+
+	resp, err := server.Emit(ctx, server.URL, event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	events := server.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].ID != event.ID {
+		t.Errorf("expected event ID '%s', got '%s'", event.ID, events[0].ID)
+	}
+	if events[0].Data.MessageID != "msg_123" {
+		t.Errorf("expected MessageID 'msg_123', got '%s'", events[0].Data.MessageID)
+	}
+}
+
+func TestWebhookServer_RejectsInvalidSignature(t *testing.T) {
+	server := NewWebhookServer("whsec_test")
+	defer server.Close()
+
+	ctx := context.Background()
+	payload := []byte(`{"id":"evt_1","type":"message.sent","created_at":"2024-01-01T00:00:00Z"}`)
+
+	resp, err := server.EmitRaw(ctx, server.URL, payload, "sha256=bogus")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+	if server.Rejected() != 1 {
+		t.Errorf("expected 1 rejected delivery, got %d", server.Rejected())
+	}
+	if len(server.Events()) != 0 {
+		t.Errorf("expected no events, got %d", len(server.Events()))
+	}
+}
+
+func TestWebhookServer_SignMatchesSDK(t *testing.T) {
+	server := NewWebhookServer("whsec_test")
+	defer server.Close()
+
+	payload := []byte(`{"id":"evt_1"}`)
+	signature := server.Sign(payload)
+
+	if !(sendly.Webhooks{}).VerifySignature(string(payload), signature, "whsec_test") {
+		t.Error("expected signature to verify with the SDK")
+	}
+}
+
+func TestWebhookServer_WaitForEvents(t *testing.T) {
+	server := NewWebhookServer("whsec_test")
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		for i := 0; i < 2; i++ {
+			event := server.NewEvent(sendly.WebhookEventMessageSent, sendly.WebhookMessageData{MessageID: "msg_1"})
+			if resp, err := server.Emit(ctx, server.URL, event); err == nil {
+				resp.Body.Close()
+			}
+		}
+	}()
+
+	events, err := server.WaitForEvents(ctx, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("expected 2 events, got %d", len(events))
+	}
+
+	server.Reset()
+	if len(server.Events()) != 0 {
+		t.Errorf("expected no events after reset, got %d", len(server.Events()))
+	}
+}
@@ -0,0 +1,250 @@
+package sendly
+
+import (
+	"context"
+	"time"
+)
+
+// SendWindow restricts when messages may be sent, such as the 8am to 9pm
+// calling hours required for marketing messages under the TCPA. A send
+// outside the window is scheduled for the next time the window opens, or
+// rejected with an *OutsideSendWindowError if Reject is set.
+//
+// Transactional messages, such as one-time codes, are exempt.
+type SendWindow struct {
+	// Timezone is the IANA time zone the window is evaluated in, such as
+	// "America/New_York" (default: UTC). Use the recipient's time zone.
+	Timezone string
+	// Start is when the window opens each day, as "15:04" (e.g. "08:00").
+	Start string
+	// End is when the window closes, as "15:04" (e.g. "21:00"). An End
+	// before Start makes the window span midnight.
+	End string
+	// Days are the days the window opens on (default: every day).
+	Days []time.Weekday
+	// Reject makes sends outside the window fail instead of being scheduled.
+	Reject bool
+}
+
+// WithSendWindow applies a send window to every non-transactional send. A
+// window set on SendMessageRequest.SendWindow takes precedence for Send.
+//
+// Send and Schedule move messages to the next time the window opens, unless
+// Reject is set. SendBatch, LaunchDraft and SendToSegment cannot be deferred,
+// so outside the window they fail with an *OutsideSendWindowError. Drafts and
+// segment sends are treated as marketing messages.
+func WithSendWindow(w SendWindow) ClientOption {
+	return func(c *Client) {
+		c.sendWindow = &w
+	}
+}
+
+// Allows reports whether t falls inside the window.
+func (w SendWindow) Allows(t time.Time) (bool, error) {
+	next, err := w.Next(t)
+	if err != nil {
+		return false, err
+	}
+	return next.Equal(t), nil
+}
+
+// Next returns t if it falls inside the window, and otherwise the next time
+// the window opens.
+func (w SendWindow) Next(t time.Time) (time.Time, error) {
+	loc, start, end, err := w.parse()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	local := t.In(loc)
+	year, month, day := local.Date()
+	// Start one day back so a window that opened yesterday and spans
+	// midnight is considered.
+	// Bounds are built from the wall clock rather than midnight plus an
+	// offset, so the window keeps its local hours on DST transition days.
+	for i := -1; i <= 7; i++ {
+		open := time.Date(year, month, day+i, start.hour, start.minute, 0, 0, loc)
+		if !w.opensOn(open.Weekday()) {
+			continue
+		}
+		closeDay := day + i
+		if !start.before(end) {
+			closeDay++
+		}
+		closeAt := time.Date(year, month, closeDay, end.hour, end.minute, 0, 0, loc)
+
+		if !local.Before(open) && local.Before(closeAt) {
+			return t, nil
+		}
+		if open.After(local) {
+			return open, nil
+		}
+	}
+	return time.Time{}, &ValidationError{APIError: APIError{Message: "send window never opens"}}
+}
+
+// parse validates the window and returns its location and its start and end
+// times of day.
+func (w SendWindow) parse() (*time.Location, clock, clock, error) {
+	loc := time.UTC
+	if w.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(w.Timezone); err != nil {
+			return nil, clock{}, clock{}, &ValidationError{APIError: APIError{Message: "send window timezone is invalid: " + w.Timezone}}
+		}
+	}
+
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return nil, clock{}, clock{}, &ValidationError{APIError: APIError{Message: "send window start must be formatted as HH:MM"}}
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return nil, clock{}, clock{}, &ValidationError{APIError: APIError{Message: "send window end must be formatted as HH:MM"}}
+	}
+	if start == end {
+		return nil, clock{}, clock{}, &ValidationError{APIError: APIError{Message: "send window start and end must differ"}}
+	}
+	return loc, start, end, nil
+}
+
+// opensOn reports whether the window opens on day.
+func (w SendWindow) opensOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// clock is a wall-clock time of day.
+type clock struct {
+	hour, minute int
+}
+
+// before reports whether c is earlier in the day than other.
+func (c clock) before(other clock) bool {
+	return c.hour < other.hour || (c.hour == other.hour && c.minute < other.minute)
+}
+
+// parseClock parses an "HH:MM" time of day.
+func parseClock(s string) (clock, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return clock{}, err
+	}
+	return clock{hour: t.Hour(), minute: t.Minute()}, nil
+}
+
+// sendWindowFor returns the window that applies to req, or nil if the
+// message may be sent at any time.
+func (s *MessagesService) sendWindowFor(req *SendMessageRequest) *SendWindow {
+	if req.MessageType == MessageTypeTransactional {
+		return nil
+	}
+	if req.SendWindow != nil {
+		return req.SendWindow
+	}
+	return s.client.sendWindow
+}
+
+// windowFor returns the client's send window for messages of type t, or nil
+// if they may be sent at any time.
+func (s *MessagesService) windowFor(t MessageType) *SendWindow {
+	if t == MessageTypeTransactional {
+		return nil
+	}
+	return s.client.sendWindow
+}
+
+// checkSendWindow returns an *OutsideSendWindowError if messages of type t
+// may not be sent now. It guards sends that cannot be deferred.
+func (s *MessagesService) checkSendWindow(t MessageType) error {
+	w := s.windowFor(t)
+	if w == nil {
+		return nil
+	}
+	now := time.Now()
+	next, err := w.Next(now)
+	if err != nil {
+		return err
+	}
+	if !next.Equal(now) {
+		return &OutsideSendWindowError{NextAllowed: next}
+	}
+	return nil
+}
+
+// fitToWindow moves a resolved schedule request into w, or rejects it if w
+// is set to Reject.
+func (r *ScheduleMessageRequest) fitToWindow(w *SendWindow) error {
+	at, err := time.Parse(time.RFC3339, r.ScheduledAt)
+	if err != nil {
+		return err
+	}
+	next, err := w.Next(at)
+	if err != nil {
+		return err
+	}
+	if next.Equal(at) {
+		return nil
+	}
+	if w.Reject {
+		return &OutsideSendWindowError{NextAllowed: next}
+	}
+	r.ScheduledAt = next.UTC().Format(time.RFC3339)
+	return nil
+}
+
+// deferToWindow schedules req for when w next opens, or rejects it if w is
+// set to Reject. It returns a nil message if the window is open now.
+func (s *MessagesService) deferToWindow(ctx context.Context, req *SendMessageRequest, w *SendWindow) (*Message, error) {
+	now := time.Now()
+	next, err := w.Next(now)
+	if err != nil {
+		return nil, err
+	}
+	if next.Equal(now) {
+		return nil, nil
+	}
+	if w.Reject {
+		return nil, &OutsideSendWindowError{NextAllowed: next}
+	}
+
+	// Send has validated req, and next is inside w, so the schedule goes
+	// straight to the API rather than through Schedule's checks.
+	scheduled, err := s.schedule(ctx, &ScheduleMessageRequest{
+		To:                req.To,
+		Text:              req.Text,
+		From:              req.From,
+		ScheduledAt:       next.UTC().Format(time.RFC3339),
+		MessageType:       req.MessageType,
+		Metadata:          req.Metadata,
+		StatusCallbackURL: req.StatusCallbackURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		ID:                scheduled.ID,
+		ClientID:          req.ClientID,
+		ThreadKey:         req.ThreadKey,
+		Metadata:          scheduled.Metadata,
+		StatusCallbackURL: scheduled.StatusCallbackURL,
+		To:                scheduled.To,
+		From:              scheduled.From,
+		Text:              scheduled.Text,
+		Status:            MessageStatusScheduled,
+		Direction:         "outbound",
+		Segments:          CountSegments(scheduled.Text),
+		CreditsUsed:       scheduled.CreditsReserved,
+		IsSandbox:         scheduled.IsSandbox,
+		CreatedAt:         scheduled.CreatedAt,
+		ScheduledAt:       scheduled.ScheduledAt,
+	}, nil
+}
@@ -0,0 +1,221 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSendWindowNext(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	tcpa := SendWindow{Timezone: "America/New_York", Start: "08:00", End: "21:00"}
+	overnight := SendWindow{Start: "22:00", End: "06:00"}
+	weekdays := SendWindow{Start: "09:00", End: "17:00", Days: []time.Weekday{
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+	}}
+
+	tests := []struct {
+		name   string
+		window SendWindow
+		at     time.Time
+		want   time.Time
+	}{
+		{"inside", tcpa, time.Date(2025, 3, 4, 12, 0, 0, 0, ny), time.Date(2025, 3, 4, 12, 0, 0, 0, ny)},
+		{"before opening", tcpa, time.Date(2025, 3, 4, 6, 30, 0, 0, ny), time.Date(2025, 3, 4, 8, 0, 0, 0, ny)},
+		{"after closing", tcpa, time.Date(2025, 3, 4, 22, 0, 0, 0, ny), time.Date(2025, 3, 5, 8, 0, 0, 0, ny)},
+		{"at closing", tcpa, time.Date(2025, 3, 4, 21, 0, 0, 0, ny), time.Date(2025, 3, 5, 8, 0, 0, 0, ny)},
+		{"converts from UTC", tcpa, time.Date(2025, 3, 4, 3, 0, 0, 0, time.UTC), time.Date(2025, 3, 4, 8, 0, 0, 0, ny)},
+		{"overnight after midnight", overnight, time.Date(2025, 3, 4, 2, 0, 0, 0, time.UTC), time.Date(2025, 3, 4, 2, 0, 0, 0, time.UTC)},
+		{"overnight gap", overnight, time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC), time.Date(2025, 3, 4, 22, 0, 0, 0, time.UTC)},
+		{"fall-back day before opening", tcpa, time.Date(2025, 11, 2, 7, 30, 0, 0, ny), time.Date(2025, 11, 2, 8, 0, 0, 0, ny)},
+		{"fall-back day before closing", tcpa, time.Date(2025, 11, 2, 20, 30, 0, 0, ny), time.Date(2025, 11, 2, 20, 30, 0, 0, ny)},
+		{"spring-forward day after opening", tcpa, time.Date(2025, 3, 9, 8, 30, 0, 0, ny), time.Date(2025, 3, 9, 8, 30, 0, 0, ny)},
+		{"spring-forward day after closing", tcpa, time.Date(2025, 3, 9, 21, 30, 0, 0, ny), time.Date(2025, 3, 10, 8, 0, 0, 0, ny)},
+		{"skips weekend", weekdays, time.Date(2025, 3, 8, 10, 0, 0, 0, time.UTC), time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.window.Next(tt.at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSendWindow_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		window SendWindow
+	}{
+		{"bad timezone", SendWindow{Timezone: "Mars/Olympus", Start: "08:00", End: "21:00"}},
+		{"bad start", SendWindow{Start: "8am", End: "21:00"}},
+		{"missing end", SendWindow{Start: "08:00"}},
+		{"empty window", SendWindow{Start: "08:00", End: "08:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.window.Allows(time.Now()); !IsValidationError(err) {
+				t.Errorf("expected ValidationError, got %T", err)
+			}
+		})
+	}
+}
+
+// closedWindow returns a window that is closed now and opens tomorrow.
+func closedWindow() SendWindow {
+	tomorrow := time.Now().UTC().Add(24 * time.Hour).Weekday()
+	return SendWindow{Start: "00:00", End: "23:59", Days: []time.Weekday{tomorrow}}
+}
+
+func TestMessagesSend_OutsideWindowSchedules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages/schedule" {
+			t.Errorf("expected path '/messages/schedule', got '%s'", r.URL.Path)
+		}
+
+		var req ScheduleMessageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		want := time.Now().UTC().Add(24*time.Hour).Format("2006-01-02") + "T00:00:00Z"
+		if req.ScheduledAt != want {
+			t.Errorf("expected scheduledAt to be '%s', got '%s'", want, req.ScheduledAt)
+		}
+		if req.From != "ACME" {
+			t.Errorf("expected the deferred send from 'ACME', got '%s'", req.From)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"sched_1","to":"` + req.To + `","text":"` + req.Text + `","scheduledAt":"` + req.ScheduledAt + `","status":"scheduled","creditsReserved":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithSendWindow(closedWindow()))
+	msg, err := client.Messages.Send(context.Background(), &SendMessageRequest{To: "+15551234567", Text: "Sale today", From: "ACME"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if msg.ID != "sched_1" || msg.Status != MessageStatusScheduled || msg.ScheduledAt == "" {
+		t.Errorf("expected a scheduled message, got %+v", msg)
+	}
+}
+
+func TestMessagesSend_OutsideWindowRejects(t *testing.T) {
+	client := NewClient("test-api-key", WithBaseURL("http://127.0.0.1:0"))
+
+	w := closedWindow()
+	w.Reject = true
+	_, err := client.Messages.Send(context.Background(), &SendMessageRequest{To: "+15551234567", Text: "Sale today", SendWindow: &w})
+	if !IsOutsideSendWindowError(err) {
+		t.Fatalf("expected OutsideSendWindowError, got %v", err)
+	}
+	if err.(*OutsideSendWindowError).NextAllowed.Before(time.Now()) {
+		t.Errorf("expected NextAllowed in the future, got %s", err.(*OutsideSendWindowError).NextAllowed)
+	}
+}
+
+func TestMessagesSend_WindowSkipsTransactional(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages" {
+			t.Errorf("expected path '/messages', got '%s'", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	w := closedWindow()
+	w.Reject = true
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithSendWindow(w))
+
+	msg, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+		To:          "+15551234567",
+		Text:        "Your code is 123456",
+		MessageType: MessageTypeTransactional,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Status != MessageStatusQueued {
+		t.Errorf("expected status 'queued', got '%s'", msg.Status)
+	}
+}
+
+func TestSendWindow_BulkSendsRejectedOutsideWindow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expected no request outside the window, got %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithSendWindow(closedWindow()))
+	ctx := context.Background()
+
+	batch := &SendBatchRequest{Messages: []BatchMessageItem{{To: "+15551234567", Text: "Sale today"}}}
+	if _, err := client.Messages.SendBatch(ctx, batch); !IsOutsideSendWindowError(err) {
+		t.Errorf("expected SendBatch to fail with OutsideSendWindowError, got %v", err)
+	}
+	if _, err := client.Messages.LaunchDraft(ctx, "draft_1"); !IsOutsideSendWindowError(err) {
+		t.Errorf("expected LaunchDraft to fail with OutsideSendWindowError, got %v", err)
+	}
+	if _, err := client.Messages.SendToSegment(ctx, "seg_1", "Sale today"); !IsOutsideSendWindowError(err) {
+		t.Errorf("expected SendToSegment to fail with OutsideSendWindowError, got %v", err)
+	}
+}
+
+func TestSendWindow_TransactionalBatchAllowed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"batchId":"batch_1","status":"processing","total":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithSendWindow(closedWindow()))
+	_, err := client.Messages.SendBatch(context.Background(), &SendBatchRequest{
+		Messages:    []BatchMessageItem{{To: "+15551234567", Text: "Your code is 123456"}},
+		MessageType: MessageTypeTransactional,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMessagesSchedule_MovedIntoWindow(t *testing.T) {
+	var scheduledAt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ScheduleMessageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		scheduledAt = req.ScheduledAt
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"sched_1","status":"scheduled"}`))
+	}))
+	defer server.Close()
+
+	window := SendWindow{Start: "09:00", End: "17:00"}
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithSendWindow(window))
+	tomorrow := time.Now().UTC().Add(24 * time.Hour).Truncate(24 * time.Hour)
+	req := &ScheduleMessageRequest{To: "+15551234567", Text: "Sale today", SendAt: tomorrow.Add(3 * time.Hour)}
+
+	if _, err := client.Messages.Schedule(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := tomorrow.Add(9 * time.Hour).Format(time.RFC3339); scheduledAt != want {
+		t.Errorf("expected the schedule to move to %s, got %s", want, scheduledAt)
+	}
+
+	window.Reject = true
+	client = NewClient("test-api-key", WithBaseURL(server.URL), WithSendWindow(window))
+	if _, err := client.Messages.Schedule(context.Background(), req); !IsOutsideSendWindowError(err) {
+		t.Errorf("expected OutsideSendWindowError with Reject, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package sendly
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// queuedMessage answers every request with a queued message.
+func queuedMessage(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(`{"id":"msg_1","status":"queued","data":[],"count":0}`))
+}
+
+func TestWithRequestSigning_SignsBodyAndPath(t *testing.T) {
+	server, seen := newTestServer(t, queuedMessage)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithRequestSigning("signing_secret"))
+
+	if _, err := client.Messages.Send(context.Background(), &SendMessageRequest{To: "+15551234567", Text: "Hello"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Messages.List(context.Background(), &ListMessagesRequest{Limit: 5}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reqs := seen()
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(reqs))
+	}
+	for _, r := range reqs {
+		body, _ := io.ReadAll(r.Body)
+		path := r.URL.RequestURI()
+		timestamp := r.Header.Get(requestTimestampHeader)
+		nonce := r.Header.Get(requestNonceHeader)
+		want := requestSignature("signing_secret", timestamp, nonce, r.Method, path, body)
+		if got := r.Header.Get(requestSignatureHeader); got != want {
+			t.Errorf("%s %s: expected signature %s, got %s", r.Method, path, want, got)
+		}
+
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil || time.Since(time.Unix(unix, 0)) > time.Minute {
+			t.Errorf("expected a current timestamp, got %q", timestamp)
+		}
+	}
+	if reqs[0].Header.Get(requestNonceHeader) == reqs[1].Header.Get(requestNonceHeader) {
+		t.Error("expected each request to get its own nonce")
+	}
+}
+
+func TestWithRequestSigning_DifferentSecret(t *testing.T) {
+	a := requestSignature("secret_a", "1700000000", "abc", "POST", "/messages", []byte(`{}`))
+	b := requestSignature("secret_b", "1700000000", "abc", "POST", "/messages", []byte(`{}`))
+	if a == b {
+		t.Error("expected different secrets to give different signatures")
+	}
+	c := requestSignature("secret_a", "1700000000", "abc", "POST", "/messages/batch", []byte(`{}`))
+	if a == c {
+		t.Error("expected the path to be covered by the signature")
+	}
+}
+
+func TestWithRequestSigning_Disabled(t *testing.T) {
+	server, seen := newTestServer(t, queuedMessage)
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+
+	if _, err := client.Messages.Get(context.Background(), "msg_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sig := seen()[0].Header.Get(requestSignatureHeader); sig != "" {
+		t.Errorf("expected no signature, got %s", sig)
+	}
+}
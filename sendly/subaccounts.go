@@ -0,0 +1,226 @@
+package sendly
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// subAccountHeader makes a request act on behalf of a sub-account.
+const subAccountHeader = "X-Sendly-Sub-Account"
+
+// WithSubAccount makes every request act on behalf of the sub-account with
+// the given ID: messages are sent from, billed to, and listed for that
+// sub-account. WithCallSubAccount overrides it for a single call.
+func WithSubAccount(subAccountID string) ClientOption {
+	return func(c *Client) {
+		c.subAccount = subAccountID
+	}
+}
+
+// WithCallSubAccount makes the call act on behalf of the sub-account with
+// the given ID, overriding WithSubAccount.
+func WithCallSubAccount(subAccountID string) CallOption {
+	return func(o *callOptions) {
+		o.subAccount = subAccountID
+	}
+}
+
+// SubAccountStatus represents the status of a sub-account.
+type SubAccountStatus string
+
+const (
+	// SubAccountStatusActive means the sub-account can send messages.
+	SubAccountStatusActive SubAccountStatus = "active"
+	// SubAccountStatusSuspended means requests for the sub-account are rejected.
+	SubAccountStatusSuspended SubAccountStatus = "suspended"
+)
+
+// SubAccount is a customer account managed by the parent account.
+type SubAccount struct {
+	// ID is the sub-account ID (sub_xxx).
+	ID string `json:"id"`
+	// Name is the sub-account name.
+	Name string `json:"name"`
+	// Email is the sub-account contact email, if set.
+	Email string `json:"email,omitempty"`
+	// Status is the sub-account status.
+	Status SubAccountStatus `json:"status"`
+	// CreditBalance is the sub-account's own credit balance.
+	CreditBalance int `json:"creditBalance"`
+	// CreatedAt is when the sub-account was created.
+	CreatedAt string `json:"createdAt"`
+	// SuspendedAt is when the sub-account was suspended.
+	SuspendedAt *string `json:"suspendedAt,omitempty"`
+}
+
+// CreateSubAccountRequest is the request to create a sub-account.
+type CreateSubAccountRequest struct {
+	// Name is the sub-account name (required).
+	Name string `json:"name"`
+	// Email is the sub-account contact email.
+	Email string `json:"email,omitempty"`
+	// InitialCredits are transferred from the parent account on creation.
+	InitialCredits int `json:"initialCredits,omitempty"`
+}
+
+// ListSubAccountsRequest is the request to list sub-accounts.
+type ListSubAccountsRequest struct {
+	// Limit is the maximum number of sub-accounts to return (default: 20, max: 100).
+	Limit int
+	// Offset is the number of sub-accounts to skip.
+	Offset int
+	// Status filters by sub-account status.
+	Status SubAccountStatus
+}
+
+// ListSubAccountsResponse is the response from listing sub-accounts.
+type ListSubAccountsResponse = Page[SubAccount]
+
+// SubAccountUsage contains usage statistics for a sub-account.
+type SubAccountUsage struct {
+	SubAccountID      string `json:"subAccountId"`
+	MessagesSent      int    `json:"messagesSent"`
+	MessagesDelivered int    `json:"messagesDelivered"`
+	MessagesFailed    int    `json:"messagesFailed"`
+	CreditsUsed       int    `json:"creditsUsed"`
+	PeriodStart       string `json:"periodStart"`
+	PeriodEnd         string `json:"periodEnd"`
+}
+
+// CreditTransfer is the result of moving credits between the parent account
+// and a sub-account.
+type CreditTransfer struct {
+	// ID is the transfer ID.
+	ID string `json:"id"`
+	// SubAccountID is the sub-account credits were moved to or from.
+	SubAccountID string `json:"subAccountId"`
+	// Amount is the number of credits moved to the sub-account; negative
+	// amounts were moved back to the parent.
+	Amount int `json:"amount"`
+	// ParentBalanceAfter is the parent's balance after the transfer.
+	ParentBalanceAfter int `json:"parentBalanceAfter"`
+	// SubAccountBalanceAfter is the sub-account's balance after the transfer.
+	SubAccountBalanceAfter int `json:"subAccountBalanceAfter"`
+	// CreatedAt is when the transfer was made.
+	CreatedAt string `json:"createdAt"`
+}
+
+// transferCreditsRequest is the body of a credit transfer.
+type transferCreditsRequest struct {
+	Amount int `json:"amount"`
+}
+
+// SubAccountsService manages sub-accounts, for platforms that resell SMS to
+// their own customers.
+type SubAccountsService struct {
+	client *Client
+}
+
+// Create creates a sub-account.
+func (s *SubAccountsService) Create(ctx context.Context, req CreateSubAccountRequest) (*SubAccount, error) {
+	if req.Name == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "sub-account name is required"}}
+	}
+	if req.InitialCredits < 0 {
+		return nil, &ValidationError{APIError: APIError{Message: "initial credits cannot be negative"}}
+	}
+
+	var resp SubAccount
+	if err := s.client.request(ctx, "POST", "/sub-accounts", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List retrieves sub-accounts.
+func (s *SubAccountsService) List(ctx context.Context, req *ListSubAccountsRequest) (*ListSubAccountsResponse, error) {
+	params := make(map[string]string)
+	offset := 0
+	if req != nil {
+		if req.Limit > 0 {
+			params["limit"] = strconv.Itoa(req.Limit)
+		}
+		offset = req.Offset
+		if req.Offset > 0 {
+			params["offset"] = strconv.Itoa(req.Offset)
+		}
+		if req.Status != "" {
+			params["status"] = string(req.Status)
+		}
+	}
+
+	var resp ListSubAccountsResponse
+	if err := s.client.request(ctx, "GET", "/sub-accounts"+buildQueryString(params), nil, &resp); err != nil {
+		return nil, err
+	}
+	resp.fill(offset)
+	return &resp, nil
+}
+
+// Get retrieves a sub-account by ID.
+func (s *SubAccountsService) Get(ctx context.Context, id string) (*SubAccount, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "sub-account ID is required"}}
+	}
+
+	var resp SubAccount
+	if err := s.client.request(ctx, "GET", "/sub-accounts/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Suspend suspends a sub-account. Requests made on its behalf are rejected
+// until it is reactivated.
+func (s *SubAccountsService) Suspend(ctx context.Context, id string) (*SubAccount, error) {
+	return s.setStatus(ctx, id, "suspend")
+}
+
+// Reactivate reactivates a suspended sub-account.
+func (s *SubAccountsService) Reactivate(ctx context.Context, id string) (*SubAccount, error) {
+	return s.setStatus(ctx, id, "reactivate")
+}
+
+func (s *SubAccountsService) setStatus(ctx context.Context, id, action string) (*SubAccount, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "sub-account ID is required"}}
+	}
+
+	var resp SubAccount
+	if err := s.client.request(ctx, "POST", "/sub-accounts/"+url.PathEscape(id)+"/"+action, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetUsage retrieves usage statistics for a sub-account.
+func (s *SubAccountsService) GetUsage(ctx context.Context, id string) (*SubAccountUsage, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "sub-account ID is required"}}
+	}
+
+	var resp SubAccountUsage
+	if err := s.client.request(ctx, "GET", "/sub-accounts/"+url.PathEscape(id)+"/usage", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// TransferCredits moves amount credits from the parent account to the
+// sub-account. A negative amount moves credits back to the parent.
+func (s *SubAccountsService) TransferCredits(ctx context.Context, id string, amount int) (*CreditTransfer, error) {
+	if id == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "sub-account ID is required"}}
+	}
+	if amount == 0 {
+		return nil, &ValidationError{APIError: APIError{Message: "amount must not be zero"}}
+	}
+
+	var resp CreditTransfer
+	path := "/sub-accounts/" + url.PathEscape(id) + "/credits/transfer"
+	if err := s.client.request(ctx, "POST", path, &transferCreditsRequest{Amount: amount}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
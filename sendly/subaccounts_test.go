@@ -0,0 +1,167 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSubAccountsCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/sub-accounts" {
+			t.Errorf("expected path '/sub-accounts', got '%s'", r.URL.Path)
+		}
+
+		var req CreateSubAccountRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Name != "Acme" || req.InitialCredits != 500 {
+			t.Errorf("unexpected request body %+v", req)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"sub_1","name":"Acme","status":"active","creditBalance":500}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	sub, err := client.SubAccounts.Create(context.Background(), CreateSubAccountRequest{Name: "Acme", InitialCredits: 500})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sub.ID != "sub_1" || sub.Status != SubAccountStatusActive || sub.CreditBalance != 500 {
+		t.Errorf("unexpected sub-account %+v", sub)
+	}
+}
+
+func TestSubAccountsCreate_Validation(t *testing.T) {
+	client := NewClient("test-api-key")
+
+	if _, err := client.SubAccounts.Create(context.Background(), CreateSubAccountRequest{}); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for missing name, got %T", err)
+	}
+	if _, err := client.SubAccounts.Create(context.Background(), CreateSubAccountRequest{Name: "Acme", InitialCredits: -1}); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for negative credits, got %T", err)
+	}
+}
+
+func TestSubAccountsList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("status"); got != "suspended" {
+			t.Errorf("expected status to be 'suspended', got '%s'", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"sub_1","status":"suspended"}],"count":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.SubAccounts.List(context.Background(), &ListSubAccountsRequest{Status: SubAccountStatusSuspended})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Count != 1 || resp.Data[0].ID != "sub_1" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestSubAccountsSuspendAndReactivate(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"sub_1","status":"active"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	ctx := context.Background()
+	if _, err := client.SubAccounts.Suspend(ctx, "sub_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.SubAccounts.Reactivate(ctx, "sub_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"POST /sub-accounts/sub_1/suspend", "POST /sub-accounts/sub_1/reactivate"}
+	for i, want := range expected {
+		if i >= len(paths) || paths[i] != want {
+			t.Errorf("expected request %d to be '%s', got %v", i, want, paths)
+		}
+	}
+}
+
+func TestSubAccountsGetUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sub-accounts/sub_1/usage" {
+			t.Errorf("expected path '/sub-accounts/sub_1/usage', got '%s'", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"subAccountId":"sub_1","messagesSent":42,"creditsUsed":50}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	usage, err := client.SubAccounts.GetUsage(context.Background(), "sub_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage.MessagesSent != 42 || usage.CreditsUsed != 50 {
+		t.Errorf("unexpected usage %+v", usage)
+	}
+}
+
+func TestSubAccountsTransferCredits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sub-accounts/sub_1/credits/transfer" {
+			t.Errorf("expected path '/sub-accounts/sub_1/credits/transfer', got '%s'", r.URL.Path)
+		}
+		var body map[string]int
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["amount"] != -100 {
+			t.Errorf("expected amount to be -100, got %d", body["amount"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"xfer_1","subAccountId":"sub_1","amount":-100,"parentBalanceAfter":1100,"subAccountBalanceAfter":400}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	transfer, err := client.SubAccounts.TransferCredits(context.Background(), "sub_1", -100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transfer.ParentBalanceAfter != 1100 || transfer.SubAccountBalanceAfter != 400 {
+		t.Errorf("unexpected transfer %+v", transfer)
+	}
+
+	if _, err := client.SubAccounts.TransferCredits(context.Background(), "sub_1", 0); !IsValidationError(err) {
+		t.Errorf("expected ValidationError for zero amount, got %T", err)
+	}
+}
+
+func TestWithSubAccount_SetsHeader(t *testing.T) {
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get(subAccountHeader))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithSubAccount("sub_1"))
+	ctx := context.Background()
+	client.Messages.List(ctx, nil)
+	client.Messages.List(WithCallOptions(ctx, WithCallSubAccount("sub_2")), nil)
+
+	if len(headers) != 2 || headers[0] != "sub_1" || headers[1] != "sub_2" {
+		t.Errorf("expected sub-account headers [sub_1 sub_2], got %v", headers)
+	}
+}
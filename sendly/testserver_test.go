@@ -0,0 +1,44 @@
+package sendly
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// newTestServer starts a server that passes each request to handler and
+// closes it when the test ends. requests returns the requests received so
+// far, in order, with bodies that can be read again.
+func newTestServer(t *testing.T, handler http.HandlerFunc) (server *httptest.Server, requests func() []*http.Request) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var seen []*http.Request
+	var bodies [][]byte
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		mu.Lock()
+		seen = append(seen, r.Clone(r.Context()))
+		bodies = append(bodies, body)
+		mu.Unlock()
+
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []*http.Request {
+		mu.Lock()
+		defer mu.Unlock()
+		out := make([]*http.Request, len(seen))
+		for i, r := range seen {
+			out[i] = r.Clone(r.Context())
+			out[i].Body = io.NopCloser(bytes.NewReader(bodies[i]))
+		}
+		return out
+	}
+}
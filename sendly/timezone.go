@@ -0,0 +1,134 @@
+package sendly
+
+import (
+	"time"
+)
+
+// TimezoneForNumber returns the IANA time zone of the country a phone number
+// in E.164 format belongs to, such as "Europe/London" for "+447911123456", or
+// "" if the country is not recognised.
+//
+// A country that spans several time zones, such as the US or Australia,
+// returns the westernmost of its main zones, so a time in that zone has
+// already been reached everywhere else in the country. Alaska and Hawaii
+// area codes return their own zones.
+func TimezoneForNumber(phone string) string {
+	if len(phone) >= 5 && phone[:2] == "+1" {
+		if tz, ok := nanpTimezones[phone[2:5]]; ok {
+			return tz
+		}
+	}
+	return countryTimezones[CountryForNumber(phone)]
+}
+
+// LocationForNumber loads the time zone TimezoneForNumber returns.
+func LocationForNumber(phone string) (*time.Location, error) {
+	tz := TimezoneForNumber(phone)
+	if tz == "" {
+		return nil, &ValidationError{APIError: APIError{Message: "no time zone is known for " + phone}}
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, &ValidationError{APIError: APIError{Message: "time zone " + tz + " could not be loaded: " + err.Error()}}
+	}
+	return loc, nil
+}
+
+// nanpTimezones maps US area codes outside the contiguous states to their
+// time zones.
+var nanpTimezones = map[string]string{
+	"907": "America/Anchorage",
+	"808": "Pacific/Honolulu",
+}
+
+// countryTimezones maps countries to the time zone TimezoneForNumber
+// returns for them.
+var countryTimezones = map[string]string{
+	// North American Numbering Plan
+	"US": "America/Los_Angeles", "CA": "America/Vancouver", "BS": "America/Nassau",
+	"BB": "America/Barbados", "AI": "America/Anguilla", "AG": "America/Antigua",
+	"VG": "America/Tortola", "VI": "America/St_Thomas", "KY": "America/Cayman",
+	"BM": "Atlantic/Bermuda", "GD": "America/Grenada", "TC": "America/Grand_Turk",
+	"JM": "America/Jamaica", "MS": "America/Montserrat", "MP": "Pacific/Saipan",
+	"GU": "Pacific/Guam", "AS": "Pacific/Pago_Pago", "SX": "America/Lower_Princes",
+	"LC": "America/St_Lucia", "DM": "America/Dominica", "VC": "America/St_Vincent",
+	"PR": "America/Puerto_Rico", "DO": "America/Santo_Domingo", "TT": "America/Port_of_Spain",
+	"KN": "America/St_Kitts",
+
+	"RU": "Europe/Kaliningrad", "EG": "Africa/Cairo", "ZA": "Africa/Johannesburg",
+	"GR": "Europe/Athens", "NL": "Europe/Amsterdam", "BE": "Europe/Brussels",
+	"FR": "Europe/Paris", "ES": "Europe/Madrid", "HU": "Europe/Budapest",
+	"IT": "Europe/Rome", "RO": "Europe/Bucharest", "CH": "Europe/Zurich",
+	"AT": "Europe/Vienna", "GB": "Europe/London", "DK": "Europe/Copenhagen",
+	"SE": "Europe/Stockholm", "NO": "Europe/Oslo", "PL": "Europe/Warsaw",
+	"DE": "Europe/Berlin", "PE": "America/Lima", "MX": "America/Tijuana",
+	"CU": "America/Havana", "AR": "America/Argentina/Buenos_Aires", "BR": "America/Rio_Branco",
+	"CL": "America/Santiago", "CO": "America/Bogota", "VE": "America/Caracas",
+	"MY": "Asia/Kuala_Lumpur", "AU": "Australia/Perth", "ID": "Asia/Jakarta",
+	"PH": "Asia/Manila", "NZ": "Pacific/Auckland", "SG": "Asia/Singapore",
+	"TH": "Asia/Bangkok", "JP": "Asia/Tokyo", "KR": "Asia/Seoul",
+	"VN": "Asia/Ho_Chi_Minh", "CN": "Asia/Shanghai", "TR": "Europe/Istanbul",
+	"IN": "Asia/Kolkata", "PK": "Asia/Karachi", "AF": "Asia/Kabul",
+	"LK": "Asia/Colombo", "MM": "Asia/Yangon", "IR": "Asia/Tehran",
+
+	"SS": "Africa/Juba", "MA": "Africa/Casablanca", "DZ": "Africa/Algiers",
+	"TN": "Africa/Tunis", "LY": "Africa/Tripoli", "GM": "Africa/Banjul",
+	"SN": "Africa/Dakar", "MR": "Africa/Nouakchott", "ML": "Africa/Bamako",
+	"GN": "Africa/Conakry", "CI": "Africa/Abidjan", "BF": "Africa/Ouagadougou",
+	"NE": "Africa/Niamey", "TG": "Africa/Lome", "BJ": "Africa/Porto-Novo",
+	"MU": "Indian/Mauritius", "LR": "Africa/Monrovia", "SL": "Africa/Freetown",
+	"GH": "Africa/Accra", "NG": "Africa/Lagos", "TD": "Africa/Ndjamena",
+	"CF": "Africa/Bangui", "CM": "Africa/Douala", "CV": "Atlantic/Cape_Verde",
+	"ST": "Africa/Sao_Tome", "GQ": "Africa/Malabo", "GA": "Africa/Libreville",
+	"CG": "Africa/Brazzaville", "CD": "Africa/Kinshasa", "AO": "Africa/Luanda",
+	"GW": "Africa/Bissau", "IO": "Indian/Chagos", "SC": "Indian/Mahe",
+	"SD": "Africa/Khartoum", "RW": "Africa/Kigali", "ET": "Africa/Addis_Ababa",
+	"SO": "Africa/Mogadishu", "DJ": "Africa/Djibouti", "KE": "Africa/Nairobi",
+	"TZ": "Africa/Dar_es_Salaam", "UG": "Africa/Kampala", "BI": "Africa/Bujumbura",
+	"MZ": "Africa/Maputo", "ZM": "Africa/Lusaka", "MG": "Indian/Antananarivo",
+	"RE": "Indian/Reunion", "ZW": "Africa/Harare", "NA": "Africa/Windhoek",
+	"MW": "Africa/Blantyre", "LS": "Africa/Maseru", "BW": "Africa/Gaborone",
+	"SZ": "Africa/Mbabane", "KM": "Indian/Comoro", "SH": "Atlantic/St_Helena",
+	"ER": "Africa/Asmara", "AW": "America/Aruba", "FO": "Atlantic/Faroe",
+	"GL": "America/Nuuk",
+
+	"GI": "Europe/Gibraltar", "PT": "Europe/Lisbon", "LU": "Europe/Luxembourg",
+	"IE": "Europe/Dublin", "IS": "Atlantic/Reykjavik", "AL": "Europe/Tirane",
+	"MT": "Europe/Malta", "CY": "Asia/Nicosia", "FI": "Europe/Helsinki",
+	"BG": "Europe/Sofia", "LT": "Europe/Vilnius", "LV": "Europe/Riga",
+	"EE": "Europe/Tallinn", "MD": "Europe/Chisinau", "AM": "Asia/Yerevan",
+	"BY": "Europe/Minsk", "AD": "Europe/Andorra", "MC": "Europe/Monaco",
+	"SM": "Europe/San_Marino", "UA": "Europe/Kiev", "RS": "Europe/Belgrade",
+	"ME": "Europe/Podgorica", "XK": "Europe/Belgrade", "HR": "Europe/Zagreb",
+	"SI": "Europe/Ljubljana", "BA": "Europe/Sarajevo", "MK": "Europe/Skopje",
+	"CZ": "Europe/Prague", "SK": "Europe/Bratislava", "LI": "Europe/Vaduz",
+
+	"FK": "Atlantic/Stanley", "BZ": "America/Belize", "GT": "America/Guatemala",
+	"SV": "America/El_Salvador", "HN": "America/Tegucigalpa", "NI": "America/Managua",
+	"CR": "America/Costa_Rica", "PA": "America/Panama", "PM": "America/Miquelon",
+	"HT": "America/Port-au-Prince", "GP": "America/Guadeloupe", "BO": "America/La_Paz",
+	"GY": "America/Guyana", "EC": "America/Guayaquil", "GF": "America/Cayenne",
+	"PY": "America/Asuncion", "MQ": "America/Martinique", "SR": "America/Paramaribo",
+	"UY": "America/Montevideo", "CW": "America/Curacao",
+
+	"TL": "Asia/Dili", "NF": "Pacific/Norfolk", "BN": "Asia/Brunei",
+	"NR": "Pacific/Nauru", "PG": "Pacific/Port_Moresby", "TO": "Pacific/Tongatapu",
+	"SB": "Pacific/Guadalcanal", "VU": "Pacific/Efate", "FJ": "Pacific/Fiji",
+	"PW": "Pacific/Palau", "WF": "Pacific/Wallis", "CK": "Pacific/Rarotonga",
+	"NU": "Pacific/Niue", "WS": "Pacific/Apia", "KI": "Pacific/Tarawa",
+	"NC": "Pacific/Noumea", "TV": "Pacific/Funafuti", "PF": "Pacific/Tahiti",
+	"TK": "Pacific/Fakaofo", "FM": "Pacific/Chuuk", "MH": "Pacific/Majuro",
+
+	"KP": "Asia/Pyongyang", "HK": "Asia/Hong_Kong", "MO": "Asia/Macau",
+	"KH": "Asia/Phnom_Penh", "LA": "Asia/Vientiane", "BD": "Asia/Dhaka",
+	"TW": "Asia/Taipei",
+
+	"MV": "Indian/Maldives", "LB": "Asia/Beirut", "JO": "Asia/Amman",
+	"SY": "Asia/Damascus", "IQ": "Asia/Baghdad", "KW": "Asia/Kuwait",
+	"SA": "Asia/Riyadh", "YE": "Asia/Aden", "OM": "Asia/Muscat",
+	"PS": "Asia/Gaza", "AE": "Asia/Dubai", "IL": "Asia/Jerusalem",
+	"BH": "Asia/Bahrain", "QA": "Asia/Qatar", "BT": "Asia/Thimphu",
+	"MN": "Asia/Hovd", "NP": "Asia/Kathmandu", "TJ": "Asia/Dushanbe",
+	"TM": "Asia/Ashgabat", "AZ": "Asia/Baku", "GE": "Asia/Tbilisi",
+	"KG": "Asia/Bishkek", "UZ": "Asia/Tashkent",
+}
@@ -0,0 +1,53 @@
+package sendly
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimezoneForNumber(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  string
+	}{
+		{"+447911123456", "Europe/London"},
+		{"+15551234567", "America/Los_Angeles"},
+		{"+14165551234", "America/Vancouver"},
+		{"+19075551234", "America/Anchorage"},
+		{"+18085551234", "Pacific/Honolulu"},
+		{"+61412345678", "Australia/Perth"},
+		{"+8801712345678", "Asia/Dhaka"},
+		{"+0001234", ""},
+		{"5551234567", ""},
+	}
+
+	for _, tt := range tests {
+		if got := TimezoneForNumber(tt.phone); got != tt.want {
+			t.Errorf("TimezoneForNumber(%q) = %q, want %q", tt.phone, got, tt.want)
+		}
+	}
+}
+
+func TestTimezoneForNumber_ZonesLoad(t *testing.T) {
+	for country, tz := range countryTimezones {
+		if _, err := time.LoadLocation(tz); err != nil {
+			t.Errorf("time zone %q for %s does not load: %v", tz, country, err)
+		}
+	}
+	for _, country := range callingCodes {
+		if _, ok := countryTimezones[country]; !ok {
+			t.Errorf("no time zone for %s", country)
+		}
+	}
+	for _, country := range nanpAreaCodes {
+		if _, ok := countryTimezones[country]; !ok {
+			t.Errorf("no time zone for %s", country)
+		}
+	}
+}
+
+func TestLocationForNumber_Unknown(t *testing.T) {
+	if _, err := LocationForNumber("+0001234"); !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+}
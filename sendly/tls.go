@@ -0,0 +1,31 @@
+package sendly
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// WithTLSConfig sets the TLS configuration used to connect to the API, for
+// networks that intercept TLS with a private CA (set RootCAs) or require
+// mutual TLS (set Certificates or GetClientCertificate). The config is
+// copied, so later changes to it have no effect.
+//
+// Like WithProxy, it is applied to the client's *http.Transport and is
+// ignored if WithHTTPClient supplies a different RoundTripper.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.tlsConfig = config.Clone()
+	}
+}
+
+// applyTLSConfig installs the configured TLS settings on a copy of the
+// client's transport, leaving a caller-supplied *http.Client untouched.
+func (c *Client) applyTLSConfig() {
+	if c.tlsConfig == nil {
+		return
+	}
+
+	c.modifyTransport(func(transport *http.Transport) {
+		transport.TLSClientConfig = c.tlsConfig.Clone()
+	})
+}
@@ -0,0 +1,129 @@
+package sendly
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newClientCertificate creates a self-signed certificate for mutual TLS tests.
+func newClientCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sendly-test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestWithTLSConfig_CustomRootCAs(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	// The untrusted client's failed handshake is expected.
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithTLSConfig(&tls.Config{RootCAs: pool}),
+	)
+	if _, err := client.Messages.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	untrusted := NewClient("test-api-key", WithBaseURL(server.URL), WithMaxRetries(0))
+	if _, err := untrusted.Messages.List(context.Background(), nil); !IsNetworkError(err) {
+		t.Errorf("expected a network error without the custom CA, got %v", err)
+	}
+}
+
+func TestWithTLSConfig_ClientCertificate(t *testing.T) {
+	var presented int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented = len(r.TLS.PeerCertificates)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithTLSConfig(&tls.Config{
+			RootCAs:      pool,
+			Certificates: []tls.Certificate{newClientCertificate(t)},
+		}),
+	)
+	if _, err := client.Messages.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if presented != 1 {
+		t.Errorf("expected 1 client certificate, got %d", presented)
+	}
+}
+
+func TestWithTLSConfig_CopiesConfig(t *testing.T) {
+	config := &tls.Config{ServerName: "api.sendly.live"}
+	client := NewClient("test-api-key", WithTLSConfig(config))
+	config.ServerName = "changed.example.com"
+
+	transport := client.Config().HTTPClient().Transport.(*http.Transport)
+	if transport.TLSClientConfig.ServerName != "api.sendly.live" {
+		t.Errorf("expected ServerName to be 'api.sendly.live', got '%s'", transport.TLSClientConfig.ServerName)
+	}
+	if transport.TLSClientConfig == http.DefaultTransport.(*http.Transport).TLSClientConfig {
+		t.Error("expected the default transport not to be modified")
+	}
+}
+
+func TestWithTLSConfig_CombinesWithProxyAndTransportOptions(t *testing.T) {
+	client := NewClient("test-api-key",
+		WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS13}),
+		WithProxy("http://proxy.example.com:8080"),
+		WithTransportOptions(TransportOptions{MaxConnsPerHost: 8}),
+	)
+
+	transport := client.Config().HTTPClient().Transport.(*http.Transport)
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Error("expected the TLS config to be installed")
+	}
+	if transport.Proxy == nil {
+		t.Error("expected the proxy to be installed")
+	}
+	if transport.MaxConnsPerHost != 8 {
+		t.Errorf("expected MaxConnsPerHost to be 8, got %d", transport.MaxConnsPerHost)
+	}
+}
@@ -0,0 +1,135 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// tokenExpiryLeeway is how long before its expiry a cached token is
+// refreshed, so it does not expire while a request is in flight.
+const tokenExpiryLeeway = 30 * time.Second
+
+// Token is a short-lived access token.
+type Token struct {
+	// AccessToken is sent as the bearer token.
+	AccessToken string
+	// Expiry is when the token expires. A zero Expiry never expires.
+	Expiry time.Time
+}
+
+// TokenSource supplies access tokens, such as ones issued by an enterprise
+// SSO provider. The client caches each token until shortly before it
+// expires, so Token should fetch a new one on every call.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource.
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// Token calls f.
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// TokenSourceFromOAuth2 adapts an oauth2.TokenSource, such as one from
+// clientcredentials.Config.TokenSource, to a TokenSource:
+//
+//	conf := &clientcredentials.Config{ClientID: id, ClientSecret: secret, TokenURL: url}
+//	client := sendly.NewClient("", sendly.WithTokenSource(
+//		sendly.TokenSourceFromOAuth2(conf.TokenSource(ctx))))
+//
+// oauth2 token sources take their context when they are created, so the
+// context passed to Token is not used. Sources wrapped in
+// oauth2.ReuseTokenSource, as clientcredentials ones are, keep returning a
+// token until it expires and offer no way to force a refresh, so a request
+// rejected with 401 is not retried with this adapter: the call fails with
+// the *AuthenticationError.
+func TokenSourceFromOAuth2(src oauth2.TokenSource) TokenSource {
+	return oauth2Source{src}
+}
+
+// oauth2Source adapts an oauth2.TokenSource to a TokenSource.
+type oauth2Source struct {
+	src oauth2.TokenSource
+}
+
+// Token fetches a token from the underlying source.
+func (s oauth2Source) Token(ctx context.Context) (*Token, error) {
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	return &Token{AccessToken: token.AccessToken, Expiry: token.Expiry}, nil
+}
+
+// WithTokenSource authenticates with tokens from src instead of the API key
+// passed to NewClient. Tokens are refreshed shortly before they expire, and
+// a request the API rejects with 401 is retried once with a new token, in
+// case the old one was revoked early; see TokenSourceFromOAuth2 for the
+// exception. If src fails, the call returns a *TokenError.
+func WithTokenSource(src TokenSource) ClientOption {
+	return func(c *Client) {
+		c.tokens = &tokenCache{source: src}
+	}
+}
+
+// tokenCache holds the current token from a TokenSource.
+type tokenCache struct {
+	source TokenSource
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// get returns a valid access token, fetching a new one if the cached token
+// is missing or about to expire. Concurrent callers share one fetch.
+func (t *tokenCache) get(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.token != nil && (t.token.Expiry.IsZero() || now.Add(tokenExpiryLeeway).Before(t.token.Expiry)) {
+		return t.token.AccessToken, nil
+	}
+
+	token, err := t.source.Token(ctx)
+	if err == nil && (token == nil || token.AccessToken == "") {
+		err = errors.New("token source returned an empty token")
+	}
+	if err != nil {
+		return "", &TokenError{Err: err}
+	}
+	t.token = token
+	return token.AccessToken, nil
+}
+
+// reusesTokens reports whether the source may hand back a token the API
+// rejected, so retrying with a new token is pointless.
+func (t *tokenCache) reusesTokens() bool {
+	_, ok := t.source.(oauth2Source)
+	return ok
+}
+
+// invalidate drops the cached token if it is the one the API rejected in
+// err. A token fetched since, by another request rejected at the same time,
+// is kept.
+func (t *tokenCache) invalidate(err error) {
+	var rejected string
+	var authErr *AuthenticationError
+	if errors.As(err, &authErr) && authErr.response != nil && authErr.response.Request != nil {
+		rejected = strings.TrimPrefix(authErr.response.Request.Header.Get("Authorization"), "Bearer ")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != nil && (rejected == "" || t.token.AccessToken == rejected) {
+		t.token = nil
+	}
+}
@@ -0,0 +1,190 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// tokenServer accepts only the bearer tokens in valid.
+func tokenServer(t *testing.T, valid ...string) (*httptest.Server, func() []*http.Request) {
+	return newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		for _, token := range valid {
+			if r.Header.Get("Authorization") == "Bearer "+token {
+				w.Write([]byte(`{"id":"msg_1","status":"queued"}`))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"unauthorized","message":"Invalid token"}`))
+	})
+}
+
+// countingTokenSource issues tok_1, tok_2, ... each valid for ttl.
+type countingTokenSource struct {
+	mu    sync.Mutex
+	calls int
+	ttl   time.Duration
+}
+
+func (s *countingTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	return &Token{AccessToken: "tok_" + strconv.Itoa(s.calls), Expiry: time.Now().Add(s.ttl)}, nil
+}
+
+func TestWithTokenSource_CachesToken(t *testing.T) {
+	server, seen := tokenServer(t, "tok_1")
+	src := &countingTokenSource{ttl: time.Hour}
+	client := NewClient("", WithBaseURL(server.URL), WithTokenSource(src))
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Messages.Get(context.Background(), "msg_1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if src.calls != 1 {
+		t.Errorf("expected 1 token fetch, got %d", src.calls)
+	}
+	for _, r := range seen() {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer tok_1" {
+			t.Errorf("expected Bearer tok_1, got %q", auth)
+		}
+	}
+}
+
+func TestWithTokenSource_RefreshesBeforeExpiry(t *testing.T) {
+	server, _ := tokenServer(t, "tok_1", "tok_2")
+	// Tokens inside the expiry leeway are refreshed on every request.
+	src := &countingTokenSource{ttl: tokenExpiryLeeway / 2}
+	client := NewClient("", WithBaseURL(server.URL), WithTokenSource(src))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Messages.Get(context.Background(), "msg_1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if src.calls != 2 {
+		t.Errorf("expected 2 token fetches, got %d", src.calls)
+	}
+}
+
+func TestWithTokenSource_RetriesOnceOn401(t *testing.T) {
+	// tok_1 has been revoked, so the first request is rejected.
+	server, seen := tokenServer(t, "tok_2")
+	src := &countingTokenSource{ttl: time.Hour}
+	client := NewClient("", WithBaseURL(server.URL), WithTokenSource(src), WithMaxRetries(0))
+
+	msg, err := client.Messages.Send(context.Background(), &SendMessageRequest{To: "+15551234567", Text: "Hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.ID != "msg_1" {
+		t.Errorf("expected msg_1, got %s", msg.ID)
+	}
+
+	var got []string
+	for _, r := range seen() {
+		got = append(got, r.Header.Get("Authorization"))
+	}
+	if len(got) != 2 || got[0] != "Bearer tok_1" || got[1] != "Bearer tok_2" {
+		t.Errorf("expected tok_1 then tok_2, got %v", got)
+	}
+}
+
+func TestWithTokenSource_401AfterRefresh(t *testing.T) {
+	server, seen := tokenServer(t)
+	src := &countingTokenSource{ttl: time.Hour}
+	client := NewClient("", WithBaseURL(server.URL), WithTokenSource(src))
+
+	_, err := client.Messages.Get(context.Background(), "msg_1")
+	if !IsAuthenticationError(err) {
+		t.Fatalf("expected an authentication error, got %v", err)
+	}
+	if n := len(seen()); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestWithTokenSource_SourceError(t *testing.T) {
+	server, seen := tokenServer(t)
+	errIdP := errors.New("identity provider unavailable")
+	client := NewClient("", WithBaseURL(server.URL), WithTokenSource(TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		return nil, errIdP
+	})))
+
+	_, err := client.Messages.Get(context.Background(), "msg_1")
+	if !IsTokenError(err) {
+		t.Fatalf("expected a token error, got %v", err)
+	}
+	if !errors.Is(err, ErrUnauthorized) || !errors.Is(err, errIdP) {
+		t.Errorf("expected the error to match ErrUnauthorized and the source error, got %v", err)
+	}
+	if n := len(seen()); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+}
+
+func TestTokenSourceFromOAuth2(t *testing.T) {
+	server, seen := tokenServer(t, "oauth_tok")
+	expiry := time.Now().Add(time.Hour)
+	src := TokenSourceFromOAuth2(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "oauth_tok", Expiry: expiry}))
+	client := NewClient("", WithBaseURL(server.URL), WithTokenSource(src))
+
+	if _, err := client.Messages.Get(context.Background(), "msg_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := seen(); len(got) != 1 || got[0].Header.Get("Authorization") != "Bearer oauth_tok" {
+		t.Errorf("expected one request with Bearer oauth_tok, got %d", len(got))
+	}
+
+	token, err := src.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !token.Expiry.Equal(expiry) {
+		t.Errorf("expected Expiry %v, got %v", expiry, token.Expiry)
+	}
+}
+
+func TestTokenSourceFromOAuth2_Error(t *testing.T) {
+	src := TokenSourceFromOAuth2(oauth2.ReuseTokenSource(nil, failingOAuth2Source{}))
+	client := NewClient("", WithTokenSource(src))
+
+	_, err := client.Messages.Get(context.Background(), "msg_1")
+	var tokenErr *TokenError
+	if !errors.As(err, &tokenErr) {
+		t.Fatalf("expected a *TokenError, got %v", err)
+	}
+}
+
+// failingOAuth2Source is an oauth2.TokenSource that always fails.
+type failingOAuth2Source struct{}
+
+func (failingOAuth2Source) Token() (*oauth2.Token, error) {
+	return nil, errors.New("sso unavailable")
+}
+
+func TestTokenSourceFromOAuth2_No401Retry(t *testing.T) {
+	server, seen := tokenServer(t)
+	src := TokenSourceFromOAuth2(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "oauth_tok"}))
+	client := NewClient("", WithBaseURL(server.URL), WithTokenSource(src))
+
+	_, err := client.Messages.Get(context.Background(), "msg_1")
+	if !IsAuthenticationError(err) {
+		t.Fatalf("expected an authentication error, got %v", err)
+	}
+	if n := len(seen()); n != 1 {
+		t.Errorf("expected the rejected token not to be resent, got %d requests", n)
+	}
+}
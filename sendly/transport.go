@@ -0,0 +1,82 @@
+package sendly
+
+import (
+	"net/http"
+	"time"
+)
+
+// TransportOptions tunes connection reuse on the client's default transport.
+// Zero fields keep the net/http defaults.
+type TransportOptions struct {
+	// MaxIdleConns is the maximum number of idle keep-alive connections.
+	// Since the client talks to a single host, it also raises the per-host
+	// idle limit, which net/http otherwise caps at 2.
+	MaxIdleConns int
+	// MaxConnsPerHost limits the total connections to the API, including
+	// those in use. Requests beyond it wait for a free connection.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open.
+	IdleConnTimeout time.Duration
+	// ForceHTTP2 attempts HTTP/2 even when the transport has been given a
+	// custom dialer or TLS configuration.
+	ForceHTTP2 bool
+}
+
+// WithTransportOptions tunes keep-alive and connection pooling for
+// high-throughput senders, without building a custom *http.Client.
+//
+// Like WithProxy, the settings are applied to a copy of the client's
+// *http.Transport and are ignored if WithHTTPClient supplies a different
+// RoundTripper.
+func WithTransportOptions(opts TransportOptions) ClientOption {
+	return func(c *Client) {
+		c.transportOpts = &opts
+	}
+}
+
+// applyTransportOptions installs the configured pool settings on a copy of
+// the client's transport, leaving a caller-supplied *http.Client untouched.
+func (c *Client) applyTransportOptions() {
+	if c.transportOpts == nil {
+		return
+	}
+
+	opts := c.transportOpts
+	c.modifyTransport(func(transport *http.Transport) {
+		if opts.MaxIdleConns > 0 {
+			transport.MaxIdleConns = opts.MaxIdleConns
+			transport.MaxIdleConnsPerHost = opts.MaxIdleConns
+		}
+		if opts.MaxConnsPerHost > 0 {
+			transport.MaxConnsPerHost = opts.MaxConnsPerHost
+		}
+		if opts.IdleConnTimeout > 0 {
+			transport.IdleConnTimeout = opts.IdleConnTimeout
+		}
+		if opts.ForceHTTP2 {
+			transport.ForceAttemptHTTP2 = true
+		}
+	})
+}
+
+// modifyTransport applies fn to a copy of the client's *http.Transport and
+// installs the copy on a copy of its *http.Client, so neither
+// http.DefaultTransport nor a caller-supplied client is changed. It does
+// nothing if the client uses some other RoundTripper.
+func (c *Client) modifyTransport(fn func(*http.Transport)) {
+	base := c.HTTPClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return
+	}
+
+	transport = transport.Clone()
+	fn(transport)
+
+	httpClient := *c.HTTPClient
+	httpClient.Transport = transport
+	c.HTTPClient = &httpClient
+}
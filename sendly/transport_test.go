@@ -0,0 +1,87 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithTransportOptions(t *testing.T) {
+	client := NewClient("test-api-key", WithTransportOptions(TransportOptions{
+		MaxIdleConns:    200,
+		MaxConnsPerHost: 50,
+		IdleConnTimeout: 5 * time.Minute,
+		ForceHTTP2:      true,
+	}))
+
+	transport, ok := client.Config().HTTPClient().Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected an *http.Transport, got %T", client.Config().HTTPClient().Transport)
+	}
+	if transport.MaxIdleConns != 200 {
+		t.Errorf("expected MaxIdleConns to be 200, got %d", transport.MaxIdleConns)
+	}
+	if transport.MaxIdleConnsPerHost != 200 {
+		t.Errorf("expected MaxIdleConnsPerHost to be 200, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 50 {
+		t.Errorf("expected MaxConnsPerHost to be 50, got %d", transport.MaxConnsPerHost)
+	}
+	if transport.IdleConnTimeout != 5*time.Minute {
+		t.Errorf("expected IdleConnTimeout to be 5m, got %v", transport.IdleConnTimeout)
+	}
+	if !transport.ForceAttemptHTTP2 {
+		t.Error("expected ForceAttemptHTTP2 to be set")
+	}
+	if transport == http.DefaultTransport {
+		t.Error("expected the default transport to be copied, not modified")
+	}
+}
+
+func TestWithTransportOptions_ZeroKeepsDefaults(t *testing.T) {
+	client := NewClient("test-api-key", WithTransportOptions(TransportOptions{MaxConnsPerHost: 10}))
+
+	transport := client.Config().HTTPClient().Transport.(*http.Transport)
+	defaults := http.DefaultTransport.(*http.Transport)
+	if transport.MaxIdleConns != defaults.MaxIdleConns {
+		t.Errorf("expected MaxIdleConns to be %d, got %d", defaults.MaxIdleConns, transport.MaxIdleConns)
+	}
+	if transport.IdleConnTimeout != defaults.IdleConnTimeout {
+		t.Errorf("expected IdleConnTimeout to be %v, got %v", defaults.IdleConnTimeout, transport.IdleConnTimeout)
+	}
+}
+
+func TestWithTransportOptions_CustomHTTPClientUntouched(t *testing.T) {
+	custom := &http.Client{Transport: &http.Transport{MaxIdleConns: 3}}
+	client := NewClient("test-api-key",
+		WithHTTPClient(custom),
+		WithTransportOptions(TransportOptions{MaxIdleConns: 100}),
+	)
+
+	if custom.Transport.(*http.Transport).MaxIdleConns != 3 {
+		t.Error("expected the caller's transport not to be modified")
+	}
+	if client.Config().HTTPClient().Transport.(*http.Transport).MaxIdleConns != 100 {
+		t.Error("expected the client to use a tuned copy of the transport")
+	}
+}
+
+func TestWithTransportOptions_Requests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithTransportOptions(TransportOptions{MaxIdleConns: 10, MaxConnsPerHost: 1}),
+	)
+	for i := 0; i < 3; i++ {
+		if _, err := client.Messages.List(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
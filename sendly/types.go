@@ -1,9 +1,27 @@
 package sendly
 
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+)
+
 // Message represents an SMS message.
 type Message struct {
 	// ID is the unique message identifier.
 	ID string `json:"id"`
+	// ClientID is the caller-generated identifier from SendMessageRequest.ClientID.
+	ClientID string `json:"clientId,omitempty"`
+	// ThreadKey is the grouping key from SendMessageRequest.ThreadKey.
+	ThreadKey string `json:"threadKey,omitempty"`
+	// BatchID is the batch the message was sent in, if any.
+	BatchID string `json:"batchId,omitempty"`
+	// Metadata is the custom metadata from SendMessageRequest.Metadata.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// StatusCallbackURL is the endpoint this message's delivery webhooks go
+	// to, if it overrides the account's webhooks.
+	StatusCallbackURL string `json:"statusCallbackUrl,omitempty"`
 	// To is the recipient phone number in E.164 format.
 	To string `json:"to"`
 	// From is the sender ID or phone number.
@@ -32,8 +50,17 @@ type Message struct {
 	SenderNote *string `json:"senderNote,omitempty"`
 	// CreatedAt is when the message was created.
 	CreatedAt string `json:"createdAt,omitempty"`
+	// ScheduledAt is when a message deferred by a SendWindow will be sent.
+	ScheduledAt string `json:"scheduledAt,omitempty"`
 	// DeliveredAt is when the message was delivered (if applicable).
 	DeliveredAt *string `json:"deliveredAt,omitempty"`
+	// ExpiresAt is when the message's validity period lapses, if it has one.
+	ExpiresAt *string `json:"expiresAt,omitempty"`
+	// ExpiredAt is when the carrier gave up on the message because its
+	// validity period lapsed (status MessageStatusExpired).
+	ExpiredAt *string `json:"expiredAt,omitempty"`
+	// DryRun indicates a synthetic message from a dry run; nothing was sent.
+	DryRun bool `json:"-"`
 }
 
 // MessageStatus represents the status of a message.
@@ -48,6 +75,16 @@ const (
 	MessageStatusDelivered MessageStatus = "delivered"
 	// MessageStatusFailed means the message failed to deliver.
 	MessageStatusFailed MessageStatus = "failed"
+	// MessageStatusExpired means the message's validity period lapsed before
+	// it could be delivered.
+	MessageStatusExpired MessageStatus = "expired"
+	// MessageStatusAcceptedLocally means the message was queued on the client
+	// during an outage and has not reached the API yet. See WithDegradedMode.
+	MessageStatusAcceptedLocally MessageStatus = "accepted_locally"
+	// MessageStatusScheduled means the message was sent outside its
+	// SendWindow and was scheduled for when the window opens. Its ID is the
+	// scheduled message ID. See SendWindow.
+	MessageStatusScheduled MessageStatus = "scheduled"
 )
 
 // SenderType indicates how a message was sent.
@@ -80,6 +117,42 @@ type SendMessageRequest struct {
 	Text string `json:"text"`
 	// MessageType is the message type for compliance: "marketing" (default) or "transactional".
 	MessageType MessageType `json:"messageType,omitempty"`
+	// From is the sender ID or number to send from (default: chosen by the
+	// API). If it cannot be used, the API picks another and sets
+	// Message.Warning.
+	From string `json:"from,omitempty"`
+	// ClientID is an optional caller-generated identifier, such as one from
+	// NewClientID. It is echoed on the Message and on webhook events, so
+	// records written before the API responds can be joined with delivery events.
+	ClientID string `json:"clientId,omitempty"`
+	// ThreadKey groups related messages, such as every message about one
+	// order. Unlike metadata it is indexed, so ListMessagesRequest.ThreadKey
+	// can filter on it. At most MaxThreadKeyLength bytes.
+	ThreadKey string `json:"threadKey,omitempty"`
+	// Metadata is custom metadata, such as internal order or user IDs. It is
+	// returned on the Message and on webhook events. It is checked against
+	// the client's MetadataLimits before sending.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// StatusCallbackURL, if set, receives this message's delivery webhooks
+	// instead of the account's webhook endpoints. It must be HTTPS.
+	StatusCallbackURL string `json:"statusCallbackUrl,omitempty"`
+	// ValidityPeriod is how long carriers keep trying to deliver the message.
+	// Once it lapses the message is dropped with status MessageStatusExpired,
+	// so a one-time code is never delivered hours late. It is sent in whole
+	// seconds, rounded up, and may be at most MaxValidityPeriod. Zero uses
+	// the carrier default.
+	ValidityPeriod time.Duration `json:"-"`
+	// ShortenLinks replaces each URL in Text with a branded short link, so
+	// clicks are counted and sent as link.clicked webhook events. The links
+	// can be listed with Links.List filtered by the message ID.
+	ShortenLinks bool `json:"shortenLinks,omitempty"`
+	// DryRun validates and prices the message without sending it.
+	DryRun bool `json:"-"`
+	// SendWindow, if set, overrides the client's WithSendWindow for this
+	// message. A send the window defers is scheduled without ClientID,
+	// ThreadKey, ValidityPeriod or ShortenLinks, since scheduled messages
+	// do not support them: links in Text go out as written.
+	SendWindow *SendWindow `json:"-"`
 }
 
 // SendMessageResponse is the response from sending a message.
@@ -96,15 +169,23 @@ type ListMessagesRequest struct {
 	Status MessageStatus
 	// To filters by recipient phone number.
 	To string
+	// From filters by sender ID or phone number.
+	From string
+	// ThreadKey filters by SendMessageRequest.ThreadKey.
+	ThreadKey string
+	// BatchID filters to the messages sent in a batch.
+	BatchID string
+	// CreatedAfter, if set, only includes messages created at or after this time.
+	CreatedAfter time.Time
+	// CreatedBefore, if set, only includes messages created before this time.
+	CreatedBefore time.Time
 }
 
+// MaxThreadKeyLength is the maximum length of a thread key in bytes.
+const MaxThreadKeyLength = 128
+
 // ListMessagesResponse is the response from listing messages.
-type ListMessagesResponse struct {
-	// Data contains the list of messages.
-	Data []Message `json:"data"`
-	// Count is the total number of messages matching the query.
-	Count int `json:"count"`
-}
+type ListMessagesResponse = Page[Message]
 
 // APIError represents an error from the API.
 type APIError struct {
@@ -114,6 +195,35 @@ type APIError struct {
 	Message string `json:"message"`
 	// Details contains additional error details.
 	Details map[string]interface{} `json:"details,omitempty"`
+	// RequestID is the correlation ID of the failed request, from the
+	// X-Request-Id header or the response body. Include it in support tickets.
+	RequestID string `json:"requestId,omitempty"`
+	// StatusCode is the HTTP status of the response, or 0 for errors raised
+	// before a request was sent.
+	StatusCode int `json:"-"`
+	// RawBody is the undecoded response body. Phone numbers and message text
+	// in it, and in Message and Details, are masked according to the
+	// client's RedactionPolicy.
+	RawBody []byte `json:"-"`
+
+	response *http.Response
+}
+
+// Response returns the HTTP response that produced the error, with its body
+// replaced by RawBody, or nil for errors raised before a request was sent.
+func (e *APIError) Response() *http.Response {
+	if e.response == nil {
+		return nil
+	}
+	resp := *e.response
+	resp.Body = io.NopCloser(bytes.NewReader(e.RawBody))
+	return &resp
+}
+
+// apiError gives errors that embed APIError a common method, so the API
+// error can be recovered from any of them with errors.As.
+func (e *APIError) apiError() *APIError {
+	return e
 }
 
 // ScheduledMessageStatus represents the status of a scheduled message.
@@ -154,6 +264,15 @@ type ScheduledMessage struct {
 	CancelledAt *string `json:"cancelledAt,omitempty"`
 	// MessageID is the ID of the sent message (after sending).
 	MessageID *string `json:"messageId,omitempty"`
+	// IsSandbox indicates if the message was scheduled in sandbox mode.
+	IsSandbox bool `json:"isSandbox,omitempty"`
+	// Metadata is the custom metadata from ScheduleMessageRequest.Metadata.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// StatusCallbackURL is ScheduleMessageRequest.StatusCallbackURL.
+	StatusCallbackURL string `json:"statusCallbackUrl,omitempty"`
+	// DryRun indicates a synthetic scheduled message from a dry run; nothing
+	// was scheduled.
+	DryRun bool `json:"-"`
 }
 
 // ScheduleMessageRequest is the request to schedule a message.
@@ -162,12 +281,29 @@ type ScheduleMessageRequest struct {
 	To string `json:"to"`
 	// Text is the message content (required).
 	Text string `json:"text"`
-	// ScheduledAt is when to send the message in ISO 8601 format (required).
+	// ScheduledAt is when to send the message in ISO 8601 format. One of
+	// ScheduledAt and SendAt is required. ScheduledAtLocal sets it from a
+	// wall-clock time in a time zone.
 	ScheduledAt string `json:"scheduledAt"`
+	// SendAt is when to send the message, as an alternative to ScheduledAt.
+	// It is formatted as RFC 3339 when the request is made.
+	SendAt time.Time `json:"-"`
+	// SendAtRecipientLocalTime makes ScheduledAt a wall-clock time in the
+	// recipient's time zone, as found by TimezoneForNumber, such as
+	// "2025-03-14T09:00:00" for 9am wherever the recipient is. Any offset in
+	// ScheduledAt is ignored.
+	SendAtRecipientLocalTime bool `json:"-"`
 	// From is the sender ID or phone number (optional).
 	From string `json:"from,omitempty"`
 	// MessageType is the message type for compliance: "marketing" (default) or "transactional".
 	MessageType MessageType `json:"messageType,omitempty"`
+	// Metadata is custom metadata, carried over to the sent message.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// StatusCallbackURL, if set, receives the sent message's delivery
+	// webhooks instead of the account's webhook endpoints. It must be HTTPS.
+	StatusCallbackURL string `json:"statusCallbackUrl,omitempty"`
+	// DryRun validates and prices the message without scheduling it.
+	DryRun bool `json:"-"`
 }
 
 // ListScheduledMessagesRequest is the request to list scheduled messages.
@@ -181,12 +317,7 @@ type ListScheduledMessagesRequest struct {
 }
 
 // ListScheduledMessagesResponse is the response from listing scheduled messages.
-type ListScheduledMessagesResponse struct {
-	// Data contains the list of scheduled messages.
-	Data []ScheduledMessage `json:"data"`
-	// Count is the total number of scheduled messages.
-	Count int `json:"count"`
-}
+type ListScheduledMessagesResponse = Page[ScheduledMessage]
 
 // CancelScheduledMessageResponse is the response from cancelling a scheduled message.
 type CancelScheduledMessageResponse struct {
@@ -196,14 +327,25 @@ type CancelScheduledMessageResponse struct {
 	Status ScheduledMessageStatus `json:"status"`
 	// CreditsRefunded is the number of credits refunded.
 	CreditsRefunded int `json:"creditsRefunded"`
+	// IsSandbox indicates if the cancellation happened in sandbox mode.
+	IsSandbox bool `json:"isSandbox,omitempty"`
 }
 
 // BatchMessageItem represents a single message in a batch request.
 type BatchMessageItem struct {
 	// To is the recipient phone number in E.164 format (required).
 	To string `json:"to"`
-	// Text is the message content (required).
-	Text string `json:"text"`
+	// Text is the message content (required unless SendBatchRequest.TemplateID
+	// is set).
+	Text string `json:"text,omitempty"`
+	// Variables fill the {{name}} placeholders of SendBatchRequest.TemplateID
+	// for this recipient. They are only allowed with a template.
+	Variables map[string]string `json:"variables,omitempty"`
+	// Metadata is custom metadata for this message.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// StatusCallbackURL overrides SendBatchRequest.StatusCallbackURL for this
+	// message.
+	StatusCallbackURL string `json:"statusCallbackUrl,omitempty"`
 }
 
 // SendBatchRequest is the request to send batch messages.
@@ -212,8 +354,17 @@ type SendBatchRequest struct {
 	Messages []BatchMessageItem `json:"messages"`
 	// From is the sender ID or phone number (optional, applies to all).
 	From string `json:"from,omitempty"`
+	// TemplateID is a message template stored on the account. When set, the
+	// API renders it for each message with the item's Variables, and items
+	// must not set Text.
+	TemplateID string `json:"templateId,omitempty"`
 	// MessageType is the message type for compliance: "marketing" (default) or "transactional".
 	MessageType MessageType `json:"messageType,omitempty"`
+	// StatusCallbackURL, if set, receives delivery webhooks for every message
+	// in the batch instead of the account's webhook endpoints. It must be HTTPS.
+	StatusCallbackURL string `json:"statusCallbackUrl,omitempty"`
+	// DryRun validates and prices the batch without sending it.
+	DryRun bool `json:"-"`
 }
 
 // BatchStatus represents the status of a batch.
@@ -264,6 +415,70 @@ type BatchMessageResponse struct {
 	CreatedAt string `json:"createdAt,omitempty"`
 	// CompletedAt is when the batch completed.
 	CompletedAt *string `json:"completedAt,omitempty"`
+	// IsSandbox indicates if the batch was sent in sandbox mode.
+	IsSandbox bool `json:"isSandbox,omitempty"`
+	// DryRun indicates a synthetic batch from a dry run; nothing was sent.
+	DryRun bool `json:"-"`
+}
+
+// ListBatchesByDateRequest is the request to list every batch in a date range.
+type ListBatchesByDateRequest struct {
+	// Start is the beginning of the range (inclusive, required).
+	Start time.Time
+	// End is the end of the range (exclusive, default: now).
+	End time.Time
+	// Status filters by batch status.
+	Status BatchStatus
+	// Window is the initial size of each date partition (default: 24 hours).
+	// Partitions holding more than MaxListOffset batches are split further.
+	Window time.Duration
+	// PageSize is the number of batches fetched per request (default and max: 100).
+	PageSize int
+}
+
+// BatchDraftStatus represents the status of a draft batch.
+type BatchDraftStatus string
+
+const (
+	// BatchDraftStatusDraft means the draft is still accepting items.
+	BatchDraftStatusDraft BatchDraftStatus = "draft"
+	// BatchDraftStatusLaunched means the draft has been sent as a batch.
+	BatchDraftStatusLaunched BatchDraftStatus = "launched"
+)
+
+// CreateBatchDraftRequest is the request to create a draft batch.
+type CreateBatchDraftRequest struct {
+	// Messages is an optional first set of items for the draft.
+	Messages []BatchMessageItem `json:"messages,omitempty"`
+	// From is the sender ID used for every message when the draft launches.
+	From string `json:"from,omitempty"`
+	// MessageType is the message type for compliance: "marketing" (default) or "transactional".
+	MessageType MessageType `json:"messageType,omitempty"`
+}
+
+// addDraftItemsRequest is the body sent when appending items to a draft.
+type addDraftItemsRequest struct {
+	Messages []BatchMessageItem `json:"messages"`
+}
+
+// BatchDraft is a batch being assembled across several calls before launch.
+type BatchDraft struct {
+	// ID is the unique draft identifier.
+	ID string `json:"id"`
+	// Status is the draft status.
+	Status BatchDraftStatus `json:"status"`
+	// ItemCount is the number of items added so far.
+	ItemCount int `json:"itemCount"`
+	// From is the sender ID used when the draft launches.
+	From string `json:"from,omitempty"`
+	// MessageType is the message type used when the draft launches.
+	MessageType MessageType `json:"messageType,omitempty"`
+	// BatchID is the ID of the launched batch (if launched).
+	BatchID *string `json:"batchId,omitempty"`
+	// CreatedAt is when the draft was created.
+	CreatedAt string `json:"createdAt,omitempty"`
+	// UpdatedAt is when the draft was last changed.
+	UpdatedAt string `json:"updatedAt,omitempty"`
 }
 
 // ListBatchesRequest is the request to list batches.
@@ -274,15 +489,14 @@ type ListBatchesRequest struct {
 	Offset int
 	// Status filters by batch status.
 	Status BatchStatus
+	// CreatedAfter only includes batches created at or after this time (ISO 8601).
+	CreatedAfter string
+	// CreatedBefore only includes batches created before this time (ISO 8601).
+	CreatedBefore string
 }
 
 // ListBatchesResponse is the response from listing batches.
-type ListBatchesResponse struct {
-	// Data contains the list of batches.
-	Data []BatchMessageResponse `json:"data"`
-	// Count is the total number of batches.
-	Count int `json:"count"`
-}
+type ListBatchesResponse = Page[BatchMessageResponse]
 
 // BatchPreviewItem represents a single message in a batch preview.
 type BatchPreviewItem struct {
@@ -324,6 +538,78 @@ type BatchPreviewResponse struct {
 	Messages []BatchPreviewItem `json:"messages"`
 	// BlockReasons is a count of block reasons.
 	BlockReasons map[string]int `json:"blockReasons,omitempty"`
+	// ByCountry breaks the sendable messages down by destination country,
+	// most expensive first.
+	ByCountry []CountryCost `json:"byCountry,omitempty"`
+	// IsSandbox indicates if the preview was computed in sandbox mode.
+	IsSandbox bool `json:"isSandbox,omitempty"`
+}
+
+// CountryCost is the cost of a batch's messages to one destination country.
+type CountryCost struct {
+	// Country is the destination country code, or empty if unknown.
+	Country string `json:"country"`
+	// PricingTier is the pricing tier for the country.
+	PricingTier string `json:"pricingTier,omitempty"`
+	// Messages is the number of sendable messages to the country.
+	Messages int `json:"messages"`
+	// Segments is the total number of segments to the country.
+	Segments int `json:"segments"`
+	// Credits is the total credits needed for the country.
+	Credits int `json:"credits"`
+	// Share is the country's fraction of the batch's credits, from 0 to 1.
+	Share float64 `json:"share"`
+}
+
+// CreditsPerSegment returns the average credits charged per segment.
+func (c CountryCost) CreditsPerSegment() float64 {
+	if c.Segments == 0 {
+		return 0
+	}
+	return float64(c.Credits) / float64(c.Segments)
+}
+
+// TransactionStatus represents the outcome of a transactional send.
+type TransactionStatus string
+
+const (
+	// TransactionStatusAccepted means every message was accepted.
+	TransactionStatusAccepted TransactionStatus = "accepted"
+	// TransactionStatusPartial means the API could not honor atomicity and
+	// only some messages were accepted.
+	TransactionStatusPartial TransactionStatus = "partial"
+)
+
+// sendTransactionRequest is the request body for a transactional send.
+type sendTransactionRequest struct {
+	Messages []SendMessageRequest `json:"messages"`
+}
+
+// TransactionRejection describes a message that was not accepted.
+type TransactionRejection struct {
+	// Index is the position of the message in the request.
+	Index int `json:"index"`
+	// To is the recipient phone number.
+	To string `json:"to"`
+	// Error is the reason the message was rejected.
+	Error string `json:"error"`
+}
+
+// TransactionResponse is the response from an atomic multi-message send.
+type TransactionResponse struct {
+	// TransactionID is the unique transaction identifier.
+	TransactionID string `json:"transactionId"`
+	// Status is the transaction outcome.
+	Status TransactionStatus `json:"status"`
+	// Messages contains the accepted messages, in request order.
+	Messages []Message `json:"messages"`
+	// Rejected contains the messages that were not accepted, if any.
+	Rejected []TransactionRejection `json:"rejected,omitempty"`
+	// CreditsUsed is the total credits used.
+	CreditsUsed int `json:"creditsUsed"`
+	// DryRun indicates a synthetic transaction from a dry run; nothing was
+	// sent.
+	DryRun bool `json:"-"`
 }
 
 // ============================================================================
@@ -506,10 +792,27 @@ type Account struct {
 	Email string `json:"email"`
 	// Name is the display name.
 	Name *string `json:"name,omitempty"`
+	// NotificationEmail is where account notifications are sent, if it
+	// differs from Email.
+	NotificationEmail *string `json:"notificationEmail,omitempty"`
+	// Timezone is the IANA time zone used for reports and quiet hours.
+	Timezone string `json:"timezone,omitempty"`
 	// CreatedAt is when the account was created.
 	CreatedAt string `json:"createdAt"`
 }
 
+// UpdateAccountRequest is the request to update the account profile. Only
+// non-nil fields are changed.
+type UpdateAccountRequest struct {
+	// Name is the display name.
+	Name *string `json:"name,omitempty"`
+	// NotificationEmail is where account notifications are sent. Changing it
+	// may require confirming the address with StartEmailVerification.
+	NotificationEmail *string `json:"notificationEmail,omitempty"`
+	// Timezone is an IANA time zone name, such as "Europe/Berlin".
+	Timezone *string `json:"timezone,omitempty"`
+}
+
 // Credits represents credit balance information.
 type Credits struct {
 	// Balance is the available credit balance.
@@ -520,6 +823,16 @@ type Credits struct {
 	AvailableBalance int `json:"availableBalance"`
 }
 
+// AutoTopUpConfig configures automatic credit purchases when the balance runs low.
+type AutoTopUpConfig struct {
+	// Enabled turns automatic top-ups on or off.
+	Enabled bool `json:"enabled"`
+	// Threshold is the balance, in credits, below which a top-up is purchased.
+	Threshold int `json:"threshold"`
+	// Amount is the number of credits purchased per top-up.
+	Amount int `json:"amount"`
+}
+
 // TransactionType represents a credit transaction type.
 type TransactionType string
 
@@ -549,6 +862,41 @@ type CreditTransaction struct {
 	CreatedAt string `json:"createdAt"`
 }
 
+// VerificationChannel is how a contact verification code is delivered.
+type VerificationChannel string
+
+const (
+	// VerificationChannelEmail verifies an account notification email address.
+	VerificationChannelEmail VerificationChannel = "email"
+	// VerificationChannelSMS verifies a personal test phone number.
+	VerificationChannelSMS VerificationChannel = "sms"
+)
+
+// VerificationStatus represents the state of a contact verification.
+type VerificationStatus string
+
+const (
+	VerificationStatusPending  VerificationStatus = "pending"
+	VerificationStatusVerified VerificationStatus = "verified"
+	VerificationStatusExpired  VerificationStatus = "expired"
+)
+
+// ContactVerification represents a verification of an account contact.
+type ContactVerification struct {
+	// ID is the verification ID (ver_xxx).
+	ID string `json:"id"`
+	// Channel is how the code was delivered.
+	Channel VerificationChannel `json:"channel"`
+	// Target is the email address or phone number being verified.
+	Target string `json:"target"`
+	// Status is the verification status.
+	Status VerificationStatus `json:"status"`
+	// ExpiresAt is when the code stops being accepted.
+	ExpiresAt string `json:"expiresAt"`
+	// VerifiedAt is when the contact was verified.
+	VerifiedAt *string `json:"verifiedAt,omitempty"`
+}
+
 // APIKey represents an API key.
 type APIKey struct {
 	// ID is the key ID.
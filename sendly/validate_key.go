@@ -0,0 +1,39 @@
+package sendly
+
+import (
+	"context"
+	"time"
+)
+
+// ValidateKey checks the client's credentials with a cheap authenticated
+// call and returns the key's metadata, so applications can fail fast at
+// startup instead of on their first send:
+//
+//	key, err := client.ValidateKey(ctx)
+//	if err != nil {
+//		log.Fatalf("sendly: %v", err)
+//	}
+//	log.Printf("sendly: using %s key %s", key.Type, key.Name)
+//
+// A missing, rejected, revoked or expired key returns an
+// *AuthenticationError. Other failures, such as network errors, return the
+// usual error types.
+func (c *Client) ValidateKey(ctx context.Context) (*APIKey, error) {
+	if c.cfg.apiKey == "" && c.tokens == nil {
+		return nil, &AuthenticationError{APIError: APIError{Code: "MISSING_API_KEY", Message: "API key is required"}}
+	}
+
+	key, err := c.Account.GetCurrentAPIKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsRevoked {
+		return nil, &AuthenticationError{APIError: APIError{Code: "API_KEY_REVOKED", Message: "API key " + key.ID + " has been revoked"}}
+	}
+	if key.ExpiresAt != nil {
+		if expiresAt, err := time.Parse(time.RFC3339, *key.ExpiresAt); err == nil && !time.Now().Before(expiresAt) {
+			return nil, &AuthenticationError{APIError: APIError{Code: "API_KEY_EXPIRED", Message: "API key " + key.ID + " expired at " + *key.ExpiresAt}}
+		}
+	}
+	return key, nil
+}
@@ -0,0 +1,74 @@
+package sendly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientValidateKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/keys/current" {
+			t.Errorf("expected /keys/current, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"key_1","name":"Production","type":"live","prefix":"sk_live_v1_","last_four":"abcd","permissions":["messages:send"],"created_at":"2026-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_live_v1_abcd", WithBaseURL(server.URL))
+	key, err := client.ValidateKey(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.ID != "key_1" || key.Type != "live" || len(key.Permissions) != 1 {
+		t.Errorf("unexpected key: %+v", key)
+	}
+}
+
+func TestClientValidateKey_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"unauthorized","message":"Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("sk_live_v1_wrong", WithBaseURL(server.URL))
+	if _, err := client.ValidateKey(context.Background()); !IsAuthenticationError(err) {
+		t.Errorf("expected an authentication error, got %v", err)
+	}
+}
+
+func TestClientValidateKey_MissingKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request")
+	}))
+	defer server.Close()
+
+	client := NewClient("", WithBaseURL(server.URL))
+	if _, err := client.ValidateKey(context.Background()); !IsAuthenticationError(err) {
+		t.Errorf("expected an authentication error, got %v", err)
+	}
+}
+
+func TestClientValidateKey_RevokedOrExpired(t *testing.T) {
+	expired := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	tests := map[string]string{
+		"revoked": `{"id":"key_1","type":"live","is_revoked":true}`,
+		"expired": `{"id":"key_1","type":"live","expires_at":"` + expired + `"}`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			client := NewClient("sk_live_v1_abcd", WithBaseURL(server.URL))
+			if _, err := client.ValidateKey(context.Background()); !IsAuthenticationError(err) {
+				t.Errorf("expected an authentication error, got %v", err)
+			}
+		})
+	}
+}
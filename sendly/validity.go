@@ -0,0 +1,54 @@
+package sendly
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MaxValidityPeriod is the longest validity period a message may have.
+const MaxValidityPeriod = 72 * time.Hour
+
+// validateValidityPeriod checks SendMessageRequest.ValidityPeriod.
+func validateValidityPeriod(d time.Duration) error {
+	if d < 0 {
+		return &ValidationError{APIError: APIError{Message: "validityPeriod cannot be negative"}}
+	}
+	if d > MaxValidityPeriod {
+		return &ValidationError{APIError: APIError{Message: "validityPeriod must be at most " + MaxValidityPeriod.String()}}
+	}
+	return nil
+}
+
+// validitySeconds converts a validity period to whole seconds for the API,
+// rounding up so a message is never given less time than requested.
+func validitySeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
+
+// sendMessageRequestJSON is SendMessageRequest with the validity period in
+// seconds, as the API expects.
+type sendMessageRequestJSON struct {
+	sendMessageRequestAlias
+	ValidityPeriod int `json:"validityPeriod,omitempty"`
+}
+
+type sendMessageRequestAlias SendMessageRequest
+
+// MarshalJSON encodes ValidityPeriod as whole seconds.
+func (r SendMessageRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sendMessageRequestJSON{
+		sendMessageRequestAlias: sendMessageRequestAlias(r),
+		ValidityPeriod:          validitySeconds(r.ValidityPeriod),
+	})
+}
+
+// UnmarshalJSON decodes ValidityPeriod from whole seconds.
+func (r *SendMessageRequest) UnmarshalJSON(b []byte) error {
+	var v sendMessageRequestJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*r = SendMessageRequest(v.sendMessageRequestAlias)
+	r.ValidityPeriod = time.Duration(v.ValidityPeriod) * time.Second
+	return nil
+}
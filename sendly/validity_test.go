@@ -0,0 +1,94 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMessagesSend_ValidityPeriod(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["validityPeriod"] != float64(300) {
+			t.Errorf("expected validityPeriod to be 300, got %v", body["validityPeriod"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_123","status":"queued","expiresAt":"2030-01-01T00:05:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	msg, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+		To:             "+15551234567",
+		Text:           "Your code is 123456",
+		ValidityPeriod: 5 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.ExpiresAt == nil || *msg.ExpiresAt != "2030-01-01T00:05:00Z" {
+		t.Errorf("expected ExpiresAt to be '2030-01-01T00:05:00Z', got %v", msg.ExpiresAt)
+	}
+}
+
+func TestMessagesSend_ValidityPeriodOutOfRange(t *testing.T) {
+	client := NewClient("test-api-key")
+	for _, d := range []time.Duration{-time.Second, MaxValidityPeriod + time.Second} {
+		_, err := client.Messages.Send(context.Background(), &SendMessageRequest{
+			To:             "+15551234567",
+			Text:           "Hello",
+			ValidityPeriod: d,
+		})
+		if !IsValidationError(err) {
+			t.Errorf("expected ValidationError for %s, got %T", d, err)
+		}
+	}
+}
+
+func TestSendMessageRequest_ValidityPeriodJSON(t *testing.T) {
+	req := SendMessageRequest{To: "+15551234567", Text: "Hi", ValidityPeriod: 1500 * time.Millisecond}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded SendMessageRequest
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Sub-second periods round up so the message never gets less time.
+	if decoded.ValidityPeriod != 2*time.Second {
+		t.Errorf("expected ValidityPeriod to be 2s, got %s", decoded.ValidityPeriod)
+	}
+	if decoded.To != req.To || decoded.Text != req.Text {
+		t.Errorf("expected other fields to round-trip, got %+v", decoded)
+	}
+
+	b, _ = json.Marshal(SendMessageRequest{To: "+15551234567", Text: "Hi"})
+	var body map[string]interface{}
+	json.Unmarshal(b, &body)
+	if _, ok := body["validityPeriod"]; ok {
+		t.Error("expected validityPeriod to be omitted when zero")
+	}
+}
+
+func TestWebhookEvent_Expired(t *testing.T) {
+	payload := `{"id":"evt_1","type":"message.expired","data":{"message_id":"msg_1","status":"expired","expired_at":"2030-01-01T00:05:00Z"}}`
+
+	var event WebhookEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Type != WebhookEventMessageExpired || event.Data.Status != WebhookStatusExpired {
+		t.Errorf("expected an expired event, got %s/%s", event.Type, event.Data.Status)
+	}
+	if event.Data.ExpiredAt != "2030-01-01T00:05:00Z" {
+		t.Errorf("expected ExpiredAt to be '2030-01-01T00:05:00Z', got '%s'", event.Data.ExpiredAt)
+	}
+}
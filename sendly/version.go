@@ -0,0 +1,125 @@
+package sendly
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// sdkVersionHeader carries the SDK name and version on every request.
+	sdkVersionHeader = "X-Sendly-SDK-Version"
+	// minSDKHeader is set by the server to the minimum SDK version it supports.
+	minSDKHeader = "X-Sendly-Min-SDK"
+	// appInfoHeader identifies the application or plugin making requests.
+	appInfoHeader = "X-Sendly-App"
+)
+
+// VersionWarning describes a server notice that this SDK is older than the
+// minimum version the API supports.
+type VersionWarning struct {
+	// CurrentVersion is the version of this SDK.
+	CurrentVersion string
+	// MinimumVersion is the minimum version reported by the server.
+	MinimumVersion string
+}
+
+// WithUserAgent overrides the User-Agent header sent with every request.
+// The SDK version is still sent in the X-Sendly-SDK-Version header, and
+// WithAppInfo is still appended.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithAppInfo identifies the plugin or platform built on the SDK, so Sendly
+// can tell integrators' traffic apart. It is sent as "name/version (url)" in
+// the X-Sendly-App header and appended to the User-Agent. version and url
+// may be empty; an empty name is ignored.
+func WithAppInfo(name, version, url string) ClientOption {
+	return func(c *Client) {
+		if name == "" {
+			c.appInfo = ""
+			return
+		}
+		info := name
+		if version != "" {
+			info += "/" + version
+		}
+		if url != "" {
+			info += " (" + url + ")"
+		}
+		c.appInfo = info
+	}
+}
+
+// WithVersionWarning sets a callback invoked when the server reports a minimum
+// SDK version newer than this one. It is called at most once per minimum
+// version. Without a callback, the warning is written to the standard logger.
+func WithVersionWarning(fn func(VersionWarning)) ClientOption {
+	return func(c *Client) {
+		c.onVersionWarning = fn
+	}
+}
+
+// checkMinSDK inspects the response headers for a minimum SDK version.
+func (c *Client) checkMinSDK(header http.Header) {
+	minVersion := header.Get(minSDKHeader)
+	if minVersion == "" || compareVersions(Version, minVersion) >= 0 {
+		return
+	}
+
+	c.versionMu.Lock()
+	if c.warnedMinSDK == minVersion {
+		c.versionMu.Unlock()
+		return
+	}
+	c.warnedMinSDK = minVersion
+	c.versionMu.Unlock()
+
+	warning := VersionWarning{CurrentVersion: Version, MinimumVersion: minVersion}
+	if c.onVersionWarning != nil {
+		c.onVersionWarning(warning)
+		return
+	}
+	log.Printf("sendly: SDK version %s is below the minimum supported version %s; please upgrade", warning.CurrentVersion, warning.MinimumVersion)
+}
+
+// compareVersions compares two dotted version strings numerically, ignoring a
+// leading "v" and any pre-release or build suffix. It returns -1, 0, or 1.
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x < y {
+			return -1
+		}
+		if x > y {
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionParts parses "v1.2.3-beta" into [1 2 3].
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+
+	var parts []int
+	for _, s := range strings.Split(v, ".") {
+		n, _ := strconv.Atoi(s)
+		parts = append(parts, n)
+	}
+	return parts
+}
@@ -0,0 +1,144 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientRequest_SDKVersionHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := r.Header.Get("X-Sendly-SDK-Version"); v != "go/"+Version {
+			t.Errorf("expected X-Sendly-SDK-Version to be 'go/%s', got '%s'", Version, v)
+		}
+		if ua := r.Header.Get("User-Agent"); ua != "my-app/1.0" {
+			t.Errorf("expected User-Agent to be 'my-app/1.0', got '%s'", ua)
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithUserAgent("my-app/1.0"))
+
+	var result map[string]string
+	if err := client.request(context.Background(), "GET", "/test", nil, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientRequest_MinSDKWarning(t *testing.T) {
+	minVersion := "99.0.0"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Sendly-Min-SDK", minVersion)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	var warnings []VersionWarning
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithVersionWarning(func(w VersionWarning) {
+			warnings = append(warnings, w)
+		}),
+	)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		var result map[string]string
+		if err := client.request(ctx, "GET", "/test", nil, &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %d", len(warnings))
+	}
+	if warnings[0].MinimumVersion != "99.0.0" {
+		t.Errorf("expected MinimumVersion to be '99.0.0', got '%s'", warnings[0].MinimumVersion)
+	}
+	if warnings[0].CurrentVersion != Version {
+		t.Errorf("expected CurrentVersion to be '%s', got '%s'", Version, warnings[0].CurrentVersion)
+	}
+}
+
+func TestClientRequest_MinSDKSatisfied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Sendly-Min-SDK", "1.0.0")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	called := false
+	client := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithVersionWarning(func(VersionWarning) { called = true }),
+	)
+
+	var result map[string]string
+	if err := client.request(context.Background(), "GET", "/test", nil, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if called {
+		t.Error("expected no warning when SDK meets the minimum version")
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"3.8.1", "3.8.1", 0},
+		{"3.8.1", "3.9.0", -1},
+		{"3.10.0", "3.9.9", 1},
+		{"v3.8", "3.8.0", 0},
+		{"3.8.1-beta", "3.8.1", 0},
+		{"4", "3.99.99", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			if result := compareVersions(tt.a, tt.b); result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestWithAppInfo(t *testing.T) {
+	var userAgent, app string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		app = r.Header.Get("X-Sendly-App")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithAppInfo("shopify-sms", "2.1.0", "https://example.com/plugin"))
+	var result map[string]string
+	if err := client.request(context.Background(), "GET", "/test", nil, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "shopify-sms/2.1.0 (https://example.com/plugin)"
+	if app != want {
+		t.Errorf("expected X-Sendly-App %q, got %q", want, app)
+	}
+	if userAgent != "sendly-go/"+Version+" "+want {
+		t.Errorf("expected the app info to be appended to the User-Agent, got %q", userAgent)
+	}
+
+	// Cloning applies the options again without repeating the app info.
+	clone := client.Clone(WithUserAgent("my-app/1.0"))
+	if err := clone.request(context.Background(), "GET", "/test", nil, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if userAgent != "my-app/1.0 "+want {
+		t.Errorf("expected the app info once after a custom User-Agent, got %q", userAgent)
+	}
+}
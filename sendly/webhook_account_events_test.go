@@ -0,0 +1,61 @@
+package sendly
+
+import "testing"
+
+func parseTestEvent(t *testing.T, eventType, data string) *WebhookEvent {
+	t.Helper()
+	payload := `{"id":"evt_1","type":"` + eventType + `","created_at":"2024-06-01T00:00:00Z","api_version":"` + CurrentWebhookAPIVersion + `","data":` + data + `}`
+	event, err := Webhooks{}.ParseEvent(payload, Webhooks{}.GenerateSignature(payload, "whsec_test"), "whsec_test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return event
+}
+
+func TestWebhookEvent_LowBalanceData(t *testing.T) {
+	event := parseTestEvent(t, "credit.low_balance", `{"balance":42,"threshold":100,"auto_top_up_enabled":true,"occurred_at":"2024-06-01T00:00:00Z"}`)
+
+	data, err := event.LowBalanceData()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data.Balance != 42 || data.Threshold != 100 || !data.AutoTopUpEnabled {
+		t.Errorf("unexpected low balance data %+v", data)
+	}
+
+	if _, err := event.CreditPurchasedData(); err == nil {
+		t.Error("expected an error for a different event type")
+	}
+}
+
+func TestWebhookEvent_CreditPurchasedData(t *testing.T) {
+	event := parseTestEvent(t, "credit.purchased", `{"transaction_id":"txn_1","credits":500,"balance_after":542,"auto_top_up":true,"purchased_at":"2024-06-01T00:00:00Z"}`)
+
+	data, err := event.CreditPurchasedData()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data.TransactionID != "txn_1" || data.Credits != 500 || data.BalanceAfter != 542 || !data.AutoTopUp {
+		t.Errorf("unexpected purchase data %+v", data)
+	}
+
+	if _, err := event.KeyExpiringData(); err == nil {
+		t.Error("expected an error for a different event type")
+	}
+}
+
+func TestWebhookEvent_KeyExpiringData(t *testing.T) {
+	event := parseTestEvent(t, "key.expiring", `{"key_id":"key_1","name":"CI","type":"live","expires_at":"2024-06-08T00:00:00Z"}`)
+
+	data, err := event.KeyExpiringData()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data.KeyID != "key_1" || data.Name != "CI" || data.Type != APIKeyTypeLive || data.ExpiresAt != "2024-06-08T00:00:00Z" {
+		t.Errorf("unexpected key data %+v", data)
+	}
+
+	if _, err := event.LowBalanceData(); err == nil {
+		t.Error("expected an error for a different event type")
+	}
+}
@@ -0,0 +1,102 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultWebhookDedupeTTL is how long DedupeWebhookHandler remembers a
+// handled event when no TTL is given.
+const DefaultWebhookDedupeTTL = 72 * time.Hour
+
+// WebhookDedupeStore records which webhook events have been handled, keyed by
+// event ID. Share one store between processes, such as one backed by Redis,
+// to dedupe deliveries that reach different instances. Implementations must
+// be safe for concurrent use.
+type WebhookDedupeStore interface {
+	// Claim marks id as handled for ttl. It returns false if id is already
+	// claimed. With Redis this is SET id 1 NX PX ttl.
+	Claim(ctx context.Context, id string, ttl time.Duration) (bool, error)
+	// Release forgets a claim so the event can be handled again. With Redis
+	// this is DEL id.
+	Release(ctx context.Context, id string) error
+}
+
+// MemoryWebhookDedupeStore is an in-memory WebhookDedupeStore. Claims are
+// lost when the process exits and are not shared between processes.
+type MemoryWebhookDedupeStore struct {
+	mu        sync.Mutex
+	claims    map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryWebhookDedupeStore creates an empty in-memory store.
+func NewMemoryWebhookDedupeStore() *MemoryWebhookDedupeStore {
+	return &MemoryWebhookDedupeStore{claims: make(map[string]time.Time)}
+}
+
+// Claim marks id as handled for ttl, returning false if it already is.
+func (s *MemoryWebhookDedupeStore) Claim(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= time.Minute {
+		for k, expires := range s.claims {
+			if !now.Before(expires) {
+				delete(s.claims, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if expires, ok := s.claims[id]; ok && now.Before(expires) {
+		return false, nil
+	}
+	s.claims[id] = now.Add(ttl)
+	return true, nil
+}
+
+// Release forgets a claim.
+func (s *MemoryWebhookDedupeStore) Release(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.claims, id)
+	return nil
+}
+
+// DedupeWebhookHandler wraps fn so each event ID is handled once within ttl
+// (default: DefaultWebhookDedupeTTL), however many times Sendly delivers it.
+// A delivery of an event that is already claimed, including one still being
+// handled, returns nil without calling fn.
+//
+// The claim is released if fn fails, so the retried delivery runs fn again.
+// If the store cannot be reached the error is returned and fn is not called.
+func DedupeWebhookHandler(store WebhookDedupeStore, ttl time.Duration, fn WebhookHandlerFunc) WebhookHandlerFunc {
+	if ttl <= 0 {
+		ttl = DefaultWebhookDedupeTTL
+	}
+	return func(ctx context.Context, event *WebhookEvent) error {
+		if event.ID == "" {
+			return fn(ctx, event)
+		}
+
+		claimed, err := store.Claim(ctx, event.ID, ttl)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return nil
+		}
+
+		if err := fn(ctx, event); err != nil {
+			if releaseErr := store.Release(context.WithoutCancel(ctx), event.ID); releaseErr != nil {
+				return errors.Join(err, releaseErr)
+			}
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,124 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDedupeWebhookHandler_SkipsRedeliveries(t *testing.T) {
+	var calls int32
+	handler := DedupeWebhookHandler(NewMemoryWebhookDedupeStore(), 0, func(ctx context.Context, event *WebhookEvent) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	event := &WebhookEvent{ID: "evt_1", Type: WebhookEventMessageDelivered}
+	for i := 0; i < 3; i++ {
+		if err := handler(context.Background(), event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := handler(context.Background(), &WebhookEvent{ID: "evt_2", Type: WebhookEventMessageDelivered}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestDedupeWebhookHandler_Concurrent(t *testing.T) {
+	var calls int32
+	handler := DedupeWebhookHandler(NewMemoryWebhookDedupeStore(), time.Hour, func(ctx context.Context, event *WebhookEvent) error {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+
+	event := &WebhookEvent{ID: "evt_1", Type: WebhookEventMessageDelivered}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handler(context.Background(), event)
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestDedupeWebhookHandler_ReleasesOnError(t *testing.T) {
+	var calls int32
+	handler := DedupeWebhookHandler(NewMemoryWebhookDedupeStore(), time.Hour, func(ctx context.Context, event *WebhookEvent) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	event := &WebhookEvent{ID: "evt_1", Type: WebhookEventMessageDelivered}
+	if err := handler(context.Background(), event); err == nil {
+		t.Fatal("expected the handler error")
+	}
+	if err := handler(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := handler(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected the redelivery after the error to run, got %d calls", calls)
+	}
+}
+
+// failingDedupeStore is a WebhookDedupeStore that cannot be reached.
+type failingDedupeStore struct{}
+
+func (failingDedupeStore) Claim(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func (failingDedupeStore) Release(ctx context.Context, id string) error {
+	return errors.New("connection refused")
+}
+
+func TestDedupeWebhookHandler_StoreError(t *testing.T) {
+	called := false
+	handler := DedupeWebhookHandler(failingDedupeStore{}, 0, func(ctx context.Context, event *WebhookEvent) error {
+		called = true
+		return nil
+	})
+
+	err := handler(context.Background(), &WebhookEvent{ID: "evt_1", Type: WebhookEventMessageDelivered})
+	if err == nil {
+		t.Fatal("expected the store error")
+	}
+	if called {
+		t.Error("expected the handler not to be called")
+	}
+}
+
+func TestMemoryWebhookDedupeStore_Expiry(t *testing.T) {
+	store := NewMemoryWebhookDedupeStore()
+	ctx := context.Background()
+
+	if ok, _ := store.Claim(ctx, "evt_1", 20*time.Millisecond); !ok {
+		t.Fatal("expected the first claim to succeed")
+	}
+	if ok, _ := store.Claim(ctx, "evt_1", 20*time.Millisecond); ok {
+		t.Fatal("expected a second claim to fail")
+	}
+	time.Sleep(30 * time.Millisecond)
+	if ok, _ := store.Claim(ctx, "evt_1", 20*time.Millisecond); !ok {
+		t.Error("expected a claim after expiry to succeed")
+	}
+}
@@ -0,0 +1,153 @@
+package sendly
+
+import (
+	"context"
+	"sort"
+)
+
+// EnsureWebhookResult is the outcome of EnsureWebhook.
+type EnsureWebhookResult struct {
+	// Webhook is the endpoint as it now exists.
+	Webhook Webhook
+	// Created reports whether the endpoint was created by this call.
+	Created bool
+	// Updated reports whether an existing endpoint's settings were changed.
+	Updated bool
+	// Secret is the signing secret. It is only returned by the API when the
+	// endpoint is created, so it is empty if the endpoint already existed;
+	// use the secret stored from the first run, or RotateSecret.
+	Secret string
+}
+
+// EnsureWebhook makes sure an endpoint for req.URL exists with req's events,
+// description, and mode, creating or updating it as needed. It is safe to run
+// on every deploy: if concurrent runs both create the endpoint, the later
+// copies are deleted and every run returns the oldest.
+func EnsureWebhook(ctx context.Context, webhooks WebhooksAPI, req CreateWebhookRequest) (*EnsureWebhookResult, error) {
+	existing, err := findWebhook(ctx, webhooks, req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		created, err := webhooks.Create(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return settleCreatedWebhook(ctx, webhooks, created)
+	}
+
+	update, drifted := webhookDrift(existing, req)
+	if !drifted {
+		return &EnsureWebhookResult{Webhook: *existing}, nil
+	}
+	updated, err := webhooks.Update(ctx, existing.ID, update)
+	if err != nil {
+		return nil, err
+	}
+	return &EnsureWebhookResult{Webhook: *updated, Updated: true}, nil
+}
+
+// settleCreatedWebhook resolves a race with concurrent EnsureWebhook calls by
+// keeping the oldest endpoint for the URL and deleting the one just created
+// if it lost.
+func settleCreatedWebhook(ctx context.Context, webhooks WebhooksAPI, created *WebhookCreatedResponse) (*EnsureWebhookResult, error) {
+	oldest, err := findWebhook(ctx, webhooks, created.URL)
+	if err != nil {
+		return nil, err
+	}
+	if oldest == nil || oldest.ID == created.ID {
+		return &EnsureWebhookResult{Webhook: created.Webhook, Created: true, Secret: created.Secret}, nil
+	}
+
+	if err := webhooks.Delete(ctx, created.ID); err != nil && !IsNotFoundError(err) {
+		return nil, err
+	}
+	return &EnsureWebhookResult{Webhook: *oldest}, nil
+}
+
+// findWebhook returns the oldest endpoint registered for url, or nil.
+func findWebhook(ctx context.Context, webhooks WebhooksAPI, url string) (*Webhook, error) {
+	list, err := webhooks.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var oldest *Webhook
+	for i := range list {
+		wh := &list[i]
+		if wh.URL != url {
+			continue
+		}
+		if oldest == nil || wh.CreatedAt < oldest.CreatedAt ||
+			(wh.CreatedAt == oldest.CreatedAt && wh.ID < oldest.ID) {
+			oldest = wh
+		}
+	}
+	return oldest, nil
+}
+
+// webhookDrift returns the update that brings wh in line with req, and
+// whether one is needed.
+func webhookDrift(wh *Webhook, req CreateWebhookRequest) (UpdateWebhookRequest, bool) {
+	var update UpdateWebhookRequest
+	drifted := false
+
+	if !sameEvents(wh.Events, req.Events) {
+		update.Events = req.Events
+		drifted = true
+	}
+	current := ""
+	if wh.Description != nil {
+		current = *wh.Description
+	}
+	if req.Description != current {
+		description := req.Description
+		update.Description = &description
+		drifted = true
+	}
+	mode := req.Mode
+	if mode == "" {
+		mode = WebhookModeAll
+	}
+	if wh.Mode != mode {
+		update.Mode = &mode
+		drifted = true
+	}
+	if !wh.IsActive {
+		active := true
+		update.IsActive = &active
+		drifted = true
+	}
+	return update, drifted
+}
+
+// sameEvents compares event subscriptions ignoring order and duplicates.
+func sameEvents(a, b []string) bool {
+	return equalStrings(uniqueSorted(a), uniqueSorted(b))
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package sendly
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+// webhookStub is an in-memory WebhooksAPI. beforeCreate, if set, runs before
+// each Create to simulate a concurrent deploy.
+type webhookStub struct {
+	WebhooksAPI
+	webhooks     []Webhook
+	seq          int
+	updates      int
+	beforeCreate func()
+}
+
+func (s *webhookStub) add(url string, events []string) Webhook {
+	s.seq++
+	wh := Webhook{
+		ID:        "whk_" + strconv.Itoa(s.seq),
+		URL:       url,
+		Events:    events,
+		Mode:      WebhookModeAll,
+		IsActive:  true,
+		CreatedAt: "2024-01-01T00:00:0" + strconv.Itoa(s.seq) + "Z",
+	}
+	s.webhooks = append(s.webhooks, wh)
+	return wh
+}
+
+func (s *webhookStub) List(ctx context.Context) ([]Webhook, error) {
+	return append([]Webhook{}, s.webhooks...), nil
+}
+
+func (s *webhookStub) Create(ctx context.Context, req CreateWebhookRequest) (*WebhookCreatedResponse, error) {
+	if s.beforeCreate != nil {
+		s.beforeCreate()
+	}
+	wh := s.add(req.URL, req.Events)
+	return &WebhookCreatedResponse{Webhook: wh, Secret: "whsec_" + wh.ID}, nil
+}
+
+func (s *webhookStub) Update(ctx context.Context, webhookID string, req UpdateWebhookRequest) (*Webhook, error) {
+	s.updates++
+	for i := range s.webhooks {
+		if s.webhooks[i].ID == webhookID {
+			if req.Events != nil {
+				s.webhooks[i].Events = req.Events
+			}
+			if req.IsActive != nil {
+				s.webhooks[i].IsActive = *req.IsActive
+			}
+			wh := s.webhooks[i]
+			return &wh, nil
+		}
+	}
+	return nil, &NotFoundError{}
+}
+
+func (s *webhookStub) Delete(ctx context.Context, webhookID string) error {
+	for i := range s.webhooks {
+		if s.webhooks[i].ID == webhookID {
+			s.webhooks = append(s.webhooks[:i], s.webhooks[i+1:]...)
+			return nil
+		}
+	}
+	return &NotFoundError{}
+}
+
+func TestEnsureWebhook(t *testing.T) {
+	stub := &webhookStub{}
+	ctx := context.Background()
+	req := CreateWebhookRequest{
+		URL:    "https://example.com/webhooks",
+		Events: []string{"message.delivered", "message.failed"},
+	}
+
+	first, err := EnsureWebhook(ctx, stub, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !first.Created || first.Secret == "" {
+		t.Errorf("expected endpoint to be created with a secret, got %+v", first)
+	}
+
+	req.Events = []string{"message.failed", "message.delivered"}
+	second, err := EnsureWebhook(ctx, stub, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.Created || second.Updated || second.Webhook.ID != first.Webhook.ID {
+		t.Errorf("expected no changes when only event order differs, got %+v", second)
+	}
+
+	req.Events = append(req.Events, "message.sent")
+	third, err := EnsureWebhook(ctx, stub, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !third.Updated || len(third.Webhook.Events) != 3 {
+		t.Errorf("expected subscriptions to be updated, got %+v", third)
+	}
+	if len(stub.webhooks) != 1 {
+		t.Errorf("expected 1 endpoint, got %d", len(stub.webhooks))
+	}
+}
+
+func TestEnsureWebhook_ReactivatesDisabledEndpoint(t *testing.T) {
+	stub := &webhookStub{}
+	wh := stub.add("https://example.com/webhooks", []string{"message.delivered"})
+	stub.webhooks[0].IsActive = false
+
+	result, err := EnsureWebhook(context.Background(), stub, CreateWebhookRequest{
+		URL:    wh.URL,
+		Events: wh.Events,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Updated || !result.Webhook.IsActive {
+		t.Errorf("expected endpoint to be reactivated, got %+v", result)
+	}
+}
+
+func TestEnsureWebhook_ConcurrentCreate(t *testing.T) {
+	stub := &webhookStub{}
+	url := "https://example.com/webhooks"
+	var winner Webhook
+	stub.beforeCreate = func() {
+		// Another deploy registers the endpoint between our List and Create.
+		stub.beforeCreate = nil
+		winner = stub.add(url, []string{"message.delivered"})
+	}
+
+	result, err := EnsureWebhook(context.Background(), stub, CreateWebhookRequest{
+		URL:    url,
+		Events: []string{"message.delivered"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Created || result.Secret != "" {
+		t.Errorf("expected the losing run not to report a creation, got %+v", result)
+	}
+	if result.Webhook.ID != winner.ID {
+		t.Errorf("expected webhook '%s', got '%s'", winner.ID, result.Webhook.ID)
+	}
+	if len(stub.webhooks) != 1 {
+		t.Errorf("expected duplicate to be deleted, got %d endpoints", len(stub.webhooks))
+	}
+}
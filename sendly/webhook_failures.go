@@ -0,0 +1,73 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+)
+
+// FailedDeliveries returns deliveries that Sendly has stopped retrying: those
+// that failed with no retry scheduled. If webhookID is empty, every webhook
+// on the account is checked.
+func FailedDeliveries(ctx context.Context, webhooks WebhooksAPI, webhookID string) ([]WebhookDelivery, error) {
+	ids := []string{webhookID}
+	if webhookID == "" {
+		all, err := webhooks.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids = ids[:0]
+		for _, wh := range all {
+			ids = append(ids, wh.ID)
+		}
+	}
+
+	var failed []WebhookDelivery
+	for _, id := range ids {
+		deliveries, err := webhooks.GetDeliveries(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range deliveries {
+			if d.Status != DeliveryStatusFailed || d.NextRetryAt != nil {
+				continue
+			}
+			if d.WebhookID == "" {
+				d.WebhookID = id
+			}
+			failed = append(failed, d)
+		}
+	}
+	return failed, nil
+}
+
+// RequeueResult is the outcome of RequeueFailedDeliveries.
+type RequeueResult struct {
+	// Requeued lists the deliveries that were queued for another attempt.
+	Requeued []WebhookDelivery
+	// Failed lists the deliveries that could not be requeued.
+	Failed []WebhookDelivery
+}
+
+// RequeueFailedDeliveries finds deliveries Sendly has stopped retrying, as
+// FailedDeliveries does, and asks Sendly to retry each of them. Errors
+// requeueing individual deliveries are joined and returned along with the
+// result. Call it once an endpoint has recovered, or when a
+// webhook.delivery_failed event arrives, so events are not silently lost.
+func RequeueFailedDeliveries(ctx context.Context, webhooks WebhooksAPI, webhookID string) (*RequeueResult, error) {
+	failed, err := FailedDeliveries(ctx, webhooks, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RequeueResult{}
+	var errs []error
+	for _, d := range failed {
+		if err := webhooks.RetryDelivery(ctx, d.WebhookID, d.ID); err != nil {
+			result.Failed = append(result.Failed, d)
+			errs = append(errs, err)
+			continue
+		}
+		result.Requeued = append(result.Requeued, d)
+	}
+	return result, errors.Join(errs...)
+}
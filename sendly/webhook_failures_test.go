@@ -0,0 +1,78 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookEvent_DeliveryFailedData(t *testing.T) {
+	payload := `{"id":"evt_1","type":"webhook.delivery_failed","created_at":"2024-06-01T00:00:00Z","api_version":"` + CurrentWebhookAPIVersion + `",` +
+		`"data":{"webhook_id":"whk_1","webhook_url":"https://example.com/hook","delivery_id":"del_1","event_id":"evt_0","event_type":"message.delivered","attempts":6,"last_status_code":503,"failed_at":"2024-06-01T00:00:00Z"}}`
+
+	event, err := Webhooks{}.ParseEvent(payload, Webhooks{}.GenerateSignature(payload, "whsec_test"), "whsec_test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := event.DeliveryFailedData()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data.DeliveryID != "del_1" || data.Attempts != 6 {
+		t.Errorf("expected delivery del_1 after 6 attempts, got %+v", data)
+	}
+	if data.LastStatusCode == nil || *data.LastStatusCode != 503 {
+		t.Errorf("expected LastStatusCode to be 503, got %v", data.LastStatusCode)
+	}
+
+	event.Type = WebhookEventMessageDelivered
+	if _, err := event.DeliveryFailedData(); err == nil {
+		t.Error("expected an error for a different event type")
+	}
+}
+
+func TestRequeueFailedDeliveries(t *testing.T) {
+	var retried []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/webhooks":
+			w.Write([]byte(`[{"id":"whk_1","url":"https://a.example.com"},{"id":"whk_2","url":"https://b.example.com"}]`))
+		case "/webhooks/whk_1/deliveries":
+			w.Write([]byte(`[
+				{"id":"del_1","webhook_id":"whk_1","status":"failed"},
+				{"id":"del_2","webhook_id":"whk_1","status":"failed","next_retry_at":"2099-01-01T00:00:00Z"},
+				{"id":"del_3","webhook_id":"whk_1","status":"delivered"}
+			]`))
+		case "/webhooks/whk_2/deliveries":
+			w.Write([]byte(`[{"id":"del_4","status":"failed"}]`))
+		case "/webhooks/whk_1/deliveries/del_1/retry":
+			retried = append(retried, "del_1")
+			w.Write([]byte(`{}`))
+		case "/webhooks/whk_2/deliveries/del_4/retry":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not_found","message":"delivery not found"}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	result, err := RequeueFailedDeliveries(context.Background(), client.WebhooksService, "")
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for del_4, got %v", err)
+	}
+	if len(result.Requeued) != 1 || result.Requeued[0].ID != "del_1" {
+		t.Errorf("expected del_1 to be requeued, got %+v", result.Requeued)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].WebhookID != "whk_2" {
+		t.Errorf("expected del_4 on whk_2 to fail, got %+v", result.Failed)
+	}
+	if len(retried) != 1 {
+		t.Errorf("expected 1 retry request, got %d", len(retried))
+	}
+}
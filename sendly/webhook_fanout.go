@@ -0,0 +1,201 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DeadLetter is an event a consumer could not process within its attempts.
+type DeadLetter struct {
+	// Consumer is the name of the consumer that failed.
+	Consumer string
+	// Event is the event that could not be processed.
+	Event *WebhookEvent
+	// Attempts is the number of times the consumer was called.
+	Attempts int
+	// Err is the error from the last attempt.
+	Err error
+}
+
+// WebhookFanout verifies webhook deliveries and passes each event to every
+// subscribed consumer. Consumers run concurrently and retry independently, so
+// a failing consumer neither blocks nor re-triggers the others; events it
+// cannot process go to its dead-letter handler.
+type WebhookFanout struct {
+	secrets   []string
+	mu        sync.RWMutex
+	consumers []*fanoutConsumer
+	wg        sync.WaitGroup
+}
+
+// fanoutConsumer is one subscriber and its delivery policy.
+type fanoutConsumer struct {
+	name        string
+	handle      WebhookHandlerFunc
+	events      map[WebhookEventType]bool
+	maxAttempts int
+	backoff     time.Duration
+	deadLetter  func(DeadLetter)
+}
+
+// ConsumerOption configures a consumer subscribed to a WebhookFanout.
+type ConsumerOption func(*fanoutConsumer)
+
+// WithConsumerEvents limits a consumer to the given event types (default: all).
+func WithConsumerEvents(types ...WebhookEventType) ConsumerOption {
+	return func(c *fanoutConsumer) {
+		c.events = make(map[WebhookEventType]bool, len(types))
+		for _, t := range types {
+			c.events[t] = true
+		}
+	}
+}
+
+// WithConsumerRetry sets how many times a consumer is called for an event and
+// the backoff before the first retry, which doubles on each later retry
+// (default: 3 attempts, 1 second).
+func WithConsumerRetry(maxAttempts int, backoff time.Duration) ConsumerOption {
+	return func(c *fanoutConsumer) {
+		c.maxAttempts = maxAttempts
+		c.backoff = backoff
+	}
+}
+
+// WithConsumerDeadLetter sets a callback for events the consumer failed to
+// process after all attempts.
+func WithConsumerDeadLetter(fn func(DeadLetter)) ConsumerOption {
+	return func(c *fanoutConsumer) {
+		c.deadLetter = fn
+	}
+}
+
+// NewWebhookFanout creates a dispatcher that verifies deliveries with secret.
+func NewWebhookFanout(secret string) *WebhookFanout {
+	return &WebhookFanout{secrets: []string{secret}}
+}
+
+// SetSecrets replaces the secrets deliveries are verified with, as
+// WebhookProcessor.SetSecrets does.
+func (f *WebhookFanout) SetSecrets(current string, previous ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.secrets = append([]string{current}, previous...)
+}
+
+// Subscribe registers a named consumer.
+func (f *WebhookFanout) Subscribe(name string, fn WebhookHandlerFunc, opts ...ConsumerOption) {
+	c := &fanoutConsumer{
+		name:        name,
+		handle:      fn,
+		maxAttempts: 3,
+		backoff:     time.Second,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.maxAttempts < 1 {
+		c.maxAttempts = 1
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.consumers = append(f.consumers, c)
+}
+
+// Dispatch passes event to every interested consumer and waits until each has
+// either processed it or given up. It returns the errors of consumers that
+// gave up, joined, or nil if all succeeded.
+func (f *WebhookFanout) Dispatch(ctx context.Context, event *WebhookEvent) error {
+	f.mu.RLock()
+	consumers := make([]*fanoutConsumer, 0, len(f.consumers))
+	for _, c := range f.consumers {
+		if c.events == nil || c.events[event.Type] {
+			consumers = append(consumers, c)
+		}
+	}
+	f.mu.RUnlock()
+
+	errs := make([]error, len(consumers))
+	var wg sync.WaitGroup
+	for i, c := range consumers {
+		wg.Add(1)
+		go func(i int, c *fanoutConsumer) {
+			defer wg.Done()
+			errs[i] = c.deliver(ctx, event)
+		}(i, c)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// deliver calls the consumer until it succeeds or runs out of attempts, then
+// reports the event to the dead-letter handler.
+func (c *fanoutConsumer) deliver(ctx context.Context, event *WebhookEvent) error {
+	var err error
+	attempts := 0
+	for attempts < c.maxAttempts {
+		if attempts > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(c.backoff << uint(attempts-1)):
+			}
+			if ctx.Err() != nil {
+				err = ctx.Err()
+				break
+			}
+		}
+
+		attempts++
+		if err = c.handle(ctx, event); err == nil {
+			return nil
+		}
+	}
+
+	if c.deadLetter != nil {
+		c.deadLetter(DeadLetter{Consumer: c.name, Event: event, Attempts: attempts, Err: err})
+	}
+	return fmt.Errorf("consumer %s: %w", c.name, err)
+}
+
+// ServeHTTP verifies a webhook delivery, responds 200, and dispatches the
+// event in the background. Failures are handled per consumer through retries
+// and dead letters rather than Sendly redelivering to every consumer.
+func (f *WebhookFanout) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.RLock()
+	secrets := f.secrets
+	f.mu.RUnlock()
+
+	event, ok := readWebhookDelivery(w, r, secrets)
+	if !ok {
+		return
+	}
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		f.Dispatch(context.Background(), event)
+	}()
+	w.WriteHeader(http.StatusOK)
+}
+
+// Wait blocks until every event accepted by ServeHTTP has been dispatched,
+// or ctx is done.
+func (f *WebhookFanout) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		f.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
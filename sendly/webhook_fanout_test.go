@@ -0,0 +1,138 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookFanout_IndependentRetries(t *testing.T) {
+	f := NewWebhookFanout("secret")
+
+	var analyticsCalls, billingCalls int32
+	f.Subscribe("analytics", func(ctx context.Context, event *WebhookEvent) error {
+		atomic.AddInt32(&analyticsCalls, 1)
+		return nil
+	})
+	f.Subscribe("billing", func(ctx context.Context, event *WebhookEvent) error {
+		if atomic.AddInt32(&billingCalls, 1) < 3 {
+			return errors.New("billing unavailable")
+		}
+		return nil
+	}, WithConsumerRetry(3, time.Millisecond))
+
+	event := &WebhookEvent{ID: "evt_1", Type: WebhookEventMessageDelivered}
+	if err := f.Dispatch(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if analyticsCalls != 1 {
+		t.Errorf("expected analytics to be called once, got %d", analyticsCalls)
+	}
+	if billingCalls != 3 {
+		t.Errorf("expected billing to be called 3 times, got %d", billingCalls)
+	}
+}
+
+func TestWebhookFanout_DeadLetter(t *testing.T) {
+	f := NewWebhookFanout("secret")
+	boom := errors.New("boom")
+
+	var dead []DeadLetter
+	var notified int32
+	f.Subscribe("notifications", func(ctx context.Context, event *WebhookEvent) error {
+		return boom
+	}, WithConsumerRetry(2, time.Millisecond), WithConsumerDeadLetter(func(d DeadLetter) {
+		dead = append(dead, d)
+	}))
+	f.Subscribe("analytics", func(ctx context.Context, event *WebhookEvent) error {
+		atomic.AddInt32(&notified, 1)
+		return nil
+	})
+
+	err := f.Dispatch(context.Background(), &WebhookEvent{ID: "evt_1", Type: WebhookEventMessageFailed})
+	if !errors.Is(err, boom) {
+		t.Errorf("expected dispatch error to wrap the consumer error, got %v", err)
+	}
+	if len(dead) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(dead))
+	}
+	if dead[0].Consumer != "notifications" || dead[0].Attempts != 2 || dead[0].Event.ID != "evt_1" {
+		t.Errorf("unexpected dead letter: %+v", dead[0])
+	}
+	if notified != 1 {
+		t.Errorf("expected other consumers to be unaffected, got %d calls", notified)
+	}
+}
+
+func TestWebhookFanout_EventFilter(t *testing.T) {
+	f := NewWebhookFanout("secret")
+
+	var calls int32
+	f.Subscribe("billing", func(ctx context.Context, event *WebhookEvent) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}, WithConsumerEvents(WebhookEventMessageDelivered))
+
+	ctx := context.Background()
+	f.Dispatch(ctx, &WebhookEvent{ID: "evt_1", Type: WebhookEventMessageSent})
+	f.Dispatch(ctx, &WebhookEvent{ID: "evt_2", Type: WebhookEventMessageDelivered})
+
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestWebhookFanout_ServeHTTP(t *testing.T) {
+	f := NewWebhookFanout("secret")
+
+	var mu sync.Mutex
+	var received []string
+	f.Subscribe("analytics", func(ctx context.Context, event *WebhookEvent) error {
+		mu.Lock()
+		received = append(received, event.ID)
+		mu.Unlock()
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	f.ServeHTTP(rec, signedDelivery(t, WebhookEventMessageDelivered, "evt_1"))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+
+	bad := signedDelivery(t, WebhookEventMessageDelivered, "evt_2")
+	bad.Header.Set(WebhookSignatureHeader, "sha256=invalid")
+	rec = httptest.NewRecorder()
+	f.ServeHTTP(rec, bad)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rec.Code)
+	}
+
+	if err := f.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(received) != 1 || received[0] != "evt_1" {
+		t.Errorf("expected only evt_1 to be dispatched, got %v", received)
+	}
+}
+
+func TestWebhookFanout_SetSecrets(t *testing.T) {
+	f := NewWebhookFanout("new-secret")
+	f.Subscribe("analytics", func(ctx context.Context, event *WebhookEvent) error { return nil })
+
+	f.SetSecrets("new-secret", "secret")
+	rec := httptest.NewRecorder()
+	f.ServeHTTP(rec, signedDelivery(t, WebhookEventMessageDelivered, "evt_1"))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 with the previous secret, got %d", rec.Code)
+	}
+	if err := f.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package sendly
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// APIGatewayRequest is the part of an AWS API Gateway proxy event that
+// webhook verification needs. It decodes from both REST API (payload 1.0)
+// and HTTP API (payload 2.0) events, so a Lambda function can accept it
+// directly without depending on the AWS Lambda libraries.
+type APIGatewayRequest struct {
+	// Headers are the request headers. HTTP APIs lowercase their names.
+	Headers map[string]string `json:"headers"`
+	// MultiValueHeaders are the request headers of REST APIs with
+	// multi-value headers enabled.
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders,omitempty"`
+	// Body is the request body, base64-encoded if IsBase64Encoded is set.
+	Body string `json:"body"`
+	// IsBase64Encoded reports whether API Gateway base64-encoded Body.
+	IsBase64Encoded bool `json:"isBase64Encoded"`
+}
+
+// Header returns the first value of the named header, ignoring case.
+func (r APIGatewayRequest) Header(name string) string {
+	for k, v := range r.Headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	for k, v := range r.MultiValueHeaders {
+		if strings.EqualFold(k, name) && len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+// RawBody returns the request body as Sendly sent it, decoding it if API
+// Gateway base64-encoded it.
+func (r APIGatewayRequest) RawBody() (string, error) {
+	if !r.IsBase64Encoded {
+		return r.Body, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(r.Body)
+	if err != nil {
+		return "", errors.New("failed to decode base64 webhook body: " + err.Error())
+	}
+	return string(b), nil
+}
+
+// APIGatewayResponse is an AWS API Gateway proxy response.
+type APIGatewayResponse struct {
+	StatusCode      int               `json:"statusCode"`
+	Headers         map[string]string `json:"headers,omitempty"`
+	Body            string            `json:"body"`
+	IsBase64Encoded bool              `json:"isBase64Encoded"`
+}
+
+// ParseAPIGatewayEvent verifies and parses a webhook delivered through API
+// Gateway. The signature header is found regardless of its casing, and
+// base64-encoded bodies are decoded before the signature is checked. While
+// rotating the webhook secret, pass the old one as previous so deliveries
+// signed with it are still accepted.
+func (w Webhooks) ParseAPIGatewayEvent(req APIGatewayRequest, secret string, previous ...string) (*WebhookEvent, error) {
+	payload, err := req.RawBody()
+	if err != nil {
+		return nil, err
+	}
+	secrets := append([]string{secret}, previous...)
+	return w.ParseEventWithSecrets(payload, req.Header(WebhookSignatureHeader), secrets...)
+}
+
+// LambdaHandler returns an AWS Lambda handler for API Gateway webhook
+// deliveries, to pass to lambda.Start. It verifies each delivery with
+// secret, or any of previous while the secret is being rotated, and calls fn
+// with the event. It answers 401 if the signature is
+// invalid, 400 if the payload cannot be parsed, and 500 if fn fails, so
+// Sendly retries the delivery.
+//
+// Example:
+//
+//	func main() {
+//		lambda.Start(sendly.Webhooks{}.LambdaHandler(os.Getenv("SENDLY_WEBHOOK_SECRET"), handleEvent))
+//	}
+func (w Webhooks) LambdaHandler(secret string, fn WebhookHandlerFunc, previous ...string) func(ctx context.Context, req APIGatewayRequest) (APIGatewayResponse, error) {
+	return func(ctx context.Context, req APIGatewayRequest) (APIGatewayResponse, error) {
+		event, err := w.ParseAPIGatewayEvent(req, secret, previous...)
+		switch {
+		case errors.Is(err, ErrInvalidSignature):
+			return apiGatewayResponse(http.StatusUnauthorized), nil
+		case err != nil:
+			return apiGatewayResponse(http.StatusBadRequest), nil
+		}
+
+		if err := fn(ctx, event); err != nil {
+			return apiGatewayResponse(http.StatusInternalServerError), nil
+		}
+		return apiGatewayResponse(http.StatusOK), nil
+	}
+}
+
+// apiGatewayResponse returns a plain-text response with the status's text
+// as its body.
+func apiGatewayResponse(status int) APIGatewayResponse {
+	return APIGatewayResponse{
+		StatusCode: status,
+		Headers:    map[string]string{"Content-Type": "text/plain; charset=utf-8"},
+		Body:       http.StatusText(status),
+	}
+}
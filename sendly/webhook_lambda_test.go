@@ -0,0 +1,135 @@
+package sendly
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+const lambdaTestPayload = `{"id":"evt_1","type":"message.delivered","created_at":"2024-06-01T00:00:00Z","data":{"message_id":"msg_1","status":"delivered","to":"+15551234567"}}`
+
+func TestWebhooksParseAPIGatewayEvent(t *testing.T) {
+	signature := Webhooks{}.GenerateSignature(lambdaTestPayload, "whsec_test")
+
+	tests := []struct {
+		name string
+		req  APIGatewayRequest
+	}{
+		{
+			name: "REST API",
+			req:  APIGatewayRequest{Headers: map[string]string{"X-Sendly-Signature": signature}, Body: lambdaTestPayload},
+		},
+		{
+			name: "HTTP API lowercased headers",
+			req:  APIGatewayRequest{Headers: map[string]string{"x-sendly-signature": signature}, Body: lambdaTestPayload},
+		},
+		{
+			name: "multi-value headers",
+			req:  APIGatewayRequest{MultiValueHeaders: map[string][]string{"X-SENDLY-SIGNATURE": {signature}}, Body: lambdaTestPayload},
+		},
+		{
+			name: "base64 body",
+			req: APIGatewayRequest{
+				Headers:         map[string]string{"x-sendly-signature": signature},
+				Body:            base64.StdEncoding.EncodeToString([]byte(lambdaTestPayload)),
+				IsBase64Encoded: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := Webhooks{}.ParseAPIGatewayEvent(tt.req, "whsec_test")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.ID != "evt_1" {
+				t.Errorf("expected event evt_1, got %s", event.ID)
+			}
+		})
+	}
+}
+
+func TestWebhooksParseAPIGatewayEvent_DecodesFromJSON(t *testing.T) {
+	signature := Webhooks{}.GenerateSignature(lambdaTestPayload, "whsec_test")
+	raw, _ := json.Marshal(map[string]interface{}{
+		"version":         "2.0",
+		"routeKey":        "POST /webhooks",
+		"headers":         map[string]string{"x-sendly-signature": signature, "content-type": "application/json"},
+		"body":            base64.StdEncoding.EncodeToString([]byte(lambdaTestPayload)),
+		"isBase64Encoded": true,
+	})
+
+	var req APIGatewayRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	if _, err := (Webhooks{}).ParseAPIGatewayEvent(req, "whsec_test"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWebhooksLambdaHandler(t *testing.T) {
+	signature := Webhooks{}.GenerateSignature(lambdaTestPayload, "whsec_test")
+	handlerErr := errors.New("database unavailable")
+
+	tests := []struct {
+		name       string
+		req        APIGatewayRequest
+		err        error
+		wantStatus int
+		wantCalled bool
+	}{
+		{"valid", APIGatewayRequest{Headers: map[string]string{"x-sendly-signature": signature}, Body: lambdaTestPayload}, nil, http.StatusOK, true},
+		{"invalid signature", APIGatewayRequest{Headers: map[string]string{"x-sendly-signature": "sha256=bad"}, Body: lambdaTestPayload}, nil, http.StatusUnauthorized, false},
+		{"bad base64", APIGatewayRequest{Headers: map[string]string{"x-sendly-signature": signature}, Body: "%%%", IsBase64Encoded: true}, nil, http.StatusBadRequest, false},
+		{"handler error", APIGatewayRequest{Headers: map[string]string{"x-sendly-signature": signature}, Body: lambdaTestPayload}, handlerErr, http.StatusInternalServerError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := Webhooks{}.LambdaHandler("whsec_test", func(ctx context.Context, event *WebhookEvent) error {
+				called = true
+				return tt.err
+			})
+
+			resp, err := handler(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("expected handler called to be %v", tt.wantCalled)
+			}
+		})
+	}
+}
+
+func TestWebhooksLambdaHandler_PreviousSecret(t *testing.T) {
+	handler := Webhooks{}.LambdaHandler("whsec_new", func(ctx context.Context, event *WebhookEvent) error {
+		return nil
+	}, "whsec_old")
+
+	for secret, want := range map[string]int{
+		"whsec_new":   http.StatusOK,
+		"whsec_old":   http.StatusOK,
+		"whsec_other": http.StatusUnauthorized,
+	} {
+		signature := Webhooks{}.GenerateSignature(lambdaTestPayload, secret)
+		req := APIGatewayRequest{Headers: map[string]string{"x-sendly-signature": signature}, Body: lambdaTestPayload}
+
+		resp, err := handler(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("signed with %s: expected status %d, got %d", secret, want, resp.StatusCode)
+		}
+	}
+}
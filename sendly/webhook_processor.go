@@ -0,0 +1,490 @@
+package sendly
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrWebhookQueueFull is returned by Enqueue when the queue for an event type
+// has no room. ServeHTTP answers with 503 so Sendly retries the delivery.
+var ErrWebhookQueueFull = errors.New("webhook queue is full")
+
+// ErrWebhookProcessorClosed is returned by Enqueue after Shutdown.
+var ErrWebhookProcessorClosed = errors.New("webhook processor is shut down")
+
+// ErrWebhookProcessorNotStarted is returned by Enqueue before Start.
+var ErrWebhookProcessorNotStarted = errors.New("webhook processor is not started")
+
+// WebhookHandlerFunc processes a single webhook event.
+type WebhookHandlerFunc func(ctx context.Context, event *WebhookEvent) error
+
+// WebhookEventStore persists events a WebhookProcessor has accepted until
+// they are processed, so events acknowledged to Sendly survive a restart.
+// Implementations must be safe for concurrent use.
+type WebhookEventStore interface {
+	// Put records an accepted event. It is called before the delivery is
+	// acknowledged.
+	Put(ctx context.Context, event *WebhookEvent) error
+	// List returns the events not yet processed, oldest first.
+	List(ctx context.Context) ([]*WebhookEvent, error)
+	// Delete removes an event by ID once it has been processed or has run
+	// out of attempts.
+	Delete(ctx context.Context, id string) error
+}
+
+// WebhookProcessor verifies webhook deliveries and processes them
+// asynchronously, with a separate queue and worker pool per event type. This
+// lets slow or constrained handlers run serially while others fan out.
+//
+// Failed handlers are retried with backoff. With a WebhookEventStore, events
+// are processed at least once: an event acknowledged before a crash is
+// processed again when the next processor starts, so handlers must be
+// idempotent.
+//
+// Register handlers with Handle, then call Start before serving requests.
+type WebhookProcessor struct {
+	secrets        []string
+	handlers       map[WebhookEventType]WebhookHandlerFunc
+	concurrency    map[WebhookEventType]queueConfig
+	defaultConfig  queueConfig
+	onError        func(event *WebhookEvent, err error)
+	store          WebhookEventStore
+	maxAttempts    int
+	backoff        time.Duration
+	queues         map[WebhookEventType]*eventQueue
+	mu             sync.RWMutex
+	started        bool
+	closed         bool
+	wg             sync.WaitGroup
+	handlerCtx     context.Context
+	cancelHandlers context.CancelFunc
+}
+
+// queueConfig is the worker and buffer size of one event type's queue.
+type queueConfig struct {
+	workers   int
+	queueSize int
+}
+
+// eventQueue is the buffered channel and counters for one event type.
+type eventQueue struct {
+	workers int
+	events  chan *WebhookEvent
+
+	mu        sync.Mutex
+	inFlight  int
+	processed int64
+	failed    int64
+	dropped   int64
+	retried   int64
+}
+
+// WebhookQueueMetrics is a snapshot of one event type's queue.
+type WebhookQueueMetrics struct {
+	// Workers is the number of goroutines processing this event type.
+	Workers int
+	// Queued is the number of events waiting for a worker.
+	Queued int
+	// Capacity is the maximum number of events that can wait.
+	Capacity int
+	// InFlight is the number of events currently being handled.
+	InFlight int
+	// Processed is the number of events handled successfully.
+	Processed int64
+	// Failed is the number of events whose handler returned an error.
+	Failed int64
+	// Dropped is the number of events rejected because the queue was full.
+	Dropped int64
+	// Retried is the number of handler calls that were retries.
+	Retried int64
+}
+
+// WebhookProcessorOption configures a WebhookProcessor.
+type WebhookProcessorOption func(*WebhookProcessor)
+
+// WithEventConcurrency sets the number of workers and the queue size for one
+// event type. Use one worker to process an event type serially.
+func WithEventConcurrency(eventType WebhookEventType, workers, queueSize int) WebhookProcessorOption {
+	return func(p *WebhookProcessor) {
+		p.concurrency[eventType] = queueConfig{workers: workers, queueSize: queueSize}
+	}
+}
+
+// WithDefaultConcurrency sets the workers and queue size for event types not
+// configured with WithEventConcurrency (default: 4 workers, 100 queued events).
+func WithDefaultConcurrency(workers, queueSize int) WebhookProcessorOption {
+	return func(p *WebhookProcessor) {
+		p.defaultConfig = queueConfig{workers: workers, queueSize: queueSize}
+	}
+}
+
+// WithProcessorRetry sets how many times a handler is called for an event and
+// the backoff before the first retry, which doubles on each later retry
+// (default: 3 attempts, 1 second).
+func WithProcessorRetry(maxAttempts int, backoff time.Duration) WebhookProcessorOption {
+	return func(p *WebhookProcessor) {
+		p.maxAttempts = maxAttempts
+		p.backoff = backoff
+	}
+}
+
+// WithProcessorStore records accepted events in store until they are
+// processed, and replays the events left in it when Start is called.
+func WithProcessorStore(store WebhookEventStore) WebhookProcessorOption {
+	return func(p *WebhookProcessor) {
+		p.store = store
+	}
+}
+
+// WithProcessorErrorHandler sets a callback for events whose handler failed
+// on every attempt. It is also called with a nil event if the
+// WebhookEventStore cannot be read when the processor starts.
+func WithProcessorErrorHandler(fn func(event *WebhookEvent, err error)) WebhookProcessorOption {
+	return func(p *WebhookProcessor) {
+		p.onError = fn
+	}
+}
+
+// NewWebhookProcessor creates a processor that verifies deliveries with secret.
+func NewWebhookProcessor(secret string, opts ...WebhookProcessorOption) *WebhookProcessor {
+	p := &WebhookProcessor{
+		secrets:       []string{secret},
+		handlers:      make(map[WebhookEventType]WebhookHandlerFunc),
+		concurrency:   make(map[WebhookEventType]queueConfig),
+		defaultConfig: queueConfig{workers: 4, queueSize: 100},
+		queues:        make(map[WebhookEventType]*eventQueue),
+		maxAttempts:   3,
+		backoff:       time.Second,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.maxAttempts < 1 {
+		p.maxAttempts = 1
+	}
+	return p
+}
+
+// SetSecrets replaces the secrets deliveries are verified with. During a
+// secret rotation, pass the new secret and the old one until its grace
+// period ends, so no deliveries are rejected. It is safe to call while
+// serving requests.
+func (p *WebhookProcessor) SetSecrets(current string, previous ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.secrets = append([]string{current}, previous...)
+}
+
+// Handle registers fn for eventType. Handlers registered after Start get
+// their worker pool straight away; registering a type again after Start has
+// no effect.
+func (p *WebhookProcessor) Handle(eventType WebhookEventType, fn WebhookHandlerFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.started {
+		if _, ok := p.queues[eventType]; ok || p.closed {
+			return
+		}
+		p.handlers[eventType] = fn
+		p.startQueue(eventType, fn)
+		return
+	}
+	p.handlers[eventType] = fn
+}
+
+// Start launches the worker pools for every registered event type, and
+// replays any events left in the WebhookEventStore. Handlers receive a
+// context that is cancelled when Shutdown gives up waiting.
+func (p *WebhookProcessor) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.started {
+		return
+	}
+	p.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.handlerCtx = ctx
+	p.cancelHandlers = cancel
+
+	for eventType, fn := range p.handlers {
+		p.startQueue(eventType, fn)
+	}
+
+	if p.store != nil {
+		p.wg.Add(1)
+		go p.replay(ctx)
+	}
+}
+
+// startQueue creates eventType's queue and launches its workers. Callers
+// must hold p.mu for writing.
+func (p *WebhookProcessor) startQueue(eventType WebhookEventType, fn WebhookHandlerFunc) {
+	cfg, ok := p.concurrency[eventType]
+	if !ok {
+		cfg = p.defaultConfig
+	}
+	if cfg.workers < 1 {
+		cfg.workers = 1
+	}
+	if cfg.queueSize < 0 {
+		cfg.queueSize = 0
+	}
+
+	q := &eventQueue{workers: cfg.workers, events: make(chan *WebhookEvent, cfg.queueSize)}
+	p.queues[eventType] = q
+	for i := 0; i < cfg.workers; i++ {
+		p.wg.Add(1)
+		go p.work(p.handlerCtx, q, fn)
+	}
+}
+
+// replay queues the events left in the store, waiting for room in each
+// queue rather than dropping them.
+func (p *WebhookProcessor) replay(ctx context.Context) {
+	defer p.wg.Done()
+
+	events, err := p.store.List(ctx)
+	if err != nil {
+		if p.onError != nil {
+			p.onError(nil, err)
+		}
+		return
+	}
+	for _, event := range events {
+		if !p.requeue(ctx, event) {
+			return
+		}
+	}
+}
+
+// requeue adds a stored event to its queue, blocking while the queue is
+// full. It returns false once the processor is shut down.
+func (p *WebhookProcessor) requeue(ctx context.Context, event *WebhookEvent) bool {
+	for {
+		p.mu.RLock()
+		if p.closed {
+			p.mu.RUnlock()
+			return false
+		}
+		q, ok := p.queues[event.Type]
+		if !ok {
+			p.mu.RUnlock()
+			p.store.Delete(ctx, event.ID)
+			return true
+		}
+		select {
+		case q.events <- event:
+			p.mu.RUnlock()
+			return true
+		default:
+		}
+		p.mu.RUnlock()
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func (p *WebhookProcessor) work(ctx context.Context, q *eventQueue, fn WebhookHandlerFunc) {
+	defer p.wg.Done()
+
+	for event := range q.events {
+		q.mu.Lock()
+		q.inFlight++
+		q.mu.Unlock()
+
+		err := p.process(ctx, q, fn, event)
+
+		q.mu.Lock()
+		q.inFlight--
+		if err != nil {
+			q.failed++
+		} else {
+			q.processed++
+		}
+		q.mu.Unlock()
+
+		if err != nil && p.onError != nil {
+			p.onError(event, err)
+		}
+		if p.store != nil && ctx.Err() == nil {
+			p.store.Delete(ctx, event.ID)
+		}
+	}
+}
+
+// process calls fn until it succeeds or runs out of attempts, backing off
+// between attempts.
+func (p *WebhookProcessor) process(ctx context.Context, q *eventQueue, fn WebhookHandlerFunc, event *WebhookEvent) error {
+	var err error
+	for attempt := 0; attempt < p.maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(p.backoff << uint(attempt-1)):
+			}
+			q.mu.Lock()
+			q.retried++
+			q.mu.Unlock()
+		}
+		if err = fn(ctx, event); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// Enqueue adds a verified event to its type's queue without blocking, first
+// recording it in the WebhookEventStore if there is one. Events of a type no
+// handler is registered for are ignored. It returns
+// ErrWebhookProcessorNotStarted before Start, so the event is not lost.
+func (p *WebhookProcessor) Enqueue(event *WebhookEvent) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrWebhookProcessorClosed
+	}
+	if !p.started {
+		return ErrWebhookProcessorNotStarted
+	}
+	q, ok := p.queues[event.Type]
+	if !ok {
+		return nil
+	}
+	if p.store != nil {
+		if err := p.store.Put(context.Background(), event); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case q.events <- event:
+		return nil
+	default:
+		q.mu.Lock()
+		q.dropped++
+		q.mu.Unlock()
+		if p.store != nil {
+			p.store.Delete(context.Background(), event.ID)
+		}
+		return ErrWebhookQueueFull
+	}
+}
+
+// ServeHTTP verifies a webhook delivery and enqueues it. It responds 200 once
+// the event is queued, 401 for an invalid signature, and 503 when the
+// processor is not started or shut down, the queue is full, or the store
+// fails, so that Sendly retries later.
+func (p *WebhookProcessor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.RLock()
+	secrets := p.secrets
+	p.mu.RUnlock()
+
+	event, ok := readWebhookDelivery(w, r, secrets)
+	if !ok {
+		return
+	}
+
+	if err := p.Enqueue(event); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Metrics returns a snapshot of every event type's queue.
+func (p *WebhookProcessor) Metrics() map[WebhookEventType]WebhookQueueMetrics {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	metrics := make(map[WebhookEventType]WebhookQueueMetrics, len(p.queues))
+	for eventType, q := range p.queues {
+		q.mu.Lock()
+		metrics[eventType] = WebhookQueueMetrics{
+			Workers:   q.workers,
+			Queued:    len(q.events),
+			Capacity:  cap(q.events),
+			InFlight:  q.inFlight,
+			Processed: q.processed,
+			Failed:    q.failed,
+			Dropped:   q.dropped,
+			Retried:   q.retried,
+		}
+		q.mu.Unlock()
+	}
+	return metrics
+}
+
+// Shutdown stops accepting events and waits for queued events to be
+// processed. If ctx is done first, handler contexts are cancelled and
+// ctx.Err() is returned.
+func (p *WebhookProcessor) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	for _, q := range p.queues {
+		close(q.events)
+	}
+	cancel := p.cancelHandlers
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		if cancel != nil {
+			cancel()
+		}
+		return nil
+	case <-ctx.Done():
+		if cancel != nil {
+			cancel()
+		}
+		return ctx.Err()
+	}
+}
+
+// readWebhookDelivery verifies and parses a webhook request signed with any
+// of secrets. On failure it writes 405, 400, or 401 and returns false.
+func readWebhookDelivery(w http.ResponseWriter, r *http.Request, secrets []string) (*WebhookEvent, bool) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return nil, false
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return nil, false
+	}
+
+	event, err := Webhooks{}.ParseEventWithSecrets(string(body), r.Header.Get(WebhookSignatureHeader), secrets...)
+	if err != nil {
+		if errors.Is(err, ErrInvalidSignature) {
+			w.WriteHeader(http.StatusUnauthorized)
+		} else {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		return nil, false
+	}
+	return event, true
+}
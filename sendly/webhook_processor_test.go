@@ -0,0 +1,346 @@
+package sendly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func signedDelivery(t *testing.T, eventType WebhookEventType, id string) *http.Request {
+	payload, err := json.Marshal(map[string]interface{}{
+		"id":          id,
+		"type":        eventType,
+		"created_at":  "2024-01-01T00:00:00Z",
+		"api_version": CurrentWebhookAPIVersion,
+		"data":        map[string]interface{}{"message_id": "msg_" + id},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal payload: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(string(payload)))
+	req.Header.Set(WebhookSignatureHeader, Webhooks{}.GenerateSignature(string(payload), "secret"))
+	return req
+}
+
+// concurrencyProbe records the highest number of overlapping handler calls.
+type concurrencyProbe struct {
+	current int32
+	peak    int32
+	calls   int32
+}
+
+func (c *concurrencyProbe) handler(ctx context.Context, event *WebhookEvent) error {
+	n := atomic.AddInt32(&c.current, 1)
+	for {
+		peak := atomic.LoadInt32(&c.peak)
+		if n <= peak || atomic.CompareAndSwapInt32(&c.peak, peak, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	atomic.AddInt32(&c.current, -1)
+	atomic.AddInt32(&c.calls, 1)
+	return nil
+}
+
+func TestWebhookProcessor_PerTypeConcurrency(t *testing.T) {
+	var delivered, failed concurrencyProbe
+
+	p := NewWebhookProcessor("secret",
+		WithEventConcurrency(WebhookEventMessageDelivered, 8, 50),
+		WithEventConcurrency(WebhookEventMessageFailed, 1, 50),
+	)
+	p.Handle(WebhookEventMessageDelivered, delivered.handler)
+	p.Handle(WebhookEventMessageFailed, failed.handler)
+	p.Start()
+
+	for i := 0; i < 8; i++ {
+		id := string(rune('a' + i))
+		for _, eventType := range []WebhookEventType{WebhookEventMessageDelivered, WebhookEventMessageFailed} {
+			rec := httptest.NewRecorder()
+			p.ServeHTTP(rec, signedDelivery(t, eventType, id))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", rec.Code)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := p.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if failed.peak != 1 {
+		t.Errorf("expected message.failed to run serially, peak concurrency was %d", failed.peak)
+	}
+	if delivered.peak < 2 {
+		t.Errorf("expected message.delivered to run concurrently, peak concurrency was %d", delivered.peak)
+	}
+
+	metrics := p.Metrics()
+	if metrics[WebhookEventMessageDelivered].Processed != 8 {
+		t.Errorf("expected 8 processed delivered events, got %d", metrics[WebhookEventMessageDelivered].Processed)
+	}
+	if metrics[WebhookEventMessageFailed].Workers != 1 {
+		t.Errorf("expected 1 worker for failed events, got %d", metrics[WebhookEventMessageFailed].Workers)
+	}
+}
+
+func TestWebhookProcessor_QueueFull(t *testing.T) {
+	release := make(chan struct{})
+	var once sync.Once
+	started := make(chan struct{})
+
+	p := NewWebhookProcessor("secret", WithEventConcurrency(WebhookEventMessageSent, 1, 1))
+	p.Handle(WebhookEventMessageSent, func(ctx context.Context, event *WebhookEvent) error {
+		once.Do(func() { close(started) })
+		<-release
+		return nil
+	})
+	p.Start()
+	defer p.Shutdown(context.Background())
+	defer close(release)
+
+	codes := make([]int, 0, 3)
+	for i, id := range []string{"1", "2", "3"} {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, signedDelivery(t, WebhookEventMessageSent, id))
+		codes = append(codes, rec.Code)
+		if i == 0 {
+			<-started
+		}
+	}
+
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK {
+		t.Errorf("expected first two deliveries to be accepted, got %v", codes)
+	}
+	if codes[2] != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503 when the queue is full, got %d", codes[2])
+	}
+	if p.Metrics()[WebhookEventMessageSent].Dropped != 1 {
+		t.Errorf("expected 1 dropped event, got %d", p.Metrics()[WebhookEventMessageSent].Dropped)
+	}
+}
+
+func TestWebhookProcessor_InvalidSignature(t *testing.T) {
+	p := NewWebhookProcessor("other-secret")
+	p.Start()
+	defer p.Shutdown(context.Background())
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, signedDelivery(t, WebhookEventMessageSent, "1"))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", rec.Code)
+	}
+}
+
+func TestWebhookProcessor_SetSecrets(t *testing.T) {
+	var calls int32
+	p := NewWebhookProcessor("other-secret")
+	p.Handle(WebhookEventMessageSent, func(ctx context.Context, event *WebhookEvent) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+	p.Start()
+	defer p.Shutdown(context.Background())
+
+	// Deliveries are still signed with "secret" while it rotates out.
+	p.SetSecrets("other-secret", "secret")
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, signedDelivery(t, WebhookEventMessageSent, "1"))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200 with the previous secret, got %d", rec.Code)
+	}
+
+	p.SetSecrets("other-secret")
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, signedDelivery(t, WebhookEventMessageSent, "2"))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 once the previous secret is dropped, got %d", rec.Code)
+	}
+}
+
+// memoryEventStore is an in-memory WebhookEventStore.
+type memoryEventStore struct {
+	mu     sync.Mutex
+	events []*WebhookEvent
+}
+
+func (s *memoryEventStore) Put(ctx context.Context, event *WebhookEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *memoryEventStore) List(ctx context.Context) ([]*WebhookEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*WebhookEvent(nil), s.events...), nil
+}
+
+func (s *memoryEventStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.events {
+		if e.ID == id {
+			s.events = append(s.events[:i], s.events[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *memoryEventStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.events)
+}
+
+func TestWebhookProcessor_Retry(t *testing.T) {
+	var calls int32
+	var failedEvent *WebhookEvent
+	var mu sync.Mutex
+	p := NewWebhookProcessor("secret",
+		WithProcessorRetry(3, time.Millisecond),
+		WithProcessorErrorHandler(func(event *WebhookEvent, err error) {
+			mu.Lock()
+			failedEvent = event
+			mu.Unlock()
+		}),
+	)
+	p.Handle(WebhookEventMessageSent, func(ctx context.Context, event *WebhookEvent) error {
+		if event.ID == "flaky" && atomic.AddInt32(&calls, 1) < 3 {
+			return errors.New("temporarily unavailable")
+		}
+		if event.ID == "broken" {
+			return errors.New("always fails")
+		}
+		return nil
+	})
+	p.Start()
+
+	for _, id := range []string{"flaky", "broken"} {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, signedDelivery(t, WebhookEventMessageSent, id))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m := p.Metrics()[WebhookEventMessageSent]
+	if m.Processed != 1 || m.Failed != 1 || m.Retried != 4 {
+		t.Errorf("expected 1 processed, 1 failed and 4 retries, got %+v", m)
+	}
+	if failedEvent == nil || failedEvent.ID != "broken" {
+		t.Errorf("expected the error handler to get the broken event, got %+v", failedEvent)
+	}
+}
+
+func TestWebhookProcessor_StoreReplay(t *testing.T) {
+	store := &memoryEventStore{}
+
+	// The first processor acknowledges an event, then stops before its
+	// handler finishes.
+	block := make(chan struct{})
+	first := NewWebhookProcessor("secret", WithProcessorStore(store))
+	first.Handle(WebhookEventMessageSent, func(ctx context.Context, event *WebhookEvent) error {
+		select {
+		case <-block:
+		case <-ctx.Done():
+		}
+		return ctx.Err()
+	})
+	first.Start()
+	rec := httptest.NewRecorder()
+	first.ServeHTTP(rec, signedDelivery(t, WebhookEventMessageSent, "evt_1"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	first.Shutdown(ctx)
+
+	if store.len() != 1 {
+		t.Fatalf("expected the unprocessed event to stay in the store, got %d", store.len())
+	}
+
+	var processed []string
+	var mu sync.Mutex
+	second := NewWebhookProcessor("secret", WithProcessorStore(store))
+	second.Handle(WebhookEventMessageSent, func(ctx context.Context, event *WebhookEvent) error {
+		mu.Lock()
+		processed = append(processed, event.ID)
+		mu.Unlock()
+		return nil
+	})
+	second.Start()
+	deadline := time.Now().Add(time.Second)
+	for store.len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	second.Shutdown(context.Background())
+
+	if len(processed) != 1 || processed[0] != "evt_1" {
+		t.Errorf("expected evt_1 to be replayed, got %v", processed)
+	}
+	if store.len() != 0 {
+		t.Errorf("expected the store to be empty, got %d", store.len())
+	}
+}
+
+func TestWebhookProcessor_NotStarted(t *testing.T) {
+	p := NewWebhookProcessor("secret")
+	p.Handle(WebhookEventMessageSent, func(ctx context.Context, event *WebhookEvent) error {
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, signedDelivery(t, WebhookEventMessageSent, "evt_1"))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503 before Start, got %d", rec.Code)
+	}
+	if err := p.Enqueue(&WebhookEvent{ID: "evt_2", Type: WebhookEventMessageSent}); !errors.Is(err, ErrWebhookProcessorNotStarted) {
+		t.Errorf("expected ErrWebhookProcessorNotStarted, got %v", err)
+	}
+}
+
+func TestWebhookProcessor_HandleAfterStart(t *testing.T) {
+	p := NewWebhookProcessor("secret")
+	p.Start()
+
+	// Types with no handler are acknowledged and ignored.
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, signedDelivery(t, WebhookEventMessageSent, "evt_1"))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200 for an unhandled type, got %d", rec.Code)
+	}
+
+	var handled int32
+	p.Handle(WebhookEventMessageSent, func(ctx context.Context, event *WebhookEvent) error {
+		atomic.AddInt32(&handled, 1)
+		return nil
+	})
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, signedDelivery(t, WebhookEventMessageSent, "evt_2"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handled != 1 {
+		t.Errorf("expected the handler registered after Start to run once, got %d", handled)
+	}
+}
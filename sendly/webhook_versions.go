@@ -0,0 +1,99 @@
+package sendly
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// CurrentWebhookAPIVersion is the webhook payload version the WebhookEvent
+// structs describe. ParseEvent migrates older payloads to this version.
+const CurrentWebhookAPIVersion = "2024-01-01"
+
+// webhookMigration upgrades a decoded webhook payload from one API version to
+// the next. migrate edits the payload in place; the api_version field is
+// updated by the caller.
+type webhookMigration struct {
+	fromVersion string
+	toVersion   string
+	migrate     func(payload map[string]interface{}) error
+}
+
+// webhookMigrations lists every known upgrade step, oldest first.
+var webhookMigrations = []webhookMigration{
+	{fromVersion: "2023-06-01", toVersion: "2024-01-01", migrate: migrateWebhook20230601},
+}
+
+// MigrateWebhookPayload rewrites a raw webhook payload into the shape of
+// CurrentWebhookAPIVersion by applying each migration step in turn. Payloads
+// without an api_version, or already at or beyond the current version, are
+// returned unchanged.
+func MigrateWebhookPayload(payload []byte) ([]byte, error) {
+	var event map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&event); err != nil {
+		return nil, err
+	}
+
+	version, _ := event["api_version"].(string)
+	if version == "" || version >= CurrentWebhookAPIVersion {
+		return payload, nil
+	}
+
+	for version < CurrentWebhookAPIVersion {
+		migration, ok := findWebhookMigration(version)
+		if !ok {
+			return nil, fmt.Errorf("no webhook migration from api_version %q", version)
+		}
+		if err := migration.migrate(event); err != nil {
+			return nil, fmt.Errorf("failed to migrate webhook payload from %s to %s: %w", migration.fromVersion, migration.toVersion, err)
+		}
+		version = migration.toVersion
+		event["api_version"] = version
+	}
+
+	return json.Marshal(event)
+}
+
+func findWebhookMigration(version string) (webhookMigration, bool) {
+	for _, m := range webhookMigrations {
+		if m.fromVersion == version {
+			return m, true
+		}
+	}
+	return webhookMigration{}, false
+}
+
+// migrateWebhook20230601 upgrades 2023-06-01 payloads, which used "event" and
+// "timestamp" at the top level and camelCase keys in data.
+func migrateWebhook20230601(payload map[string]interface{}) error {
+	renameKey(payload, "event", "type")
+	renameKey(payload, "timestamp", "created_at")
+
+	data, ok := payload["data"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	renameKey(data, "messageId", "message_id")
+	renameKey(data, "scheduledMessageId", "scheduled_message_id")
+	renameKey(data, "errorCode", "error_code")
+	renameKey(data, "deliveredAt", "delivered_at")
+	renameKey(data, "failedAt", "failed_at")
+	renameKey(data, "creditsUsed", "credits_used")
+	renameKey(data, "scheduledAt", "scheduled_at")
+	renameKey(data, "cancelDeadline", "cancel_deadline")
+	return nil
+}
+
+// renameKey moves m[from] to m[to] unless m[to] is already set.
+func renameKey(m map[string]interface{}, from, to string) {
+	v, ok := m[from]
+	if !ok {
+		return
+	}
+	delete(m, from)
+	if _, exists := m[to]; !exists {
+		m[to] = v
+	}
+}
@@ -0,0 +1,55 @@
+package sendly
+
+import (
+	"testing"
+)
+
+func TestParseEvent_MigratesLegacyPayload(t *testing.T) {
+	payload := `{"id":"evt_1","event":"message.delivered","timestamp":"2023-07-01T00:00:00Z","api_version":"2023-06-01",` +
+		`"data":{"messageId":"msg_1","status":"delivered","to":"+15551234567","creditsUsed":2,"deliveredAt":"2023-07-01T00:00:05Z"}}`
+	signature := Webhooks{}.GenerateSignature(payload, "secret")
+
+	event, err := Webhooks{}.ParseEvent(payload, signature, "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if event.Type != WebhookEventMessageDelivered {
+		t.Errorf("expected Type to be 'message.delivered', got '%s'", event.Type)
+	}
+	if event.CreatedAt != "2023-07-01T00:00:00Z" {
+		t.Errorf("expected CreatedAt to be set, got '%s'", event.CreatedAt)
+	}
+	if event.APIVersion != CurrentWebhookAPIVersion {
+		t.Errorf("expected APIVersion to be '%s', got '%s'", CurrentWebhookAPIVersion, event.APIVersion)
+	}
+	if event.Data.MessageID != "msg_1" {
+		t.Errorf("expected MessageID to be 'msg_1', got '%s'", event.Data.MessageID)
+	}
+	if event.Data.CreditsUsed != 2 {
+		t.Errorf("expected CreditsUsed to be 2, got %d", event.Data.CreditsUsed)
+	}
+	if event.Data.DeliveredAt != "2023-07-01T00:00:05Z" {
+		t.Errorf("expected DeliveredAt to be set, got '%s'", event.Data.DeliveredAt)
+	}
+}
+
+func TestMigrateWebhookPayload_CurrentUnchanged(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"message.sent","created_at":"2024-01-01T00:00:00Z","api_version":"2024-01-01","data":{}}`)
+
+	migrated, err := MigrateWebhookPayload(payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(migrated) != string(payload) {
+		t.Errorf("expected payload to be unchanged, got %s", migrated)
+	}
+}
+
+func TestMigrateWebhookPayload_UnknownVersion(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"message.sent","api_version":"2022-01-01"}`)
+
+	if _, err := MigrateWebhookPayload(payload); err == nil {
+		t.Error("expected error for a version with no migration path")
+	}
+}
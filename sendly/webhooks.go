@@ -8,7 +8,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 )
 
 // WebhookEventType represents the type of webhook event
@@ -20,6 +22,25 @@ const (
 	WebhookEventMessageDelivered   WebhookEventType = "message.delivered"
 	WebhookEventMessageFailed      WebhookEventType = "message.failed"
 	WebhookEventMessageUndelivered WebhookEventType = "message.undelivered"
+	WebhookEventMessageExpired     WebhookEventType = "message.expired"
+	WebhookEventScheduledPending   WebhookEventType = "scheduled.pending"
+	// WebhookEventDeliveryFailed is sent to the account's notification
+	// endpoint when Sendly gives up delivering an event to another endpoint.
+	WebhookEventDeliveryFailed WebhookEventType = "webhook.delivery_failed"
+	// WebhookEventLinkClicked is sent each time a ShortLink is clicked.
+	WebhookEventLinkClicked WebhookEventType = "link.clicked"
+	// WebhookEventMessageReceived is sent when a recipient replies to one of
+	// the account's numbers. See KeywordHandler for STOP and HELP replies.
+	WebhookEventMessageReceived WebhookEventType = "message.received"
+	// WebhookEventCreditLowBalance is sent when the credit balance falls
+	// below the account's low balance threshold.
+	WebhookEventCreditLowBalance WebhookEventType = "credit.low_balance"
+	// WebhookEventCreditPurchased is sent when credits are bought, by hand
+	// or by auto top-up.
+	WebhookEventCreditPurchased WebhookEventType = "credit.purchased"
+	// WebhookEventKeyExpiring is sent ahead of an API key's expiry, so it can
+	// be rotated in time.
+	WebhookEventKeyExpiring WebhookEventType = "key.expiring"
 )
 
 // WebhookMessageStatus represents the status of a message in webhook events
@@ -31,11 +52,13 @@ const (
 	WebhookStatusDelivered   WebhookMessageStatus = "delivered"
 	WebhookStatusFailed      WebhookMessageStatus = "failed"
 	WebhookStatusUndelivered WebhookMessageStatus = "undelivered"
+	WebhookStatusExpired     WebhookMessageStatus = "expired"
 )
 
 // WebhookMessageData contains the data payload for message webhook events
 type WebhookMessageData struct {
 	MessageID   string               `json:"message_id"`
+	ClientID    string               `json:"client_id,omitempty"`
 	Status      WebhookMessageStatus `json:"status"`
 	To          string               `json:"to"`
 	From        string               `json:"from"`
@@ -43,8 +66,32 @@ type WebhookMessageData struct {
 	ErrorCode   string               `json:"error_code,omitempty"`
 	DeliveredAt string               `json:"delivered_at,omitempty"`
 	FailedAt    string               `json:"failed_at,omitempty"`
+	ExpiredAt   string               `json:"expired_at,omitempty"`
 	Segments    int                  `json:"segments"`
 	CreditsUsed int                  `json:"credits_used"`
+	// Metadata is the custom metadata the message was sent with.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// WebhookScheduledPendingData contains the data payload for scheduled.pending events.
+// The event is sent shortly before a scheduled message is dispatched; the send
+// can still be cancelled until CancelDeadline.
+type WebhookScheduledPendingData struct {
+	ScheduledMessageID string `json:"scheduled_message_id"`
+	To                 string `json:"to"`
+	From               string `json:"from,omitempty"`
+	Text               string `json:"text,omitempty"`
+	ScheduledAt        string `json:"scheduled_at"`
+	CancelDeadline     string `json:"cancel_deadline"`
+}
+
+// CancelWindowOpen reports whether the send can still be cancelled at now.
+func (d *WebhookScheduledPendingData) CancelWindowOpen(now time.Time) bool {
+	deadline, err := time.Parse(time.RFC3339, d.CancelDeadline)
+	if err != nil {
+		return false
+	}
+	return now.Before(deadline)
 }
 
 // WebhookEvent represents a webhook event from Sendly
@@ -54,8 +101,177 @@ type WebhookEvent struct {
 	Data       WebhookMessageData `json:"data"`
 	CreatedAt  string             `json:"created_at"`
 	APIVersion string             `json:"api_version"`
+
+	// RawData is the undecoded data payload, used to decode event types
+	// whose data is not a message payload.
+	RawData json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the event and keeps the raw data payload.
+func (e *WebhookEvent) UnmarshalJSON(b []byte) error {
+	type alias WebhookEvent
+	aux := struct {
+		*alias
+		Data json.RawMessage `json:"data"`
+	}{alias: (*alias)(e)}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	e.RawData = aux.Data
+	e.Data = WebhookMessageData{}
+	if len(aux.Data) > 0 {
+		// Only message events are required to carry a message payload.
+		if err := json.Unmarshal(aux.Data, &e.Data); err != nil && strings.HasPrefix(string(e.Type), "message.") {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes the event, preferring RawData over Data when set.
+func (e WebhookEvent) MarshalJSON() ([]byte, error) {
+	type alias WebhookEvent
+	if len(e.RawData) == 0 {
+		return json.Marshal(alias(e))
+	}
+	return json.Marshal(struct {
+		alias
+		Data json.RawMessage `json:"data"`
+	}{alias: alias(e), Data: e.RawData})
+}
+
+// decodeEventData decodes the payload of an event of type want into T.
+func decodeEventData[T any](e *WebhookEvent, want WebhookEventType) (*T, error) {
+	if e.Type != want {
+		return nil, fmt.Errorf("event type %q is not %q", e.Type, want)
+	}
+
+	var data T
+	if err := json.Unmarshal(e.RawData, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse %s data: %w", want, err)
+	}
+	return &data, nil
+}
+
+// ScheduledPendingData decodes the payload of a scheduled.pending event.
+func (e *WebhookEvent) ScheduledPendingData() (*WebhookScheduledPendingData, error) {
+	return decodeEventData[WebhookScheduledPendingData](e, WebhookEventScheduledPending)
+}
+
+// WebhookDeliveryFailedData contains the data payload for
+// webhook.delivery_failed events. The failed delivery can be requeued with
+// WebhooksAPI.RetryDelivery or RequeueFailedDeliveries.
+type WebhookDeliveryFailedData struct {
+	WebhookID      string `json:"webhook_id"`
+	WebhookURL     string `json:"webhook_url"`
+	DeliveryID     string `json:"delivery_id"`
+	EventID        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	Attempts       int    `json:"attempts"`
+	LastStatusCode *int   `json:"last_status_code,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	FailedAt       string `json:"failed_at"`
+}
+
+// DeliveryFailedData decodes the payload of a webhook.delivery_failed event.
+func (e *WebhookEvent) DeliveryFailedData() (*WebhookDeliveryFailedData, error) {
+	return decodeEventData[WebhookDeliveryFailedData](e, WebhookEventDeliveryFailed)
+}
+
+// WebhookLinkClickedData contains the data payload for link.clicked events.
+type WebhookLinkClickedData struct {
+	LinkID     string `json:"link_id"`
+	ShortURL   string `json:"short_url"`
+	TargetURL  string `json:"target_url"`
+	MessageID  string `json:"message_id,omitempty"`
+	To         string `json:"to,omitempty"`
+	ClickCount int    `json:"click_count"`
+	ClickedAt  string `json:"clicked_at"`
 }
 
+// LinkClickedData decodes the payload of a link.clicked event.
+func (e *WebhookEvent) LinkClickedData() (*WebhookLinkClickedData, error) {
+	return decodeEventData[WebhookLinkClickedData](e, WebhookEventLinkClicked)
+}
+
+// WebhookInboundMessageData contains the data payload for message.received
+// events.
+type WebhookInboundMessageData struct {
+	MessageID  string `json:"message_id"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Text       string `json:"text"`
+	ReceivedAt string `json:"received_at"`
+}
+
+// InboundMessageData decodes the payload of a message.received event.
+func (e *WebhookEvent) InboundMessageData() (*WebhookInboundMessageData, error) {
+	return decodeEventData[WebhookInboundMessageData](e, WebhookEventMessageReceived)
+}
+
+// WebhookLowBalanceData contains the data payload for credit.low_balance
+// events.
+type WebhookLowBalanceData struct {
+	Balance          int    `json:"balance"`
+	Threshold        int    `json:"threshold"`
+	AutoTopUpEnabled bool   `json:"auto_top_up_enabled"`
+	OccurredAt       string `json:"occurred_at"`
+}
+
+// LowBalanceData decodes the payload of a credit.low_balance event.
+func (e *WebhookEvent) LowBalanceData() (*WebhookLowBalanceData, error) {
+	return decodeEventData[WebhookLowBalanceData](e, WebhookEventCreditLowBalance)
+}
+
+// WebhookCreditPurchasedData contains the data payload for credit.purchased
+// events.
+type WebhookCreditPurchasedData struct {
+	TransactionID string `json:"transaction_id"`
+	Credits       int    `json:"credits"`
+	BalanceAfter  int    `json:"balance_after"`
+	AutoTopUp     bool   `json:"auto_top_up"`
+	PurchasedAt   string `json:"purchased_at"`
+}
+
+// CreditPurchasedData decodes the payload of a credit.purchased event.
+func (e *WebhookEvent) CreditPurchasedData() (*WebhookCreditPurchasedData, error) {
+	return decodeEventData[WebhookCreditPurchasedData](e, WebhookEventCreditPurchased)
+}
+
+// WebhookKeyExpiringData contains the data payload for key.expiring events.
+type WebhookKeyExpiringData struct {
+	KeyID     string     `json:"key_id"`
+	Name      string     `json:"name"`
+	Type      APIKeyType `json:"type"`
+	ExpiresAt string     `json:"expires_at"`
+}
+
+// KeyExpiringData decodes the payload of a key.expiring event.
+func (e *WebhookEvent) KeyExpiringData() (*WebhookKeyExpiringData, error) {
+	return decodeEventData[WebhookKeyExpiringData](e, WebhookEventKeyExpiring)
+}
+
+// ScheduledPendingAction is the action a consumer returns for a scheduled.pending event.
+type ScheduledPendingAction string
+
+const (
+	// ScheduledPendingActionProceed lets the scheduled message be sent.
+	ScheduledPendingActionProceed ScheduledPendingAction = "proceed"
+	// ScheduledPendingActionCancel cancels the scheduled message and refunds its credits.
+	ScheduledPendingActionCancel ScheduledPendingAction = "cancel"
+)
+
+// ScheduledPendingResponse is the response body for a scheduled.pending delivery.
+type ScheduledPendingResponse struct {
+	Action ScheduledPendingAction `json:"action"`
+	Reason string                 `json:"reason,omitempty"`
+}
+
+// WebhookSignatureHeader is the HTTP header carrying the webhook signature.
+const WebhookSignatureHeader = "X-Sendly-Signature"
+
 // ErrInvalidSignature is returned when webhook signature verification fails
 var ErrInvalidSignature = errors.New("invalid webhook signature")
 
@@ -91,6 +307,22 @@ func (w Webhooks) VerifySignature(payload, signature, secret string) bool {
 	return hmac.Equal([]byte(signature), []byte(expected))
 }
 
+// VerifySignatureWithSecrets reports whether signature is valid for any of
+// secrets. Pass the new and the old secret while a rotation's grace period
+// lasts, so deliveries signed with either are accepted.
+//
+// Example:
+//
+//	isValid := sendly.Webhooks{}.VerifySignatureWithSecrets(rawBody, signature, newSecret, oldSecret)
+func (w Webhooks) VerifySignatureWithSecrets(payload, signature string, secrets ...string) bool {
+	for _, secret := range secrets {
+		if w.VerifySignature(payload, signature, secret) {
+			return true
+		}
+	}
+	return false
+}
+
 // ParseEvent parses and validates a webhook event
 //
 // Parameters:
@@ -108,12 +340,24 @@ func (w Webhooks) VerifySignature(payload, signature, secret string) bool {
 //	}
 //	fmt.Printf("Event type: %s\n", event.Type)
 func (w Webhooks) ParseEvent(payload, signature, secret string) (*WebhookEvent, error) {
-	if !w.VerifySignature(payload, signature, secret) {
+	return w.ParseEventWithSecrets(payload, signature, secret)
+}
+
+// ParseEventWithSecrets is like ParseEvent, but accepts a signature made
+// with any of secrets. See VerifySignatureWithSecrets.
+func (w Webhooks) ParseEventWithSecrets(payload, signature string, secrets ...string) (*WebhookEvent, error) {
+	if !w.VerifySignatureWithSecrets(payload, signature, secrets...) {
 		return nil, ErrInvalidSignature
 	}
 
+	// Signatures cover the payload as sent, so migrate only after verifying.
+	migrated, err := MigrateWebhookPayload([]byte(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
+	}
+
 	var event WebhookEvent
-	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+	if err := json.Unmarshal(migrated, &event); err != nil {
 		return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
 	}
 
@@ -125,6 +369,35 @@ func (w Webhooks) ParseEvent(payload, signature, secret string) (*WebhookEvent,
 	return &event, nil
 }
 
+// RespondCancel answers a scheduled.pending delivery by cancelling the send.
+// It must be written before the event's CancelDeadline to take effect.
+//
+// Example:
+//
+//	if event.Type == sendly.WebhookEventScheduledPending && userConverted {
+//	    sendly.Webhooks{}.RespondCancel(w, "user already converted")
+//	    return
+//	}
+func (w Webhooks) RespondCancel(rw http.ResponseWriter, reason string) error {
+	return writeScheduledPendingResponse(rw, ScheduledPendingResponse{
+		Action: ScheduledPendingActionCancel,
+		Reason: reason,
+	})
+}
+
+// RespondProceed answers a scheduled.pending delivery by letting the send go ahead.
+func (w Webhooks) RespondProceed(rw http.ResponseWriter) error {
+	return writeScheduledPendingResponse(rw, ScheduledPendingResponse{
+		Action: ScheduledPendingActionProceed,
+	})
+}
+
+func writeScheduledPendingResponse(rw http.ResponseWriter, resp ScheduledPendingResponse) error {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	return json.NewEncoder(rw).Encode(resp)
+}
+
 // GenerateSignature generates a webhook signature for testing purposes
 //
 // Parameters:
@@ -109,6 +109,9 @@ func (s *WebhooksService) Create(ctx context.Context, req CreateWebhookRequest)
 	if len(req.Events) == 0 {
 		return nil, errors.New("at least one event type is required")
 	}
+	if err := ValidateMetadata(req.Metadata, s.client.metadataLimits); err != nil {
+		return nil, err
+	}
 
 	var apiResp webhookAPIResponse
 	if err := s.client.request(ctx, "POST", "/webhooks", req, &apiResp); err != nil {
@@ -160,6 +163,9 @@ func (s *WebhooksService) Update(ctx context.Context, webhookID string, req Upda
 	if req.URL != nil && !strings.HasPrefix(*req.URL, "https://") {
 		return nil, errors.New("webhook URL must be HTTPS")
 	}
+	if err := ValidateMetadata(req.Metadata, s.client.metadataLimits); err != nil {
+		return nil, err
+	}
 
 	var apiResp webhookAPIResponse
 	if err := s.client.request(ctx, "PATCH", "/webhooks/"+webhookID, req, &apiResp); err != nil {
@@ -0,0 +1,143 @@
+package sendly
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhooksParseEvent_MessageEvent(t *testing.T) {
+	payload := `{"id":"evt_1","type":"message.delivered","created_at":"2024-01-01T00:00:00Z","api_version":"2024-01-01","data":{"message_id":"msg_1","status":"delivered","to":"+15551234567","segments":1}}`
+	signature := Webhooks{}.GenerateSignature(payload, "secret")
+
+	event, err := Webhooks{}.ParseEvent(payload, signature, "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if event.Data.MessageID != "msg_1" {
+		t.Errorf("expected MessageID to be 'msg_1', got '%s'", event.Data.MessageID)
+	}
+	if event.Data.Status != WebhookStatusDelivered {
+		t.Errorf("expected Status to be 'delivered', got '%s'", event.Data.Status)
+	}
+	if len(event.RawData) == 0 {
+		t.Error("expected RawData to be populated")
+	}
+}
+
+func TestWebhooksParseEvent_InvalidSignature(t *testing.T) {
+	payload := `{"id":"evt_1","type":"message.sent","created_at":"2024-01-01T00:00:00Z"}`
+
+	_, err := Webhooks{}.ParseEvent(payload, "sha256=invalid", "secret")
+	if err != ErrInvalidSignature {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestWebhooksVerifySignatureWithSecrets(t *testing.T) {
+	payload := `{"id":"evt_1","type":"message.sent","created_at":"2024-01-01T00:00:00Z"}`
+	oldSig := Webhooks{}.GenerateSignature(payload, "old-secret")
+	newSig := Webhooks{}.GenerateSignature(payload, "new-secret")
+
+	if !(Webhooks{}).VerifySignatureWithSecrets(payload, oldSig, "new-secret", "old-secret") {
+		t.Error("expected a signature made with the old secret to verify")
+	}
+	if !(Webhooks{}).VerifySignatureWithSecrets(payload, newSig, "new-secret", "old-secret") {
+		t.Error("expected a signature made with the new secret to verify")
+	}
+	if (Webhooks{}).VerifySignatureWithSecrets(payload, oldSig, "new-secret") {
+		t.Error("expected a signature made with a dropped secret not to verify")
+	}
+	if (Webhooks{}).VerifySignatureWithSecrets(payload, oldSig) {
+		t.Error("expected no secrets to verify nothing")
+	}
+
+	event, err := Webhooks{}.ParseEventWithSecrets(payload, oldSig, "new-secret", "old-secret")
+	if err != nil || event.ID != "evt_1" {
+		t.Errorf("expected evt_1, got %v, %v", event, err)
+	}
+}
+
+func TestWebhookEvent_ScheduledPendingData(t *testing.T) {
+	deadline := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+	payload := `{"id":"evt_2","type":"scheduled.pending","created_at":"2024-01-01T00:00:00Z","data":{"scheduled_message_id":"sched_1","to":"+15551234567","scheduled_at":"2024-01-01T00:05:00Z","cancel_deadline":"` + deadline + `"}}`
+	signature := Webhooks{}.GenerateSignature(payload, "secret")
+
+	event, err := Webhooks{}.ParseEvent(payload, signature, "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := event.ScheduledPendingData()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data.ScheduledMessageID != "sched_1" {
+		t.Errorf("expected ScheduledMessageID to be 'sched_1', got '%s'", data.ScheduledMessageID)
+	}
+	if !data.CancelWindowOpen(time.Now()) {
+		t.Error("expected cancel window to be open")
+	}
+	if data.CancelWindowOpen(time.Now().Add(2 * time.Minute)) {
+		t.Error("expected cancel window to be closed after the deadline")
+	}
+}
+
+func TestWebhookEvent_ScheduledPendingData_WrongType(t *testing.T) {
+	event := &WebhookEvent{ID: "evt_1", Type: WebhookEventMessageSent}
+
+	if _, err := event.ScheduledPendingData(); err == nil {
+		t.Error("expected error for non scheduled.pending event")
+	}
+}
+
+func TestWebhookEvent_MarshalRoundTrip(t *testing.T) {
+	event := WebhookEvent{
+		ID:        "evt_3",
+		Type:      WebhookEventScheduledPending,
+		CreatedAt: "2024-01-01T00:00:00Z",
+		RawData:   json.RawMessage(`{"scheduled_message_id":"sched_9"}`),
+	}
+
+	b, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded WebhookEvent
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := decoded.ScheduledPendingData()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data.ScheduledMessageID != "sched_9" {
+		t.Errorf("expected ScheduledMessageID to be 'sched_9', got '%s'", data.ScheduledMessageID)
+	}
+}
+
+func TestWebhooksRespondCancel(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	if err := (Webhooks{}).RespondCancel(rec, "user converted"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rec.Code != 200 {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+
+	var resp ScheduledPendingResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Action != ScheduledPendingActionCancel {
+		t.Errorf("expected Action to be 'cancel', got '%s'", resp.Action)
+	}
+	if resp.Reason != "user converted" {
+		t.Errorf("expected Reason to be 'user converted', got '%s'", resp.Reason)
+	}
+}